@@ -0,0 +1,75 @@
+// Package audit records tool invocations to an append-only sink.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Event is a single audited tool invocation.
+type Event struct {
+	Time          time.Time              `json:"time"`
+	Tool          string                 `json:"tool"`
+	Arguments     map[string]interface{} `json:"arguments,omitempty"`
+	ClientName    string                 `json:"client_name,omitempty"`
+	ClientVersion string                 `json:"client_version,omitempty"`
+	Namespace     string                 `json:"namespace"`
+	Success       bool                   `json:"success"`
+	Error         string                 `json:"error,omitempty"`
+	// Session is the MCP session making the call; Identity is the identity its Temporal RPCs carry,
+	// which tells apart agents sharing the server.
+	Session  string `json:"session,omitempty"`
+	Identity string `json:"identity,omitempty"`
+}
+
+// Sink receives audit events. Implementations must be safe for concurrent use.
+type Sink interface {
+	Record(event Event) error
+	Close() error
+}
+
+// NopSink discards all events; it is used when auditing is not configured.
+type NopSink struct{}
+
+// Record implements Sink.
+func (NopSink) Record(Event) error { return nil }
+
+// Close implements Sink.
+func (NopSink) Close() error { return nil }
+
+// FileSink appends events as JSON lines to a file.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewFileSink opens (or creates) path in append-only mode.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log %s: %w", path, err)
+	}
+	return &FileSink{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Record writes the event as one JSON line.
+func (s *FileSink) Record(event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(event)
+}
+
+// Close flushes and closes the audit file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.file.Sync(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
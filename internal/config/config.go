@@ -0,0 +1,485 @@
+// Package config loads the server configuration from environment variables and the optional
+// JSON config file.
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wricardo/temporal-mcp/internal/payload"
+)
+
+// Config holds every setting of the server.
+type Config struct {
+	// TemporalAddresses are the host:port of the Temporal frontend (TEMPORAL_ADDRESS); a
+	// comma-separated list names equivalent endpoints, tried in order and failed over between.
+	TemporalAddresses []string
+	// ClusterName names the cluster in tool descriptions, so agents using several MCP servers can
+	// tell which Temporal this one talks to (TEMPORAL_MCP_CLUSTER_NAME, default the addresses).
+	ClusterName string
+	// Namespace is the Temporal namespace tools operate on by default (TEMPORAL_NAMESPACE).
+	Namespace string
+	// AllowedNamespaces, when non-empty, restricts which namespaces tool calls may target
+	// (TEMPORAL_MCP_ALLOWED_NAMESPACES, comma-separated). It must include Namespace.
+	AllowedNamespaces []string
+	// Identity is recorded in workflow histories as the actor of the server's RPCs
+	// (TEMPORAL_MCP_IDENTITY, default "temporal-mcp@<hostname>").
+	Identity string
+	// KeepAliveTime, KeepAliveTimeout, and KeepAlivePermitWithoutStream configure gRPC keepalive
+	// pings, which detect connections silently dropped by NATs and gateways
+	// (TEMPORAL_MCP_KEEPALIVE_TIME, TEMPORAL_MCP_KEEPALIVE_TIMEOUT,
+	// TEMPORAL_MCP_KEEPALIVE_PERMIT_WITHOUT_STREAM).
+	KeepAliveTime                time.Duration
+	KeepAliveTimeout             time.Duration
+	KeepAlivePermitWithoutStream bool
+	// GRPCHeaders are sent with every RPC (TEMPORAL_MCP_GRPC_HEADERS, "name=value,..."), and
+	// HeadersCommand, when set, is run to mint more, e.g. short-lived bearer tokens
+	// (TEMPORAL_MCP_HEADERS_COMMAND); its headers are refreshed once older than HeadersCommandTTL
+	// (TEMPORAL_MCP_HEADERS_COMMAND_TTL).
+	GRPCHeaders       map[string]string
+	HeadersCommand    string
+	HeadersCommandTTL time.Duration
+
+	// LogLevel and LogFormat configure the process logger (TEMPORAL_MCP_LOG_LEVEL, TEMPORAL_MCP_LOG_FORMAT).
+	LogLevel  slog.Level
+	LogFormat string
+	// LogFile, when set, receives all logs instead of stderr (TEMPORAL_MCP_LOG_FILE); it is rotated
+	// once it grows past LogMaxSizeBytes (TEMPORAL_MCP_LOG_MAX_SIZE_MB, 0 disables rotation).
+	LogFile         string
+	LogMaxSizeBytes int64
+
+	// AuditLog is the path of the JSON-lines audit log of tool invocations (TEMPORAL_MCP_AUDIT_LOG).
+	AuditLog string
+	// MetricsAddr is the listen address of the Prometheus endpoint (TEMPORAL_MCP_METRICS_ADDR).
+	MetricsAddr string
+
+	// ToolTimeout is the default deadline of a tool call's Temporal RPCs (TEMPORAL_MCP_TOOL_TIMEOUT).
+	ToolTimeout time.Duration
+
+	// CacheTTL and CacheSize configure the read-tool response cache (TEMPORAL_MCP_CACHE_TTL,
+	// TEMPORAL_MCP_CACHE_SIZE, 0 disables it); CacheListings opts listing tools in (TEMPORAL_MCP_CACHE_LISTINGS).
+	CacheTTL      time.Duration
+	CacheSize     int
+	CacheListings bool
+
+	// RPCRate, RPCBurst, and RPCMaxWait configure the outbound RPC rate limiter (TEMPORAL_MCP_RPC_RATE,
+	// 0 disables it, TEMPORAL_MCP_RPC_BURST, TEMPORAL_MCP_RPC_MAX_WAIT).
+	RPCRate    float64
+	RPCBurst   int
+	RPCMaxWait time.Duration
+
+	// RetryMaxAttempts, RetryInitialBackoff, and RetryMaxBackoff configure retries of read-only RPCs
+	// failing with transient errors (TEMPORAL_MCP_RETRY_MAX_ATTEMPTS, 1 disables retries,
+	// TEMPORAL_MCP_RETRY_INITIAL_BACKOFF, TEMPORAL_MCP_RETRY_MAX_BACKOFF).
+	RetryMaxAttempts    int
+	RetryInitialBackoff time.Duration
+	RetryMaxBackoff     time.Duration
+
+	// FanoutConcurrency bounds concurrent RPCs of fan-out tools (TEMPORAL_MCP_FANOUT_CONCURRENCY), and
+	// FanoutMaxItems caps the executions a single fan-out call processes (TEMPORAL_MCP_FANOUT_MAX_ITEMS).
+	FanoutConcurrency int
+	FanoutMaxItems    int
+
+	// DefaultPageSize is how many workflows listing tools return when a call doesn't pass page_size
+	// (TEMPORAL_MCP_DEFAULT_PAGE_SIZE).
+	DefaultPageSize int
+
+	// HistoryWarnEvents and HistoryWarnBytes are the history event count and size past which tools
+	// warn that a workflow nears the server's history limits (TEMPORAL_MCP_HISTORY_WARN_EVENTS,
+	// TEMPORAL_MCP_HISTORY_WARN_SIZE_MB, 0 disables a warning).
+	HistoryWarnEvents int
+	HistoryWarnBytes  int64
+
+	// ExportDir is the directory listings may be exported to (TEMPORAL_MCP_EXPORT_DIR, unset disables
+	// exports), and ExportMaxRows caps the rows of one export (TEMPORAL_MCP_EXPORT_MAX_ROWS).
+	ExportDir     string
+	ExportMaxRows int
+
+	// Redaction hides payload contents in tool output (TEMPORAL_MCP_REDACT: off, keys, or full), and
+	// RedactKeys overrides the key patterns redacted in keys mode (TEMPORAL_MCP_REDACT_KEYS).
+	Redaction  payload.Redaction
+	RedactKeys []string
+	// DataConverter names the registered data converter used to encode and decode payloads
+	// (TEMPORAL_MCP_DATA_CONVERTER, default "default").
+	DataConverter string
+	// EncryptionKeyID is the primary AES-GCM key, used to encrypt payloads (TEMPORAL_MCP_ENCRYPTION_KEY_ID),
+	// and EncryptionKeys holds every key by ID: the primary one from TEMPORAL_MCP_ENCRYPTION_KEY
+	// (base64) or TEMPORAL_MCP_ENCRYPTION_KEY_FILE, plus decrypt-only keys for rotation from
+	// TEMPORAL_MCP_ENCRYPTION_OLD_KEYS ("id=base64,..."). Encryption is disabled when no key ID is set.
+	EncryptionKeyID string
+	EncryptionKeys  map[string][]byte
+	// MaxPayloadBytes is the largest payload rendered in full (TEMPORAL_MCP_MAX_PAYLOAD_BYTES).
+	MaxPayloadBytes int
+	// InputMaxBytes is the largest caller-supplied payload, such as signal input, tools send to
+	// Temporal (TEMPORAL_MCP_INPUT_MAX_BYTES), and InputWarnBytes the size past which they send one
+	// with a warning (TEMPORAL_MCP_INPUT_WARN_BYTES); 0 disables either.
+	InputMaxBytes  int
+	InputWarnBytes int
+
+	// MaxOutputBytes bounds the text of a tool result (TEMPORAL_MCP_MAX_OUTPUT_BYTES, 0 disables the
+	// bound). LargeOutput says what happens to larger outputs (TEMPORAL_MCP_LARGE_OUTPUT): "resource"
+	// attaches them as embedded resources readable for LargeOutputTTL (TEMPORAL_MCP_LARGE_OUTPUT_TTL),
+	// "truncate" cuts them, for clients that don't handle resources.
+	MaxOutputBytes int
+	LargeOutput    string
+	LargeOutputTTL time.Duration
+
+	// DryRun makes mutating tools validate and preview, then report what they would have done
+	// without doing it (TEMPORAL_MCP_DRY_RUN).
+	DryRun bool
+	// NamespaceDeletion registers the delete_namespace tool, which is otherwise unavailable
+	// (TEMPORAL_MCP_ENABLE_NAMESPACE_DELETION).
+	NamespaceDeletion bool
+
+	// File holds the settings read from the optional TEMPORAL_MCP_CONFIG file.
+	File File
+}
+
+// Load reads the configuration from the environment, applying defaults for unset variables.
+func Load() (*Config, error) {
+	cfg := &Config{
+		Namespace:   os.Getenv("TEMPORAL_NAMESPACE"),
+		LogFile:     os.Getenv("TEMPORAL_MCP_LOG_FILE"),
+		AuditLog:    os.Getenv("TEMPORAL_MCP_AUDIT_LOG"),
+		MetricsAddr: os.Getenv("TEMPORAL_MCP_METRICS_ADDR"),
+		Identity:    os.Getenv("TEMPORAL_MCP_IDENTITY"),
+
+		ClusterName:    os.Getenv("TEMPORAL_MCP_CLUSTER_NAME"),
+		HeadersCommand: os.Getenv("TEMPORAL_MCP_HEADERS_COMMAND"),
+	}
+	if cfg.TemporalAddresses = envList("TEMPORAL_ADDRESS"); len(cfg.TemporalAddresses) == 0 {
+		cfg.TemporalAddresses = []string{"localhost:7233"}
+	}
+	if cfg.ClusterName == "" {
+		cfg.ClusterName = strings.Join(cfg.TemporalAddresses, ", ")
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = "default"
+	}
+	cfg.AllowedNamespaces = envList("TEMPORAL_MCP_ALLOWED_NAMESPACES")
+	if cfg.Identity == "" {
+		hostname, _ := os.Hostname()
+		cfg.Identity = "temporal-mcp@" + hostname
+	}
+
+	var err error
+	if cfg.KeepAliveTime, err = envDuration("TEMPORAL_MCP_KEEPALIVE_TIME", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.KeepAliveTimeout, err = envDuration("TEMPORAL_MCP_KEEPALIVE_TIMEOUT", 15*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.KeepAlivePermitWithoutStream, err = envBool("TEMPORAL_MCP_KEEPALIVE_PERMIT_WITHOUT_STREAM", true); err != nil {
+		return nil, err
+	}
+	if cfg.GRPCHeaders, err = grpcHeaders(); err != nil {
+		return nil, err
+	}
+	if cfg.HeadersCommandTTL, err = envDuration("TEMPORAL_MCP_HEADERS_COMMAND_TTL", 5*time.Minute); err != nil {
+		return nil, err
+	}
+
+	if cfg.LogLevel, err = logLevel("TEMPORAL_MCP_LOG_LEVEL"); err != nil {
+		return nil, err
+	}
+	switch cfg.LogFormat = strings.ToLower(os.Getenv("TEMPORAL_MCP_LOG_FORMAT")); cfg.LogFormat {
+	case "":
+		cfg.LogFormat = "text"
+	case "text", "json":
+	default:
+		return nil, fmt.Errorf("invalid TEMPORAL_MCP_LOG_FORMAT %q (use text or json)", cfg.LogFormat)
+	}
+	logMaxSizeMB, err := envInt("TEMPORAL_MCP_LOG_MAX_SIZE_MB", 10)
+	if err != nil {
+		return nil, err
+	}
+	cfg.LogMaxSizeBytes = int64(logMaxSizeMB) << 20
+
+	if cfg.ToolTimeout, err = envDuration("TEMPORAL_MCP_TOOL_TIMEOUT", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.CacheTTL, err = envDuration("TEMPORAL_MCP_CACHE_TTL", 5*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.CacheSize, err = envInt("TEMPORAL_MCP_CACHE_SIZE", 256); err != nil {
+		return nil, err
+	}
+	if cfg.CacheListings, err = envBool("TEMPORAL_MCP_CACHE_LISTINGS", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.DryRun, err = envBool("TEMPORAL_MCP_DRY_RUN", false); err != nil {
+		return nil, err
+	}
+	if cfg.NamespaceDeletion, err = envBool("TEMPORAL_MCP_ENABLE_NAMESPACE_DELETION", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.RPCRate, err = envFloat("TEMPORAL_MCP_RPC_RATE", 0); err != nil {
+		return nil, err
+	}
+	if cfg.RPCBurst, err = envInt("TEMPORAL_MCP_RPC_BURST", max(1, int(math.Ceil(cfg.RPCRate)))); err != nil {
+		return nil, err
+	}
+	if cfg.RPCMaxWait, err = envDuration("TEMPORAL_MCP_RPC_MAX_WAIT", 2*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.RetryMaxAttempts, err = envInt("TEMPORAL_MCP_RETRY_MAX_ATTEMPTS", 3); err != nil {
+		return nil, err
+	}
+	if cfg.RetryMaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid TEMPORAL_MCP_RETRY_MAX_ATTEMPTS %d (must be at least 1)", cfg.RetryMaxAttempts)
+	}
+	if cfg.RetryInitialBackoff, err = envDuration("TEMPORAL_MCP_RETRY_INITIAL_BACKOFF", 200*time.Millisecond); err != nil {
+		return nil, err
+	}
+	if cfg.RetryMaxBackoff, err = envDuration("TEMPORAL_MCP_RETRY_MAX_BACKOFF", 2*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.FanoutConcurrency, err = envInt("TEMPORAL_MCP_FANOUT_CONCURRENCY", 8); err != nil {
+		return nil, err
+	}
+	if cfg.FanoutMaxItems, err = envInt("TEMPORAL_MCP_FANOUT_MAX_ITEMS", 100); err != nil {
+		return nil, err
+	}
+
+	if cfg.DefaultPageSize, err = envInt("TEMPORAL_MCP_DEFAULT_PAGE_SIZE", 100); err != nil {
+		return nil, err
+	}
+	if cfg.DefaultPageSize < 1 || cfg.DefaultPageSize > 1000 {
+		return nil, fmt.Errorf("invalid TEMPORAL_MCP_DEFAULT_PAGE_SIZE %d: must be between 1 and 1000", cfg.DefaultPageSize)
+	}
+
+	// The defaults are 80% of the server's default limits of 51200 events and 50 MiB
+	if cfg.HistoryWarnEvents, err = envInt("TEMPORAL_MCP_HISTORY_WARN_EVENTS", 40960); err != nil {
+		return nil, err
+	}
+	warnSizeMB, err := envInt("TEMPORAL_MCP_HISTORY_WARN_SIZE_MB", 40)
+	if err != nil {
+		return nil, err
+	}
+	cfg.HistoryWarnBytes = int64(warnSizeMB) << 20
+
+	if cfg.ExportDir = os.Getenv("TEMPORAL_MCP_EXPORT_DIR"); cfg.ExportDir != "" {
+		if info, err := os.Stat(cfg.ExportDir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("invalid TEMPORAL_MCP_EXPORT_DIR %q: not an existing directory", cfg.ExportDir)
+		}
+	}
+	if cfg.ExportMaxRows, err = envInt("TEMPORAL_MCP_EXPORT_MAX_ROWS", 100000); err != nil {
+		return nil, err
+	}
+	if cfg.ExportMaxRows < 1 {
+		return nil, fmt.Errorf("invalid TEMPORAL_MCP_EXPORT_MAX_ROWS %d: must be at least 1", cfg.ExportMaxRows)
+	}
+
+	switch raw := strings.ToLower(os.Getenv("TEMPORAL_MCP_REDACT")); raw {
+	case "", "off", "none":
+		cfg.Redaction = payload.RedactNone
+	case "keys":
+		cfg.Redaction = payload.RedactKeys
+	case "full":
+		cfg.Redaction = payload.RedactFull
+	default:
+		return nil, fmt.Errorf("invalid TEMPORAL_MCP_REDACT %q (use off, keys, or full)", raw)
+	}
+	cfg.RedactKeys = envList("TEMPORAL_MCP_REDACT_KEYS")
+	if cfg.DataConverter = os.Getenv("TEMPORAL_MCP_DATA_CONVERTER"); cfg.DataConverter == "" {
+		cfg.DataConverter = "default"
+	}
+	if cfg.EncryptionKeyID, cfg.EncryptionKeys, err = encryptionKeys(); err != nil {
+		return nil, err
+	}
+	if cfg.MaxPayloadBytes, err = envInt("TEMPORAL_MCP_MAX_PAYLOAD_BYTES", payload.DefaultMaxSize); err != nil {
+		return nil, err
+	}
+	// The defaults are well under the server's default blob size limit of 2 MiB, which it only
+	// enforces once the payload was sent
+	if cfg.InputMaxBytes, err = envInt("TEMPORAL_MCP_INPUT_MAX_BYTES", 256<<10); err != nil {
+		return nil, err
+	}
+	if cfg.InputWarnBytes, err = envInt("TEMPORAL_MCP_INPUT_WARN_BYTES", 128<<10); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxOutputBytes, err = envInt("TEMPORAL_MCP_MAX_OUTPUT_BYTES", 100000); err != nil {
+		return nil, err
+	}
+	switch cfg.LargeOutput = strings.ToLower(os.Getenv("TEMPORAL_MCP_LARGE_OUTPUT")); cfg.LargeOutput {
+	case "":
+		cfg.LargeOutput = "resource"
+	case "resource", "truncate":
+	default:
+		return nil, fmt.Errorf("invalid TEMPORAL_MCP_LARGE_OUTPUT %q (use resource or truncate)", cfg.LargeOutput)
+	}
+	if cfg.LargeOutputTTL, err = envDuration("TEMPORAL_MCP_LARGE_OUTPUT_TTL", 15*time.Minute); err != nil {
+		return nil, err
+	}
+
+	if path := os.Getenv("TEMPORAL_MCP_CONFIG"); path != "" {
+		if cfg.File, err = loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// Target describes the cluster and namespace the server talks to, as appended to every tool
+// description, e.g. "prod-us, namespace 'payments'".
+func (c *Config) Target() string {
+	return fmt.Sprintf("%s, namespace '%s'", c.ClusterName, c.Namespace)
+}
+
+// logLevel reads a slog level (debug, info, warn, error) from the named environment variable.
+func logLevel(name string) (slog.Level, error) {
+	switch raw := strings.ToLower(os.Getenv(name)); raw {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid %s %q (use debug, info, warn, or error)", name, raw)
+	}
+}
+
+// grpcHeaders reads the static gRPC headers from the environment. Errors never include header
+// values, which are usually credentials.
+func grpcHeaders() (map[string]string, error) {
+	var headers map[string]string
+	for i, pair := range envList("TEMPORAL_MCP_GRPC_HEADERS") {
+		name, value, ok := strings.Cut(pair, "=")
+		if name = strings.ToLower(strings.TrimSpace(name)); !ok || name == "" {
+			return nil, fmt.Errorf("invalid TEMPORAL_MCP_GRPC_HEADERS entry %d (expected name=value)", i+1)
+		}
+		if _, dup := headers[name]; dup {
+			return nil, fmt.Errorf("gRPC header %q is configured twice", name)
+		}
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// encryptionKeys reads the payload encryption keys from the environment.
+func encryptionKeys() (string, map[string][]byte, error) {
+	primaryID := os.Getenv("TEMPORAL_MCP_ENCRYPTION_KEY_ID")
+	encoded, keyFile := os.Getenv("TEMPORAL_MCP_ENCRYPTION_KEY"), os.Getenv("TEMPORAL_MCP_ENCRYPTION_KEY_FILE")
+	oldKeys := envList("TEMPORAL_MCP_ENCRYPTION_OLD_KEYS")
+	if primaryID == "" {
+		if encoded != "" || keyFile != "" || len(oldKeys) > 0 {
+			return "", nil, fmt.Errorf("TEMPORAL_MCP_ENCRYPTION_KEY_ID is required when encryption keys are configured")
+		}
+		return "", nil, nil
+	}
+
+	switch {
+	case encoded != "" && keyFile != "":
+		return "", nil, fmt.Errorf("set only one of TEMPORAL_MCP_ENCRYPTION_KEY and TEMPORAL_MCP_ENCRYPTION_KEY_FILE")
+	case keyFile != "":
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return "", nil, fmt.Errorf("read TEMPORAL_MCP_ENCRYPTION_KEY_FILE: %w", err)
+		}
+		encoded = strings.TrimSpace(string(data))
+	case encoded == "":
+		return "", nil, fmt.Errorf("TEMPORAL_MCP_ENCRYPTION_KEY or TEMPORAL_MCP_ENCRYPTION_KEY_FILE is required with TEMPORAL_MCP_ENCRYPTION_KEY_ID")
+	}
+	primary, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid encryption key for %q: expected base64: %w", primaryID, err)
+	}
+
+	keys := map[string][]byte{primaryID: primary}
+	for _, pair := range oldKeys {
+		id, encoded, ok := strings.Cut(pair, "=")
+		if !ok || id == "" {
+			return "", nil, fmt.Errorf("invalid TEMPORAL_MCP_ENCRYPTION_OLD_KEYS entry %q (expected id=base64key)", pair)
+		}
+		if _, dup := keys[id]; dup {
+			return "", nil, fmt.Errorf("encryption key ID %q is configured twice", id)
+		}
+		if keys[id], err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return "", nil, fmt.Errorf("invalid encryption key for %q: expected base64: %w", id, err)
+		}
+	}
+	return primaryID, keys, nil
+}
+
+// envList reads a comma-separated list from the named environment variable, dropping empty items.
+func envList(name string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(name), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// envDuration reads a Go duration (e.g. "30s", "2m") from the named environment variable,
+// returning def when it is unset.
+func envDuration(name string, def time.Duration) (time.Duration, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q (expected a positive duration such as 30s)", name, raw)
+	}
+	return d, nil
+}
+
+// envInt reads a non-negative integer from the named environment variable, returning def when it is unset.
+func envInt(name string, def int) (int, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q (expected a non-negative integer)", name, raw)
+	}
+	return n, nil
+}
+
+// envFloat reads a non-negative number from the named environment variable, returning def when it is unset.
+func envFloat(name string, def float64) (float64, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid %s %q (expected a non-negative number)", name, raw)
+	}
+	return f, nil
+}
+
+// envBool reads a boolean ("true", "1", "false", "0", ...) from the named environment variable,
+// returning def when it is unset.
+func envBool(name string, def bool) (bool, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q (expected true or false)", name, raw)
+	}
+	return b, nil
+}
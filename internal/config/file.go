@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"text/template"
+	"time"
+)
+
+// File holds the structured settings that don't fit in environment variables. It is read from the
+// JSON file named by TEMPORAL_MCP_CONFIG; every section is optional.
+type File struct {
+	// WorkflowTypes is an operator-provided catalog of known workflow types.
+	WorkflowTypes []WorkflowType `json:"workflow_types"`
+	// TaskQueues lists the task queues that should always have workers polling them.
+	TaskQueues []string `json:"task_queues"`
+	// SavedQueries are named visibility queries agents can run with run_saved_query.
+	SavedQueries []SavedQuery `json:"saved_queries"`
+}
+
+// WorkflowType describes a known workflow type.
+type WorkflowType struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SavedQuery is a named visibility query defined by the operator, e.g. the team's canonical
+// "stuck payments" query.
+type SavedQuery struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Query is a visibility query in text/template syntax whose parameters are referred to by
+	// name, e.g. `WorkflowType = "Payment" AND StartTime > {{.Since}}`.
+	Query      string           `json:"query"`
+	Parameters []QueryParameter `json:"parameters"`
+}
+
+// QueryParameter is a parameter of a saved query.
+type QueryParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Type is one of QueryParameterTypes; "" means string.
+	Type string `json:"type"`
+	// Default is used when a call doesn't pass the parameter; parameters without one are required.
+	Default string `json:"default"`
+}
+
+// QueryParameterTypes are the valid saved query parameter types: strings are inserted as quoted
+// literals, integers as numbers, and durations such as 24h as the quoted time that long ago.
+var QueryParameterTypes = []string{"string", "integer", "duration"}
+
+// Template parses the query template. Executing it fails on parameters that weren't given.
+func (q SavedQuery) Template() (*template.Template, error) {
+	return template.New(q.Name).Option("missingkey=error").Parse(q.Query)
+}
+
+// parameterName matches the parameter names a template can refer to as {{.Name}}.
+var parameterName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validate checks the query's parameters and that its template only refers to them.
+func (q SavedQuery) validate() error {
+	if q.Query == "" {
+		return fmt.Errorf("saved query %q has no query", q.Name)
+	}
+	placeholders := make(map[string]string, len(q.Parameters))
+	for i, p := range q.Parameters {
+		if !parameterName.MatchString(p.Name) {
+			return fmt.Errorf("saved query %q: parameters[%d] name %q isn't a valid identifier", q.Name, i, p.Name)
+		}
+		if _, ok := placeholders[p.Name]; ok {
+			return fmt.Errorf("saved query %q: parameter %q is listed twice", q.Name, p.Name)
+		}
+		if p.Type != "" && !slices.Contains(QueryParameterTypes, p.Type) {
+			return fmt.Errorf("saved query %q: parameter %q has type %q (use one of %v)", q.Name, p.Name, p.Type, QueryParameterTypes)
+		}
+		if p.Default != "" && !validDefault(p.Type, p.Default) {
+			return fmt.Errorf("saved query %q: parameter %q has an invalid %s default %q", q.Name, p.Name, p.Type, p.Default)
+		}
+		placeholders[p.Name] = "x"
+	}
+	tmpl, err := q.Template()
+	if err != nil {
+		return fmt.Errorf("saved query %q: %w", q.Name, err)
+	}
+	if err := tmpl.Execute(io.Discard, placeholders); err != nil {
+		return fmt.Errorf("saved query %q refers to an undeclared parameter: %w", q.Name, err)
+	}
+	return nil
+}
+
+// validDefault reports whether value is a valid default of a parameter of type typ.
+func validDefault(typ, value string) bool {
+	switch typ {
+	case "integer":
+		_, err := strconv.Atoi(value)
+		return err == nil
+	case "duration":
+		d, err := time.ParseDuration(value)
+		return err == nil && d > 0
+	default:
+		return true
+	}
+}
+
+// loadFile reads and validates the config file at path. Unknown fields are rejected so typos in
+// section names don't silently disable a setting.
+func loadFile(path string) (File, error) {
+	var f File
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return f, fmt.Errorf("read TEMPORAL_MCP_CONFIG: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return f, fmt.Errorf("parse TEMPORAL_MCP_CONFIG %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool)
+	for i, wt := range f.WorkflowTypes {
+		if wt.Name == "" {
+			return f, fmt.Errorf("TEMPORAL_MCP_CONFIG %s: workflow_types[%d] has no name", path, i)
+		}
+		if seen[wt.Name] {
+			return f, fmt.Errorf("TEMPORAL_MCP_CONFIG %s: workflow type %q is listed twice", path, wt.Name)
+		}
+		seen[wt.Name] = true
+	}
+
+	seen = make(map[string]bool)
+	for i, name := range f.TaskQueues {
+		if name == "" {
+			return f, fmt.Errorf("TEMPORAL_MCP_CONFIG %s: task_queues[%d] is empty", path, i)
+		}
+		if seen[name] {
+			return f, fmt.Errorf("TEMPORAL_MCP_CONFIG %s: task queue %q is listed twice", path, name)
+		}
+		seen[name] = true
+	}
+
+	seen = make(map[string]bool)
+	for i, q := range f.SavedQueries {
+		if q.Name == "" {
+			return f, fmt.Errorf("TEMPORAL_MCP_CONFIG %s: saved_queries[%d] has no name", path, i)
+		}
+		if seen[q.Name] {
+			return f, fmt.Errorf("TEMPORAL_MCP_CONFIG %s: saved query %q is listed twice", path, q.Name)
+		}
+		seen[q.Name] = true
+		if err := q.validate(); err != nil {
+			return f, fmt.Errorf("TEMPORAL_MCP_CONFIG %s: %w", path, err)
+		}
+	}
+	return f, nil
+}
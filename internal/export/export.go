@@ -0,0 +1,199 @@
+// Package export writes listings to files in a configured directory, for results with more rows
+// than fit comfortably in an MCP response.
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// Dir is the directory exports may be written to.
+type Dir struct {
+	// Path is the directory; every export must resolve to a file inside it.
+	Path string
+	// MaxRows caps the rows of a single export.
+	MaxRows int
+}
+
+// Writer streams records to a CSV or JSON-lines file. Records are buffered; Flush writes them out,
+// so a large export never sits in memory.
+type Writer struct {
+	path   string
+	format string
+	file   *os.File
+	buf    *bufio.Writer
+	// csv is nil for JSON lines.
+	csv     *csv.Writer
+	columns []string
+	rows    int
+	// closed is set once the file is complete.
+	closed bool
+}
+
+// Create opens name for writing inside the directory, as CSV for a .csv extension or JSON lines
+// for .jsonl and .ndjson. Relative names are resolved against the directory. Existing files are
+// only replaced when overwrite is set, and never when they are symlinks.
+func (d Dir) Create(name string, overwrite bool) (*Writer, error) {
+	var format string
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		format = "csv"
+	case ".jsonl", ".ndjson":
+		format = "jsonl"
+	default:
+		return nil, fmt.Errorf("unsupported export file extension %q (use .csv, .jsonl, or .ndjson)", filepath.Ext(name))
+	}
+	path, err := d.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if info, err := os.Lstat(path); err == nil {
+		switch {
+		case !overwrite:
+			return nil, fmt.Errorf("%s already exists (set overwrite to replace it)", path)
+		case !info.Mode().IsRegular():
+			return nil, fmt.Errorf("%s exists and isn't a regular file", path)
+		}
+		flags = os.O_WRONLY | os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("%s already exists (set overwrite to replace it)", path)
+		}
+		return nil, err
+	}
+	w := &Writer{path: path, format: format, file: file, buf: bufio.NewWriter(file)}
+	if format == "csv" {
+		w.csv = csv.NewWriter(w.buf)
+	}
+	return w, nil
+}
+
+// resolve returns the absolute path of name, checking that it lies inside the directory once
+// symlinks in its parent directories are resolved.
+func (d Dir) resolve(name string) (string, error) {
+	root, err := filepath.EvalSymlinks(d.Path)
+	if err != nil {
+		return "", fmt.Errorf("export directory: %w", err)
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return "", fmt.Errorf("export directory: %w", err)
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(d.Path, path)
+	}
+	parent, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", fmt.Errorf("export file directory: %w", err)
+	}
+	if parent, err = filepath.Abs(parent); err != nil {
+		return "", fmt.Errorf("export file directory: %w", err)
+	}
+	if rel, err := filepath.Rel(root, parent); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the export directory %s", name, d.Path)
+	}
+	return filepath.Join(parent, filepath.Base(path)), nil
+}
+
+// Write appends record, a struct, as one row. CSV columns are named after the JSON names of the
+// struct's fields, taken from the first record.
+func (w *Writer) Write(record interface{}) error {
+	if w.csv == nil {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if _, err := w.buf.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		w.rows++
+		return nil
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(record))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("export of %T as CSV: not a struct", record)
+	}
+	if w.columns == nil {
+		for i := 0; i < v.NumField(); i++ {
+			w.columns = append(w.columns, columnName(v.Type().Field(i)))
+		}
+		if err := w.csv.Write(w.columns); err != nil {
+			return err
+		}
+	}
+	row := make([]string, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		row = append(row, fmt.Sprint(v.Field(i).Interface()))
+	}
+	if err := w.csv.Write(row); err != nil {
+		return err
+	}
+	w.rows++
+	return nil
+}
+
+// Flush writes the buffered rows to the file.
+func (w *Writer) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return w.buf.Flush()
+}
+
+// Close flushes the remaining rows and closes the file.
+func (w *Writer) Close() error {
+	err := w.Flush()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	w.closed = err == nil
+	return err
+}
+
+// Abort closes and removes a file whose export failed, so it isn't mistaken for a complete one.
+// It does nothing once Close has succeeded, so it can be deferred.
+func (w *Writer) Abort() {
+	if w.closed {
+		return
+	}
+	w.file.Close()
+	os.Remove(w.path)
+}
+
+// Path returns the absolute path of the file.
+func (w *Writer) Path() string {
+	return w.path
+}
+
+// Format returns "csv" or "jsonl".
+func (w *Writer) Format() string {
+	return w.format
+}
+
+// Rows returns how many rows were written.
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+func columnName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
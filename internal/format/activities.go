@@ -0,0 +1,75 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ActivityExecution is one activity invocation reconstructed from history: its scheduled, started,
+// and closing events correlated into a single row.
+type ActivityExecution struct {
+	ScheduledEventID int64  `json:"scheduled_event_id"`
+	ActivityID       string `json:"activity_id"`
+	Type             string `json:"type"`
+	// Local is set for local activities, which are recorded as markers rather than activity events.
+	Local bool `json:"local,omitempty"`
+	// Status is Scheduled, Started, Completed, Failed, TimedOut, or Canceled.
+	Status  string   `json:"status"`
+	Attempt int32    `json:"attempt,omitempty"`
+	Input   []string `json:"input,omitempty"`
+	Result  []string `json:"result,omitempty"`
+	Failure string   `json:"failure,omitempty"`
+	// Duration runs from scheduling to the closing event, so it includes every retry; for local
+	// activities it is reconstructed from the marker, see Marker.Duration.
+	Duration string `json:"duration,omitempty"`
+}
+
+// Failed reports whether the activity ended in failure or timed out.
+func (a ActivityExecution) Failed() bool {
+	return a.Status == "Failed" || a.Status == "TimedOut" || (a.Local && a.Failure != "")
+}
+
+// ActivityList is the activities of a workflow execution, in scheduling order.
+type ActivityList struct {
+	WorkflowID string              `json:"workflow_id"`
+	RunID      string              `json:"run_id"`
+	Activities []ActivityExecution `json:"activities"`
+	// More is set when further matching activities exist past the returned ones.
+	More bool `json:"more,omitempty"`
+}
+
+// ActivityListText renders the activities of a workflow, one block per activity.
+func ActivityListText(l ActivityList) string {
+	if len(l.Activities) == 0 {
+		return fmt.Sprintf("No matching activities in workflow %s (run %s).\n", l.WorkflowID, l.RunID)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d activity execution(s) in workflow %s (run %s):\n", len(l.Activities), l.WorkflowID, l.RunID)
+	for _, a := range l.Activities {
+		kind := ""
+		if a.Local {
+			kind = " [local]"
+		}
+		fmt.Fprintf(&b, "- Event %d: %s%s | ID: %s | Status: %s", a.ScheduledEventID, a.Type, kind, a.ActivityID, a.Status)
+		if a.Attempt > 0 {
+			fmt.Fprintf(&b, " | Attempts: %d", a.Attempt)
+		}
+		if a.Duration != "" {
+			fmt.Fprintf(&b, " | Duration: %s", a.Duration)
+		}
+		b.WriteString("\n")
+		for _, input := range a.Input {
+			fmt.Fprintf(&b, "  Input: %s\n", strings.ReplaceAll(input, "\n", "\n  "))
+		}
+		for _, result := range a.Result {
+			fmt.Fprintf(&b, "  Result: %s\n", strings.ReplaceAll(result, "\n", "\n  "))
+		}
+		if a.Failure != "" {
+			fmt.Fprintf(&b, "  Failure: %s\n", a.Failure)
+		}
+	}
+	if l.More {
+		b.WriteString("More activities follow; raise max_activities to see them.\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ActivityControl is a pause, unpause, or reset of a pending activity: a preview, or its outcome
+// once performed.
+type ActivityControl struct {
+	// Action is pause, unpause, or reset.
+	Action     string `json:"action"`
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+	// Activity is the activity as it was before the action.
+	Activity PendingActivity `json:"activity"`
+	// Options are the action's flags that were set, e.g. reset_attempts.
+	Options []string `json:"options,omitempty"`
+	// Effect says what the action does to the activity.
+	Effect    string `json:"effect"`
+	Performed bool   `json:"performed"`
+	// DryRun is set when the server is in dry-run mode and the confirmed action was skipped.
+	DryRun bool `json:"dry_run,omitempty"`
+	// Gone is set when the activity stopped being pending (it completed, failed, or was canceled)
+	// before the action could be performed.
+	Gone bool `json:"gone,omitempty"`
+	// After is the activity once the action was performed, unless it is no longer pending.
+	After *PendingActivity `json:"after,omitempty"`
+}
+
+// activityActionsPast are the past tenses of the activity actions.
+var activityActionsPast = map[string]string{"pause": "paused", "unpause": "unpaused", "reset": "reset"}
+
+// ActivityControlText renders an activity action preview, or its outcome when it has been performed.
+func ActivityControlText(c ActivityControl) string {
+	var b strings.Builder
+	a := c.Activity
+	switch {
+	case c.Gone:
+		fmt.Fprintf(&b, "Activity %s (type %s) of workflow %s is no longer pending: it completed, failed, or was canceled before it could be %s. Nothing was changed.\n",
+			a.ActivityID, a.Type, c.WorkflowID, activityActionsPast[c.Action])
+		return b.String()
+	case c.DryRun:
+		fmt.Fprintf(&b, "[DRY RUN] would have %s activity %s (type %s) of workflow %s; nothing was changed.\n", activityActionsPast[c.Action], a.ActivityID, a.Type, c.WorkflowID)
+	case c.Performed:
+		fmt.Fprintf(&b, "%s activity %s (type %s) of workflow %s.\n", capitalize(activityActionsPast[c.Action]), a.ActivityID, a.Type, c.WorkflowID)
+	default:
+		fmt.Fprintf(&b, "%s preview for activity %s of workflow %s (not performed):\n", capitalize(c.Action), a.ActivityID, c.WorkflowID)
+	}
+	fmt.Fprintf(&b, "Run ID: %s\n", c.RunID)
+	fmt.Fprintf(&b, "Activity Type: %s\n", a.Type)
+	fmt.Fprintf(&b, "State: %s | Attempt: %d | Paused: %t\n", a.State, a.Attempt, a.Paused)
+	if a.LastFailure != "" {
+		fmt.Fprintf(&b, "Last Failure: %s\n", a.LastFailure)
+	}
+	if len(c.Options) > 0 {
+		fmt.Fprintf(&b, "Options: %s\n", strings.Join(c.Options, ", "))
+	}
+	fmt.Fprintf(&b, "Effect: %s\n", c.Effect)
+	switch {
+	case c.After != nil:
+		fmt.Fprintf(&b, "Now: State: %s | Attempt: %d | Paused: %t\n", c.After.State, c.After.Attempt, c.After.Paused)
+	case c.Performed:
+		b.WriteString("Now: the activity is no longer pending.\n")
+	case !c.DryRun:
+		fmt.Fprintf(&b, "Call again with confirm=true to %s the activity.\n", c.Action)
+	}
+	return b.String()
+}
+
+// capitalize upper-cases the first letter of an ASCII word.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
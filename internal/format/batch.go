@@ -0,0 +1,58 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// BatchEntry is the compact per-workflow summary of a batch describe. Error is set instead of the
+// other fields when the workflow couldn't be described.
+type BatchEntry struct {
+	WorkflowID        string `json:"workflow_id"`
+	RunID             string `json:"run_id,omitempty"`
+	Type              string `json:"type,omitempty"`
+	Status            string `json:"status,omitempty"`
+	Duration          string `json:"duration,omitempty"`
+	PendingActivities int    `json:"pending_activities"`
+	FailingActivities int    `json:"failing_activities"`
+	Error             string `json:"error,omitempty"`
+}
+
+// NewBatchEntry builds a batch entry from a Describe response. The duration of a running workflow is
+// measured up to now.
+func NewBatchEntry(resp *workflowservice.DescribeWorkflowExecutionResponse) BatchEntry {
+	info := resp.GetWorkflowExecutionInfo()
+	entry := BatchEntry{
+		WorkflowID:        info.GetExecution().GetWorkflowId(),
+		RunID:             info.GetExecution().GetRunId(),
+		Type:              info.GetType().GetName(),
+		Status:            WorkflowStatus(info.GetStatus()),
+		PendingActivities: len(resp.GetPendingActivities()),
+		Duration:          RunDuration(info),
+	}
+	for _, activity := range resp.GetPendingActivities() {
+		if activity.GetLastFailure() != nil {
+			entry.FailingActivities++
+		}
+	}
+	return entry
+}
+
+// BatchDescribeText renders a batch describe, one line per requested workflow, followed by how
+// many were processed.
+func BatchDescribeText(entries []BatchEntry, processed string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Described %d workflow(s):\n", len(entries))
+	for _, e := range entries {
+		if e.Error != "" {
+			fmt.Fprintf(&b, "- ID: %s | Error: %s\n", e.WorkflowID, e.Error)
+			continue
+		}
+		fmt.Fprintf(&b, "- ID: %s | Run: %s | Type: %s | Status: %s | Duration: %s | Pending Activities: %d (%d failing)\n",
+			e.WorkflowID, e.RunID, e.Type, e.Status, e.Duration, e.PendingActivities, e.FailingActivities)
+	}
+	fmt.Fprintf(&b, "Batch: %s\n", processed)
+	return b.String()
+}
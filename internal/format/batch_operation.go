@@ -0,0 +1,107 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// BatchOperation is a server batch job (a signal, cancel, terminate, reset, ... applied to the
+// executions matched by a query) and its progress.
+type BatchOperation struct {
+	JobID string `json:"job_id"`
+	// Type is e.g. Signal, Terminate, or Reset; State is Running, Completed, or Failed (a stopped
+	// job reports Failed).
+	Type      string `json:"type"`
+	State     string `json:"state"`
+	StartTime string `json:"start_time"`
+	CloseTime string `json:"close_time,omitempty"`
+	Identity  string `json:"identity,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	// Total is the number of executions the job matched; Completed and Failed count those it
+	// processed as of its last progress checkpoint, so they can lag behind.
+	Total     int64 `json:"total"`
+	Completed int64 `json:"completed"`
+	Failed    int64 `json:"failed"`
+}
+
+// NewBatchOperation builds a batch operation from a DescribeBatchOperation response.
+func NewBatchOperation(resp *workflowservice.DescribeBatchOperationResponse) BatchOperation {
+	return BatchOperation{
+		JobID:     resp.GetJobId(),
+		Type:      resp.GetOperationType().String(),
+		State:     resp.GetState().String(),
+		StartTime: Time(resp.GetStartTime()),
+		CloseTime: Time(resp.GetCloseTime()),
+		Identity:  resp.GetIdentity(),
+		Reason:    resp.GetReason(),
+		Total:     resp.GetTotalOperationCount(),
+		Completed: resp.GetCompleteOperationCount(),
+		Failed:    resp.GetFailureOperationCount(),
+	}
+}
+
+// BatchStop is a stop of a batch job: a preview, or its outcome once performed.
+type BatchStop struct {
+	JobID  string `json:"job_id"`
+	Reason string `json:"reason"`
+	// Job is the batch job as it was before the stop.
+	Job       BatchOperation `json:"job"`
+	Performed bool           `json:"performed"`
+	// DryRun is set when the server is in dry-run mode and the confirmed stop was skipped.
+	DryRun bool `json:"dry_run,omitempty"`
+	// Finished is set when the job had already finished, so there was nothing to stop.
+	Finished bool `json:"finished,omitempty"`
+	// After is the batch job once stopped; Processed counts the executions it processed before the
+	// stop took effect, which keep the operation applied.
+	After     *BatchOperation `json:"after,omitempty"`
+	Processed int64           `json:"processed"`
+}
+
+// BatchStopText renders a batch job stop preview, or its outcome when it has been performed.
+func BatchStopText(s BatchStop) string {
+	var b strings.Builder
+	switch {
+	case s.Finished:
+		fmt.Fprintf(&b, "Batch job %s has already finished (%s); there is nothing to stop. Nothing was changed.\n", s.JobID, s.Job.State)
+	case s.DryRun:
+		fmt.Fprintf(&b, "[DRY RUN] would have stopped batch job %s; nothing was changed.\n", s.JobID)
+	case s.Performed:
+		fmt.Fprintf(&b, "Stopped batch job %s.\n", s.JobID)
+	default:
+		fmt.Fprintf(&b, "Stop preview for batch job %s (not performed):\n", s.JobID)
+	}
+	job := s.Job
+	if s.After != nil {
+		job = *s.After
+	}
+	batchOperationText(&b, job)
+	switch {
+	case s.Performed:
+		fmt.Fprintf(&b, "Processed before the stop: %d of %d execution(s) (%d completed, %d failed). The operation stays applied to them; the rest were left untouched.\n",
+			s.Processed, job.Total, job.Completed, job.Failed)
+		b.WriteString("The server updates these counts as the job checkpoints its progress, so executions processed just before the stop can be missing from them: check the matched executions themselves before cleaning up.\n")
+	case !s.DryRun && !s.Finished:
+		fmt.Fprintf(&b, "Stop reason: %s\n", s.Reason)
+		b.WriteString("Call again with confirm=true to stop the batch job; executions it already processed are not rolled back.\n")
+	}
+	return b.String()
+}
+
+// batchOperationText writes the details of a batch job.
+func batchOperationText(b *strings.Builder, o BatchOperation) {
+	fmt.Fprintf(b, "Operation: %s | State: %s\n", o.Type, o.State)
+	fmt.Fprintf(b, "Started: %s", o.StartTime)
+	if o.CloseTime != "" {
+		fmt.Fprintf(b, " | Closed: %s", o.CloseTime)
+	}
+	b.WriteString("\n")
+	if o.Identity != "" {
+		fmt.Fprintf(b, "Identity: %s\n", o.Identity)
+	}
+	if o.Reason != "" {
+		fmt.Fprintf(b, "Reason: %s\n", o.Reason)
+	}
+	fmt.Fprintf(b, "Progress: %d completed, %d failed of %d execution(s)\n", o.Completed, o.Failed, o.Total)
+}
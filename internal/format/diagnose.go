@@ -0,0 +1,67 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DiagnosticStage is one connection check of a diagnosis.
+type DiagnosticStage struct {
+	Name string `json:"name"`
+	// Result is pass, fail, or skipped.
+	Result   string `json:"result"`
+	Detail   string `json:"detail,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// ConnectionDiagnosis is the staged check of the connection to Temporal.
+type ConnectionDiagnosis struct {
+	// Address is the active endpoint, the one checked; Addresses lists every configured endpoint
+	// when there are several to fail over between.
+	Address   string   `json:"address"`
+	Addresses []string `json:"addresses,omitempty"`
+	Namespace string   `json:"namespace"`
+	Identity  string   `json:"identity"`
+	TLS       string   `json:"tls"`
+	// Reconnects counts rebuilds of the connection after persistent failures; LastReconnect is
+	// when the last one happened.
+	Reconnects    int               `json:"reconnects"`
+	LastReconnect *time.Time        `json:"last_reconnect,omitempty"`
+	OK            bool              `json:"ok"`
+	Stages        []DiagnosticStage `json:"stages"`
+}
+
+// ConnectionDiagnosisText renders a connection diagnosis, one line per stage.
+func ConnectionDiagnosisText(d ConnectionDiagnosis) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Connection to %s (namespace %s):\n", d.Address, d.Namespace)
+	if len(d.Addresses) > 0 {
+		fmt.Fprintf(&b, "Active endpoint %s of %s\n", d.Address, strings.Join(d.Addresses, ", "))
+	}
+	for _, s := range d.Stages {
+		fmt.Fprintf(&b, "- %s: %s", s.Name, strings.ToUpper(s.Result))
+		if s.Duration != "" {
+			fmt.Fprintf(&b, " (%s)", s.Duration)
+		}
+		switch {
+		case s.Error != "":
+			fmt.Fprintf(&b, ": %s", s.Error)
+		case s.Detail != "":
+			fmt.Fprintf(&b, ": %s", s.Detail)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "TLS: %s\n", d.TLS)
+	fmt.Fprintf(&b, "Client identity: %s\n", d.Identity)
+	if d.LastReconnect != nil {
+		fmt.Fprintf(&b, "Reconnects: %d (last at %s)\n", d.Reconnects, d.LastReconnect.Format(time.RFC3339))
+	} else {
+		b.WriteString("Reconnects: none\n")
+	}
+	if d.OK {
+		b.WriteString("All checks passed.\n")
+	}
+	return b.String()
+}
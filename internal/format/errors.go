@@ -0,0 +1,88 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToolError is a failed tool call: the error message, and for failed Temporal calls the gRPC code
+// name with a one-line explanation and a hint on what to do next.
+type ToolError struct {
+	Error       string `json:"error"`
+	Code        string `json:"code,omitempty"`
+	Explanation string `json:"explanation,omitempty"`
+	Hint        string `json:"hint,omitempty"`
+	// Suggestions lists workflow IDs similar to one that wasn't found.
+	Suggestions []string `json:"suggestions,omitempty"`
+	// Namespace is the namespace that doesn't exist, and AvailableNamespaces those that do.
+	Namespace           string   `json:"namespace,omitempty"`
+	AvailableNamespaces []string `json:"available_namespaces,omitempty"`
+	// Argument names the tool argument that was missing or invalid.
+	Argument string `json:"argument,omitempty"`
+	// Retention explains, for a workflow that wasn't found, when the namespace deletes closed
+	// executions and whether they were archived.
+	Retention string `json:"retention,omitempty"`
+}
+
+// ErrorEnvelope is the JSON body of a failed call that asked for JSON output.
+type ErrorEnvelope struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes a failed call in an ErrorEnvelope. Code is the gRPC code name of a failed
+// Temporal call, InvalidArgument for a rejected argument, and Unknown otherwise; Details holds the
+// error's specifics, such as the argument at fault or similar workflow IDs.
+type ErrorDetail struct {
+	Code        string                 `json:"code"`
+	Message     string                 `json:"message"`
+	Explanation string                 `json:"explanation,omitempty"`
+	Hint        string                 `json:"hint,omitempty"`
+	Details     map[string]interface{} `json:"details,omitempty"`
+}
+
+// Envelope returns e as the body of a JSON error.
+func (e ToolError) Envelope() ErrorEnvelope {
+	detail := ErrorDetail{Code: e.Code, Message: e.Error, Explanation: e.Explanation, Hint: e.Hint}
+	if detail.Code == "" {
+		detail.Code = "Unknown"
+	}
+	details := map[string]interface{}{}
+	if e.Argument != "" {
+		details["argument"] = e.Argument
+	}
+	if len(e.Suggestions) > 0 {
+		details["suggestions"] = e.Suggestions
+	}
+	if e.Namespace != "" {
+		details["namespace"] = e.Namespace
+	}
+	if len(e.AvailableNamespaces) > 0 {
+		details["available_namespaces"] = e.AvailableNamespaces
+	}
+	if e.Retention != "" {
+		details["retention"] = e.Retention
+	}
+	if len(details) > 0 {
+		detail.Details = details
+	}
+	return ErrorEnvelope{Error: detail}
+}
+
+// ToolErrorText renders the error message followed by its code, hint, and suggestions.
+func ToolErrorText(e ToolError) string {
+	var b strings.Builder
+	b.WriteString(e.Error)
+	if e.Code != "" {
+		fmt.Fprintf(&b, "\nCode: %s (%s)", e.Code, e.Explanation)
+	}
+	if e.Hint != "" {
+		fmt.Fprintf(&b, "\nHint: %s", e.Hint)
+	}
+	if e.Retention != "" {
+		fmt.Fprintf(&b, "\nRetention: %s", e.Retention)
+	}
+	if len(e.Suggestions) > 0 {
+		b.WriteString("\nDid you mean one of these workflow IDs?\n- " + strings.Join(e.Suggestions, "\n- "))
+	}
+	return b.String()
+}
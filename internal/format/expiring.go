@@ -0,0 +1,60 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExpiringWorkflow is a running workflow that will hit its execution timeout within the searched
+// window.
+type ExpiringWorkflow struct {
+	WorkflowID       string `json:"workflow_id"`
+	RunID            string `json:"run_id"`
+	WorkflowType     string `json:"workflow_type"`
+	StartTime        string `json:"start_time"`
+	ExecutionTimeout string `json:"execution_timeout"`
+	ExpirationTime   string `json:"expiration_time"`
+	// Remaining is the time left before the timeout, negative once it passed but the server hasn't
+	// timed the workflow out yet.
+	Remaining        string `json:"remaining"`
+	RemainingSeconds int64  `json:"remaining_seconds"`
+}
+
+// ExpiringWorkflowSearch is the result of searching running workflows for those about to hit
+// their execution timeout.
+type ExpiringWorkflowSearch struct {
+	Within string `json:"within"`
+	// Running is the number of running workflows matching the filters, or -1 if it couldn't be counted.
+	Running int64 `json:"running"`
+	// Listed is how many of them were listed, and Coverage how many of those were described.
+	Listed   int    `json:"listed"`
+	Coverage string `json:"coverage"`
+	// WithoutTimeout counts the described workflows that have no execution timeout.
+	WithoutTimeout int                `json:"without_timeout"`
+	Workflows      []ExpiringWorkflow `json:"workflows"`
+}
+
+// ExpiringWorkflowsText renders the workflows about to time out, most urgent first, followed by
+// the coverage of the search.
+func ExpiringWorkflowsText(s ExpiringWorkflowSearch) string {
+	var b strings.Builder
+	if len(s.Workflows) == 0 {
+		fmt.Fprintf(&b, "No running workflows will hit their execution timeout within %s.\n", s.Within)
+	} else {
+		fmt.Fprintf(&b, "Found %d running workflow(s) that will hit their execution timeout within %s:\n", len(s.Workflows), s.Within)
+		for _, w := range s.Workflows {
+			remaining := "in " + w.Remaining
+			if w.RemainingSeconds < 0 {
+				remaining = "overdue by " + strings.TrimPrefix(w.Remaining, "-")
+			}
+			fmt.Fprintf(&b, "- ID: %s | Run: %s | Type: %s | Started: %s | Timeout: %s | Expires: %s (%s)\n",
+				w.WorkflowID, w.RunID, w.WorkflowType, w.StartTime, w.ExecutionTimeout, w.ExpirationTime, remaining)
+		}
+	}
+	running := "unknown"
+	if s.Running >= 0 {
+		running = fmt.Sprint(s.Running)
+	}
+	fmt.Fprintf(&b, "Running workflows matching the filters: %s; listed %d; described: %s; %d without an execution timeout (skipped).\n", running, s.Listed, s.Coverage, s.WithoutTimeout)
+	return b.String()
+}
@@ -0,0 +1,83 @@
+// Package format renders Temporal data into the text output returned by the tools. Tool output is
+// built in two steps: proto responses are converted into plain view structs, which renderers turn
+// into text. Agents key off these formats, so changes here are user-visible.
+package format
+
+import (
+	"fmt"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Time formats a timestamp as RFC 3339 in UTC, or returns "" when it is unset.
+func Time(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
+
+// RunDuration returns how long an execution ran, or has been running so far if it is still open,
+// rounded to the second; it returns "" when the start time is unknown.
+func RunDuration(info *workflowpb.WorkflowExecutionInfo) string {
+	if info.GetStartTime() == nil {
+		return ""
+	}
+	end := time.Now()
+	if info.GetCloseTime() != nil {
+		end = info.GetCloseTime().AsTime()
+	}
+	return end.Sub(info.GetStartTime().AsTime()).Round(time.Second).String()
+}
+
+// WorkflowStatus converts a WorkflowExecutionStatus enum to a readable string.
+func WorkflowStatus(status enumspb.WorkflowExecutionStatus) string {
+	switch status {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:
+		return "Running"
+	case enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		return "Completed"
+	case enumspb.WORKFLOW_EXECUTION_STATUS_FAILED:
+		return "Failed"
+	case enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED:
+		return "Canceled"
+	case enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED:
+		return "Terminated"
+	case enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW:
+		return "ContinuedAsNew"
+	case enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT:
+		return "TimedOut"
+	default:
+		return "Unknown"
+	}
+}
+
+// ActivityState converts a PendingActivityState enum to a readable string.
+func ActivityState(state enumspb.PendingActivityState) string {
+	switch state {
+	case enumspb.PENDING_ACTIVITY_STATE_SCHEDULED:
+		return "Scheduled"
+	case enumspb.PENDING_ACTIVITY_STATE_STARTED:
+		return "Started"
+	case enumspb.PENDING_ACTIVITY_STATE_CANCEL_REQUESTED:
+		return "CancelRequested"
+	default:
+		return "Unknown"
+	}
+}
+
+// Failure summarizes a failure as its message, with the application error type when set, or
+// returns "" when it is nil.
+func Failure(f *failurepb.Failure) string {
+	if f == nil {
+		return ""
+	}
+	if errType := f.GetApplicationFailureInfo().GetType(); errType != "" {
+		return fmt.Sprintf("%s (type %s)", f.GetMessage(), errType)
+	}
+	return f.GetMessage()
+}
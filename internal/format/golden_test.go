@@ -0,0 +1,205 @@
+package format
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata with the current output")
+
+// now is the fixed instant renderers that compute ages are given.
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// goldenCase is a view rendered as text and as JSON, each compared with its golden file.
+type goldenCase struct {
+	name string
+	view any
+	text func() string
+}
+
+func golden[T any](name string, view T, render func(T) string) goldenCase {
+	return goldenCase{name: name, view: view, text: func() string { return render(view) }}
+}
+
+func TestGolden(t *testing.T) {
+	summaries := []WorkflowSummary{
+		{WorkflowID: "order-1", RunID: "order-1-run", Type: "OrderWorkflow", Status: "Completed", StartTime: "2024-05-01T09:30:00Z", CloseTime: "2024-05-01T10:30:00Z"},
+		{WorkflowID: "order-2", RunID: "order-2-run", Type: "OrderWorkflow", Status: "Failed", StartTime: "2024-05-01T09:45:00Z", CloseTime: "2024-05-01T09:46:12Z", Duration: "1m12s"},
+	}
+	pending := PendingActivity{ActivityID: "charge-1", Type: "ChargeCard", State: "Scheduled", Attempt: 4, HeartbeatDetails: []string{`{"progress": 3}`}, LastFailure: "card declined (ApplicationError)"}
+	batch := BatchOperation{JobID: "job-7", Type: "Signal", State: "Running", StartTime: "2024-05-01T09:00:00Z", Identity: "ops@build-01", Reason: "backfill", Total: 120, Completed: 80, Failed: 2}
+	tests := []goldenCase{
+		golden("workflow_list", summaries, func(w []WorkflowSummary) string { return WorkflowListText("completed", w, true) }),
+		golden("workflow_list_empty", []WorkflowSummary{}, func(w []WorkflowSummary) string { return WorkflowListText("running", w, false) }),
+		golden("workflow_details", WorkflowDetails{
+			WorkflowID:        "order-1",
+			RunID:             "order-1-run",
+			Type:              "OrderWorkflow",
+			Status:            "Running",
+			StartTime:         "2024-05-01T09:30:00Z",
+			Memo:              map[string]string{"customer": `"acme"`},
+			Input:             []string{"{\n  \"order\": \"A-17\"\n}"},
+			PendingActivities: []PendingActivity{pending},
+			PendingNexusOperations: []PendingNexusOperation{{
+				Endpoint: "payments", Service: "billing", Operation: "charge", State: "BackingOff", Attempt: 2,
+				ScheduledTime: "2024-05-01T09:31:00Z", ScheduledEventID: 5, LastFailure: "handler unavailable", NextAttempt: "2024-05-01T09:32:00Z",
+			}},
+			PendingWorkflowTask: &PendingWorkflowTask{State: "Scheduled", ScheduledTime: "2024-05-01T11:59:00Z", Attempt: 3, OriginalScheduledTime: "2024-05-01T11:58:00Z", Age: "2m0s"},
+			HistoryLength:       42000,
+			HistorySizeBytes:    3 << 20,
+			HistoryWarnings:     []string{"42000 events, past the 40960-event warning threshold (the server terminates the workflow at 51200)"},
+			FirstRunID:          "order-1-first",
+			ChainStartTime:      "2024-04-01T09:30:00Z",
+			ChainAge:            "30d2h30m0s",
+			Versioning:          &WorkflowVersioning{Behavior: "Pinned", Version: "orders.v12"},
+		}, WorkflowDetailsText),
+		golden("server_info", ServerInfo{
+			Version: "v1.2.3", Commit: "abc1234", BuildDate: "2024-01-01T00:00:00Z", GoVersion: "go1.24.0",
+			TemporalAddress: "localhost:7233", TemporalNamespace: "default", Identity: "temporal-mcp@build-01",
+			TemporalServerVersion: "1.27.1",
+		}, ServerInfoText),
+		golden("tool_error", ToolError{
+			Error:       "Failed to describe workflow: workflow not found for ID: order-A17",
+			Code:        "NotFound",
+			Explanation: "the workflow, run, or other resource doesn't exist, or was deleted after its retention period",
+			Hint:        "check the workflow_id (and run_id), or find it with list_workflows",
+			Suggestions: []string{"order-A17-2024", "order-A17-2025"},
+			Retention:   "namespace default keeps closed executions for 3 days",
+		}, ToolErrorText),
+		golden("schedule_runs", ScheduleRuns{ScheduleID: "nightly", Truncated: true, Runs: []ScheduleRun{
+			{WorkflowID: "nightly-2024-05-01T00:00:00Z", RunID: "r1", Type: "Report", Status: "Completed", ScheduledTime: "2024-05-01T00:00:00Z", StartTime: "2024-05-01T00:00:02Z", CloseTime: "2024-05-01T00:10:00Z", Drift: "2s"},
+		}}, ScheduleRunsText),
+		golden("schedule_preview", SchedulePreview{Spec: []string{"cron 0 9 * * MON-FRI"}, Start: "2024-05-01T00:00:00Z", End: "2024-05-08T00:00:00Z", Times: []string{"2024-05-01T09:00:00Z", "2024-05-02T09:00:00Z"}, Total: 5}, SchedulePreviewText),
+		golden("schedule_preview_not_evaluated", SchedulePreview{Spec: []string{"cron 0 9 * * MON-FRI"}, Start: "2024-05-01T00:00:00Z", End: "2024-05-08T00:00:00Z", NotEvaluated: true}, SchedulePreviewText),
+		golden("schedule_actions", ScheduleActions{ScheduleID: "nightly", Paused: true, Notes: "paused for maintenance", ActionCount: 31, MissedCatchupWindow: 1, OverlapSkipped: 2, ConsecutiveFailures: 2, Actions: []ScheduleAction{
+			{ScheduledTime: "2024-05-01T00:00:00Z", ActualTime: "2024-05-01T00:00:01Z", WorkflowID: "nightly-1", RunID: "r1", Status: "Failed", CloseTime: "2024-05-01T00:05:00Z"},
+			{ScheduledTime: "2024-04-30T00:00:00Z", ActualTime: "2024-04-30T00:00:01Z", WorkflowID: "nightly-0", RunID: "r0", Error: "workflow not found"},
+		}}, ScheduleActionsText),
+		golden("namespace_list", []NamespaceSummary{
+			{Name: "default", State: "Registered", Retention: "3 days", Default: true},
+			{Name: "payments", State: "Registered", Description: "Payment workflows", Retention: "30 days"},
+		}, NamespaceListText),
+		golden("namespace_deletion", NamespaceDeletion{Namespace: NamespaceSummary{Name: "staging", State: "Registered", Retention: "1 day"}, Workflows: 12, Running: 3}, NamespaceDeletionText),
+		golden("namespace_replication", NamespaceReplication{Namespace: "payments", Global: true, ActiveCluster: "east", Clusters: []string{"east", "west"}, FailoverVersion: 12, State: "Normal", Failover: []Failover{{Time: "2024-04-01T00:00:00Z", Version: 2}}, ConnectedCluster: "west", Standby: true}, NamespaceReplicationText),
+		golden("archival_update", ArchivalUpdate{Namespace: "default", Before: Archival{History: "Disabled", Visibility: "Disabled"}, Changes: []string{"history archival: Disabled -> Enabled"}, Performed: true, After: &Archival{History: "Enabled", HistoryURI: "file:///tmp/archival", Visibility: "Disabled"}}, ArchivalUpdateText),
+		golden("activity_list", ActivityList{WorkflowID: "order-1", RunID: "order-1-run", More: true, Activities: []ActivityExecution{
+			{ScheduledEventID: 5, ActivityID: "1", Type: "ChargeCard", Status: "Completed", Attempt: 2, Input: []string{`"A-17"`}, Result: []string{`"ok"`}, Duration: "1.5s"},
+			{ScheduledEventID: 9, ActivityID: "2", Type: "Lookup", Local: true, Status: "Failed", Failure: "timeout (ApplicationError)"},
+		}}, ActivityListText),
+		golden("signal_list", SignalList{WorkflowID: "order-1", RunID: "order-1-run", Signals: []Signal{{EventID: 7, Name: "approve", Time: "2024-05-01T09:40:00Z", Identity: "ops@host", Input: []string{`{"by": "ada"}`}}}}, SignalListText),
+		golden("signal_counts", SignalCounts{WorkflowID: "order-1", RunID: "order-1-run", Total: 3, Scanned: 120, PageLimit: true, Signals: []SignalSummary{{Name: "approve", Count: 3, First: "2024-05-01T09:40:00Z", Last: "2024-05-01T11:40:00Z"}}}, SignalCountsText),
+		golden("history_search", HistorySearch{WorkflowID: "order-1", RunID: "order-1-run", Pattern: "declined", Scanned: 40, MatchLimit: true, Matches: []HistoryMatch{{EventID: 12, EventType: "ActivityTaskFailed", EventTime: "2024-05-01T09:35:00Z", Snippet: "...card declined..."}}}, HistorySearchText),
+		golden("activity_control", ActivityControl{Action: "pause", WorkflowID: "order-1", RunID: "order-1-run", Activity: pending, Effect: "no new attempts are scheduled until the activity is unpaused", DryRun: true}, ActivityControlText),
+		golden("batch_stop", BatchStop{JobID: "job-7", Reason: "wrong cohort", Job: batch}, BatchStopText),
+		golden("signal_matching", SignalMatching{Query: "WorkflowType = 'OrderWorkflow'", SignalName: "cancel", Input: `"now"`, Matched: 3, Closed: 1, Performed: true, Targets: []SignalTarget{
+			{WorkflowID: "order-1", RunID: "r1", Type: "OrderWorkflow", Outcome: "signaled"},
+			{WorkflowID: "order-2", RunID: "r2", Type: "OrderWorkflow", Outcome: "failed", Error: "context deadline exceeded"},
+		}}, SignalMatchingText),
+		golden("workflow_stats", WorkflowStats{GroupBy: "status", Window: "in the last 24h0m0s", Total: 15, Counts: []StatusCount{{Status: "Completed", Count: 12}, {Status: "Failed", Count: 3}}}, WorkflowStatsText),
+		golden("workflow_histogram", WorkflowHistogram{Bucket: "1h0m0s", Status: "Failed", Total: 5, Partial: true, Buckets: []HistogramBucket{
+			{Start: "2024-05-01T10:00:00Z", End: "2024-05-01T11:00:00Z", Count: 4},
+			{Start: "2024-05-01T11:00:00Z", End: "2024-05-01T12:00:00Z", Count: 1},
+		}}, WorkflowHistogramText),
+		golden("reset_points", []ResetPoint{{BuildID: "v12", RunID: "order-1-run", EventID: 4, CreateTime: "2024-05-01T09:30:01Z", ExpireTime: "2024-05-04T09:30:01Z", Resettable: true}}, func(p []ResetPoint) string { return ResetPointsText("order-1", p) }),
+		golden("reset_plan", ResetPlan{WorkflowID: "order-1", RunID: "order-1-run", WorkflowType: "OrderWorkflow", Status: "Running", Duration: "2h30m0s", EventID: 4, EventType: "WorkflowTaskCompleted", Reapply: "exclude_signals", SignalsAfter: 2, UpdatesAfter: 1, UpdatesReapplied: true}, ResetPlanText),
+		golden("history_summary", HistorySummary{
+			WorkflowID: "order-1", RunID: "order-1-run", WorkflowType: "OrderWorkflow", Events: 40,
+			StartTime: "2024-05-01T09:30:00Z", TaskQueue: "orders", InputPayloads: 1, InputBytes: 18, InputKeys: []string{"order"},
+			Activities: []ActivityTypeSummary{{Type: "ChargeCard", Scheduled: 2, Completed: 1, Failed: 1, Retries: 3, TotalDuration: "4s"}},
+			Signals:    []SignalSummary{{Name: "approve", Count: 1, First: "2024-05-01T09:40:00Z", Last: "2024-05-01T09:40:00Z"}},
+			Children:   []ChildSummary{{WorkflowID: "ship-1", Type: "ShipWorkflow", Outcome: "Completed"}},
+			Timers:     2,
+			Failures:   []FailureSummary{{EventID: 12, EventType: "ActivityTaskFailed", Message: "card declined"}},
+			Versions:   []VersionSummary{{EventID: 6, ChangeID: "new-pricing", Version: "1"}},
+			Markers:    []MarkerSummary{{Name: "SideEffect", Count: 2}},
+			Close:      "Completed", CloseTime: "2024-05-01T10:30:00Z",
+		}, HistorySummaryText),
+		golden("timer_list", TimerList{WorkflowID: "order-1", RunID: "order-1-run", Status: "Running", Timers: []Timer{
+			{TimerID: "2", StartedEventID: 15, StartTime: "2024-05-01T11:00:00Z", Duration: "3h0m0s", FireTime: "2024-05-01T14:00:00Z", Status: "Pending"},
+			{TimerID: "1", StartedEventID: 8, StartTime: "2024-05-01T10:00:00Z", Duration: "1h0m0s", FireTime: "2024-05-01T11:00:00Z", Status: "Fired"},
+		}}, func(l TimerList) string { return TimerListText(l, now) }),
+		golden("expiring_workflows", ExpiringWorkflowSearch{Within: "24h0m0s", Running: 40, Listed: 40, Coverage: "described 40 of 40", WithoutTimeout: 38, Workflows: []ExpiringWorkflow{
+			{WorkflowID: "order-1", RunID: "r1", WorkflowType: "OrderWorkflow", StartTime: "2024-04-30T13:00:00Z", ExecutionTimeout: "24h0m0s", ExpirationTime: "2024-05-01T13:00:00Z", Remaining: "1h0m0s", RemainingSeconds: 3600},
+		}}, ExpiringWorkflowsText),
+		golden("update_result", UpdateResult{WorkflowID: "order-1", RunID: "order-1-run", UpdateID: "set-address", Found: true, Stage: "Completed", Outcome: "success", Result: []string{`"ok"`}}, UpdateResultText),
+		golden("nexus_endpoint", NexusEndpoint{Name: "payments", ID: "ep-1", Version: 3, Target: "worker", TargetNamespace: "payments", TargetTaskQueue: "nexus", Description: "Payments API", URLPrefix: "/nexus/endpoints/ep-1", CreatedTime: "2024-04-01T00:00:00Z"}, NexusEndpointText),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertGolden(t, tt.name+".golden", tt.text())
+			out, err := JSON(tt.view)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, tt.name+".json.golden", out)
+		})
+	}
+}
+
+// TestGoldenErrorEnvelope pins the JSON body of failed calls that asked for JSON output, which
+// clients parse as an API.
+func TestGoldenErrorEnvelope(t *testing.T) {
+	tests := []struct {
+		name string
+		err  ToolError
+	}{
+		{"not_found", ToolError{
+			Error:       "Failed to describe workflow: workflow not found for ID: order-A17",
+			Code:        "NotFound",
+			Explanation: "the workflow, run, or other resource doesn't exist, or was deleted after its retention period",
+			Hint:        "check the workflow_id (and run_id), or find it with list_workflows",
+			Suggestions: []string{"order-A17-2024", "order-A17-2025"},
+			Retention:   "namespace default keeps closed executions for 3 days",
+		}},
+		{"invalid_argument", ToolError{
+			Error:       "invalid value for 'page_size': must be between 1 and 1000",
+			Code:        "InvalidArgument",
+			Explanation: "an argument of the call is missing or invalid",
+			Hint:        "fix the argument named in the error; the tool's input schema lists the accepted values",
+			Argument:    "page_size",
+		}},
+		{"namespace_not_found", ToolError{
+			Error:               "Failed to list workflows: namespace 'payments' does not exist on this cluster; available: default, orders",
+			Code:                "NotFound",
+			Explanation:         "the namespace isn't registered on the connected Temporal cluster",
+			Hint:                "check the namespace argument or TEMPORAL_NAMESPACE; list_namespaces shows the registered ones",
+			Namespace:           "payments",
+			AvailableNamespaces: []string{"default", "orders"},
+		}},
+		{"unknown", ToolError{Error: "Exports are disabled on this server"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := JSON(tt.err.Envelope())
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, "error_envelope_"+tt.name+".json.golden", out)
+		})
+	}
+}
+
+// assertGolden compares got with the golden file testdata/name, or rewrites the file with -update.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test ./internal/format -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s (run go test ./internal/format -update if the change is intended):\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HealthCount is a workflow count of a namespace health summary; Error is set when it couldn't be counted.
+type HealthCount struct {
+	Count int64  `json:"count"`
+	Error string `json:"error,omitempty"`
+}
+
+// ScheduleHealth reports the paused schedules of a namespace.
+type ScheduleHealth struct {
+	Total  int      `json:"total"`
+	Paused []string `json:"paused"`
+	// Truncated means the namespace has more schedules than were listed.
+	Truncated bool   `json:"truncated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// TaskQueueHealth reports which of the operator's known task queues have no workflow pollers.
+type TaskQueueHealth struct {
+	Known    int      `json:"known"`
+	Unpolled []string `json:"unpolled"`
+	// Errors lists known task queues that couldn't be checked.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// OldestRunning is the longest-running open workflow of a namespace.
+type OldestRunning struct {
+	WorkflowID   string `json:"workflow_id,omitempty"`
+	RunID        string `json:"run_id,omitempty"`
+	WorkflowType string `json:"workflow_type,omitempty"`
+	StartTime    string `json:"start_time,omitempty"`
+	Running      string `json:"running,omitempty"`
+	// Sampled is set when the server can't sort by start time and the oldest of a sample of
+	// running workflows was taken instead.
+	Sampled int    `json:"sampled,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// NamespaceHealth is the morning-check summary of a namespace. Each section is filled in
+// independently, so one failing RPC only blanks its own section.
+type NamespaceHealth struct {
+	Namespace  string          `json:"namespace"`
+	Window     string          `json:"window"`
+	Running    HealthCount     `json:"running"`
+	Failed     HealthCount     `json:"failed"`
+	Terminated HealthCount     `json:"terminated"`
+	Schedules  ScheduleHealth  `json:"schedules"`
+	TaskQueues TaskQueueHealth `json:"task_queues"`
+	Oldest     OldestRunning   `json:"oldest_running"`
+}
+
+// NamespaceHealthText renders a compact namespace health report, one line per section, suitable
+// for pasting into a chat channel.
+func NamespaceHealthText(h NamespaceHealth) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Namespace %s health:\n", h.Namespace)
+	fmt.Fprintf(&b, "- Running: %s\n", healthCount(h.Running))
+	fmt.Fprintf(&b, "- Failed %s: %s\n", h.Window, healthCount(h.Failed))
+	fmt.Fprintf(&b, "- Terminated %s: %s\n", h.Window, healthCount(h.Terminated))
+
+	b.WriteString("- Paused schedules: ")
+	switch s := h.Schedules; {
+	case s.Error != "":
+		fmt.Fprintf(&b, "unavailable (%s)", s.Error)
+	case len(s.Paused) == 0:
+		fmt.Fprintf(&b, "none of %d", s.Total)
+	default:
+		fmt.Fprintf(&b, "%d of %d (%s)", len(s.Paused), s.Total, strings.Join(s.Paused, ", "))
+	}
+	if h.Schedules.Truncated {
+		b.WriteString(", more schedules not listed")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("- Task queues without pollers: ")
+	switch q := h.TaskQueues; {
+	case q.Known == 0:
+		b.WriteString("not checked (no task_queues in the config file)")
+	case len(q.Unpolled) == 0 && len(q.Errors) == 0:
+		fmt.Fprintf(&b, "none of %d known", q.Known)
+	default:
+		fmt.Fprintf(&b, "%d of %d known", len(q.Unpolled), q.Known)
+		if len(q.Unpolled) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(q.Unpolled, ", "))
+		}
+		if len(q.Errors) > 0 {
+			fmt.Fprintf(&b, "; unable to check %s", strings.Join(q.Errors, "; "))
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString("- Oldest running: ")
+	switch o := h.Oldest; {
+	case o.Error != "":
+		fmt.Fprintf(&b, "unavailable (%s)", o.Error)
+	case o.WorkflowID == "":
+		b.WriteString("none")
+	default:
+		fmt.Fprintf(&b, "%s (type %s, run %s) started %s, running %s", o.WorkflowID, o.WorkflowType, o.RunID, o.StartTime, o.Running)
+		if o.Sampled > 0 {
+			fmt.Fprintf(&b, " (oldest of %d sampled)", o.Sampled)
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// healthCount renders a health count, or why it is missing.
+func healthCount(c HealthCount) string {
+	if c.Error != "" {
+		return fmt.Sprintf("unavailable (%s)", c.Error)
+	}
+	return fmt.Sprint(c.Count)
+}
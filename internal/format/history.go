@@ -0,0 +1,238 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wricardo/temporal-mcp/internal/payload"
+)
+
+// ProtoJSON renders every field of a proto message as indented protojson, with each payload
+// replaced by its rendering from r: decoded JSON where possible, a description string otherwise.
+// Redaction and the payload size limit apply as for any other payload output.
+func ProtoJSON(msg proto.Message, r *payload.Renderer) (string, error) {
+	tree, err := protoTree(msg, r)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.MarshalIndent(tree, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// CompactProtoJSON is ProtoJSON on a single line, without HTML escaping, for matching and snippets.
+func CompactProtoJSON(msg proto.Message, r *payload.Renderer) (string, error) {
+	tree, err := protoTree(msg, r)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(tree); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// protoTree converts a proto message into a generic JSON tree with its payloads rendered.
+func protoTree(msg proto.Message, r *payload.Renderer) (interface{}, error) {
+	raw, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return nil, err
+	}
+	return renderPayloads(tree, r), nil
+}
+
+// renderPayloads walks a protojson tree, replacing every object shaped like a Payload.
+func renderPayloads(node interface{}, r *payload.Renderer) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if p, ok := asPayload(v); ok {
+			rendered := r.Render(p)
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(rendered), &decoded); err == nil {
+				return decoded
+			}
+			return rendered
+		}
+		for key, child := range v {
+			v[key] = renderPayloads(child, r)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = renderPayloads(child, r)
+		}
+		return v
+	default:
+		return node
+	}
+}
+
+// asPayload converts a protojson object back into a Payload if it has a Payload's shape: a
+// metadata map with an encoding, and no fields other than those of a Payload.
+func asPayload(obj map[string]interface{}) (*commonpb.Payload, bool) {
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok || metadata["encoding"] == nil {
+		return nil, false
+	}
+	for key := range obj {
+		if key != "metadata" && key != "data" && key != "externalPayloads" {
+			return nil, false
+		}
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, false
+	}
+	var p commonpb.Payload
+	if err := protojson.Unmarshal(raw, &p); err != nil {
+		return nil, false
+	}
+	return &p, true
+}
+
+// HistoryEventText renders a single history event with its full attributes.
+func HistoryEventText(workflowID, runID string, event *historypb.HistoryEvent, attributes string) string {
+	return fmt.Sprintf("Event %d (%s) of workflow %s (run %s), at %s:\n%s\n",
+		event.GetEventId(), event.GetEventType(), workflowID, runID, Time(event.GetEventTime()), attributes)
+}
+
+// SignalCounts is the number of signals received by a workflow execution, in total and per signal
+// name, without their payloads.
+type SignalCounts struct {
+	WorkflowID string          `json:"workflow_id"`
+	RunID      string          `json:"run_id"`
+	SignalName string          `json:"signal_name,omitempty"`
+	Total      int             `json:"total"`
+	Signals    []SignalSummary `json:"signals"`
+	Scanned    int             `json:"events_scanned"`
+	// PageLimit is set when counting stopped before the end of the history, so the counts are lower
+	// bounds.
+	PageLimit bool `json:"page_limit_reached,omitempty"`
+}
+
+// SignalCountsText renders signal counts, one line per signal name.
+func SignalCountsText(c SignalCounts) string {
+	var b strings.Builder
+	subject := "signal(s)"
+	if c.SignalName != "" {
+		subject = fmt.Sprintf("%q signal(s)", c.SignalName)
+	}
+	fmt.Fprintf(&b, "Workflow %s (run %s) received %d %s (%d events scanned)", c.WorkflowID, c.RunID, c.Total, subject, c.Scanned)
+	if len(c.Signals) == 0 {
+		b.WriteString(".\n")
+	} else {
+		b.WriteString(":\n")
+	}
+	for _, s := range c.Signals {
+		fmt.Fprintf(&b, "- %s: %d (first %s, last %s)\n", s.Name, s.Count, s.First, s.Last)
+	}
+	if c.PageLimit {
+		b.WriteString("Stopped at the page limit before the end of the history, so the counts are lower bounds; raise max_pages to count further.\n")
+	}
+	return b.String()
+}
+
+// HistoryMatch is a history event matching a search, with a snippet around the first match.
+type HistoryMatch struct {
+	EventID   int64  `json:"event_id"`
+	EventType string `json:"event_type"`
+	EventTime string `json:"event_time"`
+	Snippet   string `json:"snippet"`
+}
+
+// HistorySearch is the result of searching a workflow history.
+type HistorySearch struct {
+	WorkflowID string         `json:"workflow_id"`
+	RunID      string         `json:"run_id"`
+	Pattern    string         `json:"pattern"`
+	Matches    []HistoryMatch `json:"matches"`
+	Scanned    int            `json:"events_scanned"`
+	// MatchLimit is set when more matches may exist past the last one returned; PageLimit when the
+	// scan stopped before the end of the history.
+	MatchLimit bool `json:"match_limit_reached,omitempty"`
+	PageLimit  bool `json:"page_limit_reached,omitempty"`
+}
+
+// HistorySearchText renders history search results, one line per matching event.
+func HistorySearchText(s HistorySearch) string {
+	var b strings.Builder
+	if len(s.Matches) == 0 {
+		fmt.Fprintf(&b, "No events in workflow %s (run %s) match %q (%d events scanned).\n", s.WorkflowID, s.RunID, s.Pattern, s.Scanned)
+	} else {
+		fmt.Fprintf(&b, "Found %d event(s) in workflow %s (run %s) matching %q (%d events scanned):\n", len(s.Matches), s.WorkflowID, s.RunID, s.Pattern, s.Scanned)
+		for _, m := range s.Matches {
+			fmt.Fprintf(&b, "- Event %d (%s) at %s: %s\n", m.EventID, m.EventType, m.EventTime, m.Snippet)
+		}
+	}
+	if s.MatchLimit {
+		b.WriteString("Stopped at the match limit; raise max_matches to see more.\n")
+	}
+	if s.PageLimit {
+		b.WriteString("Stopped at the page limit before the end of the history; raise max_pages to search further.\n")
+	}
+	return b.String()
+}
+
+// Signal is a signal received by a workflow execution.
+type Signal struct {
+	EventID  int64    `json:"event_id"`
+	Name     string   `json:"name"`
+	Time     string   `json:"time"`
+	Identity string   `json:"identity,omitempty"`
+	Input    []string `json:"input"`
+}
+
+// SignalList is the signals received by a workflow execution, in the order they were received.
+type SignalList struct {
+	WorkflowID string   `json:"workflow_id"`
+	RunID      string   `json:"run_id"`
+	SignalName string   `json:"signal_name,omitempty"`
+	Signals    []Signal `json:"signals"`
+	// More is set when further signals exist past the returned ones.
+	More bool `json:"more,omitempty"`
+}
+
+// SignalListText renders the signals of a workflow, one block per signal.
+func SignalListText(l SignalList) string {
+	var b strings.Builder
+	if len(l.Signals) == 0 {
+		if l.SignalName != "" {
+			return fmt.Sprintf("Workflow %s (run %s) has received no %q signals.\n", l.WorkflowID, l.RunID, l.SignalName)
+		}
+		return fmt.Sprintf("Workflow %s (run %s) has received no signals.\n", l.WorkflowID, l.RunID)
+	}
+	subject := "signal(s)"
+	if l.SignalName != "" {
+		subject = fmt.Sprintf("%q signal(s)", l.SignalName)
+	}
+	fmt.Fprintf(&b, "Workflow %s (run %s) received %d %s:\n", l.WorkflowID, l.RunID, len(l.Signals), subject)
+	for _, s := range l.Signals {
+		fmt.Fprintf(&b, "- Event %d: %s at %s", s.EventID, s.Name, s.Time)
+		if s.Identity != "" {
+			fmt.Fprintf(&b, " from %s", s.Identity)
+		}
+		b.WriteString("\n")
+		for _, input := range s.Input {
+			fmt.Fprintf(&b, "  Input: %s\n", strings.ReplaceAll(input, "\n", "\n  "))
+		}
+	}
+	if l.More {
+		b.WriteString("More signals follow; raise max_signals to see them.\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The server's default history limits (the limit.historyCount.error and limit.historySize.error
+// dynamic config), past which it terminates a workflow.
+const (
+	DefaultHistoryEventLimit = 51200
+	DefaultHistorySizeLimit  = 50 << 20
+)
+
+// HistoryThresholds are the history event count and size past which tools warn that a workflow is
+// nearing the server's history limits; 0 disables a threshold.
+type HistoryThresholds struct {
+	Events int64
+	Bytes  int64
+}
+
+// Warnings returns a warning for each threshold a history of events events and bytes bytes
+// exceeds, or nil.
+func (t HistoryThresholds) Warnings(events, bytes int64) []string {
+	var warnings []string
+	if t.Events > 0 && events > t.Events {
+		warnings = append(warnings, fmt.Sprintf("%d events exceeds the warning threshold of %d (server default limit %d)", events, t.Events, DefaultHistoryEventLimit))
+	}
+	if t.Bytes > 0 && bytes > t.Bytes {
+		warnings = append(warnings, fmt.Sprintf("%s exceeds the warning threshold of %s (server default limit %s)", ByteSize(bytes), ByteSize(t.Bytes), ByteSize(DefaultHistorySizeLimit)))
+	}
+	return warnings
+}
+
+// ByteSize formats a size in bytes with a binary unit, e.g. "1.5 MiB".
+func ByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// LargeHistory is a running workflow with its history size.
+type LargeHistory struct {
+	WorkflowID       string `json:"workflow_id"`
+	RunID            string `json:"run_id"`
+	WorkflowType     string `json:"workflow_type"`
+	StartTime        string `json:"start_time"`
+	HistoryLength    int64  `json:"history_length"`
+	HistorySizeBytes int64  `json:"history_size_bytes"`
+	// Warnings say which warning thresholds the history exceeds.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// LargeHistorySearch is the result of ranking running workflows by history length.
+type LargeHistorySearch struct {
+	// Running is the number of running workflows matching the filters, or -1 if it couldn't be counted.
+	Running int64 `json:"running"`
+	// Listed is how many of them were listed, and Coverage how many of those were described.
+	Listed   int    `json:"listed"`
+	Coverage string `json:"coverage"`
+	// Flagged counts the described workflows over a warning threshold, including any beyond Limit.
+	Flagged   int            `json:"flagged"`
+	Limit     int            `json:"limit"`
+	Workflows []LargeHistory `json:"workflows"`
+}
+
+// LargeHistoriesText renders the largest histories first, followed by the coverage of the search.
+func LargeHistoriesText(s LargeHistorySearch) string {
+	var b strings.Builder
+	if len(s.Workflows) == 0 {
+		b.WriteString("No running workflows were described.\n")
+	} else {
+		fmt.Fprintf(&b, "Largest histories of running workflows (top %d; %d over a warning threshold):\n", len(s.Workflows), s.Flagged)
+		for _, w := range s.Workflows {
+			fmt.Fprintf(&b, "- ID: %s | Run: %s | Type: %s | Started: %s | History: %d events, %s",
+				w.WorkflowID, w.RunID, w.WorkflowType, w.StartTime, w.HistoryLength, ByteSize(w.HistorySizeBytes))
+			for _, warning := range w.Warnings {
+				fmt.Fprintf(&b, " | WARNING: %s", warning)
+			}
+			b.WriteString("\n")
+		}
+	}
+	running := "unknown"
+	if s.Running >= 0 {
+		running = fmt.Sprint(s.Running)
+	}
+	fmt.Fprintf(&b, "Running workflows matching the filters: %s; listed %d; described: %s.\n", running, s.Listed, s.Coverage)
+	return b.String()
+}
@@ -0,0 +1,12 @@
+package format
+
+import "encoding/json"
+
+// JSON renders a view struct as indented JSON, for tools called with format=json.
+func JSON(v any) (string, error) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,74 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LatencySummary summarizes the schedule-to-start latencies of one kind of task. Durations are
+// empty when there are no samples.
+type LatencySummary struct {
+	Samples int    `json:"samples"`
+	P50     string `json:"p50,omitempty"`
+	P95     string `json:"p95,omitempty"`
+	Max     string `json:"max,omitempty"`
+}
+
+// TaskLatencyReport is the schedule-to-start latency of workflow tasks and activities across a
+// sample of recent executions.
+type TaskLatencyReport struct {
+	TaskQueue    string `json:"task_queue,omitempty"`
+	WorkflowType string `json:"workflow_type,omitempty"`
+	// Window describes the start times sampled, e.g. "in the last 1h0m0s".
+	Window string `json:"window"`
+	// Sampled is how many executions were listed, and Coverage how many histories were read.
+	Sampled       int            `json:"sampled"`
+	Coverage      string         `json:"coverage"`
+	WorkflowTasks LatencySummary `json:"workflow_tasks"`
+	Activities    LatencySummary `json:"activities"`
+	// RetriedActivities counts activity starts left out because they weren't first attempts.
+	RetriedActivities int `json:"retried_activities,omitempty"`
+	// WaitingWorkflowTasks counts workflow tasks scheduled but not yet started, the oldest of which
+	// has waited OldestWaiting.
+	WaitingWorkflowTasks int    `json:"waiting_workflow_tasks,omitempty"`
+	OldestWaiting        string `json:"oldest_waiting,omitempty"`
+	// Unreadable counts histories that couldn't be read.
+	Unreadable int `json:"unreadable,omitempty"`
+}
+
+// TaskLatencyReportText renders the latencies as a small table followed by notes on the sample.
+func TaskLatencyReportText(r TaskLatencyReport) string {
+	var b strings.Builder
+	b.WriteString("Schedule-to-start latency")
+	if r.TaskQueue != "" {
+		fmt.Fprintf(&b, " on task queue %s", r.TaskQueue)
+	}
+	if r.WorkflowType != "" {
+		fmt.Fprintf(&b, " for workflow type %s", r.WorkflowType)
+	}
+	fmt.Fprintf(&b, " (executions started %s):\n", r.Window)
+
+	fmt.Fprintf(&b, "%-15s %-8s %-10s %-10s %s\n", "KIND", "SAMPLES", "P50", "P95", "MAX")
+	for _, row := range []struct {
+		kind    string
+		summary LatencySummary
+	}{{"Workflow tasks", r.WorkflowTasks}, {"Activities", r.Activities}} {
+		if row.summary.Samples == 0 {
+			fmt.Fprintf(&b, "%-15s %-8d %-10s %-10s %s\n", row.kind, 0, "-", "-", "-")
+			continue
+		}
+		fmt.Fprintf(&b, "%-15s %-8d %-10s %-10s %s\n", row.kind, row.summary.Samples, row.summary.P50, row.summary.P95, row.summary.Max)
+	}
+
+	fmt.Fprintf(&b, "Sampled %d execution(s); histories read: %s.\n", r.Sampled, r.Coverage)
+	if r.Unreadable > 0 {
+		fmt.Fprintf(&b, "%d history(ies) couldn't be read and were left out.\n", r.Unreadable)
+	}
+	if r.RetriedActivities > 0 {
+		fmt.Fprintf(&b, "Left out %d retried activity start(s), whose latency includes the retry backoff.\n", r.RetriedActivities)
+	}
+	if r.WaitingWorkflowTasks > 0 {
+		fmt.Fprintf(&b, "%d workflow task(s) are still waiting to start, the oldest for %s.\n", r.WaitingWorkflowTasks, r.OldestWaiting)
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Marker is a MarkerRecorded event decoded by kind: the SDKs record local activities, side effects,
+// and version checks (patches) as markers.
+type Marker struct {
+	EventID int64  `json:"event_id"`
+	Name    string `json:"name"`
+	// Kind is local_activity, version, side_effect, mutable_side_effect, or other.
+	Kind string `json:"kind"`
+	Time string `json:"time"`
+
+	// Local activities
+	ActivityID   string   `json:"activity_id,omitempty"`
+	ActivityType string   `json:"activity_type,omitempty"`
+	Attempt      int32    `json:"attempt,omitempty"`
+	Result       []string `json:"result,omitempty"`
+	// CompleteTime is when the local activity completed, by the workflow's clock, and Duration how
+	// long it ran, as far as the marker tells: see DurationNote.
+	CompleteTime string `json:"complete_time,omitempty"`
+	Duration     string `json:"duration,omitempty"`
+	DurationNote string `json:"duration_note,omitempty"`
+
+	// Version markers
+	ChangeID string `json:"change_id,omitempty"`
+	Version  string `json:"version,omitempty"`
+
+	// Side effects
+	SideEffectID string   `json:"side_effect_id,omitempty"`
+	Data         []string `json:"data,omitempty"`
+
+	// Details holds the decoded details of markers of other kinds, by key.
+	Details map[string][]string `json:"details,omitempty"`
+	Failure string              `json:"failure,omitempty"`
+}
+
+// MarkerList is the markers of a workflow execution, in history order.
+type MarkerList struct {
+	WorkflowID string   `json:"workflow_id"`
+	RunID      string   `json:"run_id"`
+	Kind       string   `json:"kind,omitempty"`
+	Markers    []Marker `json:"markers"`
+	// More is set when further matching markers exist past the returned ones.
+	More bool `json:"more,omitempty"`
+}
+
+// MarkerListText renders the markers of a workflow, one block per marker.
+func MarkerListText(l MarkerList) string {
+	if len(l.Markers) == 0 {
+		if l.Kind != "" {
+			return fmt.Sprintf("No %s markers in workflow %s (run %s).\n", l.Kind, l.WorkflowID, l.RunID)
+		}
+		return fmt.Sprintf("No markers in workflow %s (run %s).\n", l.WorkflowID, l.RunID)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d marker(s) in workflow %s (run %s):\n", len(l.Markers), l.WorkflowID, l.RunID)
+	for _, m := range l.Markers {
+		fmt.Fprintf(&b, "- Event %d: %s at %s", m.EventID, m.Name, m.Time)
+		switch m.Kind {
+		case "local_activity":
+			fmt.Fprintf(&b, " | Type: %s | ID: %s", m.ActivityType, m.ActivityID)
+			if m.Attempt > 0 {
+				fmt.Fprintf(&b, " | Attempts: %d", m.Attempt)
+			}
+			if m.CompleteTime != "" {
+				fmt.Fprintf(&b, " | Completed: %s", m.CompleteTime)
+			}
+			if m.Duration != "" {
+				fmt.Fprintf(&b, " | Duration: %s (%s)", m.Duration, m.DurationNote)
+			}
+		case "version":
+			fmt.Fprintf(&b, " | Change ID: %s | Version: %s", m.ChangeID, m.Version)
+		case "side_effect", "mutable_side_effect":
+			if m.SideEffectID != "" {
+				fmt.Fprintf(&b, " | ID: %s", m.SideEffectID)
+			}
+		}
+		b.WriteString("\n")
+		for _, result := range m.Result {
+			fmt.Fprintf(&b, "  Result: %s\n", strings.ReplaceAll(result, "\n", "\n  "))
+		}
+		for _, data := range m.Data {
+			fmt.Fprintf(&b, "  Data: %s\n", strings.ReplaceAll(data, "\n", "\n  "))
+		}
+		keys := make([]string, 0, len(m.Details))
+		for key := range m.Details {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, value := range m.Details[key] {
+				fmt.Fprintf(&b, "  %s: %s\n", key, strings.ReplaceAll(value, "\n", "\n  "))
+			}
+		}
+		if m.Failure != "" {
+			fmt.Fprintf(&b, "  Failure: %s\n", m.Failure)
+		}
+	}
+	if l.More {
+		b.WriteString("More markers follow; raise max_markers to see them.\n")
+	}
+	return b.String()
+}
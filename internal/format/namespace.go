@@ -0,0 +1,271 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// NamespaceSummary is one row of a namespace listing.
+type NamespaceSummary struct {
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+	Retention   string `json:"retention,omitempty"`
+	// Default marks the server's default namespace.
+	Default bool `json:"default,omitempty"`
+}
+
+// NewNamespaceSummary builds a listing row from a DescribeNamespace response.
+func NewNamespaceSummary(ns *workflowservice.DescribeNamespaceResponse) NamespaceSummary {
+	summary := NamespaceSummary{
+		Name:        ns.GetNamespaceInfo().GetName(),
+		State:       NamespaceState(ns.GetNamespaceInfo().GetState()),
+		Description: ns.GetNamespaceInfo().GetDescription(),
+	}
+	if retention := ns.GetConfig().GetWorkflowExecutionRetentionTtl(); retention != nil {
+		summary.Retention = retention.AsDuration().String()
+	}
+	return summary
+}
+
+// NamespaceState converts a NamespaceState enum to a readable string.
+func NamespaceState(state enumspb.NamespaceState) string {
+	switch state {
+	case enumspb.NAMESPACE_STATE_REGISTERED:
+		return "Registered"
+	case enumspb.NAMESPACE_STATE_DEPRECATED:
+		return "Deprecated"
+	case enumspb.NAMESPACE_STATE_DELETED:
+		return "Deleted"
+	default:
+		return "Unknown"
+	}
+}
+
+// NamespaceListText renders a namespace listing.
+func NamespaceListText(namespaces []NamespaceSummary) string {
+	if len(namespaces) == 0 {
+		return "No namespaces found."
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d namespace(s):\n", len(namespaces))
+	for _, ns := range namespaces {
+		fmt.Fprintf(&b, "- Name: %s | State: %s", ns.Name, ns.State)
+		if ns.Retention != "" {
+			fmt.Fprintf(&b, " | Retention: %s", ns.Retention)
+		}
+		if ns.Description != "" {
+			fmt.Fprintf(&b, " | Description: %s", ns.Description)
+		}
+		if ns.Default {
+			b.WriteString(" | (default)")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// NamespaceDeletion is a deletion of a namespace: a preview of what it would delete, or its
+// outcome once requested.
+type NamespaceDeletion struct {
+	Namespace NamespaceSummary `json:"namespace"`
+	// Workflows and Running count the namespace's workflow executions still in visibility, and the
+	// running ones among them; they are -1 when the count failed, with CountError saying why.
+	Workflows  int64  `json:"workflows"`
+	Running    int64  `json:"running"`
+	CountError string `json:"count_error,omitempty"`
+	Performed  bool   `json:"performed"`
+	// DryRun is set when the server is in dry-run mode and the confirmed deletion was skipped.
+	DryRun bool `json:"dry_run,omitempty"`
+	// AlreadyDeleted is set when the namespace was already being deleted, so nothing was done.
+	AlreadyDeleted bool `json:"already_deleted,omitempty"`
+	// DeletedNamespace is the temporary name the server renamed the namespace to while it deletes its
+	// executions, and DeletedState the state it reports under that name.
+	DeletedNamespace string `json:"deleted_namespace,omitempty"`
+	DeletedState     string `json:"deleted_state,omitempty"`
+}
+
+// NamespaceDeletionText renders a namespace deletion preview, or its outcome once requested.
+func NamespaceDeletionText(d NamespaceDeletion) string {
+	var b strings.Builder
+	name := d.Namespace.Name
+	switch {
+	case d.AlreadyDeleted:
+		fmt.Fprintf(&b, "Namespace %s is already being deleted (state %s); nothing was changed.\n", name, d.Namespace.State)
+		return b.String()
+	case d.DryRun:
+		fmt.Fprintf(&b, "[DRY RUN] would have deleted namespace %s; nothing was changed.\n", name)
+	case d.Performed:
+		fmt.Fprintf(&b, "Deletion of namespace %s started.\n", name)
+	default:
+		fmt.Fprintf(&b, "Deletion preview for namespace %s (not performed):\n", name)
+	}
+	fmt.Fprintf(&b, "State: %s", d.Namespace.State)
+	if d.Namespace.Retention != "" {
+		fmt.Fprintf(&b, " | Retention: %s", d.Namespace.Retention)
+	}
+	if d.Namespace.Description != "" {
+		fmt.Fprintf(&b, " | Description: %s", d.Namespace.Description)
+	}
+	b.WriteString("\n")
+	if d.Workflows >= 0 {
+		fmt.Fprintf(&b, "Workflow executions: %d (%d running)\n", d.Workflows, d.Running)
+	} else {
+		fmt.Fprintf(&b, "Workflow executions: unknown (%s)\n", d.CountError)
+	}
+	switch {
+	case d.Performed:
+		fmt.Fprintf(&b, "The namespace was renamed to %s", d.DeletedNamespace)
+		if d.DeletedState != "" {
+			fmt.Fprintf(&b, " (state %s)", d.DeletedState)
+		}
+		b.WriteString(" and no longer accepts requests. The server now deletes its workflow executions in the background, then removes the namespace; this can take a while for large namespaces.\n")
+	case !d.DryRun:
+		b.WriteString("Deleting the namespace permanently deletes all of the above, including running workflows, and can't be undone.\n")
+		fmt.Fprintf(&b, "Call again with confirm=true and confirm_namespace=%q to delete it.\n", name)
+	}
+	return b.String()
+}
+
+// NamespaceReplication is the replication configuration of a namespace, and whether the connected
+// cluster is the one accepting its writes.
+type NamespaceReplication struct {
+	Namespace string `json:"namespace"`
+	// Global is set for namespaces replicated across clusters; the rest is empty otherwise.
+	Global          bool     `json:"global"`
+	ActiveCluster   string   `json:"active_cluster,omitempty"`
+	Clusters        []string `json:"clusters,omitempty"`
+	FailoverVersion int64    `json:"failover_version,omitempty"`
+	// State is Normal, or Handover while the namespace fails over and writes are blocked.
+	State    string     `json:"state,omitempty"`
+	Failover []Failover `json:"failover_history,omitempty"`
+	// ConnectedCluster is the name of the cluster this server talks to, "" if it couldn't be
+	// fetched (ClusterError says why).
+	ConnectedCluster string `json:"connected_cluster,omitempty"`
+	ClusterError     string `json:"cluster_error,omitempty"`
+	// Standby is set when the connected cluster isn't the active one, so writes fail on it.
+	Standby bool `json:"standby,omitempty"`
+}
+
+// Failover is a past failover of a namespace: when it happened, and the failover version it set.
+type Failover struct {
+	Time    string `json:"time"`
+	Version int64  `json:"version"`
+}
+
+// NamespaceReplicationText renders the replication of a namespace, warnings first.
+func NamespaceReplicationText(r NamespaceReplication) string {
+	if !r.Global {
+		return fmt.Sprintf("Namespace %s is not global: replication is not configured, and it lives on one cluster only.\n", r.Namespace)
+	}
+	var b strings.Builder
+	if r.Standby {
+		fmt.Fprintf(&b, "WARNING: the connected cluster %s is not the active cluster of namespace %s (%s is). Writes (starts, signals, resets, terminations) fail here unless the cluster forwards them to the active one, and what it shows may lag the active cluster. Connect to %s to act on this namespace.\n",
+			r.ConnectedCluster, r.Namespace, r.ActiveCluster, r.ActiveCluster)
+	}
+	if r.State == "Handover" {
+		fmt.Fprintf(&b, "WARNING: namespace %s is in handover: it is failing over, and writes are blocked until the failover completes.\n", r.Namespace)
+	}
+	fmt.Fprintf(&b, "Namespace %s is global, replicated to: %s\n", r.Namespace, strings.Join(r.Clusters, ", "))
+	fmt.Fprintf(&b, "Active cluster: %s\n", r.ActiveCluster)
+	switch {
+	case r.ConnectedCluster != "":
+		fmt.Fprintf(&b, "Connected cluster: %s", r.ConnectedCluster)
+		if !r.Standby {
+			b.WriteString(" (active)")
+		}
+		b.WriteString("\n")
+	case r.ClusterError != "":
+		fmt.Fprintf(&b, "Connected cluster: unknown (%s)\n", r.ClusterError)
+	}
+	fmt.Fprintf(&b, "Failover version: %d | Replication state: %s\n", r.FailoverVersion, r.State)
+	if len(r.Failover) > 0 {
+		b.WriteString("Failover history (most recent first):\n")
+		for _, f := range r.Failover {
+			fmt.Fprintf(&b, "- %s: version %d\n", f.Time, f.Version)
+		}
+	}
+	return b.String()
+}
+
+// Archival is the archival configuration of a namespace: whether history and visibility records
+// are archived, and where to.
+type Archival struct {
+	History       string `json:"history"`
+	HistoryURI    string `json:"history_uri,omitempty"`
+	Visibility    string `json:"visibility"`
+	VisibilityURI string `json:"visibility_uri,omitempty"`
+}
+
+// NewArchival builds the archival configuration of a namespace from a DescribeNamespace response.
+func NewArchival(ns *workflowservice.DescribeNamespaceResponse) Archival {
+	config := ns.GetConfig()
+	return Archival{
+		History:       config.GetHistoryArchivalState().String(),
+		HistoryURI:    config.GetHistoryArchivalUri(),
+		Visibility:    config.GetVisibilityArchivalState().String(),
+		VisibilityURI: config.GetVisibilityArchivalUri(),
+	}
+}
+
+// ArchivalUpdate is a change of a namespace's archival configuration: a preview, or its outcome
+// once performed.
+type ArchivalUpdate struct {
+	Namespace string `json:"namespace"`
+	// Before is the configuration before the update, and Changes the changes requested, e.g.
+	// "history archival: Disabled -> Enabled".
+	Before    Archival `json:"before"`
+	Changes   []string `json:"changes"`
+	Performed bool     `json:"performed"`
+	// DryRun is set when the server is in dry-run mode and the confirmed update was skipped.
+	DryRun bool `json:"dry_run,omitempty"`
+	// After is the configuration once updated, as described again, and NotApplied the requested
+	// changes it doesn't show.
+	After      *Archival `json:"after,omitempty"`
+	NotApplied []string  `json:"not_applied,omitempty"`
+}
+
+// ArchivalUpdateText renders an archival update preview, or its outcome when it has been performed.
+func ArchivalUpdateText(u ArchivalUpdate) string {
+	var b strings.Builder
+	switch {
+	case len(u.Changes) == 0:
+		fmt.Fprintf(&b, "Archival of namespace %s is already as requested; nothing was changed.\n", u.Namespace)
+	case u.DryRun:
+		fmt.Fprintf(&b, "[DRY RUN] would have updated the archival of namespace %s; nothing was changed.\n", u.Namespace)
+	case u.Performed:
+		fmt.Fprintf(&b, "Updated the archival of namespace %s.\n", u.Namespace)
+	default:
+		fmt.Fprintf(&b, "Archival update preview for namespace %s (not performed):\n", u.Namespace)
+	}
+	archivalText(&b, "Before", u.Before)
+	for _, change := range u.Changes {
+		fmt.Fprintf(&b, "- %s\n", change)
+	}
+	switch {
+	case u.After != nil:
+		archivalText(&b, "Now", *u.After)
+		if len(u.NotApplied) > 0 {
+			fmt.Fprintf(&b, "WARNING: the server accepted the update but didn't apply %s. Archival is most likely disabled in the cluster's configuration, which silently overrides namespace settings.\n", strings.Join(u.NotApplied, " and "))
+		}
+	case !u.Performed && !u.DryRun && len(u.Changes) > 0:
+		b.WriteString("Call again with confirm=true to apply the changes.\n")
+	}
+	return b.String()
+}
+
+// archivalText writes an archival configuration on one line.
+func archivalText(b *strings.Builder, label string, a Archival) {
+	fmt.Fprintf(b, "%s: History archival: %s", label, a.History)
+	if a.HistoryURI != "" {
+		fmt.Fprintf(b, " (%s)", a.HistoryURI)
+	}
+	fmt.Fprintf(b, " | Visibility archival: %s", a.Visibility)
+	if a.VisibilityURI != "" {
+		fmt.Fprintf(b, " (%s)", a.VisibilityURI)
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,112 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	nexuspb "go.temporal.io/api/nexus/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/payload"
+)
+
+// NexusEndpoint is a Nexus endpoint of the cluster and where it routes operations.
+type NexusEndpoint struct {
+	Name    string `json:"name"`
+	ID      string `json:"id"`
+	Version int64  `json:"version"`
+	// Target is worker, routing to TargetNamespace and TargetTaskQueue, or external, routing to
+	// TargetURL.
+	Target          string `json:"target"`
+	TargetNamespace string `json:"target_namespace,omitempty"`
+	TargetTaskQueue string `json:"target_task_queue,omitempty"`
+	TargetURL       string `json:"target_url,omitempty"`
+	Description     string `json:"description,omitempty"`
+	URLPrefix       string `json:"url_prefix,omitempty"`
+	CreatedTime     string `json:"created_time,omitempty"`
+	LastModified    string `json:"last_modified_time,omitempty"`
+}
+
+// NewNexusEndpoint builds the view of an endpoint, rendering its description with r.
+func NewNexusEndpoint(e *nexuspb.Endpoint, r *payload.Renderer) NexusEndpoint {
+	endpoint := NexusEndpoint{
+		Name:         e.GetSpec().GetName(),
+		ID:           e.GetId(),
+		Version:      e.GetVersion(),
+		URLPrefix:    e.GetUrlPrefix(),
+		CreatedTime:  Time(e.GetCreatedTime()),
+		LastModified: Time(e.GetLastModifiedTime()),
+	}
+	switch target := e.GetSpec().GetTarget(); {
+	case target.GetWorker() != nil:
+		endpoint.Target = "worker"
+		endpoint.TargetNamespace = target.GetWorker().GetNamespace()
+		endpoint.TargetTaskQueue = target.GetWorker().GetTaskQueue()
+	case target.GetExternal() != nil:
+		endpoint.Target, endpoint.TargetURL = "external", target.GetExternal().GetUrl()
+	default:
+		endpoint.Target = "unknown"
+	}
+	if description := e.GetSpec().GetDescription(); description != nil {
+		endpoint.Description = r.Render(description)
+		// Descriptions are markdown held in a JSON string; show the markdown, not its quoted form
+		var text string
+		if json.Unmarshal([]byte(endpoint.Description), &text) == nil {
+			endpoint.Description = text
+		}
+	}
+	return endpoint
+}
+
+// targetText describes where the endpoint routes operations.
+func (e NexusEndpoint) targetText() string {
+	switch e.Target {
+	case "worker":
+		return fmt.Sprintf("namespace %s, task queue %s", e.TargetNamespace, e.TargetTaskQueue)
+	case "external":
+		return "external URL " + e.TargetURL
+	default:
+		return "unknown target"
+	}
+}
+
+// NexusEndpointListText renders a listing of Nexus endpoints, with the first line of each description.
+func NexusEndpointListText(endpoints []NexusEndpoint) string {
+	if len(endpoints) == 0 {
+		return "No Nexus endpoints found."
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d Nexus endpoint(s):\n", len(endpoints))
+	for _, e := range endpoints {
+		fmt.Fprintf(&b, "- Name: %s | Target: %s", e.Name, e.targetText())
+		if e.Description != "" {
+			line, _, _ := strings.Cut(e.Description, "\n")
+			fmt.Fprintf(&b, " | Description: %s", line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// NexusEndpointText renders one Nexus endpoint in detail.
+func NexusEndpointText(e NexusEndpoint) string {
+	var b strings.Builder
+	b.WriteString("Nexus Endpoint Details:\n")
+	fmt.Fprintf(&b, "Name: %s\n", e.Name)
+	fmt.Fprintf(&b, "ID: %s\n", e.ID)
+	fmt.Fprintf(&b, "Version: %d\n", e.Version)
+	fmt.Fprintf(&b, "Target: %s\n", e.targetText())
+	if e.URLPrefix != "" {
+		fmt.Fprintf(&b, "URL Prefix: %s\n", e.URLPrefix)
+	}
+	if e.CreatedTime != "" {
+		fmt.Fprintf(&b, "Created: %s\n", e.CreatedTime)
+	}
+	if e.LastModified != "" {
+		fmt.Fprintf(&b, "Last Modified: %s\n", e.LastModified)
+	}
+	if e.Description != "" {
+		fmt.Fprintf(&b, "Description:\n%s\n", e.Description)
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BlockedWorkflow is a running workflow with a pending activity of the searched type.
+type BlockedWorkflow struct {
+	WorkflowID   string `json:"workflow_id"`
+	RunID        string `json:"run_id"`
+	WorkflowType string `json:"workflow_type"`
+	StartTime    string `json:"start_time"`
+	ActivityID   string `json:"activity_id"`
+	State        string `json:"state"`
+	Attempt      int32  `json:"attempt"`
+	LastFailure  string `json:"last_failure,omitempty"`
+}
+
+// BlockedWorkflowSearch is the result of searching running workflows for a pending activity type.
+type BlockedWorkflowSearch struct {
+	ActivityType string `json:"activity_type"`
+	// Running is the number of running workflows matching the filters, or -1 if it couldn't be counted.
+	Running int64 `json:"running"`
+	// Listed is how many of them were listed, and Coverage how many of those were described.
+	Listed    int               `json:"listed"`
+	Coverage  string            `json:"coverage"`
+	Workflows []BlockedWorkflow `json:"workflows"`
+}
+
+// BlockedWorkflowsText renders the workflows blocked on an activity type, followed by the coverage
+// of the search.
+func BlockedWorkflowsText(s BlockedWorkflowSearch) string {
+	var b strings.Builder
+	if len(s.Workflows) == 0 {
+		fmt.Fprintf(&b, "No running workflows have a pending %s activity.\n", s.ActivityType)
+	} else {
+		fmt.Fprintf(&b, "Found %d running workflow(s) with a pending %s activity:\n", len(s.Workflows), s.ActivityType)
+		for _, w := range s.Workflows {
+			fmt.Fprintf(&b, "- ID: %s | Run: %s | Type: %s | Started: %s | Activity: %s | State: %s | Attempt: %d",
+				w.WorkflowID, w.RunID, w.WorkflowType, w.StartTime, w.ActivityID, w.State, w.Attempt)
+			if w.LastFailure != "" {
+				fmt.Fprintf(&b, " | Last Failure: %s", w.LastFailure)
+			}
+			b.WriteString("\n")
+		}
+	}
+	running := "unknown"
+	if s.Running >= 0 {
+		running = fmt.Sprint(s.Running)
+	}
+	fmt.Fprintf(&b, "Running workflows matching the filters: %s; listed %d; described: %s.\n", running, s.Listed, s.Coverage)
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SavedQuery describes one of the operator's saved queries.
+type SavedQuery struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Query       string                `json:"query"`
+	Parameters  []SavedQueryParameter `json:"parameters,omitempty"`
+}
+
+// SavedQueryParameter describes a parameter of a saved query.
+type SavedQueryParameter struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	// Default is the value used when a call doesn't pass one; "" means the parameter is required.
+	Default string `json:"default,omitempty"`
+}
+
+// SavedQueriesText renders the saved queries with their parameters.
+func SavedQueriesText(queries []SavedQuery) string {
+	if len(queries) == 0 {
+		return "No saved queries are configured."
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d saved query(ies):\n", len(queries))
+	for _, q := range queries {
+		fmt.Fprintf(&b, "- %s", q.Name)
+		if q.Description != "" {
+			fmt.Fprintf(&b, ": %s", q.Description)
+		}
+		fmt.Fprintf(&b, "\n  Query: %s\n", q.Query)
+		for _, p := range q.Parameters {
+			fmt.Fprintf(&b, "  Parameter %s (%s", p.Name, p.Type)
+			if p.Default != "" {
+				fmt.Fprintf(&b, ", default %s", p.Default)
+			} else {
+				b.WriteString(", required")
+			}
+			b.WriteString(")")
+			if p.Description != "" {
+				fmt.Fprintf(&b, ": %s", p.Description)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// SavedQueryRunText reports the visibility query a saved query expanded to, below its results.
+func SavedQueryRunText(name, query string) string {
+	return fmt.Sprintf("\nSaved query %s ran: %s\n", name, query)
+}
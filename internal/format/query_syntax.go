@@ -0,0 +1,80 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuerySyntax is a reference of the visibility query language for one namespace, with the
+// validation of a candidate query when one was given.
+type QuerySyntax struct {
+	Namespace  string           `json:"namespace"`
+	Validation *QueryValidation `json:"validation,omitempty"`
+	// The reference is left out of the JSON output when a candidate query was valid.
+	Columns   []QueryColumn   `json:"columns,omitempty"`
+	Operators []QueryOperator `json:"operators,omitempty"`
+	Examples  []QueryExample  `json:"examples,omitempty"`
+	Notes     []string        `json:"notes,omitempty"`
+}
+
+// QueryColumn is a search attribute usable in visibility queries.
+type QueryColumn struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Custom bool   `json:"custom,omitempty"`
+}
+
+// QueryOperator is an operator of the visibility query language and the column types it applies to.
+type QueryOperator struct {
+	Syntax  string `json:"syntax"`
+	Applies string `json:"applies"`
+}
+
+// QueryExample is a worked visibility query.
+type QueryExample struct {
+	Query       string `json:"query"`
+	Description string `json:"description"`
+}
+
+// QueryValidation is the server's verdict on a candidate query: Valid with the number of matching
+// executions, or the server's parse error verbatim.
+type QueryValidation struct {
+	Query string `json:"query"`
+	Valid bool   `json:"valid"`
+	Count int64  `json:"count,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// QuerySyntaxText renders the validation verdict, then the reference unless the query was valid.
+func QuerySyntaxText(s QuerySyntax) string {
+	var b strings.Builder
+	if v := s.Validation; v != nil {
+		if v.Valid {
+			fmt.Fprintf(&b, "Valid query in namespace %s; it matches %d execution(s):\n  %s\n", s.Namespace, v.Count, v.Query)
+			return b.String()
+		}
+		fmt.Fprintf(&b, "Invalid query:\n  %s\nServer error: %s\n\n", v.Query, v.Error)
+	}
+
+	fmt.Fprintf(&b, "Visibility query columns in namespace %s:\n", s.Namespace)
+	for _, c := range s.Columns {
+		fmt.Fprintf(&b, "- %s (%s", c.Name, c.Type)
+		if c.Custom {
+			b.WriteString(", custom")
+		}
+		b.WriteString(")\n")
+	}
+	b.WriteString("\nOperators:\n")
+	for _, o := range s.Operators {
+		fmt.Fprintf(&b, "- %s: %s\n", o.Syntax, o.Applies)
+	}
+	b.WriteString("\nExamples:\n")
+	for _, e := range s.Examples {
+		fmt.Fprintf(&b, "- %s\n  %s\n", e.Query, e.Description)
+	}
+	b.WriteString("\nNotes:\n")
+	for _, n := range s.Notes {
+		fmt.Fprintf(&b, "- %s\n", n)
+	}
+	return b.String()
+}
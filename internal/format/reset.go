@@ -0,0 +1,176 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	workflowpb "go.temporal.io/api/workflow/v1"
+)
+
+// ResetPoint is an auto-reset point recorded by the server: the first workflow task completed by
+// a worker build, which a reset can target.
+type ResetPoint struct {
+	// BuildID is the worker build ID, or the deprecated binary checksum for older workers.
+	BuildID    string `json:"build_id"`
+	RunID      string `json:"run_id"`
+	EventID    int64  `json:"event_id"`
+	CreateTime string `json:"create_time,omitempty"`
+	ExpireTime string `json:"expire_time,omitempty"`
+	Resettable bool   `json:"resettable"`
+}
+
+// NewResetPoint builds a reset point from the server's record.
+func NewResetPoint(p *workflowpb.ResetPointInfo) ResetPoint {
+	buildID := p.GetBuildId()
+	if buildID == "" {
+		buildID = p.GetBinaryChecksum()
+	}
+	return ResetPoint{
+		BuildID:    buildID,
+		RunID:      p.GetRunId(),
+		EventID:    p.GetFirstWorkflowTaskCompletedId(),
+		CreateTime: Time(p.GetCreateTime()),
+		ExpireTime: Time(p.GetExpireTime()),
+		Resettable: p.GetResettable(),
+	}
+}
+
+// ResetPointsText renders the reset points of a workflow execution.
+func ResetPointsText(workflowID string, points []ResetPoint) string {
+	if len(points) == 0 {
+		return fmt.Sprintf("Workflow %s has no auto-reset points recorded.", workflowID)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d reset point(s) for workflow %s:\n", len(points), workflowID)
+	for _, p := range points {
+		fmt.Fprintf(&b, "- Build: %s | Event ID: %d | Run: %s | Created: %s | Resettable: %t",
+			p.BuildID, p.EventID, p.RunID, p.CreateTime, p.Resettable)
+		if p.ExpireTime != "" {
+			fmt.Fprintf(&b, " | Expires: %s", p.ExpireTime)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ResetPlan describes a reset of a workflow execution: the preview shown before confirmation, and
+// the outcome once the reset is performed.
+type ResetPlan struct {
+	WorkflowID   string `json:"workflow_id"`
+	RunID        string `json:"run_id"`
+	WorkflowType string `json:"workflow_type"`
+	Status       string `json:"status"`
+	// Duration is how long the run has been running, or ran if it is closed.
+	Duration  string `json:"duration,omitempty"`
+	EventID   int64  `json:"event_id"`
+	EventType string `json:"event_type"`
+	// Reapply is the reapply mode: all, exclude_signals, exclude_updates, or exclude_all.
+	Reapply string `json:"reapply"`
+	// SignalsAfter and UpdatesAfter count the events after the reset point that the mode reapplies
+	// or drops.
+	SignalsAfter     int  `json:"signals_after"`
+	UpdatesAfter     int  `json:"updates_after"`
+	SignalsReapplied bool `json:"signals_reapplied"`
+	UpdatesReapplied bool `json:"updates_reapplied"`
+	// ExpectedBuildID is the worker build the new run should be handled by, if the caller said.
+	ExpectedBuildID string `json:"expected_build_id,omitempty"`
+	// NewRunID is set once the reset has been performed, and Verification once the new run was
+	// watched for its first workflow task.
+	NewRunID     string             `json:"new_run_id,omitempty"`
+	Verification *ResetVerification `json:"verification,omitempty"`
+	// DryRun is set when the server is in dry-run mode and the confirmed reset was skipped.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// ResetVerification is what the new run of a reset did within the seconds it was watched.
+type ResetVerification struct {
+	// Waited is how long the new run was watched.
+	Waited string `json:"waited"`
+	Status string `json:"status,omitempty"`
+	// Outcome is that of the first workflow task after the reset point: completed, failed,
+	// timed_out, or pending when no worker finished it in time.
+	Outcome string `json:"outcome"`
+	// BuildID and Identity identify the worker that handled the task, when known.
+	BuildID  string `json:"build_id,omitempty"`
+	Identity string `json:"identity,omitempty"`
+	// Failure is the cause and message of a failed task.
+	Failure string `json:"failure,omitempty"`
+	// Verified is set when the task completed, on the expected build if one was given.
+	Verified bool `json:"verified"`
+	// Error is set when the new run couldn't be read.
+	Error string `json:"error,omitempty"`
+}
+
+// ResetPlanText renders a reset preview, or its outcome when the reset has been performed.
+func ResetPlanText(p ResetPlan) string {
+	var b strings.Builder
+	switch {
+	case p.DryRun:
+		fmt.Fprintf(&b, "[DRY RUN] would have reset workflow %s (type %s, %s %s) to event %d; nothing was changed.\n",
+			p.WorkflowID, p.WorkflowType, strings.ToLower(p.Status), p.Duration, p.EventID)
+	case p.NewRunID != "":
+		fmt.Fprintf(&b, "Reset workflow %s to event %d. New run ID: %s\n", p.WorkflowID, p.EventID, p.NewRunID)
+	default:
+		fmt.Fprintf(&b, "Reset preview for workflow %s (not performed):\n", p.WorkflowID)
+	}
+	fmt.Fprintf(&b, "Run ID: %s\n", p.RunID)
+	fmt.Fprintf(&b, "Type: %s\n", p.WorkflowType)
+	fmt.Fprintf(&b, "Status: %s\n", p.Status)
+	fmt.Fprintf(&b, "Reset Point: event %d (%s)\n", p.EventID, p.EventType)
+	fmt.Fprintf(&b, "Reapply: %s\n", p.Reapply)
+	if p.ExpectedBuildID != "" {
+		fmt.Fprintf(&b, "Expected Build: %s\n", p.ExpectedBuildID)
+	}
+	fmt.Fprintf(&b, "Signals after reset point: %d (%s)\n", p.SignalsAfter, reapplied(p.SignalsReapplied))
+	fmt.Fprintf(&b, "Updates after reset point: %d (%s)\n", p.UpdatesAfter, reapplied(p.UpdatesReapplied))
+	if p.NewRunID == "" && !p.DryRun {
+		b.WriteString("Call again with confirm=true to perform the reset.\n")
+	}
+	if v := p.Verification; v != nil {
+		b.WriteString(resetVerificationText(p, v))
+	}
+	return b.String()
+}
+
+// resetVerificationText renders the verification of a performed reset, leading with its verdict.
+func resetVerificationText(p ResetPlan, v *ResetVerification) string {
+	var b strings.Builder
+	worker := ""
+	if v.BuildID != "" {
+		worker = " by build " + v.BuildID
+	}
+	if v.Identity != "" {
+		worker += " (worker " + v.Identity + ")"
+	}
+	switch {
+	case v.Error != "":
+		fmt.Fprintf(&b, "Verification: unable to read the new run: %s\n", v.Error)
+	case v.Outcome == "failed" || v.Outcome == "timed_out":
+		fmt.Fprintf(&b, "Verification FAILED: the first workflow task of the new run %s%s within %s", strings.ReplaceAll(v.Outcome, "_", " "), worker, v.Waited)
+		if v.Failure != "" {
+			fmt.Fprintf(&b, ": %s", v.Failure)
+		}
+		b.WriteString(".\nThe reset target is likely wrong (e.g. the new run still hits the bad code path); consider an earlier reset point or another build.\n")
+	case v.Outcome == "pending":
+		fmt.Fprintf(&b, "Verification inconclusive: no worker finished the first workflow task of the new run within %s; check it with describe_workflow (run_id %s).\n", v.Waited, p.NewRunID)
+	case !v.Verified:
+		fmt.Fprintf(&b, "Verification FAILED: the first workflow task completed%s, not on the expected build %s.\n", worker, p.ExpectedBuildID)
+	default:
+		fmt.Fprintf(&b, "Verified: the first workflow task of the new run completed%s", worker)
+		if p.ExpectedBuildID != "" {
+			b.WriteString(", the expected build")
+		}
+		b.WriteString(".\n")
+	}
+	if v.Status != "" {
+		fmt.Fprintf(&b, "New run status: %s\n", v.Status)
+	}
+	return b.String()
+}
+
+func reapplied(yes bool) string {
+	if yes {
+		return "reapplied"
+	}
+	return "dropped"
+}
@@ -0,0 +1,18 @@
+package format
+
+// ResourceLink points to a node of the MCP resource hierarchy.
+type ResourceLink struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// NamespaceNode is the resource of one namespace: links to its most recent workflows and to its
+// schedules. A child list that couldn't be fetched has its error instead.
+type NamespaceNode struct {
+	Namespace      string         `json:"namespace"`
+	Workflows      []ResourceLink `json:"workflows"`
+	WorkflowsError string         `json:"workflows_error,omitempty"`
+	Schedules      []ResourceLink `json:"schedules"`
+	SchedulesError string         `json:"schedules_error,omitempty"`
+}
@@ -0,0 +1,186 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScheduleRun is one workflow started by a schedule.
+type ScheduleRun struct {
+	WorkflowID    string `json:"workflow_id"`
+	RunID         string `json:"run_id"`
+	Type          string `json:"type"`
+	Status        string `json:"status"`
+	ScheduledTime string `json:"scheduled_time"`
+	StartTime     string `json:"start_time"`
+	CloseTime     string `json:"close_time,omitempty"`
+	// Drift is how much later than scheduled the workflow actually started.
+	Drift string `json:"drift,omitempty"`
+}
+
+// ScheduleRuns lists the workflows a schedule started, most recently scheduled first.
+type ScheduleRuns struct {
+	ScheduleID string        `json:"schedule_id"`
+	Runs       []ScheduleRun `json:"runs"`
+	// Truncated means the schedule started more workflows than were listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// ScheduleRunsText renders the runs of a schedule, one line each.
+func ScheduleRunsText(r ScheduleRuns) string {
+	if len(r.Runs) == 0 {
+		return fmt.Sprintf("No workflows started by schedule %s found.\n", r.ScheduleID)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d workflow(s) started by schedule %s, most recently scheduled first:\n", len(r.Runs), r.ScheduleID)
+	for _, run := range r.Runs {
+		fmt.Fprintf(&b, "- ID: %s | Run: %s | Type: %s | Status: %s | Scheduled: %s | Start: %s",
+			run.WorkflowID, run.RunID, run.Type, run.Status, run.ScheduledTime, run.StartTime)
+		if run.Drift != "" {
+			fmt.Fprintf(&b, " (drift %s)", run.Drift)
+		}
+		if run.CloseTime != "" {
+			fmt.Fprintf(&b, " | End: %s", run.CloseTime)
+		}
+		b.WriteString("\n")
+	}
+	if r.Truncated {
+		b.WriteString("More runs exist; increase page_size to see older ones.\n")
+	}
+	return b.String()
+}
+
+// SchedulePreview is the times a schedule spec matches within a window, as computed by the server.
+type SchedulePreview struct {
+	// ScheduleID is the existing schedule previewed, or "" for a spec given in the call.
+	ScheduleID string `json:"schedule_id,omitempty"`
+	// Spec describes the spec given in the call, e.g. "cron 0 9 * * MON-FRI".
+	Spec  []string `json:"spec,omitempty"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	// Times are the first matching times, in the spec's time zone; Total counts all of them.
+	Times []string `json:"times"`
+	Total int      `json:"total"`
+	// Leftover names the temporary schedule created to compute the times when it couldn't be
+	// deleted afterwards.
+	Leftover string `json:"leftover_schedule,omitempty"`
+	// NotEvaluated is set for a spec given without confirm=true: evaluating it creates a temporary
+	// schedule, so no times were computed.
+	NotEvaluated bool `json:"not_evaluated,omitempty"`
+	// DryRun is set when the server is in dry-run mode and the temporary schedule wasn't created.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// SchedulePreviewText renders the matching times of a schedule spec, one per line.
+func SchedulePreviewText(p SchedulePreview) string {
+	var b strings.Builder
+	subject := "The spec"
+	if p.ScheduleID != "" {
+		subject = "Schedule " + p.ScheduleID
+	}
+	if len(p.Spec) > 0 {
+		fmt.Fprintf(&b, "Spec: %s\n", strings.Join(p.Spec, " | "))
+	}
+	switch {
+	case p.DryRun:
+		fmt.Fprintf(&b, "[DRY RUN] would have created a temporary paused schedule to list the times the spec matches between %s and %s; nothing was changed.\n", p.Start, p.End)
+		return b.String()
+	case p.NotEvaluated:
+		fmt.Fprintf(&b, "Preview of the spec between %s and %s (not evaluated): the server computes matching times through a temporary paused schedule, created and deleted right after. Call again with confirm=true to evaluate it.\n", p.Start, p.End)
+		return b.String()
+	}
+	if p.Total == 0 {
+		fmt.Fprintf(&b, "%s matches no times between %s and %s.\n", subject, p.Start, p.End)
+	} else {
+		fmt.Fprintf(&b, "%s matches %d time(s) between %s and %s:\n", subject, p.Total, p.Start, p.End)
+		for _, t := range p.Times {
+			fmt.Fprintf(&b, "- %s\n", t)
+		}
+		if more := p.Total - len(p.Times); more > 0 {
+			fmt.Fprintf(&b, "[%d more times not shown; narrow the window to see them]\n", more)
+		}
+	}
+	if p.Leftover != "" {
+		fmt.Fprintf(&b, "WARNING: the temporary paused schedule %s used for the preview couldn't be deleted; delete it by hand.\n", p.Leftover)
+	}
+	return b.String()
+}
+
+// ScheduleAction is one recent firing of a schedule and the current outcome of the workflow it
+// started.
+type ScheduleAction struct {
+	ScheduledTime string `json:"scheduled_time"`
+	ActualTime    string `json:"actual_time"`
+	WorkflowID    string `json:"workflow_id"`
+	RunID         string `json:"run_id"`
+	// Status is the workflow's current status, or empty with Error when it couldn't be described,
+	// e.g. once retention deleted it.
+	Status    string `json:"status,omitempty"`
+	CloseTime string `json:"close_time,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ScheduleActions is the recent firings of a schedule, most recent first.
+type ScheduleActions struct {
+	ScheduleID string `json:"schedule_id"`
+	Paused     bool   `json:"paused"`
+	Notes      string `json:"notes,omitempty"`
+	// ActionCount counts every action the schedule took; MissedCatchupWindow and OverlapSkipped
+	// count the firings it skipped.
+	ActionCount         int64            `json:"action_count"`
+	MissedCatchupWindow int64            `json:"missed_catchup_window"`
+	OverlapSkipped      int64            `json:"overlap_skipped"`
+	Actions             []ScheduleAction `json:"actions"`
+	// ConsecutiveFailures counts the most recent firings whose workflows failed, timed out, or were
+	// terminated, in a row.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// FailedOnly means only the failed firings are listed; Hidden counts the others.
+	FailedOnly bool `json:"failed_only,omitempty"`
+	Hidden     int  `json:"hidden,omitempty"`
+}
+
+// ScheduleActionsText renders the recent firings of a schedule, one line each, with a warning for
+// a streak of failures.
+func ScheduleActionsText(a ScheduleActions) string {
+	var b strings.Builder
+	state := "active"
+	if a.Paused {
+		state = "paused"
+		if a.Notes != "" {
+			state += " (" + a.Notes + ")"
+		}
+	}
+	fmt.Fprintf(&b, "Schedule %s is %s; %d action(s) taken", a.ScheduleID, state, a.ActionCount)
+	if a.MissedCatchupWindow > 0 || a.OverlapSkipped > 0 {
+		fmt.Fprintf(&b, ", %d firing(s) missed past the catchup window, %d skipped by the overlap policy", a.MissedCatchupWindow, a.OverlapSkipped)
+	}
+	b.WriteString(".\n")
+	if a.ConsecutiveFailures > 1 {
+		fmt.Fprintf(&b, "WARNING: the last %d firings failed in a row; the scheduled work isn't succeeding. Check the latest failure with describe_workflow.\n", a.ConsecutiveFailures)
+	}
+	switch {
+	case len(a.Actions) == 0 && a.FailedOnly:
+		fmt.Fprintf(&b, "None of its %d recent firing(s) failed.\n", a.Hidden)
+	case len(a.Actions) == 0:
+		b.WriteString("It has no recent firings.\n")
+	default:
+		label := "Recent firings"
+		if a.FailedOnly {
+			label = fmt.Sprintf("Failed recent firings (%d successful or running hidden)", a.Hidden)
+		}
+		fmt.Fprintf(&b, "%s, most recent first:\n", label)
+		for _, action := range a.Actions {
+			fmt.Fprintf(&b, "- Scheduled: %s | Started: %s | ID: %s | Run: %s", action.ScheduledTime, action.ActualTime, action.WorkflowID, action.RunID)
+			if action.Error != "" {
+				fmt.Fprintf(&b, " | Outcome: unknown (%s)", action.Error)
+			} else {
+				fmt.Fprintf(&b, " | Outcome: %s", action.Status)
+			}
+			if action.CloseTime != "" {
+				fmt.Fprintf(&b, " | End: %s", action.CloseTime)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
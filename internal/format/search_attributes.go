@@ -0,0 +1,63 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AttributeValueCount is how many executions have one value of a search attribute.
+type AttributeValueCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// AttributeAggregate counts the distinct values of a search attribute across executions.
+type AttributeAggregate struct {
+	Attribute string `json:"attribute"`
+	Type      string `json:"type"`
+	Query     string `json:"query,omitempty"`
+	// Values holds the most frequent values, most frequent first; Distinct is how many values were
+	// found in total, and Other how many executions have a value beyond those listed.
+	Values   []AttributeValueCount `json:"values"`
+	Distinct int                   `json:"distinct"`
+	Other    int64                 `json:"other,omitempty"`
+	// Method is "group_by" when the server counted, or "scan" when executions were listed and
+	// counted here; a scan covers Scanned executions, of which Unset had no value, and Truncated
+	// means more exist.
+	Method    string `json:"method"`
+	Scanned   int    `json:"scanned,omitempty"`
+	Unset     int64  `json:"unset,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// AttributeAggregateText renders the value counts of a search attribute, one line per value.
+func AttributeAggregateText(a AttributeAggregate) string {
+	var b strings.Builder
+	scope := "all executions"
+	if a.Query != "" {
+		scope = "executions matching " + a.Query
+	}
+	if len(a.Values) == 0 {
+		fmt.Fprintf(&b, "No values of %s (%s) found across %s.\n", a.Attribute, a.Type, scope)
+	} else {
+		fmt.Fprintf(&b, "%d distinct value(s) of %s (%s) across %s:\n", a.Distinct, a.Attribute, a.Type, scope)
+		for _, v := range a.Values {
+			fmt.Fprintf(&b, "- %s: %d\n", v.Value, v.Count)
+		}
+		if a.Other > 0 {
+			fmt.Fprintf(&b, "- (%d other value(s)): %d\n", a.Distinct-len(a.Values), a.Other)
+		}
+	}
+	if a.Method == "scan" {
+		fmt.Fprintf(&b, "The server can't group by %s, so %d execution(s) were listed and counted client-side", a.Attribute, a.Scanned)
+		if a.Unset > 0 {
+			fmt.Fprintf(&b, "; %d had no value", a.Unset)
+		}
+		b.WriteString(".")
+		if a.Truncated {
+			b.WriteString(" Scan cap reached; counts cover the scanned executions only.")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ServerInfo describes this server's build and the Temporal server it is connected to.
+type ServerInfo struct {
+	Version           string `json:"version"`
+	Commit            string `json:"commit"`
+	BuildDate         string `json:"build_date"`
+	GoVersion         string `json:"go_version"`
+	TemporalAddress   string `json:"temporal_address"`
+	TemporalNamespace string `json:"temporal_namespace"`
+	Identity          string `json:"identity"`
+	// Reconnects counts rebuilds of the Temporal connection after persistent failures;
+	// LastReconnect is when the last one happened.
+	Reconnects    int        `json:"reconnects"`
+	LastReconnect *time.Time `json:"last_reconnect,omitempty"`
+	// TemporalServerVersion is empty when it could not be fetched; TemporalServerError explains why.
+	TemporalServerVersion string `json:"temporal_server_version,omitempty"`
+	TemporalServerError   string `json:"temporal_server_error,omitempty"`
+	// Cloud is set when the address is a Temporal Cloud endpoint rather than a self-hosted server.
+	Cloud bool `json:"cloud"`
+}
+
+// ServerInfoText renders the server info as multi-line text.
+func ServerInfoText(i ServerInfo) string {
+	var b strings.Builder
+	b.WriteString("Server Info:\n")
+	fmt.Fprintf(&b, "Version: %s\n", i.Version)
+	fmt.Fprintf(&b, "Commit: %s\n", i.Commit)
+	fmt.Fprintf(&b, "Build Date: %s\n", i.BuildDate)
+	fmt.Fprintf(&b, "Go Version: %s\n", i.GoVersion)
+	fmt.Fprintf(&b, "Temporal Address: %s\n", i.TemporalAddress)
+	if i.Cloud {
+		b.WriteString("Temporal Deployment: Temporal Cloud\n")
+	} else {
+		b.WriteString("Temporal Deployment: self-hosted\n")
+	}
+	fmt.Fprintf(&b, "Temporal Namespace: %s\n", i.TemporalNamespace)
+	fmt.Fprintf(&b, "Client Identity: %s\n", i.Identity)
+	if i.LastReconnect != nil {
+		fmt.Fprintf(&b, "Temporal Reconnects: %d (last at %s)\n", i.Reconnects, i.LastReconnect.Format(time.RFC3339))
+	}
+	if i.TemporalServerError != "" {
+		fmt.Fprintf(&b, "Temporal Server Version: unavailable (%s)\n", i.TemporalServerError)
+	} else {
+		fmt.Fprintf(&b, "Temporal Server Version: %s\n", i.TemporalServerVersion)
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SignalMatching is a signal sent to every running workflow matching a visibility query: a preview
+// of the targets, or the outcome per workflow once sent.
+type SignalMatching struct {
+	Query      string `json:"query"`
+	SignalName string `json:"signal_name"`
+	// Input is the signal's input as given, or empty for none.
+	Input string `json:"input,omitempty"`
+	// InputWarning is set when the input is large enough to be sent with a warning.
+	InputWarning string `json:"input_warning,omitempty"`
+	// Matched counts the executions the query matched; Closed those among them that were already
+	// closed and are skipped.
+	Matched int            `json:"matched"`
+	Closed  int            `json:"closed"`
+	Targets []SignalTarget `json:"targets"`
+	// Performed is set once the signals were sent, and DryRun when the server is in dry-run mode
+	// and they were skipped.
+	Performed bool `json:"performed"`
+	DryRun    bool `json:"dry_run,omitempty"`
+	// Processed reports how many targets the fan-out reached, e.g. when cancelled part way.
+	Processed string `json:"processed,omitempty"`
+}
+
+// SignalTarget is a running workflow a signal is sent to, with the outcome once sent: signaled,
+// closed (it closed before the signal reached it), failed with Error, or empty if not attempted.
+type SignalTarget struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+	Type       string `json:"type"`
+	Outcome    string `json:"outcome,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// SignalMatchingText renders a signal_matching preview, or its per-workflow outcome.
+func SignalMatchingText(s SignalMatching) string {
+	var b strings.Builder
+	switch {
+	case s.DryRun:
+		fmt.Fprintf(&b, "[DRY RUN] would have sent signal %s to %d running workflow(s); nothing was changed.\n", s.SignalName, len(s.Targets))
+	case s.Performed:
+		counts := map[string]int{}
+		for _, t := range s.Targets {
+			counts[t.Outcome]++
+		}
+		fmt.Fprintf(&b, "Sent signal %s: %d signaled, %d failed, %d closed before the signal reached them", s.SignalName, counts["signaled"], counts["failed"], counts["closed"])
+		if n := counts[""]; n > 0 {
+			fmt.Fprintf(&b, ", %d not attempted", n)
+		}
+		b.WriteString(".\n")
+	default:
+		fmt.Fprintf(&b, "Signal preview (not sent): signal %s to %d running workflow(s).\n", s.SignalName, len(s.Targets))
+	}
+	fmt.Fprintf(&b, "Query: %s\n", s.Query)
+	if s.Input != "" {
+		fmt.Fprintf(&b, "Input: %s\n", s.Input)
+	}
+	if s.InputWarning != "" {
+		fmt.Fprintf(&b, "WARNING: %s\n", s.InputWarning)
+	}
+	fmt.Fprintf(&b, "Matched: %d execution(s); %d already closed (skipped)\n", s.Matched, s.Closed)
+	for _, t := range s.Targets {
+		fmt.Fprintf(&b, "- ID: %s | Run: %s | Type: %s", t.WorkflowID, t.RunID, t.Type)
+		switch {
+		case t.Error != "":
+			fmt.Fprintf(&b, " | %s: %s", t.Outcome, t.Error)
+		case t.Outcome != "":
+			fmt.Fprintf(&b, " | %s", t.Outcome)
+		}
+		b.WriteString("\n")
+	}
+	if s.Processed != "" {
+		fmt.Fprintf(&b, "Batch: %s\n", s.Processed)
+	}
+	if !s.Performed && !s.DryRun && len(s.Targets) > 0 {
+		b.WriteString("Call again with confirm=true to send the signal.\n")
+	}
+	return b.String()
+}
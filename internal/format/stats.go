@@ -0,0 +1,138 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StatusCount is the number of executions in one status.
+type StatusCount struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+// TypeCount is the number of executions of one workflow type.
+type TypeCount struct {
+	Type  string `json:"type"`
+	Count int64  `json:"count"`
+}
+
+// WorkflowStats is a count of executions grouped by status or by workflow type.
+type WorkflowStats struct {
+	// GroupBy is "status" or "type"; Counts is set for the former and Types for the latter.
+	GroupBy string `json:"group_by"`
+	// Window describes the time range counted, e.g. "in the last 24h0m0s".
+	Window       string        `json:"window"`
+	WorkflowType string        `json:"workflow_type,omitempty"`
+	Status       string        `json:"status,omitempty"`
+	Counts       []StatusCount `json:"counts,omitempty"`
+	Types        []TypeCount   `json:"types,omitempty"`
+	// DistinctTypes is the number of types seen before Types was cut to the top N.
+	DistinctTypes int   `json:"distinct_types,omitempty"`
+	Total         int64 `json:"total"`
+	// Sampled is set when the counts were computed by listing this many executions because the
+	// server can't group counts; Truncated means the listing stopped early, so counts are approximate.
+	Sampled   int  `json:"sampled,omitempty"`
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// WorkflowStatsText renders the counts as a small table followed by the total.
+func WorkflowStatsText(s WorkflowStats) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Workflow counts by %s (started %s", s.GroupBy, s.Window)
+	if s.WorkflowType != "" {
+		fmt.Fprintf(&b, ", type %s", s.WorkflowType)
+	}
+	if s.Status != "" {
+		fmt.Fprintf(&b, ", status %s", s.Status)
+	}
+	b.WriteString("):\n")
+
+	if s.GroupBy == "type" {
+		fmt.Fprintf(&b, "%-40s %s\n", "TYPE", "COUNT")
+		for _, c := range s.Types {
+			fmt.Fprintf(&b, "%-40s %d\n", c.Type, c.Count)
+		}
+		if len(s.Types) < s.DistinctTypes {
+			fmt.Fprintf(&b, "(top %d of %d types)\n", len(s.Types), s.DistinctTypes)
+		}
+	} else {
+		fmt.Fprintf(&b, "%-15s %s\n", "STATUS", "COUNT")
+		for _, c := range s.Counts {
+			fmt.Fprintf(&b, "%-15s %d\n", c.Status, c.Count)
+		}
+	}
+	fmt.Fprintf(&b, "Total: %d\n", s.Total)
+
+	switch {
+	case s.Truncated:
+		fmt.Fprintf(&b, "Approximate: the server can't group counts by type, so these counts come from a sample of the %d most recent matching executions.\n", s.Sampled)
+	case s.Sampled > 0:
+		fmt.Fprintf(&b, "Counted by listing all %d matching execution(s), since the server can't group counts by type.\n", s.Sampled)
+	}
+	return b.String()
+}
+
+// HistogramBucket is the number of executions started in one time bucket [Start, End).
+type HistogramBucket struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Count int64  `json:"count"`
+}
+
+// WorkflowHistogram counts the executions started per time bucket over a window.
+type WorkflowHistogram struct {
+	// Bucket is the bucket size, e.g. "1h0m0s".
+	Bucket       string            `json:"bucket"`
+	WorkflowType string            `json:"workflow_type,omitempty"`
+	Status       string            `json:"status,omitempty"`
+	Buckets      []HistogramBucket `json:"buckets"`
+	Total        int64             `json:"total"`
+	// Partial marks the last bucket as still filling, because it ends in the future.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// histogramWidth is the length of the longest bar of a histogram.
+const histogramWidth = 40
+
+// WorkflowHistogramText renders the buckets as aligned rows with a bar scaled to the largest count.
+func WorkflowHistogramText(h WorkflowHistogram) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Workflow starts per %s", h.Bucket)
+	if h.WorkflowType != "" {
+		fmt.Fprintf(&b, ", type %s", h.WorkflowType)
+	}
+	if h.Status != "" {
+		fmt.Fprintf(&b, ", status %s", h.Status)
+	}
+	b.WriteString(" (UTC):\n")
+
+	var peak int64
+	for _, bucket := range h.Buckets {
+		peak = max(peak, bucket.Count)
+	}
+	countWidth := len(fmt.Sprint(peak))
+	for i, bucket := range h.Buckets {
+		bar := 0
+		if peak > 0 {
+			bar = int((bucket.Count*histogramWidth + peak - 1) / peak)
+		}
+		row := fmt.Sprintf("%s  %*d  %s", histogramLabel(bucket.Start), countWidth, bucket.Count, strings.Repeat("#", bar))
+		if h.Partial && i == len(h.Buckets)-1 {
+			row += " (in progress)"
+		}
+		b.WriteString(strings.TrimRight(row, " ") + "\n")
+	}
+	fmt.Fprintf(&b, "Total: %d\n", h.Total)
+	return b.String()
+}
+
+// histogramLabel shortens an RFC 3339 bucket start to "2006-01-02 15:04" for the text histogram.
+func histogramLabel(start string) string {
+	t, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return start
+	}
+	return t.UTC().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,185 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HistorySummary is a condensed, deterministic account of a workflow history, sized for an LLM
+// context window.
+type HistorySummary struct {
+	WorkflowID   string `json:"workflow_id"`
+	RunID        string `json:"run_id"`
+	WorkflowType string `json:"workflow_type"`
+	Events       int    `json:"events"`
+
+	StartTime string `json:"start_time"`
+	TaskQueue string `json:"task_queue"`
+	// InputBytes is the total size of the input payloads; InputKeys the top-level keys of JSON object
+	// inputs, sorted.
+	InputPayloads int      `json:"input_payloads"`
+	InputBytes    int      `json:"input_bytes"`
+	InputKeys     []string `json:"input_keys,omitempty"`
+
+	Activities []ActivityTypeSummary `json:"activities"`
+	Signals    []SignalSummary       `json:"signals"`
+	Children   []ChildSummary        `json:"children"`
+	Timers     int                   `json:"timers"`
+	Failures   []FailureSummary      `json:"failures"`
+	// Versions are the version markers recorded by GetVersion or patched calls, in history order, and
+	// Markers counts the other markers by name; local activities are among the Activities.
+	Versions []VersionSummary `json:"versions"`
+	Markers  []MarkerSummary  `json:"markers"`
+
+	// Close describes the terminal event; it is empty while the workflow is running.
+	Close     string `json:"close,omitempty"`
+	CloseTime string `json:"close_time,omitempty"`
+
+	// Omitted counts list entries dropped to fit the output size limit.
+	Omitted int `json:"omitted,omitempty"`
+}
+
+// ActivityTypeSummary aggregates the executions of one activity type.
+type ActivityTypeSummary struct {
+	Type      string `json:"type"`
+	Scheduled int    `json:"scheduled"`
+	Completed int    `json:"completed"`
+	Failed    int    `json:"failed"`
+	TimedOut  int    `json:"timed_out"`
+	Canceled  int    `json:"canceled"`
+	// Retries counts attempts beyond the first, over every closed execution.
+	Retries int `json:"retries"`
+	// TotalDuration sums the start-to-close time of the last attempt of every closed execution.
+	TotalDuration string `json:"total_duration"`
+	// Local is set for local activities, summarized apart from regular ones of the same type.
+	Local bool `json:"local,omitempty"`
+}
+
+// SignalSummary aggregates the signals received under one name.
+type SignalSummary struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	First string `json:"first"`
+	Last  string `json:"last"`
+}
+
+// VersionSummary is a version marker: the change ID and the version the workflow took.
+type VersionSummary struct {
+	EventID  int64  `json:"event_id"`
+	ChangeID string `json:"change_id"`
+	Version  string `json:"version"`
+}
+
+// MarkerSummary counts the markers recorded under one name.
+type MarkerSummary struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// ChildSummary describes a child workflow started by the execution.
+type ChildSummary struct {
+	WorkflowID string `json:"workflow_id"`
+	Type       string `json:"type"`
+	Outcome    string `json:"outcome"`
+}
+
+// FailureSummary is a failure recorded in the history.
+type FailureSummary struct {
+	EventID   int64  `json:"event_id"`
+	EventType string `json:"event_type"`
+	Message   string `json:"message"`
+}
+
+// Truncate drops list entries from the end of each list, keeping at most limit per list, and
+// records how many were dropped.
+func (s *HistorySummary) Truncate(limit int) {
+	if len(s.Activities) > limit {
+		s.Omitted += len(s.Activities) - limit
+		s.Activities = s.Activities[:limit]
+	}
+	if len(s.Signals) > limit {
+		s.Omitted += len(s.Signals) - limit
+		s.Signals = s.Signals[:limit]
+	}
+	if len(s.Children) > limit {
+		s.Omitted += len(s.Children) - limit
+		s.Children = s.Children[:limit]
+	}
+	if len(s.Failures) > limit {
+		s.Omitted += len(s.Failures) - limit
+		s.Failures = s.Failures[:limit]
+	}
+	if len(s.Versions) > limit {
+		s.Omitted += len(s.Versions) - limit
+		s.Versions = s.Versions[:limit]
+	}
+	if len(s.Markers) > limit {
+		s.Omitted += len(s.Markers) - limit
+		s.Markers = s.Markers[:limit]
+	}
+}
+
+// HistorySummaryText renders a history summary as compact sections.
+func HistorySummaryText(s HistorySummary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "History summary for workflow %s (run %s, type %s, %d events):\n", s.WorkflowID, s.RunID, s.WorkflowType, s.Events)
+	fmt.Fprintf(&b, "Started: %s on task queue %s with %d input payload(s), %d bytes", s.StartTime, s.TaskQueue, s.InputPayloads, s.InputBytes)
+	if len(s.InputKeys) > 0 {
+		fmt.Fprintf(&b, " (keys: %s)", strings.Join(s.InputKeys, ", "))
+	}
+	b.WriteString("\n")
+
+	if len(s.Activities) > 0 {
+		b.WriteString("Activities:\n")
+		for _, a := range s.Activities {
+			local := ""
+			if a.Local {
+				local = " (local)"
+			}
+			fmt.Fprintf(&b, "- %s%s: %d scheduled, %d completed, %d failed, %d timed out, %d canceled, %d retries, %s total\n",
+				a.Type, local, a.Scheduled, a.Completed, a.Failed, a.TimedOut, a.Canceled, a.Retries, a.TotalDuration)
+		}
+	}
+	if len(s.Signals) > 0 {
+		b.WriteString("Signals:\n")
+		for _, sig := range s.Signals {
+			fmt.Fprintf(&b, "- %s: %d received (first %s, last %s)\n", sig.Name, sig.Count, sig.First, sig.Last)
+		}
+	}
+	if len(s.Children) > 0 {
+		b.WriteString("Child Workflows:\n")
+		for _, c := range s.Children {
+			fmt.Fprintf(&b, "- %s (%s): %s\n", c.WorkflowID, c.Type, c.Outcome)
+		}
+	}
+	if s.Timers > 0 {
+		fmt.Fprintf(&b, "Timers: %d started\n", s.Timers)
+	}
+	if len(s.Versions) > 0 {
+		b.WriteString("Versions:\n")
+		for _, v := range s.Versions {
+			fmt.Fprintf(&b, "- %s: %s (event %d)\n", v.ChangeID, v.Version, v.EventID)
+		}
+	}
+	if len(s.Markers) > 0 {
+		b.WriteString("Markers:\n")
+		for _, m := range s.Markers {
+			fmt.Fprintf(&b, "- %s: %d recorded\n", m.Name, m.Count)
+		}
+	}
+	if len(s.Failures) > 0 {
+		b.WriteString("Failures:\n")
+		for _, f := range s.Failures {
+			fmt.Fprintf(&b, "- Event %d (%s): %s\n", f.EventID, f.EventType, f.Message)
+		}
+	}
+	if s.Close != "" {
+		fmt.Fprintf(&b, "Closed: %s at %s\n", s.Close, s.CloseTime)
+	} else {
+		b.WriteString("Closed: still running\n")
+	}
+	if s.Omitted > 0 {
+		fmt.Fprintf(&b, "(%d list entries omitted to fit the size limit)\n", s.Omitted)
+	}
+	return b.String()
+}
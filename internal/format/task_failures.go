@@ -0,0 +1,116 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkflowTaskFailure is a WorkflowTaskFailed event: a worker failed to process a workflow task.
+// The server records only the first failure of a run of retries, so one event can stand for many
+// failed attempts.
+type WorkflowTaskFailure struct {
+	EventID int64  `json:"event_id"`
+	Time    string `json:"time"`
+	Cause   string `json:"cause"`
+	// Class is nondeterminism, panic, unhandled_error, bad_command, limit_exceeded, bad_binary,
+	// or other.
+	Class      string `json:"class"`
+	Message    string `json:"message,omitempty"`
+	StackTrace string `json:"stack_trace,omitempty"`
+	Identity   string `json:"identity,omitempty"`
+	// BuildID is the failing worker's build ID, and BinaryChecksum its deprecated binary checksum.
+	BuildID        string `json:"build_id,omitempty"`
+	BinaryChecksum string `json:"binary_checksum,omitempty"`
+}
+
+// FailingWorker is a worker that failed workflow tasks of the workflow.
+type FailingWorker struct {
+	Identity       string `json:"identity"`
+	BuildID        string `json:"build_id,omitempty"`
+	BinaryChecksum string `json:"binary_checksum,omitempty"`
+	Failures       int    `json:"failures"`
+}
+
+// WorkflowTaskCheck is the verdict on a workflow's workflow task failures: whether its pending
+// workflow task is stuck retrying, why, and on which workers.
+type WorkflowTaskCheck struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+	Status     string `json:"status"`
+	// PendingWorkflowTask is the workflow task in flight, if any; an attempt over 1 means it is
+	// retrying after failures.
+	PendingWorkflowTask *PendingWorkflowTask `json:"pending_workflow_task,omitempty"`
+	// Stuck is set when the pending task is retrying after a failure, and Recovered when a workflow
+	// task completed after the last failure.
+	Stuck     bool `json:"stuck"`
+	Recovered bool `json:"recovered,omitempty"`
+	// Class and Explanation classify the most recent failure.
+	Class       string `json:"class,omitempty"`
+	Explanation string `json:"explanation,omitempty"`
+	// Total counts the failures in history; Failures holds the most recent ones, latest first.
+	Total    int                   `json:"total"`
+	Failures []WorkflowTaskFailure `json:"failures"`
+	Workers  []FailingWorker       `json:"workers,omitempty"`
+}
+
+// WorkflowTaskCheckText renders the verdict first, then the failing workers and the failures.
+func WorkflowTaskCheckText(c WorkflowTaskCheck) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Workflow task failures of workflow %s (run %s, %s):\n", c.WorkflowID, c.RunID, c.Status)
+	switch {
+	case c.Stuck:
+		fmt.Fprintf(&b, "Verdict: STUCK; the pending workflow task is failing repeatedly (attempt %d, scheduled %s): %s\n", c.PendingWorkflowTask.Attempt, c.PendingWorkflowTask.ScheduledTime, c.Class)
+	case c.Total == 0:
+		b.WriteString("Verdict: no workflow task failures in history.\n")
+	case c.Recovered:
+		fmt.Fprintf(&b, "Verdict: recovered; a workflow task completed after the last failure (%s).\n", c.Class)
+	default:
+		fmt.Fprintf(&b, "Verdict: the last workflow task failed (%s) and hasn't been retried successfully yet.\n", c.Class)
+	}
+	if c.Explanation != "" {
+		fmt.Fprintf(&b, "Explanation: %s\n", c.Explanation)
+	}
+	if c.Stuck {
+		b.WriteString("Note: the server records only the first failure of a streak of retries; the attempt count includes the failures not in history.\n")
+	}
+	if len(c.Workers) > 0 {
+		b.WriteString("Failing workers:\n")
+		for _, w := range c.Workers {
+			fmt.Fprintf(&b, "- Identity: %s", w.Identity)
+			if w.BuildID != "" {
+				fmt.Fprintf(&b, " | Build: %s", w.BuildID)
+			}
+			if w.BinaryChecksum != "" {
+				fmt.Fprintf(&b, " | Binary Checksum: %s", w.BinaryChecksum)
+			}
+			fmt.Fprintf(&b, " | Failures: %d\n", w.Failures)
+		}
+	}
+	if c.Total > 0 {
+		fmt.Fprintf(&b, "Failures in history (%d, most recent first", c.Total)
+		if len(c.Failures) < c.Total {
+			fmt.Fprintf(&b, "; showing %d", len(c.Failures))
+		}
+		b.WriteString("):\n")
+	}
+	for _, f := range c.Failures {
+		fmt.Fprintf(&b, "- Event %d at %s: %s (%s)", f.EventID, f.Time, f.Cause, f.Class)
+		if f.Identity != "" {
+			fmt.Fprintf(&b, " | Identity: %s", f.Identity)
+		}
+		if f.BuildID != "" {
+			fmt.Fprintf(&b, " | Build: %s", f.BuildID)
+		}
+		if f.BinaryChecksum != "" {
+			fmt.Fprintf(&b, " | Binary Checksum: %s", f.BinaryChecksum)
+		}
+		b.WriteString("\n")
+		if f.Message != "" {
+			fmt.Fprintf(&b, "  Message: %s\n", f.Message)
+		}
+		if f.StackTrace != "" {
+			fmt.Fprintf(&b, "  Stack Trace:\n    %s\n", strings.ReplaceAll(f.StackTrace, "\n", "\n    "))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OrphanedTaskQueue is a task queue with running workflows but no workflow pollers.
+type OrphanedTaskQueue struct {
+	Name             string `json:"name"`
+	RunningWorkflows int    `json:"running_workflows"`
+	// Examples holds a few of the affected workflow IDs.
+	Examples []string `json:"examples"`
+}
+
+// OrphanedTaskQueues is the result of checking the task queues of running workflows for pollers.
+type OrphanedTaskQueues struct {
+	// Scanned is how many running workflows were listed; Truncated means more exist.
+	Scanned   int  `json:"scanned"`
+	Truncated bool `json:"truncated,omitempty"`
+	// Queues is how many distinct task queues were found, and Coverage how many were checked.
+	Queues   int                 `json:"queues"`
+	Coverage string              `json:"coverage"`
+	Orphaned []OrphanedTaskQueue `json:"orphaned"`
+	// Errors lists task queues that couldn't be checked.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// OrphanedTaskQueuesText renders the orphaned task queues, most affected first.
+func OrphanedTaskQueuesText(r OrphanedTaskQueues) string {
+	var b strings.Builder
+	if len(r.Orphaned) == 0 {
+		b.WriteString("No task queues with running workflows are missing workflow pollers.\n")
+	} else {
+		fmt.Fprintf(&b, "Found %d task queue(s) with running workflows but no workflow pollers:\n", len(r.Orphaned))
+		for _, q := range r.Orphaned {
+			fmt.Fprintf(&b, "- %s: %d running workflow(s) (e.g. %s)\n", q.Name, q.RunningWorkflows, strings.Join(q.Examples, ", "))
+		}
+	}
+	for _, e := range r.Errors {
+		fmt.Fprintf(&b, "Unable to check %s\n", e)
+	}
+	fmt.Fprintf(&b, "Scanned %d running workflow(s) on %d task queue(s); checked: %s.", r.Scanned, r.Queues, r.Coverage)
+	if r.Truncated {
+		b.WriteString(" More running workflows exist; counts cover the scanned ones only.")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
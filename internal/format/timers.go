@@ -0,0 +1,97 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	workflowpb "go.temporal.io/api/workflow/v1"
+)
+
+// Timer is a durable timer started by a workflow (including the timers behind workflow sleeps).
+type Timer struct {
+	TimerID        string `json:"timer_id"`
+	StartedEventID int64  `json:"started_event_id"`
+	StartTime      string `json:"start_time"`
+	Duration       string `json:"duration"`
+	// FireTime is when the timer fired or, while it is pending, when it is expected to fire.
+	FireTime string `json:"fire_time"`
+	// Status is Pending, Fired, or Canceled.
+	Status string `json:"status"`
+}
+
+// PendingWorkflowTask is a workflow task waiting to be picked up or completed by a worker.
+type PendingWorkflowTask struct {
+	State         string `json:"state"`
+	ScheduledTime string `json:"scheduled_time"`
+	Attempt       int32  `json:"attempt"`
+	StartedTime   string `json:"started_time,omitempty"`
+	// OriginalScheduledTime is when the task was scheduled before any workflow task heartbeats
+	// (long-running local activities), and Age how long ago, rounded to the second: a high attempt
+	// count or a large age means workers are failing or absent.
+	OriginalScheduledTime string `json:"original_scheduled_time,omitempty"`
+	Age                   string `json:"age,omitempty"`
+}
+
+// NewPendingWorkflowTask builds the view of a pending workflow task, aged as of now.
+func NewPendingWorkflowTask(task *workflowpb.PendingWorkflowTaskInfo, now time.Time) *PendingWorkflowTask {
+	p := &PendingWorkflowTask{
+		State:                 task.GetState().String(),
+		ScheduledTime:         Time(task.GetScheduledTime()),
+		Attempt:               task.GetAttempt(),
+		StartedTime:           Time(task.GetStartedTime()),
+		OriginalScheduledTime: Time(task.GetOriginalScheduledTime()),
+	}
+	first := task.GetOriginalScheduledTime()
+	if first == nil {
+		first = task.GetScheduledTime()
+	}
+	if first != nil {
+		p.Age = now.Sub(first.AsTime()).Round(time.Second).String()
+	}
+	return p
+}
+
+// TimerList is the timers of a workflow execution, pending timers first by soonest fire time.
+type TimerList struct {
+	WorkflowID string  `json:"workflow_id"`
+	RunID      string  `json:"run_id"`
+	Status     string  `json:"status"`
+	Timers     []Timer `json:"timers"`
+	// PendingWorkflowTask is set when the workflow has work to do right now, ahead of any timer.
+	PendingWorkflowTask *PendingWorkflowTask `json:"pending_workflow_task,omitempty"`
+}
+
+// TimerListText renders the timers of a workflow, leading with when it will next wake up.
+func TimerListText(l TimerList, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Timers of workflow %s (run %s, %s):\n", l.WorkflowID, l.RunID, l.Status)
+	if t := l.PendingWorkflowTask; t != nil {
+		fmt.Fprintf(&b, "Next wake-up: now; a workflow task is %s (scheduled %s, attempt %d)\n", strings.ToLower(t.State), t.ScheduledTime, t.Attempt)
+	} else if len(l.Timers) > 0 && l.Timers[0].Status == "Pending" {
+		next := l.Timers[0]
+		fmt.Fprintf(&b, "Next wake-up: %s (timer %s", next.FireTime, next.TimerID)
+		if fire, err := time.Parse(time.RFC3339, next.FireTime); err == nil && fire.After(now) {
+			fmt.Fprintf(&b, ", in %s", fire.Sub(now).Round(time.Second))
+		}
+		b.WriteString(")\n")
+	} else if l.Status == "Running" {
+		b.WriteString("Next wake-up: none scheduled; the workflow is waiting on a signal, update, activity, or child workflow\n")
+	}
+	if len(l.Timers) == 0 {
+		b.WriteString("No timers started.\n")
+		return b.String()
+	}
+	for _, t := range l.Timers {
+		fmt.Fprintf(&b, "- Timer %s (event %d): %s | Duration: %s | Started: %s | ", t.TimerID, t.StartedEventID, t.Status, t.Duration, t.StartTime)
+		switch t.Status {
+		case "Pending":
+			fmt.Fprintf(&b, "Fires: %s\n", t.FireTime)
+		case "Fired":
+			fmt.Fprintf(&b, "Fired: %s\n", t.FireTime)
+		default:
+			fmt.Fprintf(&b, "Canceled: %s\n", t.FireTime)
+		}
+	}
+	return b.String()
+}
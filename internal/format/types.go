@@ -0,0 +1,52 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkflowTypeSummary describes one workflow type seen in recent executions or listed in the
+// operator's catalog.
+type WorkflowTypeSummary struct {
+	Type string `json:"type"`
+	// Executions is the number of scanned executions of this type.
+	Executions  int    `json:"executions"`
+	LatestStart string `json:"latest_start,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Cataloged reports whether the type is listed in the operator's catalog.
+	Cataloged bool `json:"cataloged"`
+}
+
+// WorkflowTypeList is the result of list_workflow_types.
+type WorkflowTypeList struct {
+	Types []WorkflowTypeSummary `json:"types"`
+	// Scanned is how many recent executions were inspected; Truncated means older executions exist
+	// beyond the scan.
+	Scanned   int  `json:"scanned"`
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// WorkflowTypeListText renders the workflow types, one line each.
+func WorkflowTypeListText(l WorkflowTypeList) string {
+	if len(l.Types) == 0 {
+		return fmt.Sprintf("No workflow types found in the %d most recent execution(s).", l.Scanned)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Found %d workflow type(s) (scanned %d execution(s)", len(l.Types), l.Scanned)
+	if l.Truncated {
+		b.WriteString(", most recent first; older executions not scanned")
+	}
+	b.WriteString("):\n")
+	for _, t := range l.Types {
+		latest := t.LatestStart
+		if latest == "" {
+			latest = "none in scan"
+		}
+		fmt.Fprintf(&b, "- Type: %s | Executions: %d | Latest Start: %s", t.Type, t.Executions, latest)
+		if t.Description != "" {
+			fmt.Fprintf(&b, " | Description: %s", t.Description)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UpdateResult is the state of a workflow update: the lifecycle stage it reached and, once
+// completed, its decoded result or failure.
+type UpdateResult struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+	UpdateID   string `json:"update_id"`
+	// Found is false when the workflow has no update with this ID.
+	Found bool `json:"found"`
+	// Stage is Admitted, Accepted, or Completed.
+	Stage string `json:"stage,omitempty"`
+	// Outcome is success or failure once the update completed.
+	Outcome string   `json:"outcome,omitempty"`
+	Result  []string `json:"result,omitempty"`
+	Failure string   `json:"failure,omitempty"`
+	// Waited is how long the call waited for an update that is still running.
+	Waited string `json:"waited,omitempty"`
+}
+
+// UpdateResultText renders the state of a workflow update.
+func UpdateResultText(u UpdateResult) string {
+	var b strings.Builder
+	if !u.Found {
+		fmt.Fprintf(&b, "Workflow %s (run %s) has no update with ID %s.\n", u.WorkflowID, u.RunID, u.UpdateID)
+		b.WriteString("The update was never sent to this run, was rejected by the workflow's validator (rejected updates aren't recorded), or was sent to another run (e.g. before a reset or continue-as-new).\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Update %s of workflow %s (run %s)\n", u.UpdateID, u.WorkflowID, u.RunID)
+	switch u.Outcome {
+	case "success":
+		b.WriteString("Stage: Completed\nOutcome: success\n")
+		if len(u.Result) > 0 {
+			fmt.Fprintf(&b, "Result: %s\n", strings.Join(u.Result, ", "))
+		}
+	case "failure":
+		b.WriteString("Stage: Completed\nOutcome: failure\n")
+		fmt.Fprintf(&b, "Failure: %s\n", u.Failure)
+	default:
+		fmt.Fprintf(&b, "Stage: %s (still running after waiting %s)\n", u.Stage, u.Waited)
+		b.WriteString("Call again, with a longer wait if needed, to get its outcome.\n")
+	}
+	return b.String()
+}
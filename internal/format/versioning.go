@@ -0,0 +1,183 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionReport is the worker versioning state of a task queue during a rollout: its build ID
+// rules, the builds its workers poll with, and the running workflows pinned to each build.
+type VersionReport struct {
+	TaskQueue string `json:"task_queue"`
+	// NotVersioned is set when nothing on the task queue uses worker versioning; the rest is empty.
+	NotVersioned bool `json:"not_versioned,omitempty"`
+	// RulesNote explains why the rules are missing, when the server didn't return them.
+	RulesNote       string           `json:"rules_note,omitempty"`
+	AssignmentRules []AssignmentRule `json:"assignment_rules"`
+	RedirectRules   []RedirectRule   `json:"redirect_rules"`
+	Builds          []BuildReport    `json:"builds"`
+	// UnversionedPollers are the pollers that don't report a versioned build.
+	UnversionedPollers []VersionPoller `json:"unversioned_pollers"`
+	// Running is the number of running workflows on the task queue (-1 if unknown), and Unpinned how
+	// many of them aren't pinned to a build.
+	Running  int64 `json:"running"`
+	Unpinned int64 `json:"unpinned"`
+	// Method is group_by when the per-build counts come from a GROUP BY count, or scan when they come
+	// from listing Scanned executions; Truncated means the scan stopped before the end.
+	Method    string   `json:"method"`
+	Scanned   int      `json:"scanned,omitempty"`
+	Truncated bool     `json:"truncated,omitempty"`
+	Stranded  []string `json:"stranded"`
+	Orphans   []string `json:"orphans"`
+	// Behaviors counts the running workflows by their effective worker deployment versioning
+	// behavior (Pinned or AutoUpgrade), execution overrides included; nil when none has one.
+	Behaviors map[string]int64 `json:"behaviors,omitempty"`
+}
+
+// AssignmentRule is a build ID assignment rule. Rules are evaluated in order for new workflows:
+// the first unramped rule is the default, and the rules after it are unreachable.
+type AssignmentRule struct {
+	Index          int     `json:"index"`
+	BuildID        string  `json:"build_id"`
+	RampPercentage float32 `json:"ramp_percentage"`
+	CreateTime     string  `json:"create_time"`
+	// Role is default, ramping, or unreachable.
+	Role string `json:"role"`
+}
+
+// RedirectRule moves the workflows of a source build to a compatible target build.
+type RedirectRule struct {
+	SourceBuildID string `json:"source_build_id"`
+	TargetBuildID string `json:"target_build_id"`
+	CreateTime    string `json:"create_time"`
+}
+
+// BuildReport is one build ID seen on a task queue, in its rules, pollers, or running workflows.
+type BuildReport struct {
+	BuildID string `json:"build_id"`
+	// Deployment is the worker deployment the build's pollers belong to, if any.
+	Deployment string `json:"deployment,omitempty"`
+	// Rules describes how the rules reference the build, e.g. "assignment #0 (default)".
+	Rules   []string        `json:"rules"`
+	Pollers []VersionPoller `json:"pollers"`
+	// Pinned counts the running workflows assigned to the build, and Processed those it processed
+	// tasks of.
+	Pinned    int64 `json:"pinned"`
+	Processed int64 `json:"processed"`
+	// Stranded is set when workflows are pinned to the build but no live poller runs it; Orphan when
+	// pollers run it but no rule references it, so it gets no new workflows.
+	Stranded bool `json:"stranded,omitempty"`
+	Orphan   bool `json:"orphan,omitempty"`
+}
+
+// VersionPoller is a worker polling a task queue, merged over the task queue types it polls.
+type VersionPoller struct {
+	Identity       string   `json:"identity"`
+	Types          []string `json:"types"`
+	LastAccessTime string   `json:"last_access_time"`
+	// Live is unset when the poller hasn't polled recently: the server remembers pollers for a few
+	// minutes after their worker stops.
+	Live bool `json:"live"`
+}
+
+// VersionReportText renders a version report: rules, then one line per build, then the findings.
+func VersionReportText(r VersionReport) string {
+	var b strings.Builder
+	if r.NotVersioned {
+		fmt.Fprintf(&b, "Worker versioning isn't in use on task queue %s: ", r.TaskQueue)
+		if r.RulesNote != "" {
+			fmt.Fprintf(&b, "%s, ", r.RulesNote)
+		} else {
+			b.WriteString("it has no build ID rules, ")
+		}
+		b.WriteString("no poller reports a versioned build, and no running workflow is pinned to a build.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Worker versioning on task queue %s:\n", r.TaskQueue)
+	if r.Running >= 0 {
+		fmt.Fprintf(&b, "Running workflows: %d", r.Running)
+		if r.Unpinned >= 0 {
+			fmt.Fprintf(&b, " (%d not pinned to a build)", r.Unpinned)
+		}
+		b.WriteString("\n")
+	}
+	if len(r.Behaviors) > 0 {
+		fmt.Fprintf(&b, "Versioning behavior (overrides included): %d Pinned, %d AutoUpgrade\n", r.Behaviors["Pinned"], r.Behaviors["AutoUpgrade"])
+	}
+	if r.RulesNote != "" {
+		fmt.Fprintf(&b, "Rules: unavailable, %s\n", r.RulesNote)
+	} else {
+		if len(r.AssignmentRules) == 0 {
+			b.WriteString("Assignment rules: none\n")
+		} else {
+			b.WriteString("Assignment rules (evaluated in order for new workflows):\n")
+			for _, rule := range r.AssignmentRules {
+				fmt.Fprintf(&b, "- #%d %s: %s", rule.Index, rule.BuildID, rule.Role)
+				if rule.Role == "ramping" {
+					fmt.Fprintf(&b, " %g%%", rule.RampPercentage)
+				}
+				fmt.Fprintf(&b, " (created %s)\n", rule.CreateTime)
+			}
+		}
+		if len(r.RedirectRules) > 0 {
+			b.WriteString("Redirect rules:\n")
+			for _, rule := range r.RedirectRules {
+				fmt.Fprintf(&b, "- %s -> %s (created %s)\n", rule.SourceBuildID, rule.TargetBuildID, rule.CreateTime)
+			}
+		}
+	}
+	b.WriteString("Builds:\n")
+	for _, build := range r.Builds {
+		fmt.Fprintf(&b, "- %s", build.BuildID)
+		if build.Deployment != "" {
+			fmt.Fprintf(&b, " (deployment %s)", build.Deployment)
+		}
+		fmt.Fprintf(&b, ": %d pinned, %d processed running workflow(s)", build.Pinned, build.Processed)
+		if len(build.Rules) > 0 {
+			fmt.Fprintf(&b, " | Rules: %s", strings.Join(build.Rules, ", "))
+		} else {
+			b.WriteString(" | Rules: none")
+		}
+		fmt.Fprintf(&b, " | Pollers: %s", versionPollersText(build.Pollers))
+		switch {
+		case build.Stranded:
+			b.WriteString(" | STRANDED")
+		case build.Orphan:
+			b.WriteString(" | ORPHAN")
+		}
+		b.WriteString("\n")
+	}
+	if len(r.UnversionedPollers) > 0 {
+		fmt.Fprintf(&b, "Unversioned pollers: %s\n", versionPollersText(r.UnversionedPollers))
+	}
+	if len(r.Stranded) > 0 {
+		fmt.Fprintf(&b, "Stranded builds: %s. Running workflows are pinned to them but no live worker runs them, so they make no progress; restart workers of these builds, or add a redirect rule to a compatible build.\n", strings.Join(r.Stranded, ", "))
+	}
+	if len(r.Orphans) > 0 {
+		fmt.Fprintf(&b, "Orphan builds: %s. Workers poll with them but no rule references them, so they get no new workflows; add an assignment rule or stop them.\n", strings.Join(r.Orphans, ", "))
+	}
+	if r.Method == "scan" {
+		fmt.Fprintf(&b, "Per-build counts come from scanning %d running workflow(s)", r.Scanned)
+		if r.Truncated {
+			b.WriteString("; more exist, so they are lower bounds")
+		}
+		b.WriteString(".\n")
+	}
+	return b.String()
+}
+
+// versionPollersText renders pollers as "identity (types, last seen)", or "none".
+func versionPollersText(pollers []VersionPoller) string {
+	if len(pollers) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(pollers))
+	for i, p := range pollers {
+		state := "last poll " + p.LastAccessTime
+		if !p.Live {
+			state = "gone, last poll " + p.LastAccessTime
+		}
+		parts[i] = fmt.Sprintf("%s (%s; %s)", p.Identity, strings.Join(p.Types, ", "), state)
+	}
+	return strings.Join(parts, "; ")
+}
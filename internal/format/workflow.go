@@ -0,0 +1,561 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	deploymentpb "go.temporal.io/api/deployment/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/payload"
+)
+
+// WorkflowSummary is one row of a workflow listing.
+type WorkflowSummary struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	StartTime  string `json:"start_time"`
+	CloseTime  string `json:"close_time,omitempty"`
+	// Duration is the execution duration, included when the listing filters by it.
+	Duration string `json:"duration,omitempty"`
+	// Namespace is set in listings across several namespaces.
+	Namespace string `json:"namespace,omitempty"`
+	// FirstRunID is the first run of the execution's chain of runs (continue-as-new, retries,
+	// cron), which groups the runs of one chain.
+	FirstRunID string `json:"first_run_id,omitempty"`
+}
+
+// NewWorkflowSummary builds a listing row from a visibility record.
+func NewWorkflowSummary(info *workflowpb.WorkflowExecutionInfo) WorkflowSummary {
+	return WorkflowSummary{
+		WorkflowID: info.GetExecution().GetWorkflowId(),
+		RunID:      info.GetExecution().GetRunId(),
+		Type:       info.GetType().GetName(),
+		Status:     WorkflowStatus(info.GetStatus()),
+		StartTime:  Time(info.GetStartTime()),
+		CloseTime:  Time(info.GetCloseTime()),
+		FirstRunID: info.GetFirstRunId(),
+	}
+}
+
+// WorkflowListText renders a listing of workflows matching the given status filter. more reports
+// that the listing stopped at page_size with workflows left unscanned, so the count isn't exhaustive.
+func WorkflowListText(statusFilter string, workflows []WorkflowSummary, more bool) string {
+	if len(workflows) == 0 {
+		return fmt.Sprintf("No %s workflows found.", statusFilter)
+	}
+	var b strings.Builder
+	if more {
+		fmt.Fprintf(&b, "Found %d %s workflow(s) (page full, more may exist; raise page_size or narrow the filters):\n", len(workflows), statusFilter)
+	} else {
+		fmt.Fprintf(&b, "Found %d %s workflow(s):\n", len(workflows), statusFilter)
+	}
+	for _, wf := range workflows {
+		b.WriteString("- ")
+		if wf.Namespace != "" {
+			fmt.Fprintf(&b, "Namespace: %s | ", wf.Namespace)
+		}
+		fmt.Fprintf(&b, "ID: %s | Run: %s | Type: %s | Status: %s | Start: %s",
+			wf.WorkflowID, wf.RunID, wf.Type, wf.Status, wf.StartTime)
+		if wf.CloseTime != "" {
+			fmt.Fprintf(&b, " | End: %s", wf.CloseTime)
+		}
+		if wf.Duration != "" {
+			fmt.Fprintf(&b, " | Duration: %s", wf.Duration)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// WorkflowDetails is the detailed view of one workflow execution. Payload fields hold strings
+// already rendered (and redacted) by a payload.Renderer.
+type WorkflowDetails struct {
+	WorkflowID        string            `json:"workflow_id"`
+	RunID             string            `json:"run_id"`
+	Type              string            `json:"type"`
+	Status            string            `json:"status"`
+	StartTime         string            `json:"start_time"`
+	CloseTime         string            `json:"close_time,omitempty"`
+	Memo              map[string]string `json:"memo,omitempty"`
+	Input             []string          `json:"input,omitempty"`
+	Result            []string          `json:"result,omitempty"`
+	Failure           string            `json:"failure,omitempty"`
+	PendingActivities []PendingActivity `json:"pending_activities,omitempty"`
+	// PendingNexusOperations and Callbacks are only reported by servers with Nexus support.
+	PendingNexusOperations []PendingNexusOperation `json:"pending_nexus_operations,omitempty"`
+	Callbacks              []Callback              `json:"callbacks,omitempty"`
+	// PendingWorkflowTask is set while a workflow task is scheduled or running.
+	PendingWorkflowTask *PendingWorkflowTask `json:"pending_workflow_task,omitempty"`
+	// FirstWorkflowTaskBackoff is how long the run's first workflow task was delayed after it
+	// started, e.g. until the next firing of a cron schedule, and FirstWorkflowTask when it fires.
+	FirstWorkflowTaskBackoff string `json:"first_workflow_task_backoff,omitempty"`
+	FirstWorkflowTask        string `json:"first_workflow_task,omitempty"`
+	// Cron is set for workflows started with a (legacy) cron schedule.
+	Cron *CronDetails `json:"cron,omitempty"`
+	// HistoryLength and HistorySizeBytes measure the run's history, and HistoryWarnings say which
+	// warning thresholds it exceeds on its way to the server's history limits.
+	HistoryLength    int64    `json:"history_length"`
+	HistorySizeBytes int64    `json:"history_size_bytes"`
+	HistoryWarnings  []string `json:"history_warnings,omitempty"`
+	// FirstRunID is the first run of the chain this run belongs to when it isn't that run itself
+	// (after continue-as-new, retries, or cron), ChainStartTime when that run started (empty if
+	// unknown, e.g. once retention deleted it), and ChainAge how long the chain has been going.
+	FirstRunID     string `json:"first_run_id,omitempty"`
+	ChainStartTime string `json:"chain_start_time,omitempty"`
+	ChainAge       string `json:"chain_age,omitempty"`
+	// Versioning is set for executions on worker deployment versioning.
+	Versioning *WorkflowVersioning `json:"versioning,omitempty"`
+}
+
+// WorkflowVersioning is how an execution is tied to worker deployment versions.
+type WorkflowVersioning struct {
+	// Behavior is Pinned or AutoUpgrade as the workers report it, and Version the deployment version
+	// ("<deployment>.<build_id>") that completed the last workflow task.
+	Behavior string `json:"behavior,omitempty"`
+	Version  string `json:"version,omitempty"`
+	// OverrideBehavior and OverrideVersion are an override set on the execution, which takes
+	// precedence over Behavior (and over Version when pinned).
+	OverrideBehavior string `json:"override_behavior,omitempty"`
+	OverrideVersion  string `json:"override_version,omitempty"`
+	// Transition is the version an auto-upgrading execution is moving to on its pending workflow task.
+	Transition string `json:"transition,omitempty"`
+}
+
+// CronDetails is the cron schedule of a workflow and when it next fires.
+type CronDetails struct {
+	Schedule string `json:"schedule"`
+	// NextExecution is empty when the schedule couldn't be evaluated.
+	NextExecution string `json:"next_execution,omitempty"`
+}
+
+// PendingActivity is an activity of a running workflow that hasn't completed yet.
+type PendingActivity struct {
+	ActivityID string `json:"activity_id"`
+	Type       string `json:"type"`
+	State      string `json:"state"`
+	Attempt    int32  `json:"attempt"`
+	// Paused is set when the activity was paused with pause_activity; no new attempts are
+	// scheduled until it is unpaused.
+	Paused           bool     `json:"paused,omitempty"`
+	HeartbeatDetails []string `json:"heartbeat_details,omitempty"`
+	LastFailure      string   `json:"last_failure,omitempty"`
+}
+
+// PendingNexusOperation is a Nexus operation a running workflow started that hasn't completed yet.
+type PendingNexusOperation struct {
+	Endpoint  string `json:"endpoint"`
+	Service   string `json:"service"`
+	Operation string `json:"operation"`
+	// State is Scheduled, BackingOff (after a failed attempt), Started (running asynchronously
+	// on the handler's side), or Blocked, with BlockedReason.
+	State            string `json:"state"`
+	BlockedReason    string `json:"blocked_reason,omitempty"`
+	Attempt          int32  `json:"attempt"`
+	ScheduledTime    string `json:"scheduled_time,omitempty"`
+	ScheduledEventID int64  `json:"scheduled_event_id,omitempty"`
+	LastFailure      string `json:"last_failure,omitempty"`
+	NextAttempt      string `json:"next_attempt,omitempty"`
+	// Cancellation is the state of a requested cancellation, if any.
+	Cancellation string `json:"cancellation,omitempty"`
+}
+
+// Callback is a completion callback attached to a workflow, e.g. by the Nexus operation that
+// started it, delivered when the workflow closes.
+type Callback struct {
+	URL              string `json:"url,omitempty"`
+	State            string `json:"state"`
+	BlockedReason    string `json:"blocked_reason,omitempty"`
+	Attempt          int32  `json:"attempt"`
+	RegistrationTime string `json:"registration_time,omitempty"`
+	LastFailure      string `json:"last_failure,omitempty"`
+	NextAttempt      string `json:"next_attempt,omitempty"`
+}
+
+// NewWorkflowDetails builds the detailed view from a Describe response, rendering the memo and
+// heartbeat details with r. Input and result come from history and are filled in by the caller.
+func NewWorkflowDetails(resp *workflowservice.DescribeWorkflowExecutionResponse, r *payload.Renderer) WorkflowDetails {
+	info := resp.GetWorkflowExecutionInfo()
+	d := WorkflowDetails{
+		WorkflowID: info.GetExecution().GetWorkflowId(),
+		RunID:      info.GetExecution().GetRunId(),
+		Type:       info.GetType().GetName(),
+		Status:     WorkflowStatus(info.GetStatus()),
+		StartTime:  Time(info.GetStartTime()),
+		CloseTime:  Time(info.GetCloseTime()),
+
+		HistoryLength:    info.GetHistoryLength(),
+		HistorySizeBytes: info.GetHistorySizeBytes(),
+	}
+	if first := info.GetFirstRunId(); first != d.RunID {
+		d.FirstRunID = first
+	}
+	if fields := info.GetMemo().GetFields(); len(fields) > 0 {
+		d.Memo = make(map[string]string, len(fields))
+		for key, value := range fields {
+			d.Memo[key] = r.Render(value)
+		}
+	}
+	if task := resp.GetPendingWorkflowTask(); task != nil {
+		d.PendingWorkflowTask = NewPendingWorkflowTask(task, time.Now())
+	}
+	for _, activity := range resp.GetPendingActivities() {
+		d.PendingActivities = append(d.PendingActivities, NewPendingActivity(activity, r))
+	}
+	for _, op := range resp.GetPendingNexusOperations() {
+		pending := PendingNexusOperation{
+			Endpoint:         op.GetEndpoint(),
+			Service:          op.GetService(),
+			Operation:        op.GetOperation(),
+			State:            op.GetState().String(),
+			BlockedReason:    op.GetBlockedReason(),
+			Attempt:          op.GetAttempt(),
+			ScheduledTime:    Time(op.GetScheduledTime()),
+			ScheduledEventID: op.GetScheduledEventId(),
+			LastFailure:      Failure(op.GetLastAttemptFailure()),
+			NextAttempt:      Time(op.GetNextAttemptScheduleTime()),
+		}
+		if cancel := op.GetCancellationInfo(); cancel != nil {
+			pending.Cancellation = cancel.GetState().String()
+		}
+		d.PendingNexusOperations = append(d.PendingNexusOperations, pending)
+	}
+	for _, callback := range resp.GetCallbacks() {
+		d.Callbacks = append(d.Callbacks, Callback{
+			URL:              callback.GetCallback().GetNexus().GetUrl(),
+			State:            callback.GetState().String(),
+			BlockedReason:    callback.GetBlockedReason(),
+			Attempt:          callback.GetAttempt(),
+			RegistrationTime: Time(callback.GetRegistrationTime()),
+			LastFailure:      Failure(callback.GetLastAttemptFailure()),
+			NextAttempt:      Time(callback.GetNextAttemptScheduleTime()),
+		})
+	}
+	d.Versioning = NewWorkflowVersioning(info.GetVersioningInfo())
+	return d
+}
+
+// NewWorkflowVersioning builds the versioning of an execution, or nil when it has none. Versions
+// reported by older servers as deployments are rendered as "<series>.<build_id>".
+func NewWorkflowVersioning(info *workflowpb.WorkflowExecutionVersioningInfo) *WorkflowVersioning {
+	v := WorkflowVersioning{Version: info.GetVersion(), Transition: info.GetVersionTransition().GetVersion()}
+	if info.GetBehavior() != enumspb.VERSIONING_BEHAVIOR_UNSPECIFIED {
+		v.Behavior = info.GetBehavior().String()
+	}
+	if v.Version == "" {
+		v.Version = deploymentVersion(info.GetDeployment())
+	}
+	if v.Transition == "" {
+		v.Transition = deploymentVersion(info.GetDeploymentTransition().GetDeployment())
+	}
+	if override := info.GetVersioningOverride(); override != nil {
+		v.OverrideBehavior = override.GetBehavior().String()
+		v.OverrideVersion = override.GetPinnedVersion()
+		if v.OverrideVersion == "" {
+			v.OverrideVersion = deploymentVersion(override.GetDeployment())
+		}
+	}
+	if v == (WorkflowVersioning{}) {
+		return nil
+	}
+	return &v
+}
+
+// deploymentVersion renders a deployment as a version, "<series>.<build_id>", or "" for none.
+func deploymentVersion(d *deploymentpb.Deployment) string {
+	if d.GetBuildId() == "" {
+		return ""
+	}
+	return d.GetSeriesName() + "." + d.GetBuildId()
+}
+
+// workflowVersioningText writes the versioning of an execution, explaining which version its
+// workflow tasks go to.
+func workflowVersioningText(b *strings.Builder, v WorkflowVersioning) {
+	b.WriteString("Versioning:")
+	if v.Behavior != "" {
+		fmt.Fprintf(b, " Behavior: %s |", v.Behavior)
+	}
+	if v.Version != "" {
+		fmt.Fprintf(b, " Version: %s", v.Version)
+	} else {
+		b.WriteString(" Version: none (no workflow task completed on a versioned worker yet)")
+	}
+	b.WriteString("\n")
+	behavior, version := v.Behavior, v.Version
+	if v.OverrideBehavior != "" {
+		fmt.Fprintf(b, "Versioning Override: %s", v.OverrideBehavior)
+		if v.OverrideVersion != "" {
+			fmt.Fprintf(b, " to %s", v.OverrideVersion)
+			version = v.OverrideVersion
+		}
+		b.WriteString(" (set on the execution; takes precedence over what the workers report)\n")
+		behavior = v.OverrideBehavior
+	}
+	if v.Transition != "" {
+		fmt.Fprintf(b, "Versioning Transition: moving to %s on its pending workflow task; pending activities wait for it\n", v.Transition)
+	}
+	switch behavior {
+	case "Pinned":
+		fmt.Fprintf(b, "Note: pinned, so every workflow task runs on %s until the workflow closes, even after the deployment's current version changes; keep workers of that version running, or override the execution's versioning.\n", version)
+	case "AutoUpgrade":
+		b.WriteString("Note: auto-upgrade, so its next workflow task moves to the task queue's current deployment version.\n")
+	}
+}
+
+// NewPendingActivity builds the view of a pending activity, rendering its heartbeat details with r.
+func NewPendingActivity(activity *workflowpb.PendingActivityInfo, r *payload.Renderer) PendingActivity {
+	return PendingActivity{
+		ActivityID:       activity.GetActivityId(),
+		Type:             activity.GetActivityType().GetName(),
+		State:            ActivityState(activity.GetState()),
+		Attempt:          activity.GetAttempt(),
+		Paused:           activity.GetPaused(),
+		HeartbeatDetails: r.RenderAll(activity.GetHeartbeatDetails()),
+		LastFailure:      Failure(activity.GetLastFailure()),
+	}
+}
+
+// WorkflowDetailsText renders the detailed view as multi-line text.
+func WorkflowDetailsText(d WorkflowDetails) string {
+	var b strings.Builder
+	b.WriteString("Workflow Execution Details:\n")
+	fmt.Fprintf(&b, "Workflow ID: %s\n", d.WorkflowID)
+	fmt.Fprintf(&b, "Run ID: %s\n", d.RunID)
+	fmt.Fprintf(&b, "Type: %s\n", d.Type)
+	fmt.Fprintf(&b, "Status: %s\n", d.Status)
+	fmt.Fprintf(&b, "Start Time: %s\n", d.StartTime)
+	if d.CloseTime != "" {
+		fmt.Fprintf(&b, "End Time: %s\n", d.CloseTime)
+	}
+	if d.FirstRunID != "" {
+		fmt.Fprintf(&b, "First Run ID: %s\n", d.FirstRunID)
+		if d.ChainStartTime != "" {
+			fmt.Fprintf(&b, "Chain Start Time: %s (chain age %s; Start Time is this run's)\n", d.ChainStartTime, d.ChainAge)
+		}
+	}
+	fmt.Fprintf(&b, "History: %d events, %s\n", d.HistoryLength, ByteSize(d.HistorySizeBytes))
+	for _, w := range d.HistoryWarnings {
+		fmt.Fprintf(&b, "WARNING: history of %s; continue-as-new before the server terminates the workflow at its history limit\n", w)
+	}
+	if len(d.Memo) > 0 {
+		b.WriteString("Memo:\n")
+		keys := make([]string, 0, len(d.Memo))
+		for key := range d.Memo {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "  %s: %s\n", key, d.Memo[key])
+		}
+	}
+	if len(d.Input) > 0 {
+		fmt.Fprintf(&b, "Input: %s\n", strings.Join(d.Input, ", "))
+	}
+	if len(d.Result) > 0 {
+		fmt.Fprintf(&b, "Result: %s\n", strings.Join(d.Result, ", "))
+	}
+	if d.Failure != "" {
+		fmt.Fprintf(&b, "Failure: %s\n", d.Failure)
+	}
+	if d.Cron != nil {
+		fmt.Fprintf(&b, "Cron Schedule: %s", d.Cron.Schedule)
+		if d.Cron.NextExecution != "" {
+			fmt.Fprintf(&b, " (next execution %s)", d.Cron.NextExecution)
+		}
+		b.WriteString("\n")
+		if d.Status == "Running" {
+			b.WriteString("Note: a cron workflow shows as Running between firings; this run sleeps until its first workflow task fires, and each run that closes starts the run for the next firing.\n")
+		}
+	}
+	if v := d.Versioning; v != nil {
+		workflowVersioningText(&b, *v)
+	}
+	if d.FirstWorkflowTaskBackoff != "" {
+		fmt.Fprintf(&b, "First Workflow Task Backoff: %s (fires at %s)\n", d.FirstWorkflowTaskBackoff, d.FirstWorkflowTask)
+	}
+	if t := d.PendingWorkflowTask; t != nil {
+		fmt.Fprintf(&b, "Pending Workflow Task: State: %s | Attempt: %d | Scheduled: %s", t.State, t.Attempt, t.ScheduledTime)
+		if t.StartedTime != "" {
+			fmt.Fprintf(&b, " | Started: %s", t.StartedTime)
+		}
+		if t.OriginalScheduledTime != "" && t.OriginalScheduledTime != t.ScheduledTime {
+			fmt.Fprintf(&b, " | Originally Scheduled: %s", t.OriginalScheduledTime)
+		}
+		if t.Age != "" {
+			fmt.Fprintf(&b, " | Age: %s", t.Age)
+		}
+		b.WriteString("\n")
+		if t.Attempt > 1 {
+			b.WriteString("Note: the workflow task is being retried, so workers are failing it; check_workflow_task_failures explains why.\n")
+		}
+	}
+	if len(d.PendingActivities) > 0 {
+		fmt.Fprintf(&b, "Pending Activities (%d):\n", len(d.PendingActivities))
+		for _, a := range d.PendingActivities {
+			fmt.Fprintf(&b, "- ID: %s | Type: %s | State: %s | Attempt: %d", a.ActivityID, a.Type, a.State, a.Attempt)
+			if a.Paused {
+				b.WriteString(" | PAUSED")
+			}
+			if len(a.HeartbeatDetails) > 0 {
+				fmt.Fprintf(&b, " | Heartbeat: %s", strings.Join(a.HeartbeatDetails, ", "))
+			}
+			if a.LastFailure != "" {
+				fmt.Fprintf(&b, " | Last Failure: %s", a.LastFailure)
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(d.PendingNexusOperations) > 0 {
+		fmt.Fprintf(&b, "Pending Nexus Operations (%d):\n", len(d.PendingNexusOperations))
+		for _, op := range d.PendingNexusOperations {
+			fmt.Fprintf(&b, "- Operation: %s/%s | Endpoint: %s | State: %s", op.Service, op.Operation, op.Endpoint, op.State)
+			if op.BlockedReason != "" {
+				fmt.Fprintf(&b, " (%s)", op.BlockedReason)
+			}
+			fmt.Fprintf(&b, " | Attempt: %d", op.Attempt)
+			if op.ScheduledTime != "" {
+				fmt.Fprintf(&b, " | Scheduled: %s", op.ScheduledTime)
+			}
+			if op.LastFailure != "" {
+				fmt.Fprintf(&b, " | Last Failure: %s", op.LastFailure)
+			}
+			if op.NextAttempt != "" {
+				fmt.Fprintf(&b, " | Next Attempt: %s", op.NextAttempt)
+			}
+			if op.Cancellation != "" {
+				fmt.Fprintf(&b, " | Cancellation: %s", op.Cancellation)
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(d.Callbacks) > 0 {
+		fmt.Fprintf(&b, "Callbacks (%d):\n", len(d.Callbacks))
+		for _, c := range d.Callbacks {
+			fmt.Fprintf(&b, "- State: %s", c.State)
+			if c.BlockedReason != "" {
+				fmt.Fprintf(&b, " (%s)", c.BlockedReason)
+			}
+			fmt.Fprintf(&b, " | Attempt: %d", c.Attempt)
+			if c.URL != "" {
+				fmt.Fprintf(&b, " | URL: %s", c.URL)
+			}
+			if c.LastFailure != "" {
+				fmt.Fprintf(&b, " | Last Failure: %s", c.LastFailure)
+			}
+			if c.NextAttempt != "" {
+				fmt.Fprintf(&b, " | Next Attempt: %s", c.NextAttempt)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// ChildrenFromHistoryText notes that a child workflow listing was read from the parent's history,
+// because the server's visibility store doesn't support ParentWorkflowId queries.
+func ChildrenFromHistoryText(parentID, parentRunID string) string {
+	return fmt.Sprintf("\nNote: the server's visibility store doesn't support this query, so these children were read from the history of %s (run %s). They are listed in any state, regardless of the status filter, with the status the parent's history records; duration filters don't apply.\n", parentID, parentRunID)
+}
+
+// ClientSideFilterText notes that workflow types and durations were filtered after fetching,
+// because the server's visibility store doesn't support the listing query.
+func ClientSideFilterText(scanned int, capped bool) string {
+	text := fmt.Sprintf("\nNote: the server's visibility store doesn't support this query (IN clauses or the ExecutionDuration search attribute), so workflow types and durations were filtered client-side after scanning %d workflow(s).", scanned)
+	if capped {
+		text += " Scan cap reached; more matches may exist."
+	}
+	return text + "\n"
+}
+
+// RecentWorkflow is a workflow a session recently looked at.
+type RecentWorkflow struct {
+	Namespace  string `json:"namespace"`
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id,omitempty"`
+	Type       string `json:"type,omitempty"`
+	// Status is the status the workflow had when last looked at; "" when it wasn't seen.
+	Status string `json:"status,omitempty"`
+	// Tool is the tool that last looked at it, at AccessedAt.
+	Tool       string `json:"tool"`
+	AccessedAt string `json:"accessed_at"`
+}
+
+// RecentWorkflowList is the result of recent_workflows and the temporal://recent resource.
+type RecentWorkflowList struct {
+	Workflows []RecentWorkflow `json:"workflows"`
+}
+
+// RecentWorkflowsText renders the recent workflows, most recent first.
+func RecentWorkflowsText(l RecentWorkflowList) string {
+	if len(l.Workflows) == 0 {
+		return "No workflows looked at yet in this session."
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d recent workflow(s), most recent first:\n", len(l.Workflows))
+	for _, w := range l.Workflows {
+		fmt.Fprintf(&b, "- ID: %s | Namespace: %s", w.WorkflowID, w.Namespace)
+		if w.RunID != "" {
+			fmt.Fprintf(&b, " | Run: %s", w.RunID)
+		}
+		if w.Type != "" {
+			fmt.Fprintf(&b, " | Type: %s", w.Type)
+		}
+		status := w.Status
+		if status == "" {
+			status = "unknown"
+		}
+		fmt.Fprintf(&b, " | Last Status: %s | Via: %s at %s\n", status, w.Tool, w.AccessedAt)
+	}
+	return b.String()
+}
+
+// NamespaceError is a namespace a listing across several namespaces couldn't list.
+type NamespaceError struct {
+	Namespace string `json:"namespace"`
+	Error     string `json:"error"`
+}
+
+// NamespacesText reports which namespaces a listing across several namespaces covered: the ones it
+// queried, how many more "*" matched beyond the cap, and the ones that couldn't be listed.
+func NamespacesText(namespaces []string, skipped int, failed []NamespaceError) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nNamespaces: %s.", strings.Join(namespaces, ", "))
+	if skipped > 0 {
+		fmt.Fprintf(&b, " %d more not listed: * covers at most %d namespaces.", skipped, len(namespaces))
+	}
+	b.WriteString("\n")
+	if len(failed) > 0 {
+		fmt.Fprintf(&b, "Not listed in %d namespace(s):\n", len(failed))
+		for _, f := range failed {
+			fmt.Fprintf(&b, "- %s: %s\n", f.Namespace, f.Error)
+		}
+	}
+	return b.String()
+}
+
+// WorkflowExportText reports a listing exported to a file instead of returned. capped notes that
+// the export stopped at its row cap of maxRows while more workflows matched.
+func WorkflowExportText(statusFilter string, rows int, path, fileFormat string, maxRows int, capped bool) string {
+	text := fmt.Sprintf("Exported %d %s workflow(s) to %s (%s).", rows, statusFilter, path, fileFormat)
+	if capped {
+		text += fmt.Sprintf(" Stopped at the export cap of %d rows; more workflows may match.", maxRows)
+	}
+	return text + "\n"
+}
+
+// RegexScanText reports the cost of a client-side ID filter: how many workflows were scanned and
+// how many matched, and whether the scan stopped at its cap before reaching the end of the list.
+func RegexScanText(pattern string, scanned, matched int, capped bool) string {
+	text := fmt.Sprintf("\nScanned %d workflow(s), %d matched id_regex %q.", scanned, matched, pattern)
+	if capped {
+		text += " Scan cap reached; more matches may exist."
+	}
+	return text + "\n"
+}
@@ -0,0 +1,339 @@
+// Package integration runs the tools end to end against a Temporal dev server, started with
+// `temporal server start-dev`, and a worker running the workflows defined here.
+//
+// The suite is opt-in: it runs when TEMPORAL_MCP_INTEGRATION is set and the temporal CLI is on
+// the PATH, and is skipped otherwise.
+//
+//	TEMPORAL_MCP_INTEGRATION=1 go test ./internal/integration/
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/wricardo/temporal-mcp/internal/audit"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+	"github.com/wricardo/temporal-mcp/internal/tools"
+)
+
+const taskQueue = "temporal-mcp-integration"
+
+// address is the dev server's frontend, empty when the suite is skipped; skipReason says why.
+var address, skipReason string
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	if os.Getenv("TEMPORAL_MCP_INTEGRATION") == "" {
+		skipReason = "set TEMPORAL_MCP_INTEGRATION=1 to run the integration tests"
+		return m.Run()
+	}
+	cli, err := exec.LookPath("temporal")
+	if err != nil {
+		skipReason = "the temporal CLI isn't on the PATH"
+		return m.Run()
+	}
+
+	port, err := freePort()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "integration:", err)
+		return 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	devServer := exec.CommandContext(ctx, cli, "server", "start-dev", "--headless", "--ip", "127.0.0.1", "--port", strconv.Itoa(port), "--log-level", "error")
+	devServer.Stderr = os.Stderr
+	if err := devServer.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, "integration: start dev server:", err)
+		return 1
+	}
+	defer devServer.Wait()
+	defer cancel()
+
+	address = net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	sdkClient, err := dialWhenReady(address, 30*time.Second)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "integration:", err)
+		return 1
+	}
+	defer sdkClient.Close()
+
+	w := worker.New(sdkClient, taskQueue, worker.Options{})
+	w.RegisterWorkflow(Greet)
+	w.RegisterWorkflow(Idle)
+	w.RegisterActivity(Greeting)
+	if err := w.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, "integration: start worker:", err)
+		return 1
+	}
+	defer w.Stop()
+	return m.Run()
+}
+
+// freePort returns a TCP port nothing listens on.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("find a free port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// dialWhenReady connects to the dev server at address, waiting up to timeout for it to start.
+func dialWhenReady(address string, timeout time.Duration) (client.Client, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		c, err := client.Dial(client.Options{HostPort: address, Logger: quietLogger{}})
+		if err == nil {
+			return c, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("dev server at %s not ready after %s: %w", address, timeout, err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// quietLogger silences the SDK's logs, which would drown the test output.
+type quietLogger struct{}
+
+func (quietLogger) Debug(string, ...any) {}
+func (quietLogger) Info(string, ...any)  {}
+func (quietLogger) Warn(string, ...any)  {}
+func (quietLogger) Error(string, ...any) {}
+
+// Greet waits for a "proceed" signal carrying a name, then greets it through an activity.
+func Greet(ctx workflow.Context) (string, error) {
+	var name string
+	workflow.GetSignalChannel(ctx, "proceed").Receive(ctx, &name)
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	var greeting string
+	err := workflow.ExecuteActivity(ctx, Greeting, name).Get(ctx, &greeting)
+	return greeting, err
+}
+
+// Greeting is Greet's activity.
+func Greeting(_ context.Context, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("no name to greet")
+	}
+	return "Hello, " + name + "!", nil
+}
+
+// Idle runs until it is terminated.
+func Idle(ctx workflow.Context) error {
+	return workflow.Await(ctx, func() bool { return false })
+}
+
+// harness is an MCP server with every tool under test registered against the dev server, through
+// the same middleware as the real server.
+type harness struct {
+	t      *testing.T
+	server *server.MCPServer
+	sdk    client.Client
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	if address == "" {
+		t.Skip(skipReason)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c, err := temporal.Dial(temporal.Options{
+		Addresses: []string{address},
+		Namespace: "default",
+		Logger:    logger,
+		Retry:     temporal.RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(c.Close)
+	clients, err := temporal.NewClients(c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloads, err := payload.NewRenderer(payload.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdk, err := client.Dial(client.Options{HostPort: address, Logger: quietLogger{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(sdk.Close)
+
+	srv := server.NewMCPServer("temporal-mcp-integration", "0.0.0")
+	registry := &tools.Registry{
+		Server:    srv,
+		Logger:    logger,
+		Auditor:   audit.NopSink{},
+		Namespace: "default",
+		Timeout:   30 * time.Second,
+		Clients:   clients,
+		Calls:     &tools.InflightCalls{},
+	}
+	for _, tool := range []tools.Tool{
+		&tools.ListWorkflows{Clients: clients, Logger: logger},
+		&tools.DescribeWorkflow{Clients: clients, Logger: logger, Payloads: payloads},
+		&tools.SummarizeHistory{Clients: clients, Logger: logger, Payloads: payloads},
+		&tools.ListSignals{Clients: clients, Logger: logger, Payloads: payloads},
+		&tools.SignalMatching{Clients: clients, Logger: logger, Pool: pool.New(4)},
+	} {
+		registry.Register(tool)
+	}
+	return &harness{t: t, server: srv, sdk: sdk}
+}
+
+// call calls the named tool, failing the test on an error result.
+func (h *harness) call(name string, args map[string]any) string {
+	h.t.Helper()
+	text, isError := h.try(name, args)
+	if isError {
+		h.t.Fatalf("%s: %s", name, text)
+	}
+	return text
+}
+
+// try calls the named tool and returns its text and whether it is an error result.
+func (h *harness) try(name string, args map[string]any) (string, bool) {
+	h.t.Helper()
+	tool := h.server.GetTool(name)
+	if tool == nil {
+		h.t.Fatalf("%s isn't registered", name)
+	}
+	req := mcp.CallToolRequest{}
+	req.Params.Name, req.Params.Arguments = name, args
+	result, err := tool.Handler(context.Background(), req)
+	if err != nil {
+		h.t.Fatalf("%s: %v", name, err)
+	}
+	var b strings.Builder
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			b.WriteString(text.Text)
+		}
+	}
+	return b.String(), result.IsError
+}
+
+// eventually calls the named tool until its output contains want, since visibility records are
+// written asynchronously.
+func (h *harness) eventually(name string, args map[string]any, want string) string {
+	h.t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		text := h.call(name, args)
+		if strings.Contains(text, want) {
+			return text
+		}
+		if time.Now().After(deadline) {
+			h.t.Fatalf("%s: output doesn't contain %q:\n%s", name, want, text)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// start starts workflowType with a workflow ID unique to the test.
+func (h *harness) start(workflowType any, id string) client.WorkflowRun {
+	h.t.Helper()
+	id = fmt.Sprintf("%s-%d", id, time.Now().UnixNano())
+	run, err := h.sdk.ExecuteWorkflow(context.Background(), client.StartWorkflowOptions{ID: id, TaskQueue: taskQueue}, workflowType)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return run
+}
+
+func assertContains(t *testing.T, text string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(text, w) {
+			t.Errorf("output doesn't contain %q:\n%s", w, text)
+		}
+	}
+}
+
+func TestSignalAndDescribe(t *testing.T) {
+	h := newHarness(t)
+	run := h.start(Greet, "greet")
+	id := run.GetID()
+
+	h.eventually("list_workflows", map[string]any{"status": "running", "workflow_type": "Greet"}, id)
+	text := h.call("describe_workflow", map[string]any{"workflow_id": id})
+	assertContains(t, text, "Workflow ID: "+id, "Type: Greet", "Status: Running")
+
+	// The preview lists the workflow the query matches; confirming sends the signal it waits for
+	query := fmt.Sprintf("WorkflowId = '%s' AND ExecutionStatus = 'Running'", id)
+	text = h.call("signal_matching", map[string]any{"query": query, "signal_name": "proceed"})
+	assertContains(t, text, id)
+	text = h.call("signal_matching", map[string]any{"query": query, "signal_name": "proceed", "input": "Ada", "confirm": true})
+	assertContains(t, text, "Sent signal proceed: 1 signaled, 0 failed")
+
+	var greeting string
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := run.Get(ctx, &greeting); err != nil {
+		t.Fatalf("workflow failed: %v", err)
+	}
+	if greeting != "Hello, Ada!" {
+		t.Fatalf("workflow result = %q, want %q", greeting, "Hello, Ada!")
+	}
+
+	h.eventually("list_workflows", map[string]any{"status": "completed", "workflow_type": "Greet"}, id)
+	text = h.call("describe_workflow", map[string]any{"workflow_id": id})
+	assertContains(t, text, "Status: Completed", `Result: "Hello, Ada!"`)
+	text = h.call("list_signals", map[string]any{"workflow_id": id})
+	assertContains(t, text, "proceed", `"Ada"`)
+	text = h.call("summarize_history", map[string]any{"workflow_id": id})
+	assertContains(t, text, "Greeting", "proceed")
+}
+
+// The tree has no terminate or query tools; terminating with the SDK checks how the tools report
+// the outcome.
+func TestTerminatedWorkflow(t *testing.T) {
+	h := newHarness(t)
+	run := h.start(Idle, "idle")
+	id := run.GetID()
+	h.eventually("list_workflows", map[string]any{"status": "running", "workflow_type": "Idle"}, id)
+
+	if err := h.sdk.TerminateWorkflow(context.Background(), id, "", "integration test"); err != nil {
+		t.Fatal(err)
+	}
+	text := h.call("describe_workflow", map[string]any{"workflow_id": id})
+	assertContains(t, text, "Status: Terminated")
+
+	// The terminated workflow no longer matches a query for running ones
+	query := fmt.Sprintf("WorkflowId = '%s' AND ExecutionStatus = 'Running'", id)
+	h.eventually("signal_matching", map[string]any{"query": query, "signal_name": "proceed"}, "signal proceed to 0 running workflow(s)")
+}
+
+func TestDescribeUnknownWorkflow(t *testing.T) {
+	h := newHarness(t)
+	text, isError := h.try("describe_workflow", map[string]any{"workflow_id": "no-such-workflow"})
+	if !isError {
+		t.Fatalf("want an error result, got:\n%s", text)
+	}
+	assertContains(t, text, "Code: NotFound")
+}
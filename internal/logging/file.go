@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// rotatingFile is an append-only log file that is rotated to "<path>.1" once it grows past maxSize bytes.
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+// openRotatingFile opens (or creates) the log file at path in append mode.
+func openRotatingFile(path string, maxSize int64) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, size, err := openAppend(r.path)
+	if err != nil {
+		return err
+	}
+	r.file, r.size = f, size
+	return nil
+}
+
+// openAppend opens (or creates) the file at path in append mode, returning its current size.
+func openAppend(path string) (*os.File, int64, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, info.Size(), nil
+}
+
+// Write appends p to the log file, rotating first if the write would exceed the size limit. When
+// rotation fails, p still goes to the current file and the rotation error is returned; rotation is
+// tried again on the next write.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rotateErr error
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		rotateErr = r.rotate()
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// rotate moves the current file aside to "<path>.1" (replacing any previous backup) and starts a new
+// one. The current file stays open until the new one is, so a failed rename or open leaves logging
+// on the current file rather than on a closed one.
+func (r *rotatingFile) rotate() error {
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	f, size, err := openAppend(r.path)
+	if err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	old := r.file
+	r.file, r.size = f, size
+	if err := old.Close(); err != nil {
+		return fmt.Errorf("rotate log file: close %s.1: %w", r.path, err)
+	}
+	return nil
+}
+
+// Close closes the underlying file.
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// OpenOutput returns the writer all logs go to: the rotating file at path, or stderr when path is
+// empty. On failure it falls back to stderr and returns the error for a single warning.
+func OpenOutput(path string, maxSize int64) (w io.Writer, destination string, err error) {
+	if path == "" {
+		return os.Stderr, "stderr", nil
+	}
+	f, err := openRotatingFile(path, maxSize)
+	if err != nil {
+		return os.Stderr, "stderr", fmt.Errorf("open log file %s: %w", path, err)
+	}
+	return f, path, nil
+}
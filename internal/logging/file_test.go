@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingFileRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	f, err := openRotatingFile(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatalf("Write(%q): %v", line, err)
+		}
+	}
+	assertContent(t, path+".1", "first\n")
+	assertContent(t, path, "second\n")
+}
+
+func TestRotatingFileKeepsLoggingWhenRotationFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	// A non-empty directory where the backup goes makes the rename fail
+	if err := os.MkdirAll(filepath.Join(path+".1", "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := openRotatingFile(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write([]byte("first\n")); err != nil {
+		t.Fatalf("first Write: %v", err)
+	}
+	n, err := f.Write([]byte("second\n"))
+	if err == nil {
+		t.Fatal("second Write: want the rotation error, got nil")
+	}
+	if n != len("second\n") {
+		t.Fatalf("second Write wrote %d bytes, want them all despite the failed rotation", n)
+	}
+	if _, err := f.Write([]byte("third\n")); err == nil {
+		t.Fatal("third Write: want the rotation to be retried and fail again")
+	}
+	assertContent(t, path, "first\nsecond\nthird\n")
+
+	// Once the backup path is free, the next write rotates
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("fourth\n")); err != nil {
+		t.Fatalf("fourth Write: %v", err)
+	}
+	assertContent(t, path+".1", "first\nsecond\nthird\n")
+	assertContent(t, path, "fourth\n")
+}
+
+func assertContent(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("%s = %q, want %q", filepath.Base(path), got, want)
+	}
+}
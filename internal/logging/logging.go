@@ -0,0 +1,16 @@
+// Package logging builds the process logger and its output destination.
+package logging
+
+import (
+	"io"
+	"log/slog"
+)
+
+// New builds a slog logger writing to w at the given level, in "text" or "json" format.
+func New(w io.Writer, level slog.Level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
@@ -0,0 +1,112 @@
+// Package metrics exposes Prometheus metrics for tool calls and the Temporal SDK client.
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/uber-go/tally/v4"
+	tallyprom "github.com/uber-go/tally/v4/prometheus"
+	"go.temporal.io/sdk/client"
+	contribtally "go.temporal.io/sdk/contrib/tally"
+)
+
+// Metrics records tool call metrics and backs the Temporal SDK metrics handler.
+// A nil *Metrics is valid and turns every method into a cheap no-op.
+type Metrics struct {
+	registry    *prometheus.Registry
+	toolCalls   *prometheus.CounterVec
+	toolLatency *prometheus.HistogramVec
+	rateLimited prometheus.Counter
+	sdkScope    tally.Scope
+	sdkCloser   io.Closer
+}
+
+// New creates a metrics registry with the tool call collectors and an SDK metrics scope
+// reporting into the same registry.
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+	m := &Metrics{
+		registry: registry,
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "temporal_mcp_tool_calls_total",
+			Help: "Number of MCP tool calls by tool and outcome.",
+		}, []string{"tool", "outcome"}),
+		toolLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "temporal_mcp_tool_call_duration_seconds",
+			Help:    "Latency of MCP tool calls by tool and outcome.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"tool", "outcome"}),
+	}
+	m.rateLimited = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "temporal_mcp_rate_limited_total",
+		Help: "Number of Temporal RPCs rejected by the outbound rate limiter.",
+	})
+	registry.MustRegister(m.toolCalls, m.toolLatency, m.rateLimited)
+
+	reporter := tallyprom.NewReporter(tallyprom.Options{Registerer: registry})
+	scope, closer := tally.NewRootScope(tally.ScopeOptions{
+		CachedReporter:  reporter,
+		Separator:       tallyprom.DefaultSeparator,
+		SanitizeOptions: &contribtally.PrometheusSanitizeOptions,
+	}, time.Second)
+	m.sdkScope = contribtally.NewPrometheusNamingScope(scope)
+	m.sdkCloser = closer
+	return m
+}
+
+// ObserveToolCall records one tool call with its outcome ("success" or "error") and duration.
+func (m *Metrics) ObserveToolCall(tool string, success bool, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	outcome := "success"
+	if !success {
+		outcome = "error"
+	}
+	m.toolCalls.WithLabelValues(tool, outcome).Inc()
+	m.toolLatency.WithLabelValues(tool, outcome).Observe(duration.Seconds())
+}
+
+// ObserveRateLimiter exposes the outbound rate limiter's available tokens as a gauge.
+func (m *Metrics) ObserveRateLimiter(tokens func() float64) {
+	if m == nil {
+		return
+	}
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "temporal_mcp_rate_limiter_tokens",
+		Help: "Tokens currently available in the outbound Temporal RPC rate limiter.",
+	}, tokens))
+}
+
+// RateLimited counts one RPC rejected by the outbound rate limiter.
+func (m *Metrics) RateLimited() {
+	if m == nil {
+		return
+	}
+	m.rateLimited.Inc()
+}
+
+// SDKHandler returns the metrics handler to set on client.Options.MetricsHandler.
+func (m *Metrics) SDKHandler() client.MetricsHandler {
+	if m == nil {
+		return client.MetricsNopHandler
+	}
+	return contribtally.NewMetricsHandler(m.sdkScope)
+}
+
+// Handler returns the HTTP handler serving the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Close stops the SDK metrics reporting loop.
+func (m *Metrics) Close() error {
+	if m == nil {
+		return nil
+	}
+	return m.sdkCloser.Close()
+}
@@ -0,0 +1,204 @@
+package metrics_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/wricardo/temporal-mcp/internal/audit"
+	"github.com/wricardo/temporal-mcp/internal/metrics"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+	"github.com/wricardo/temporal-mcp/internal/tools"
+)
+
+// fakeClient serves the one RPC list_workflows makes and the identity the audit log records;
+// calling any other method panics on the nil embedded interface.
+type fakeClient struct {
+	temporal.Client
+}
+
+func (fakeClient) Namespace() string { return "default" }
+
+func (fakeClient) Identity(ctx context.Context) string {
+	return temporal.CallIdentity(ctx, "temporal-mcp@test")
+}
+
+func (fakeClient) ListOpenWorkflow(context.Context, *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+	return &workflowservice.ListOpenWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: "order-1", RunId: "order-1-run"},
+		Type:      &commonpb.WorkflowType{Name: "OrderWorkflow"},
+		Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+	}}}, nil
+}
+
+// fakeProvider serves fakeClient for the default namespace only.
+type fakeProvider struct{}
+
+func (fakeProvider) Client(namespace string) (temporal.Client, error) {
+	if namespace != "" && namespace != "default" {
+		return nil, fmt.Errorf("%w: %q", temporal.ErrNamespaceNotAllowed, namespace)
+	}
+	return fakeClient{}, nil
+}
+func (fakeProvider) Allowed(namespace string) bool                { return namespace == "default" }
+func (fakeProvider) DefaultNamespace() string                     { return "default" }
+func (fakeProvider) Namespaces(context.Context) ([]string, error) { return []string{"default"}, nil }
+
+// scrape returns the exposition served by m's /metrics handler.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	srv := httptest.NewServer(m.Handler())
+	defer srv.Close()
+	resp, err := srv.Client().Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+// assertMetric fails the test unless exposition has a sample line starting with series and
+// carrying value.
+func assertMetric(t *testing.T, exposition, series, value string) {
+	t.Helper()
+	for _, line := range strings.Split(exposition, "\n") {
+		if name, got, ok := strings.Cut(line, " "); ok && name == series {
+			if got != value {
+				t.Errorf("%s = %s, want %s", series, got, value)
+			}
+			return
+		}
+	}
+	t.Errorf("no %s sample in:\n%s", series, exposition)
+}
+
+func TestToolCallMetrics(t *testing.T) {
+	m := metrics.New()
+	defer m.Close()
+	srv := server.NewMCPServer("test", "0.0.0")
+	registry := &tools.Registry{
+		Server:    srv,
+		Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Auditor:   audit.NopSink{},
+		Metrics:   m,
+		Namespace: "default",
+		Timeout:   10 * time.Second,
+		Clients:   fakeProvider{},
+		Calls:     &tools.InflightCalls{},
+	}
+	registry.Register(&tools.ListWorkflows{Clients: fakeProvider{}, Logger: registry.Logger})
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Name, req.Params.Arguments = "list_workflows", args
+		result, err := srv.GetTool("list_workflows").Handler(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	for range 2 {
+		if result := call(map[string]any{"status": "running"}); result.IsError {
+			t.Fatalf("unexpected error result: %v", result.Content)
+		}
+	}
+	if result := call(map[string]any{"status": "sleeping"}); !result.IsError {
+		t.Fatal("want an error result for an invalid status")
+	}
+
+	exposition := scrape(t, m)
+	assertMetric(t, exposition, `temporal_mcp_tool_calls_total{outcome="success",tool="list_workflows"}`, "2")
+	assertMetric(t, exposition, `temporal_mcp_tool_calls_total{outcome="error",tool="list_workflows"}`, "1")
+	assertMetric(t, exposition, `temporal_mcp_tool_call_duration_seconds_count{outcome="success",tool="list_workflows"}`, "2")
+	assertMetric(t, exposition, `temporal_mcp_tool_call_duration_seconds_count{outcome="error",tool="list_workflows"}`, "1")
+	assertMetric(t, exposition, `temporal_mcp_tool_call_duration_seconds_bucket{outcome="success",tool="list_workflows",le="+Inf"}`, "2")
+}
+
+// frontend is a Temporal frontend serving the RPCs a dial and a describe make.
+type frontend struct {
+	workflowservice.UnimplementedWorkflowServiceServer
+}
+
+func (frontend) GetSystemInfo(context.Context, *workflowservice.GetSystemInfoRequest) (*workflowservice.GetSystemInfoResponse, error) {
+	return &workflowservice.GetSystemInfoResponse{ServerVersion: "1.27.1", Capabilities: &workflowservice.GetSystemInfoResponse_Capabilities{}}, nil
+}
+
+func (frontend) DescribeWorkflowExecution(_ context.Context, req *workflowservice.DescribeWorkflowExecutionRequest) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+	return &workflowservice.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{
+		Execution: req.GetExecution(),
+		Type:      &commonpb.WorkflowType{Name: "OrderWorkflow"},
+		Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+	}}, nil
+}
+
+// sdkRequests returns the temporal_request_total sample of operation in exposition, or "" if
+// there is none.
+func sdkRequests(exposition, operation string) string {
+	for _, line := range strings.Split(exposition, "\n") {
+		name, value, ok := strings.Cut(line, " ")
+		if ok && strings.HasPrefix(name, "temporal_request_total{") && strings.Contains(name, `operation="`+operation+`"`) {
+			return value
+		}
+	}
+	return ""
+}
+
+func TestSDKRequestMetrics(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	workflowservice.RegisterWorkflowServiceServer(grpcServer, frontend{})
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(workflowservice.WorkflowService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	m := metrics.New()
+	client, err := temporal.Dial(temporal.Options{
+		Addresses:      []string{lis.Addr().String()},
+		Namespace:      "default",
+		MetricsHandler: m.SDKHandler(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for range 2 {
+		if _, err := client.DescribeWorkflowExecution(context.Background(), "order-1", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Closing flushes the SDK scope into the registry ahead of its reporting interval
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	exposition := scrape(t, m)
+	if got := sdkRequests(exposition, "DescribeWorkflowExecution"); got != "2" {
+		t.Errorf("temporal_request_total for DescribeWorkflowExecution = %q, want 2 in:\n%s", got, exposition)
+	}
+}
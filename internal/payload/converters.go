@@ -0,0 +1,82 @@
+package payload
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.temporal.io/sdk/converter"
+)
+
+// ConverterFactory creates a data converter.
+type ConverterFactory func() (converter.DataConverter, error)
+
+var (
+	convertersMu sync.Mutex
+	converters   = map[string]ConverterFactory{
+		"default":    func() (converter.DataConverter, error) { return converter.GetDefaultDataConverter(), nil },
+		"proto-json": newProtoJSONFirstConverter,
+	}
+)
+
+// RegisterConverter makes a data converter selectable by name with TEMPORAL_MCP_DATA_CONVERTER.
+// Builds that need a custom converter register it from an init function in a single file:
+//
+//	func init() {
+//		payload.RegisterConverter("acme", func() (converter.DataConverter, error) {
+//			return converter.NewCompositeDataConverter(
+//				converter.NewNilPayloadConverter(),
+//				acme.NewPayloadConverter(),
+//				converter.NewJSONPayloadConverter(),
+//			), nil
+//		})
+//	}
+//
+// It panics if name is already registered.
+func RegisterConverter(name string, factory ConverterFactory) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	if _, dup := converters[name]; dup {
+		panic("payload: RegisterConverter called twice for converter " + name)
+	}
+	converters[name] = factory
+}
+
+// NewConverter creates the data converter registered under name.
+func NewConverter(name string) (converter.DataConverter, error) {
+	convertersMu.Lock()
+	factory, ok := converters[name]
+	convertersMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown data converter %q (available: %s)", name, strings.Join(ConverterNames(), ", "))
+	}
+	return factory()
+}
+
+// ConverterNames returns the registered converter names in sorted order.
+func ConverterNames() []string {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	names := make([]string, 0, len(converters))
+	for name := range converters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newProtoJSONFirstConverter tries proto JSON before every other non-nil encoding, and decodes
+// leniently: unknown fields are ignored and legacy SCREAMING_SNAKE_CASE enums are accepted.
+func newProtoJSONFirstConverter() (converter.DataConverter, error) {
+	return converter.NewCompositeDataConverter(
+		converter.NewNilPayloadConverter(),
+		converter.NewProtoJSONPayloadConverterWithOptions(converter.ProtoJSONPayloadConverterOptions{
+			AllowUnknownFields:        true,
+			LegacyTemporalProtoCompat: true,
+		}),
+		converter.NewByteSlicePayloadConverter(),
+		converter.NewProtoPayloadConverter(),
+		converter.NewJSONPayloadConverter(),
+	), nil
+}
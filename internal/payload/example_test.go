@@ -0,0 +1,43 @@
+package payload_test
+
+import (
+	"fmt"
+
+	"go.temporal.io/sdk/converter"
+
+	"github.com/wricardo/temporal-mcp/internal/payload"
+)
+
+// A build with its own data converter registers it from an init function; the converter is then
+// selected with TEMPORAL_MCP_DATA_CONVERTER=json-only.
+func init() {
+	payload.RegisterConverter("json-only", func() (converter.DataConverter, error) {
+		return converter.NewCompositeDataConverter(
+			converter.NewNilPayloadConverter(),
+			converter.NewJSONPayloadConverter(),
+		), nil
+	})
+}
+
+func ExampleRegisterConverter() {
+	fmt.Println(payload.ConverterNames())
+
+	dc, err := payload.NewConverter("json-only")
+	if err != nil {
+		panic(err)
+	}
+	renderer, err := payload.NewRenderer(payload.Options{Converter: dc})
+	if err != nil {
+		panic(err)
+	}
+	p, err := dc.ToPayload(map[string]int{"attempt": 3})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(renderer.Render(p))
+	// Output:
+	// [default json-only proto-json]
+	// {
+	//   "attempt": 3
+	// }
+}
@@ -0,0 +1,217 @@
+// Package payload renders Temporal payloads (workflow inputs and results, memos, heartbeat details,
+// history event attributes) for display. Every tool that shows payload contents goes through a
+// Renderer, so redaction applies everywhere payloads are decoded.
+package payload
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+// Redaction selects how payload contents are hidden from tool output.
+type Redaction int
+
+const (
+	// RedactNone shows payloads verbatim.
+	RedactNone Redaction = iota
+	// RedactKeys replaces the values of JSON object keys matching the key patterns.
+	RedactKeys
+	// RedactFull replaces whole payloads with their size and encoding.
+	RedactFull
+)
+
+// DefaultKeyPatterns are the key patterns redacted in RedactKeys mode when none are configured.
+var DefaultKeyPatterns = []string{"*email*", "*password*", "*secret*", "*token*", "ssn", "*phone*"}
+
+const (
+	// redactedValue replaces the value of a redacted key.
+	redactedValue = "[REDACTED]"
+	// DefaultMaxSize is the largest payload rendered in full when no limit is configured.
+	DefaultMaxSize = 16 << 10
+	// binaryPreviewBytes is how many leading bytes of a binary payload are shown, base64-encoded.
+	binaryPreviewBytes = 24
+)
+
+// Options configures a Renderer.
+type Options struct {
+	Redaction Redaction
+	// KeyPatterns are case-insensitive glob patterns (path.Match syntax) matched against JSON
+	// object keys at any depth in RedactKeys mode; DefaultKeyPatterns is used when empty.
+	KeyPatterns []string
+	// MaxSize is the largest payload, in bytes, whose contents are rendered; larger ones show only
+	// their metadata. DefaultMaxSize is used when it is 0.
+	MaxSize int
+	// Converter decodes payloads; the SDK's default data converter is used when nil. It must be the
+	// same converter the Temporal client encodes tool inputs with.
+	Converter converter.DataConverter
+	// Codecs decode payloads (e.g. decrypt them) before conversion, applied in reverse order like
+	// converter.NewCodecDataConverter does.
+	Codecs []converter.PayloadCodec
+}
+
+// Renderer turns payloads into display strings.
+type Renderer struct {
+	redaction   Redaction
+	keyPatterns []string
+	maxSize     int
+	converter   converter.DataConverter
+	codecs      []converter.PayloadCodec
+}
+
+// NewRenderer creates a renderer, validating the key patterns.
+func NewRenderer(opts Options) (*Renderer, error) {
+	patterns := opts.KeyPatterns
+	if len(patterns) == 0 {
+		patterns = DefaultKeyPatterns
+	}
+	r := &Renderer{redaction: opts.Redaction, maxSize: opts.MaxSize, converter: opts.Converter, codecs: opts.Codecs}
+	if r.maxSize <= 0 {
+		r.maxSize = DefaultMaxSize
+	}
+	if r.converter == nil {
+		r.converter = converter.GetDefaultDataConverter()
+	}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid redaction key pattern %q: %w", pattern, err)
+		}
+		r.keyPatterns = append(r.keyPatterns, pattern)
+	}
+	return r, nil
+}
+
+// Render returns a display string for one payload. JSON payloads are pretty-printed; binary ones
+// (protobuf, compressed, or encrypted blobs) and payloads over the size limit show only their
+// metadata, plus a short base64 prefix for binary payloads.
+func (r *Renderer) Render(p *commonpb.Payload) string {
+	if p == nil {
+		return "null"
+	}
+	encoding := string(p.GetMetadata()[converter.MetadataEncoding])
+	if encoding == converter.MetadataEncodingNil {
+		return "null"
+	}
+	if r.redaction == RedactFull {
+		return fmt.Sprintf("[REDACTED %s, %d bytes]", describe(p), len(p.GetData()))
+	}
+
+	// A payload the codecs can't decode (e.g. encrypted with an unknown key) is shown as is
+	for i := len(r.codecs) - 1; i >= 0; i-- {
+		decoded, err := r.codecs[i].Decode([]*commonpb.Payload{p})
+		if err != nil {
+			return fmt.Sprintf("[%s, %d bytes: WARNING unable to decode: %v]", describe(p), len(p.GetData()), err)
+		}
+		p = decoded[0]
+	}
+	encoding = string(p.GetMetadata()[converter.MetadataEncoding])
+	if encoding == converter.MetadataEncodingNil {
+		return "null"
+	}
+	size := len(p.GetData())
+	if size > r.maxSize {
+		return fmt.Sprintf("[%s, %d bytes: larger than the %d-byte display limit (TEMPORAL_MCP_MAX_PAYLOAD_BYTES); only metadata is shown]", describe(p), size, r.maxSize)
+	}
+
+	// Let the data converter decode into raw JSON first, so custom converters get a say; payloads it
+	// can't turn into JSON (e.g. proto JSON, which needs the concrete message type) are parsed
+	// directly when their encoding is JSON-based
+	var decoded json.RawMessage
+	data := p.GetData()
+	if err := r.converter.FromPayload(p, &decoded); err == nil && json.Valid(decoded) {
+		data = decoded
+	} else if encoding != converter.MetadataEncodingJSON && encoding != converter.MetadataEncodingProtoJSON {
+		data = nil
+	}
+
+	switch {
+	case data != nil:
+		var value interface{}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Sprintf("[undecodable %s, %d bytes: %v]", describe(p), size, err)
+		}
+		if r.redaction == RedactKeys {
+			value = r.redactKeys(value)
+		}
+		out, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return fmt.Sprintf("[undecodable %s, %d bytes: %v]", describe(p), size, err)
+		}
+		return string(out)
+	default:
+		// Binary data may hold anything, so it is never shown in full, even when redaction is off
+		preview := p.GetData()
+		if len(preview) > binaryPreviewBytes {
+			preview = preview[:binaryPreviewBytes]
+		}
+		text := fmt.Sprintf("[%s, %d bytes", describe(p), size)
+		if r.redaction == RedactNone && size > 0 {
+			text += ", base64 prefix: " + base64.StdEncoding.EncodeToString(preview)
+			if size > binaryPreviewBytes {
+				text += "..."
+			}
+		}
+		return text + "]"
+	}
+}
+
+// RenderAll renders each payload of a Payloads list, e.g. the arguments of a workflow.
+func (r *Renderer) RenderAll(ps *commonpb.Payloads) []string {
+	rendered := make([]string, 0, len(ps.GetPayloads()))
+	for _, p := range ps.GetPayloads() {
+		rendered = append(rendered, r.Render(p))
+	}
+	return rendered
+}
+
+// redactKeys replaces the values of matching keys in decoded JSON, recursing into nested objects
+// and arrays.
+func (r *Renderer) redactKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if r.sensitive(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = r.redactKeys(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = r.redactKeys(nested)
+		}
+	}
+	return value
+}
+
+// sensitive reports whether key matches one of the redaction patterns.
+func (r *Renderer) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range r.keyPatterns {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// describe names a payload's encoding for display, including the protobuf message type when set.
+func describe(p *commonpb.Payload) string {
+	encoding := string(p.GetMetadata()[converter.MetadataEncoding])
+	if encoding == "" {
+		encoding = "unknown encoding"
+	}
+	if messageType := string(p.GetMetadata()[converter.MetadataMessageType]); messageType != "" {
+		return fmt.Sprintf("%s payload (%s)", encoding, messageType)
+	}
+	return encoding + " payload"
+}
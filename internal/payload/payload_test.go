@@ -0,0 +1,271 @@
+package payload
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+// encoded builds a payload with the given encoding metadata and raw data.
+func encoded(encoding, data string) *commonpb.Payload {
+	return &commonpb.Payload{
+		Metadata: map[string][]byte{converter.MetadataEncoding: []byte(encoding)},
+		Data:     []byte(data),
+	}
+}
+
+func newRenderer(t *testing.T, opts Options) *Renderer {
+	t.Helper()
+	r, err := NewRenderer(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRenderRedaction(t *testing.T) {
+	nested := encoded(converter.MetadataEncodingJSON,
+		`{"user":{"Email":"ada@example.com","name":"Ada"},"items":[{"api_token":"t1","sku":"A-1"},[{"password":"p"}]],"secrets":{"a":1},"ssn":"123-45-6789"}`)
+	tests := []struct {
+		name string
+		opts Options
+		p    *commonpb.Payload
+		want string
+	}{
+		{
+			name: "no redaction",
+			opts: Options{Redaction: RedactNone},
+			p:    encoded(converter.MetadataEncodingJSON, `{"email":"ada@example.com"}`),
+			want: "{\n  \"email\": \"ada@example.com\"\n}",
+		},
+		{
+			// Matching keys are redacted at any depth, inside arrays too; a matching key hides its
+			// whole value, however deep
+			name: "default patterns in nested objects and arrays",
+			opts: Options{Redaction: RedactKeys},
+			p:    nested,
+			want: `{
+  "items": [
+    {
+      "api_token": "[REDACTED]",
+      "sku": "A-1"
+    },
+    [
+      {
+        "password": "[REDACTED]"
+      }
+    ]
+  ],
+  "secrets": "[REDACTED]",
+  "ssn": "[REDACTED]",
+  "user": {
+    "Email": "[REDACTED]",
+    "name": "Ada"
+  }
+}`,
+		},
+		{
+			// Patterns are globs matched case-insensitively against the whole key
+			name: "glob patterns",
+			opts: Options{Redaction: RedactKeys, KeyPatterns: []string{"*_KEY", "card?"}},
+			p:    encoded(converter.MetadataEncodingJSON, `{"api_key":"k","Stripe_Key":"s","card1":"4111","card10":"x","key":"plain"}`),
+			want: `{
+  "Stripe_Key": "[REDACTED]",
+  "api_key": "[REDACTED]",
+  "card1": "[REDACTED]",
+  "card10": "x",
+  "key": "plain"
+}`,
+		},
+		{
+			name: "configured patterns replace the defaults",
+			opts: Options{Redaction: RedactKeys, KeyPatterns: []string{"iban"}},
+			p:    encoded(converter.MetadataEncodingJSON, `{"email":"ada@example.com","iban":"DE89"}`),
+			want: "{\n  \"email\": \"ada@example.com\",\n  \"iban\": \"[REDACTED]\"\n}",
+		},
+		{
+			name: "scalar payload",
+			opts: Options{Redaction: RedactKeys},
+			p:    encoded(converter.MetadataEncodingJSON, `"ada@example.com"`),
+			want: `"ada@example.com"`,
+		},
+		{
+			name: "full redaction of JSON",
+			opts: Options{Redaction: RedactFull},
+			p:    nested,
+			want: "[REDACTED json/plain payload, 147 bytes]",
+		},
+		{
+			name: "full redaction of protobuf",
+			opts: Options{Redaction: RedactFull},
+			p: &commonpb.Payload{
+				Metadata: map[string][]byte{
+					converter.MetadataEncoding:    []byte(converter.MetadataEncodingProto),
+					converter.MetadataMessageType: []byte("temporal.api.common.v1.WorkflowExecution"),
+				},
+				Data: []byte{0x0a, 0x02, 'i', 'd'},
+			},
+			want: "[REDACTED binary/protobuf payload (temporal.api.common.v1.WorkflowExecution), 4 bytes]",
+		},
+		{
+			name: "full redaction keeps null",
+			opts: Options{Redaction: RedactFull},
+			p:    encoded(converter.MetadataEncodingNil, ""),
+			want: "null",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newRenderer(t, tt.opts).Render(tt.p); got != tt.want {
+				t.Errorf("Render:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRendererRejectsInvalidPattern(t *testing.T) {
+	_, err := NewRenderer(Options{Redaction: RedactKeys, KeyPatterns: []string{"*token*", "[a-"}})
+	if err == nil || !strings.Contains(err.Error(), `invalid redaction key pattern "[a-"`) {
+		t.Fatalf("error = %v, want an invalid pattern error", err)
+	}
+}
+
+func TestRenderEncodings(t *testing.T) {
+	dc := converter.GetDefaultDataConverter()
+	toPayload := func(value interface{}) *commonpb.Payload {
+		p, err := dc.ToPayload(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	long := bytes.Repeat([]byte{0xff}, 30)
+
+	tests := []struct {
+		name string
+		opts Options
+		p    *commonpb.Payload
+		want string
+	}{
+		{
+			name: "JSON is pretty-printed",
+			p:    toPayload(map[string]interface{}{"order": "o-1", "lines": []int{1, 2}}),
+			want: "{\n  \"lines\": [\n    1,\n    2\n  ],\n  \"order\": \"o-1\"\n}",
+		},
+		{
+			// Numbers keep their digits instead of going through float64
+			name: "large JSON numbers",
+			p:    encoded(converter.MetadataEncodingJSON, `{"id":12345678901234567890,"ratio":0.1}`),
+			want: "{\n  \"id\": 12345678901234567890,\n  \"ratio\": 0.1\n}",
+		},
+		{
+			name: "malformed JSON",
+			p:    encoded(converter.MetadataEncodingJSON, `{"id":`),
+			want: "[undecodable json/plain payload, 6 bytes: unexpected EOF]",
+		},
+		{
+			name: "nil",
+			p:    toPayload(nil),
+			want: "null",
+		},
+		{
+			name: "missing payload",
+			want: "null",
+		},
+		{
+			name: "short binary",
+			p:    toPayload([]byte("hi")),
+			want: "[binary/plain payload, 2 bytes, base64 prefix: aGk=]",
+		},
+		{
+			name: "long binary shows a prefix",
+			p:    toPayload(long),
+			want: "[binary/plain payload, 30 bytes, base64 prefix: " + strings.Repeat("/", 32) + "...]",
+		},
+		{
+			name: "binary under key redaction hides the prefix",
+			opts: Options{Redaction: RedactKeys},
+			p:    toPayload(long),
+			want: "[binary/plain payload, 30 bytes]",
+		},
+		{
+			name: "empty binary",
+			p:    toPayload([]byte{}),
+			want: "[binary/plain payload, 0 bytes]",
+		},
+		{
+			name: "unknown encoding",
+			p:    &commonpb.Payload{Data: []byte{0x01}},
+			want: "[unknown encoding payload, 1 bytes, base64 prefix: AQ==]",
+		},
+		{
+			name: "oversized",
+			opts: Options{MaxSize: 8},
+			p:    encoded(converter.MetadataEncodingJSON, `{"order":"o-1"}`),
+			want: "[json/plain payload, 15 bytes: larger than the 8-byte display limit (TEMPORAL_MCP_MAX_PAYLOAD_BYTES); only metadata is shown]",
+		},
+		{
+			name: "at the size limit",
+			opts: Options{MaxSize: 15},
+			p:    encoded(converter.MetadataEncodingJSON, `{"order":"o-1"}`),
+			want: "{\n  \"order\": \"o-1\"\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newRenderer(t, tt.opts).Render(tt.p); got != tt.want {
+				t.Errorf("Render:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderDecodesWithCodecs(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	writer, err := NewAESCodec("k1", map[string][]byte{"k1": key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := writer.Encode([]*commonpb.Payload{encoded(converter.MetadataEncodingJSON, `{"token":"t","order":"o-1"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Decrypted payloads are rendered, and redacted, like any other
+	r := newRenderer(t, Options{Redaction: RedactKeys, Codecs: []converter.PayloadCodec{writer}})
+	want := "{\n  \"order\": \"o-1\",\n  \"token\": \"[REDACTED]\"\n}"
+	if got := r.Render(sealed[0]); got != want {
+		t.Errorf("Render:\n%s\nwant:\n%s", got, want)
+	}
+
+	// A payload sealed with a key the renderer doesn't have is described, not rendered
+	other, err := NewAESCodec("k2", map[string][]byte{"k2": key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = newRenderer(t, Options{Codecs: []converter.PayloadCodec{other}})
+	got := r.Render(sealed[0])
+	wantPrefix := "[binary/encrypted payload, "
+	wantSuffix := ` bytes: WARNING unable to decode: no key configured for encryption key ID "k1"]`
+	if !strings.HasPrefix(got, wantPrefix) || !strings.HasSuffix(got, wantSuffix) {
+		t.Errorf("Render = %s, want %s<size>%s", got, wantPrefix, wantSuffix)
+	}
+}
+
+func TestRenderAll(t *testing.T) {
+	r := newRenderer(t, Options{})
+	ps := &commonpb.Payloads{Payloads: []*commonpb.Payload{
+		encoded(converter.MetadataEncodingJSON, `"o-1"`),
+		encoded(converter.MetadataEncodingNil, ""),
+	}}
+	got := r.RenderAll(ps)
+	if len(got) != 2 || got[0] != `"o-1"` || got[1] != "null" {
+		t.Errorf("RenderAll = %q", got)
+	}
+	if got := r.RenderAll(nil); len(got) != 0 {
+		t.Errorf("RenderAll(nil) = %q, want none", got)
+	}
+}
@@ -0,0 +1,96 @@
+// Package pool provides a bounded worker pool shared by fan-out tools, so the total number of
+// concurrent Temporal RPCs stays capped no matter how many tool calls are running.
+package pool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Pool bounds the number of tasks running concurrently across every Run call sharing it.
+type Pool struct {
+	sem chan struct{}
+}
+
+// New creates a pool allowing at most size concurrent tasks. Sizes below 1 are treated as 1.
+func New(size int) *Pool {
+	if size < 1 {
+		size = 1
+	}
+	return &Pool{sem: make(chan struct{}, size)}
+}
+
+// Size returns the maximum number of concurrent tasks.
+func (p *Pool) Size() int {
+	return cap(p.sem)
+}
+
+// Stats reports how many items a Run processed, and how many it skipped because of the item cap
+// or because the context was cancelled first.
+type Stats struct {
+	Total     int
+	Processed int
+	Capped    int
+	Cancelled int
+}
+
+// String summarizes the run for tool output, e.g. "processed 50 of 80 (30 skipped: cap of 50)".
+func (s Stats) String() string {
+	out := fmt.Sprintf("processed %d of %d", s.Processed, s.Total)
+	if s.Capped > 0 {
+		out += fmt.Sprintf(" (%d skipped: cap of %d)", s.Capped, s.Total-s.Capped)
+	}
+	if s.Cancelled > 0 {
+		out += fmt.Sprintf(" (%d not processed: cancelled)", s.Cancelled)
+	}
+	return out
+}
+
+// Run calls fn for each index in [0, n), processing at most limit items (limit <= 0 means no cap).
+// fn runs concurrently, bounded by the pool size; Run returns once every started task has finished.
+// Once ctx is done no further tasks are started.
+func (p *Pool) Run(ctx context.Context, n, limit int, fn func(ctx context.Context, i int)) Stats {
+	stats := Stats{Total: n}
+	todo := n
+	if limit > 0 && limit < n {
+		todo = limit
+		stats.Capped = n - limit
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		processed int
+	)
+	started := 0
+	for ; started < todo; started++ {
+		if !p.acquire(ctx) {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-p.sem }()
+			fn(ctx, i)
+			mu.Lock()
+			processed++
+			mu.Unlock()
+		}(started)
+	}
+	wg.Wait()
+
+	stats.Processed = processed
+	stats.Cancelled = todo - started
+	return stats
+}
+
+// acquire takes a slot in the pool, returning false if ctx is done first.
+func (p *Pool) acquire(ctx context.Context) bool {
+	select {
+	case p.sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
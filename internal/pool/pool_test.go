@@ -0,0 +1,138 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowClient stands in for a Temporal client whose RPCs take a while, recording the peak number of
+// calls in flight at once.
+type slowClient struct {
+	delay    time.Duration
+	inFlight atomic.Int32
+	peak     atomic.Int32
+	calls    atomic.Int32
+}
+
+func (c *slowClient) call(context.Context, int) {
+	n := c.inFlight.Add(1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(c.delay)
+	c.inFlight.Add(-1)
+	c.calls.Add(1)
+}
+
+func TestRunBoundsConcurrency(t *testing.T) {
+	p := New(3)
+	client := &slowClient{delay: 5 * time.Millisecond}
+
+	// Concurrent runs share the bound, as concurrent tool calls do
+	const runs, items = 4, 20
+	stats := make([]Stats, runs)
+	var wg sync.WaitGroup
+	for r := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats[r] = p.Run(context.Background(), items, 0, client.call)
+		}()
+	}
+	wg.Wait()
+
+	if peak := int(client.peak.Load()); peak > p.Size() {
+		t.Errorf("peak in-flight calls = %d, want at most the pool size %d", peak, p.Size())
+	} else if peak < 2 {
+		t.Errorf("peak in-flight calls = %d, want the calls to run concurrently", peak)
+	}
+	for r, s := range stats {
+		if want := (Stats{Total: items, Processed: items}); s != want {
+			t.Errorf("run %d: stats = %+v, want %+v", r, s, want)
+		}
+	}
+	if calls := client.calls.Load(); calls != runs*items {
+		t.Errorf("calls = %d, want %d", calls, runs*items)
+	}
+}
+
+func TestRunLimit(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seen []int
+	)
+	stats := New(2).Run(context.Background(), 10, 4, func(_ context.Context, i int) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, i)
+	})
+
+	if want := (Stats{Total: 10, Processed: 4, Capped: 6}); stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+	if len(seen) != 4 {
+		t.Fatalf("processed items %v, want the first 4", seen)
+	}
+	for _, i := range seen {
+		if i >= 4 {
+			t.Errorf("processed item %d beyond the cap", i)
+		}
+	}
+	if got, want := stats.String(), "processed 4 of 10 (6 skipped: cap of 4)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	p := New(1)
+
+	// Another run holds the only slot, so acquiring one can only end with the context
+	holding, release := make(chan struct{}), make(chan struct{})
+	var other sync.WaitGroup
+	other.Add(1)
+	go func() {
+		defer other.Done()
+		p.Run(context.Background(), 1, 0, func(context.Context, int) {
+			close(holding)
+			<-release
+		})
+	}()
+	<-holding
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	var calls atomic.Int32
+	stats := p.Run(ctx, 10, 4, func(context.Context, int) { calls.Add(1) })
+	close(release)
+	other.Wait()
+
+	if want := (Stats{Total: 10, Capped: 6, Cancelled: 4}); stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("%d items processed after cancellation, want none", calls.Load())
+	}
+	if got, want := stats.String(), "processed 0 of 10 (6 skipped: cap of 4) (4 not processed: cancelled)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	// Once the slot is free again the pool serves new runs
+	if stats := p.Run(context.Background(), 2, 0, func(context.Context, int) {}); stats.Processed != 2 {
+		t.Errorf("stats after cancellation = %+v, want both items processed", stats)
+	}
+}
+
+func TestNewMinimumSize(t *testing.T) {
+	if size := New(0).Size(); size != 1 {
+		t.Errorf("New(0).Size() = %d, want 1", size)
+	}
+}
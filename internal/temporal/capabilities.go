@@ -0,0 +1,83 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// Version is a Temporal server release as major and minor version.
+type Version [2]int
+
+// String formats the version as "major.minor".
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v[0], v[1])
+}
+
+// ServerCapabilities is the connected server's version and capability flags, as reported by
+// GetSystemInfo.
+type ServerCapabilities struct {
+	Version      string
+	Capabilities *workflowservice.GetSystemInfoResponse_Capabilities
+}
+
+// AtLeast reports whether the server version (e.g. "1.24.2") is at least minimum. Unparseable
+// versions are assumed to be older.
+func (s ServerCapabilities) AtLeast(minimum Version) bool {
+	parts := strings.SplitN(strings.TrimPrefix(s.Version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return major > minimum[0] || (major == minimum[0] && minor >= minimum[1])
+}
+
+// Capabilities caches the connected server's capabilities, fetching them on first use and again
+// after the connection was lost, since the server may have been upgraded or replaced meanwhile.
+type Capabilities struct {
+	client Client
+	// generation returns a counter that changes whenever the connection is lost.
+	generation func() uint64
+
+	mu      sync.Mutex
+	cached  *ServerCapabilities
+	fetched uint64
+}
+
+// NewCapabilities returns a cache fetching capabilities through client. generation reports the
+// connection generation; nil means the connection is never considered lost.
+func NewCapabilities(client Client, generation func() uint64) *Capabilities {
+	if generation == nil {
+		generation = func() uint64 { return 0 }
+	}
+	return &Capabilities{client: client, generation: generation}
+}
+
+// Get returns the server capabilities, fetching them unless a fetch on the current connection
+// generation is cached. Failed fetches aren't cached.
+func (c *Capabilities) Get(ctx context.Context) (ServerCapabilities, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	generation := c.generation()
+	if c.cached != nil && c.fetched == generation {
+		return *c.cached, nil
+	}
+	resp, err := c.client.GetSystemInfo(ctx)
+	if err != nil {
+		return ServerCapabilities{}, err
+	}
+	c.cached = &ServerCapabilities{Version: resp.GetServerVersion(), Capabilities: resp.GetCapabilities()}
+	c.fetched = generation
+	return *c.cached, nil
+}
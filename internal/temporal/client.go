@@ -0,0 +1,496 @@
+// Package temporal defines the narrow Temporal client interface used by the tools, and its
+// implementation on top of the Temporal Go SDK.
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	commonpb "go.temporal.io/api/common/v1"
+	operatorservice "go.temporal.io/api/operatorservice/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/grpc"
+)
+
+// Client is the set of Temporal operations the tools use. It is bound to a single namespace.
+// Implementations must be safe for concurrent use.
+type Client interface {
+	// Namespace returns the namespace the client operates on.
+	Namespace() string
+	// Address returns the frontend address the client is currently connected to.
+	Address() string
+	// Reconnects returns how many times the connection was rebuilt after persistent failures, and
+	// when it last was (zero if never).
+	Reconnects() (count int, last time.Time)
+	// Identity returns the identity recorded in history for RPCs made with ctx.
+	Identity(ctx context.Context) string
+	ListOpenWorkflow(ctx context.Context, request *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error)
+	ListClosedWorkflow(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest) (*workflowservice.ListClosedWorkflowExecutionsResponse, error)
+	// ListWorkflow runs a visibility query (request.Query uses the SQL-like list filter syntax).
+	ListWorkflow(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error)
+	// CountWorkflow counts executions matching a visibility query, optionally grouped (GROUP BY).
+	CountWorkflow(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest) (*workflowservice.CountWorkflowExecutionsResponse, error)
+	DescribeWorkflowExecution(ctx context.Context, workflowID, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, error)
+	GetWorkflowExecutionHistory(ctx context.Context, request *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error)
+	GetSystemInfo(ctx context.Context) (*workflowservice.GetSystemInfoResponse, error)
+	// GetClusterInfo describes the connected cluster, including its name in replication configs.
+	GetClusterInfo(ctx context.Context) (*workflowservice.GetClusterInfoResponse, error)
+	// DescribeTaskQueue reports a task queue's pollers and, when requested, its backlog statistics.
+	DescribeTaskQueue(ctx context.Context, request *workflowservice.DescribeTaskQueueRequest) (*workflowservice.DescribeTaskQueueResponse, error)
+	// GetWorkerVersioningRules returns a task queue's build ID assignment and redirect rules.
+	GetWorkerVersioningRules(ctx context.Context, request *workflowservice.GetWorkerVersioningRulesRequest) (*workflowservice.GetWorkerVersioningRulesResponse, error)
+	// ListSchedules lists the schedules of the namespace with their paused state.
+	ListSchedules(ctx context.Context, request *workflowservice.ListSchedulesRequest) (*workflowservice.ListSchedulesResponse, error)
+	// DescribeSchedule describes a schedule, with its recent actions and the workflows it runs.
+	DescribeSchedule(ctx context.Context, request *workflowservice.DescribeScheduleRequest) (*workflowservice.DescribeScheduleResponse, error)
+	// ListScheduleMatchingTimes returns the times an existing schedule's spec matches in a window.
+	ListScheduleMatchingTimes(ctx context.Context, request *workflowservice.ListScheduleMatchingTimesRequest) (*workflowservice.ListScheduleMatchingTimesResponse, error)
+	// CreateSchedule creates a schedule.
+	CreateSchedule(ctx context.Context, request *workflowservice.CreateScheduleRequest) (*workflowservice.CreateScheduleResponse, error)
+	// DeleteSchedule deletes a schedule; the workflows it started are left alone.
+	DeleteSchedule(ctx context.Context, request *workflowservice.DeleteScheduleRequest) (*workflowservice.DeleteScheduleResponse, error)
+	// ResetWorkflowExecution resets an execution to a workflow task event, starting a new run.
+	ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest) (*workflowservice.ResetWorkflowExecutionResponse, error)
+	// SignalWorkflow signals an execution, encoding input with the client's data converter; a nil
+	// input sends the signal without one.
+	SignalWorkflow(ctx context.Context, workflowID, runID, signalName string, input interface{}) error
+	// PauseActivity pauses a pending activity: no new attempts are scheduled until it is unpaused.
+	PauseActivity(ctx context.Context, request *workflowservice.PauseActivityRequest) (*workflowservice.PauseActivityResponse, error)
+	// UnpauseActivity resumes the scheduling of a paused activity's attempts.
+	UnpauseActivity(ctx context.Context, request *workflowservice.UnpauseActivityRequest) (*workflowservice.UnpauseActivityResponse, error)
+	// ResetActivity restarts a pending activity's attempts from the first.
+	ResetActivity(ctx context.Context, request *workflowservice.ResetActivityRequest) (*workflowservice.ResetActivityResponse, error)
+	// DescribeBatchOperation returns the state and progress of a batch operation.
+	DescribeBatchOperation(ctx context.Context, request *workflowservice.DescribeBatchOperationRequest) (*workflowservice.DescribeBatchOperationResponse, error)
+	// StopBatchOperation stops a running batch operation; executions it already processed stay as they are.
+	StopBatchOperation(ctx context.Context, request *workflowservice.StopBatchOperationRequest) (*workflowservice.StopBatchOperationResponse, error)
+	// PollWorkflowExecutionUpdate waits, until ctx is done or the server's long-poll timeout, for a
+	// workflow update to reach the stage of request.WaitPolicy, and returns the stage it reached.
+	PollWorkflowExecutionUpdate(ctx context.Context, request *workflowservice.PollWorkflowExecutionUpdateRequest) (*workflowservice.PollWorkflowExecutionUpdateResponse, error)
+	// ListNamespaces lists the namespaces registered on the server, regardless of the client's own.
+	ListNamespaces(ctx context.Context, request *workflowservice.ListNamespacesRequest) (*workflowservice.ListNamespacesResponse, error)
+	// NamespaceSettings describes the client's own namespace, cached for a minute: it is context for
+	// tool output, such as the retention period, rather than a source of fresh state.
+	NamespaceSettings(ctx context.Context) (*workflowservice.DescribeNamespaceResponse, error)
+	// DescribeNamespace describes a namespace by name, regardless of the client's own.
+	DescribeNamespace(ctx context.Context, namespace string) (*workflowservice.DescribeNamespaceResponse, error)
+	// UpdateNamespace changes the settings of a namespace; unset fields of request.Config are kept.
+	UpdateNamespace(ctx context.Context, request *workflowservice.UpdateNamespaceRequest) (*workflowservice.UpdateNamespaceResponse, error)
+	// DeleteNamespace starts the asynchronous deletion of a namespace and all its executions.
+	DeleteNamespace(ctx context.Context, request *operatorservice.DeleteNamespaceRequest) (*operatorservice.DeleteNamespaceResponse, error)
+	// ListSearchAttributes lists the system and custom search attributes of the namespace with their types.
+	ListSearchAttributes(ctx context.Context) (*operatorservice.ListSearchAttributesResponse, error)
+	// ListNexusEndpoints lists the Nexus endpoints of the cluster, which belong to no namespace.
+	ListNexusEndpoints(ctx context.Context, request *operatorservice.ListNexusEndpointsRequest) (*operatorservice.ListNexusEndpointsResponse, error)
+	// GetNexusEndpoint returns a Nexus endpoint by its server-generated ID.
+	GetNexusEndpoint(ctx context.Context, id string) (*operatorservice.GetNexusEndpointResponse, error)
+}
+
+// Options configures the connection to Temporal.
+type Options struct {
+	// Addresses are the host:port of the Temporal frontend, or of several equivalent endpoints
+	// tried in order.
+	Addresses []string
+	Namespace string
+	// Identity is recorded in history as the actor of the client's RPCs; per call it is extended
+	// with the caller set by WithCaller. Empty uses the SDK's pid@host default.
+	Identity string
+	// KeepAlive configures gRPC keepalive pings, which detect connections silently dropped by NATs
+	// and gateways.
+	KeepAlive KeepAlive
+	// Headers, when set, supplies gRPC headers sent with every RPC (e.g. for an authenticating proxy).
+	Headers *Headers
+	// Logger receives the SDK's log messages.
+	Logger *slog.Logger
+	// MetricsHandler receives the SDK's client metrics; nil discards them.
+	MetricsHandler client.MetricsHandler
+	// Limiter throttles every RPC made through the client; nil disables rate limiting.
+	Limiter *Limiter
+	// DataConverter encodes the payloads the client sends; nil uses the SDK's default converter.
+	DataConverter converter.DataConverter
+	// Retry is the retry policy of read-only (and idempotent) RPCs.
+	Retry RetryPolicy
+	// DialOptions are added to the gRPC dial options of the connection, e.g. tracing instrumentation.
+	DialOptions []grpc.DialOption
+}
+
+// KeepAlive configures the gRPC keepalive pings of the connection. Zero durations use the SDK's
+// defaults (30s and 15s).
+type KeepAlive struct {
+	// Time is how long the connection may be idle before a ping is sent.
+	Time time.Duration
+	// Timeout is how long to wait for a ping's acknowledgement before closing the connection.
+	Timeout time.Duration
+	// PermitWithoutStream sends pings even when no RPC is in flight.
+	PermitWithoutStream bool
+}
+
+// SDKClient implements Client with the Temporal Go SDK.
+type SDKClient struct {
+	conn      *connection
+	namespace string
+	limiter   *Limiter
+	retry     RetryPolicy
+	// capabilities is shared by every client on the connection.
+	capabilities *Capabilities
+
+	// settings caches the NamespaceSettings response, fetched at settingsFetched.
+	settingsMu      sync.Mutex
+	settings        *workflowservice.DescribeNamespaceResponse
+	settingsFetched time.Time
+}
+
+var _ Client = (*SDKClient)(nil)
+
+// Dial connects to the first reachable Temporal frontend of opts.Addresses.
+func Dial(opts Options) (*SDKClient, error) {
+	conn, err := connect(opts)
+	if err != nil {
+		return nil, fmt.Errorf("connect to Temporal at %s (namespace %s): %w", strings.Join(opts.Addresses, ", "), opts.Namespace, err)
+	}
+	sdkClient := &SDKClient{conn: conn, namespace: opts.Namespace, limiter: opts.Limiter, retry: opts.Retry}
+	sdkClient.capabilities = NewCapabilities(sdkClient, conn.generation.Load)
+	return sdkClient, nil
+}
+
+// forNamespace returns a client for another namespace sharing this client's connection, limiter,
+// logger, and metrics.
+func (c *SDKClient) forNamespace(namespace string) (*SDKClient, error) {
+	if _, err := c.conn.client(namespace); err != nil {
+		return nil, err
+	}
+	return &SDKClient{conn: c.conn, namespace: namespace, limiter: c.limiter, retry: c.retry, capabilities: c.capabilities}, nil
+}
+
+// Capabilities returns the cached capabilities of the connected server.
+func (c *SDKClient) Capabilities() *Capabilities {
+	return c.capabilities
+}
+
+// Close closes the underlying connection.
+func (c *SDKClient) Close() {
+	c.conn.close()
+}
+
+// begin must be called before every RPC attempt: it records the RPC as in flight for the current
+// tool call and takes a token from the shared rate limiter, so multi-RPC tools and retries are
+// limited per RPC. It returns the SDK client to make the RPC with, on the currently active address.
+func (c *SDKClient) begin(ctx context.Context, rpc string) (client.Client, error) {
+	trackRPC(ctx, rpc)
+	if err := c.limiter.Wait(ctx, rpc); err != nil {
+		return nil, err
+	}
+	return c.conn.client(c.namespace)
+}
+
+// read makes a read-only RPC, retrying transient failures under the client's retry policy.
+func read[T any](ctx context.Context, c *SDKClient, rpc string, fn func(sdk client.Client) (T, error)) (T, error) {
+	var resp T
+	err := c.retry.Do(ctx, func() error {
+		sdk, err := c.begin(ctx, rpc)
+		if err != nil {
+			return err
+		}
+		resp, err = fn(sdk)
+		return err
+	})
+	return resp, err
+}
+
+// mutate makes an RPC that changes Temporal state. It is refused in dry-run mode, and retried only
+// when idempotent (e.g. deduplicated by a request ID), since a blind retry could apply it twice.
+func mutate[T any](ctx context.Context, c *SDKClient, rpc string, idempotent bool, fn func(sdk client.Client) (T, error)) (T, error) {
+	if DryRun(ctx) {
+		var zero T
+		return zero, ErrDryRun
+	}
+	if !idempotent {
+		sdk, err := c.begin(ctx, rpc)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return fn(sdk)
+	}
+	return read(ctx, c, rpc, fn)
+}
+
+// Identity implements Client.
+func (c *SDKClient) Identity(ctx context.Context) string {
+	return CallIdentity(ctx, c.conn.opts.Identity)
+}
+
+// Reconnects implements Client.
+func (c *SDKClient) Reconnects() (int, time.Time) {
+	return c.conn.reconnected()
+}
+
+// Address implements Client.
+func (c *SDKClient) Address() string {
+	return c.conn.address()
+}
+
+// Namespace implements Client.
+func (c *SDKClient) Namespace() string {
+	return c.namespace
+}
+
+// ListOpenWorkflow implements Client.
+func (c *SDKClient) ListOpenWorkflow(ctx context.Context, request *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+	return read(ctx, c, "ListOpenWorkflowExecutions", func(sdk client.Client) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+		return sdk.ListOpenWorkflow(ctx, request)
+	})
+}
+
+// ListClosedWorkflow implements Client.
+func (c *SDKClient) ListClosedWorkflow(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest) (*workflowservice.ListClosedWorkflowExecutionsResponse, error) {
+	return read(ctx, c, "ListClosedWorkflowExecutions", func(sdk client.Client) (*workflowservice.ListClosedWorkflowExecutionsResponse, error) {
+		return sdk.ListClosedWorkflow(ctx, request)
+	})
+}
+
+// ListWorkflow implements Client.
+func (c *SDKClient) ListWorkflow(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	return read(ctx, c, "ListWorkflowExecutions", func(sdk client.Client) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+		return sdk.ListWorkflow(ctx, request)
+	})
+}
+
+// CountWorkflow implements Client.
+func (c *SDKClient) CountWorkflow(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest) (*workflowservice.CountWorkflowExecutionsResponse, error) {
+	return read(ctx, c, "CountWorkflowExecutions", func(sdk client.Client) (*workflowservice.CountWorkflowExecutionsResponse, error) {
+		return sdk.CountWorkflow(ctx, request)
+	})
+}
+
+// DescribeWorkflowExecution implements Client.
+func (c *SDKClient) DescribeWorkflowExecution(ctx context.Context, workflowID, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+	return read(ctx, c, "DescribeWorkflowExecution", func(sdk client.Client) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+		return sdk.DescribeWorkflowExecution(ctx, workflowID, runID)
+	})
+}
+
+// GetWorkflowExecutionHistory implements Client.
+func (c *SDKClient) GetWorkflowExecutionHistory(ctx context.Context, request *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+	return read(ctx, c, "GetWorkflowExecutionHistory", func(sdk client.Client) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+		return sdk.WorkflowService().GetWorkflowExecutionHistory(ctx, request)
+	})
+}
+
+// GetSystemInfo implements Client.
+func (c *SDKClient) GetSystemInfo(ctx context.Context) (*workflowservice.GetSystemInfoResponse, error) {
+	return read(ctx, c, "GetSystemInfo", func(sdk client.Client) (*workflowservice.GetSystemInfoResponse, error) {
+		return sdk.WorkflowService().GetSystemInfo(ctx, &workflowservice.GetSystemInfoRequest{})
+	})
+}
+
+// GetClusterInfo implements Client.
+func (c *SDKClient) GetClusterInfo(ctx context.Context) (*workflowservice.GetClusterInfoResponse, error) {
+	return read(ctx, c, "GetClusterInfo", func(sdk client.Client) (*workflowservice.GetClusterInfoResponse, error) {
+		return sdk.WorkflowService().GetClusterInfo(ctx, &workflowservice.GetClusterInfoRequest{})
+	})
+}
+
+// DescribeTaskQueue implements Client.
+func (c *SDKClient) DescribeTaskQueue(ctx context.Context, request *workflowservice.DescribeTaskQueueRequest) (*workflowservice.DescribeTaskQueueResponse, error) {
+	return read(ctx, c, "DescribeTaskQueue", func(sdk client.Client) (*workflowservice.DescribeTaskQueueResponse, error) {
+		return sdk.WorkflowService().DescribeTaskQueue(ctx, request)
+	})
+}
+
+// GetWorkerVersioningRules implements Client.
+func (c *SDKClient) GetWorkerVersioningRules(ctx context.Context, request *workflowservice.GetWorkerVersioningRulesRequest) (*workflowservice.GetWorkerVersioningRulesResponse, error) {
+	return read(ctx, c, "GetWorkerVersioningRules", func(sdk client.Client) (*workflowservice.GetWorkerVersioningRulesResponse, error) {
+		return sdk.WorkflowService().GetWorkerVersioningRules(ctx, request)
+	})
+}
+
+// ListSchedules implements Client.
+func (c *SDKClient) ListSchedules(ctx context.Context, request *workflowservice.ListSchedulesRequest) (*workflowservice.ListSchedulesResponse, error) {
+	return read(ctx, c, "ListSchedules", func(sdk client.Client) (*workflowservice.ListSchedulesResponse, error) {
+		return sdk.WorkflowService().ListSchedules(ctx, request)
+	})
+}
+
+// DescribeSchedule implements Client.
+func (c *SDKClient) DescribeSchedule(ctx context.Context, request *workflowservice.DescribeScheduleRequest) (*workflowservice.DescribeScheduleResponse, error) {
+	return read(ctx, c, "DescribeSchedule", func(sdk client.Client) (*workflowservice.DescribeScheduleResponse, error) {
+		return sdk.WorkflowService().DescribeSchedule(ctx, request)
+	})
+}
+
+// ListScheduleMatchingTimes implements Client.
+func (c *SDKClient) ListScheduleMatchingTimes(ctx context.Context, request *workflowservice.ListScheduleMatchingTimesRequest) (*workflowservice.ListScheduleMatchingTimesResponse, error) {
+	return read(ctx, c, "ListScheduleMatchingTimes", func(sdk client.Client) (*workflowservice.ListScheduleMatchingTimesResponse, error) {
+		return sdk.WorkflowService().ListScheduleMatchingTimes(ctx, request)
+	})
+}
+
+// CreateSchedule implements Client.
+func (c *SDKClient) CreateSchedule(ctx context.Context, request *workflowservice.CreateScheduleRequest) (*workflowservice.CreateScheduleResponse, error) {
+	// The request ID deduplicates retries
+	return mutate(ctx, c, "CreateSchedule", request.GetRequestId() != "", func(sdk client.Client) (*workflowservice.CreateScheduleResponse, error) {
+		return sdk.WorkflowService().CreateSchedule(ctx, request)
+	})
+}
+
+// DeleteSchedule implements Client.
+func (c *SDKClient) DeleteSchedule(ctx context.Context, request *workflowservice.DeleteScheduleRequest) (*workflowservice.DeleteScheduleResponse, error) {
+	// A retry of a delete that went through fails with NotFound, which callers treat as deleted
+	return mutate(ctx, c, "DeleteSchedule", true, func(sdk client.Client) (*workflowservice.DeleteScheduleResponse, error) {
+		return sdk.WorkflowService().DeleteSchedule(ctx, request)
+	})
+}
+
+// ResetWorkflowExecution implements Client.
+func (c *SDKClient) ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest) (*workflowservice.ResetWorkflowExecutionResponse, error) {
+	// Resets carrying a request ID are idempotent, so only those are retried
+	return mutate(ctx, c, "ResetWorkflowExecution", request.GetRequestId() != "", func(sdk client.Client) (*workflowservice.ResetWorkflowExecutionResponse, error) {
+		return sdk.ResetWorkflowExecution(ctx, request)
+	})
+}
+
+// SignalWorkflow implements Client.
+func (c *SDKClient) SignalWorkflow(ctx context.Context, workflowID, runID, signalName string, input interface{}) error {
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace:         c.namespace,
+		WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: runID},
+		SignalName:        signalName,
+		Identity:          c.Identity(ctx),
+		// The request ID deduplicates the signal, so the client may retry it on transient errors
+		RequestId: uuid.NewString(),
+	}
+	if input != nil {
+		dataConverter := c.conn.opts.DataConverter
+		if dataConverter == nil {
+			dataConverter = converter.GetDefaultDataConverter()
+		}
+		payloads, err := dataConverter.ToPayloads(input)
+		if err != nil {
+			return fmt.Errorf("encode signal input: %w", err)
+		}
+		request.Input = payloads
+	}
+	_, err := mutate(ctx, c, "SignalWorkflowExecution", true, func(sdk client.Client) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+		return sdk.WorkflowService().SignalWorkflowExecution(ctx, request)
+	})
+	return err
+}
+
+// PauseActivity implements Client.
+func (c *SDKClient) PauseActivity(ctx context.Context, request *workflowservice.PauseActivityRequest) (*workflowservice.PauseActivityResponse, error) {
+	// Pausing an activity twice leaves it paused, so retries are safe
+	return mutate(ctx, c, "PauseActivity", true, func(sdk client.Client) (*workflowservice.PauseActivityResponse, error) {
+		return sdk.WorkflowService().PauseActivity(ctx, request)
+	})
+}
+
+// UnpauseActivity implements Client.
+func (c *SDKClient) UnpauseActivity(ctx context.Context, request *workflowservice.UnpauseActivityRequest) (*workflowservice.UnpauseActivityResponse, error) {
+	return mutate(ctx, c, "UnpauseActivity", true, func(sdk client.Client) (*workflowservice.UnpauseActivityResponse, error) {
+		return sdk.WorkflowService().UnpauseActivity(ctx, request)
+	})
+}
+
+// ResetActivity implements Client.
+func (c *SDKClient) ResetActivity(ctx context.Context, request *workflowservice.ResetActivityRequest) (*workflowservice.ResetActivityResponse, error) {
+	// A retried reset could restart attempts made in between, so it isn't retried
+	return mutate(ctx, c, "ResetActivity", false, func(sdk client.Client) (*workflowservice.ResetActivityResponse, error) {
+		return sdk.WorkflowService().ResetActivity(ctx, request)
+	})
+}
+
+// DescribeBatchOperation implements Client.
+func (c *SDKClient) DescribeBatchOperation(ctx context.Context, request *workflowservice.DescribeBatchOperationRequest) (*workflowservice.DescribeBatchOperationResponse, error) {
+	return read(ctx, c, "DescribeBatchOperation", func(sdk client.Client) (*workflowservice.DescribeBatchOperationResponse, error) {
+		return sdk.WorkflowService().DescribeBatchOperation(ctx, request)
+	})
+}
+
+// StopBatchOperation implements Client.
+func (c *SDKClient) StopBatchOperation(ctx context.Context, request *workflowservice.StopBatchOperationRequest) (*workflowservice.StopBatchOperationResponse, error) {
+	// Stopping a stopped job leaves it stopped, so retries are safe
+	return mutate(ctx, c, "StopBatchOperation", true, func(sdk client.Client) (*workflowservice.StopBatchOperationResponse, error) {
+		return sdk.WorkflowService().StopBatchOperation(ctx, request)
+	})
+}
+
+// PollWorkflowExecutionUpdate implements Client.
+func (c *SDKClient) PollWorkflowExecutionUpdate(ctx context.Context, request *workflowservice.PollWorkflowExecutionUpdateRequest) (*workflowservice.PollWorkflowExecutionUpdateResponse, error) {
+	return read(ctx, c, "PollWorkflowExecutionUpdate", func(sdk client.Client) (*workflowservice.PollWorkflowExecutionUpdateResponse, error) {
+		return sdk.WorkflowService().PollWorkflowExecutionUpdate(ctx, request)
+	})
+}
+
+// ListNamespaces implements Client.
+func (c *SDKClient) ListNamespaces(ctx context.Context, request *workflowservice.ListNamespacesRequest) (*workflowservice.ListNamespacesResponse, error) {
+	return read(ctx, c, "ListNamespaces", func(sdk client.Client) (*workflowservice.ListNamespacesResponse, error) {
+		return sdk.WorkflowService().ListNamespaces(ctx, request)
+	})
+}
+
+// NamespaceSettings implements Client. Failed lookups aren't cached.
+func (c *SDKClient) NamespaceSettings(ctx context.Context) (*workflowservice.DescribeNamespaceResponse, error) {
+	c.settingsMu.Lock()
+	defer c.settingsMu.Unlock()
+	if c.settings != nil && time.Since(c.settingsFetched) < namespacesTTL {
+		return c.settings, nil
+	}
+	resp, err := c.DescribeNamespace(ctx, c.namespace)
+	if err != nil {
+		return nil, err
+	}
+	c.settings, c.settingsFetched = resp, time.Now()
+	return resp, nil
+}
+
+// DescribeNamespace implements Client.
+func (c *SDKClient) DescribeNamespace(ctx context.Context, namespace string) (*workflowservice.DescribeNamespaceResponse, error) {
+	return read(ctx, c, "DescribeNamespace", func(sdk client.Client) (*workflowservice.DescribeNamespaceResponse, error) {
+		return sdk.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: namespace})
+	})
+}
+
+// UpdateNamespace implements Client.
+func (c *SDKClient) UpdateNamespace(ctx context.Context, request *workflowservice.UpdateNamespaceRequest) (*workflowservice.UpdateNamespaceResponse, error) {
+	// The request sets absolute values, so applying it twice is harmless
+	return mutate(ctx, c, "UpdateNamespace", true, func(sdk client.Client) (*workflowservice.UpdateNamespaceResponse, error) {
+		return sdk.WorkflowService().UpdateNamespace(ctx, request)
+	})
+}
+
+// DeleteNamespace implements Client.
+func (c *SDKClient) DeleteNamespace(ctx context.Context, request *operatorservice.DeleteNamespaceRequest) (*operatorservice.DeleteNamespaceResponse, error) {
+	// A retry would find the namespace already renamed for deletion and fail, so it isn't retried
+	return mutate(ctx, c, "DeleteNamespace", false, func(sdk client.Client) (*operatorservice.DeleteNamespaceResponse, error) {
+		return sdk.OperatorService().DeleteNamespace(ctx, request)
+	})
+}
+
+// ListSearchAttributes implements Client.
+func (c *SDKClient) ListSearchAttributes(ctx context.Context) (*operatorservice.ListSearchAttributesResponse, error) {
+	return read(ctx, c, "ListSearchAttributes", func(sdk client.Client) (*operatorservice.ListSearchAttributesResponse, error) {
+		return sdk.OperatorService().ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{Namespace: c.namespace})
+	})
+}
+
+// ListNexusEndpoints implements Client.
+func (c *SDKClient) ListNexusEndpoints(ctx context.Context, request *operatorservice.ListNexusEndpointsRequest) (*operatorservice.ListNexusEndpointsResponse, error) {
+	return read(ctx, c, "ListNexusEndpoints", func(sdk client.Client) (*operatorservice.ListNexusEndpointsResponse, error) {
+		return sdk.OperatorService().ListNexusEndpoints(ctx, request)
+	})
+}
+
+// GetNexusEndpoint implements Client.
+func (c *SDKClient) GetNexusEndpoint(ctx context.Context, id string) (*operatorservice.GetNexusEndpointResponse, error) {
+	return read(ctx, c, "GetNexusEndpoint", func(sdk client.Client) (*operatorservice.GetNexusEndpointResponse, error) {
+		return sdk.OperatorService().GetNexusEndpoint(ctx, &operatorservice.GetNexusEndpointRequest{Id: id})
+	})
+}
@@ -0,0 +1,166 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// namespacesTTL is how long the list of namespaces registered on the server is cached.
+const namespacesTTL = time.Minute
+
+// ErrNamespaceNotAllowed is returned for namespaces outside the configured allowlist.
+var ErrNamespaceNotAllowed = errors.New("namespace not allowed by this server's namespace policy")
+
+// Provider hands out the Client for the namespace a tool call targets. It is the single place the
+// namespace allowlist is enforced, so no tool can reach a namespace without going through it.
+type Provider interface {
+	// Client returns the client for namespace, or for the default namespace when it is "".
+	Client(namespace string) (Client, error)
+	// Allowed reports whether tools may access namespace.
+	Allowed(namespace string) bool
+	// DefaultNamespace returns the namespace used when a call doesn't name one.
+	DefaultNamespace() string
+	// Namespaces lists the namespaces registered on the server that tools may access, sorted.
+	Namespaces(ctx context.Context) ([]string, error)
+}
+
+// Clients implements Provider on top of a connected SDKClient, creating clients for other
+// namespaces on first use over the same connection.
+type Clients struct {
+	base *SDKClient
+	// allowed is the namespace allowlist; nil allows every namespace.
+	allowed map[string]bool
+
+	mu          sync.Mutex
+	byNamespace map[string]*SDKClient
+
+	// namespaces caches the Namespaces list, fetched at namespacesFetched.
+	namespacesMu      sync.Mutex
+	namespaces        []string
+	namespacesFetched time.Time
+}
+
+var _ Provider = (*Clients)(nil)
+
+// NewClients wraps base, whose namespace becomes the default. When allowed is non-empty, only
+// those namespaces may be used, and the default namespace must be one of them.
+func NewClients(base *SDKClient, allowed []string) (*Clients, error) {
+	c := &Clients{
+		base:        base,
+		byNamespace: map[string]*SDKClient{base.Namespace(): base},
+	}
+	if len(allowed) > 0 {
+		c.allowed = make(map[string]bool, len(allowed))
+		for _, namespace := range allowed {
+			c.allowed[namespace] = true
+		}
+		if !c.allowed[base.Namespace()] {
+			return nil, fmt.Errorf("default namespace %q is not in the allowed namespaces (%s)", base.Namespace(), strings.Join(allowed, ", "))
+		}
+	}
+	// On Temporal Cloud a bare namespace name fails every call with an opaque permission error
+	for _, namespace := range append([]string{base.Namespace()}, allowed...) {
+		if err := CheckCloudNamespace(base.Address(), namespace); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Client implements Provider.
+func (c *Clients) Client(namespace string) (Client, error) {
+	if namespace == "" {
+		return c.base, nil
+	}
+	if !c.Allowed(namespace) {
+		return nil, fmt.Errorf("%w: %q (allowed: %s)", ErrNamespaceNotAllowed, namespace, strings.Join(c.allowedList(), ", "))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if client, ok := c.byNamespace[namespace]; ok {
+		return client, nil
+	}
+	if err := CheckCloudNamespace(c.base.Address(), namespace); err != nil {
+		return nil, err
+	}
+	client, err := c.base.forNamespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+	c.byNamespace[namespace] = client
+	return client, nil
+}
+
+// Allowed implements Provider.
+func (c *Clients) Allowed(namespace string) bool {
+	return c.allowed == nil || c.allowed[namespace]
+}
+
+// DefaultNamespace implements Provider.
+func (c *Clients) DefaultNamespace() string {
+	return c.base.Namespace()
+}
+
+// Namespaces implements Provider. The list is cached for namespacesTTL, as tools look it up on
+// every call naming an unknown namespace; failed lookups aren't cached.
+func (c *Clients) Namespaces(ctx context.Context) ([]string, error) {
+	c.namespacesMu.Lock()
+	defer c.namespacesMu.Unlock()
+	if c.namespaces != nil && time.Since(c.namespacesFetched) < namespacesTTL {
+		return c.namespaces, nil
+	}
+
+	names := []string{}
+	var token []byte
+	for {
+		resp, err := c.base.ListNamespaces(ctx, &workflowservice.ListNamespacesRequest{
+			PageSize:      100,
+			NextPageToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range resp.GetNamespaces() {
+			if name := ns.GetNamespaceInfo().GetName(); c.Allowed(name) {
+				names = append(names, name)
+			}
+		}
+		if token = resp.GetNextPageToken(); len(token) == 0 {
+			break
+		}
+	}
+	sort.Strings(names)
+	c.namespaces, c.namespacesFetched = names, time.Now()
+	return names, nil
+}
+
+// Close closes every client, including the base one.
+func (c *Clients) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for namespace, client := range c.byNamespace {
+		if client != c.base {
+			client.Close()
+		}
+		delete(c.byNamespace, namespace)
+	}
+	c.base.Close()
+}
+
+// allowedList returns the allowlist in sorted order.
+func (c *Clients) allowedList() []string {
+	list := make([]string, 0, len(c.allowed))
+	for namespace := range c.allowed {
+		list = append(list, namespace)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,59 @@
+package temporal
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+const (
+	// cloudNamespaceDomain ends the namespace endpoints of Temporal Cloud, <namespace>.<account>.tmprl.cloud.
+	cloudNamespaceDomain = ".tmprl.cloud"
+	// cloudRegionDomain ends the regional endpoints of Temporal Cloud used with API keys, e.g.
+	// us-east-1.aws.api.temporal.io.
+	cloudRegionDomain = ".api.temporal.io"
+)
+
+// cloudNamespace matches a Temporal Cloud namespace name: the namespace, then the account ID.
+var cloudNamespace = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*\.[a-z0-9]+$`)
+
+// IsCloud reports whether address (host:port) is a Temporal Cloud endpoint.
+func IsCloud(address string) bool {
+	host := cloudHost(address)
+	return strings.HasSuffix(host, cloudNamespaceDomain) || strings.HasSuffix(host, cloudRegionDomain)
+}
+
+// CheckCloudNamespace returns why namespace can't be reached through address when it is a Temporal
+// Cloud endpoint: Cloud namespaces are named <namespace>.<account>, and a namespace endpoint only
+// serves its own namespace. Self-hosted addresses accept any namespace.
+func CheckCloudNamespace(address, namespace string) error {
+	if !IsCloud(address) {
+		return nil
+	}
+	host := cloudHost(address)
+	// The namespace endpoint's host names the namespace it serves
+	endpointNamespace := ""
+	if strings.HasSuffix(host, cloudNamespaceDomain) {
+		endpointNamespace = strings.TrimSuffix(host, cloudNamespaceDomain)
+	}
+	if !cloudNamespace.MatchString(namespace) {
+		if endpointNamespace != "" {
+			return fmt.Errorf("namespace %q is not a Temporal Cloud namespace name: Cloud namespaces include the account ID, as <namespace>.<account>; the endpoint %s serves %q", namespace, host, endpointNamespace)
+		}
+		return fmt.Errorf("namespace %q is not a Temporal Cloud namespace name: Cloud namespaces include the account ID, as <namespace>.<account> (see the namespace page of the Cloud UI)", namespace)
+	}
+	if endpointNamespace != "" && namespace != endpointNamespace {
+		return fmt.Errorf("namespace %q can't be reached through %s, the endpoint of namespace %q: connect to that namespace's own endpoint, or to a regional API key endpoint", namespace, host, endpointNamespace)
+	}
+	return nil
+}
+
+// cloudHost returns the lowercased host of address, which may lack a port.
+func cloudHost(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
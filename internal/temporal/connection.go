@@ -0,0 +1,293 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/sdk/client"
+	tlog "go.temporal.io/sdk/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// dialTimeout bounds each attempt to connect to one frontend address.
+	dialTimeout = 10 * time.Second
+	// failoverThreshold is how many consecutive RPCs must fail with Unavailable before the
+	// connection fails over to the next address, or redials the only one.
+	failoverThreshold = 3
+	// maxReconnectAttempts bounds the dials of a reconnect, cycling through the addresses, so an
+	// outage shorter than about half a minute is recovered from by the same reconnect.
+	maxReconnectAttempts = 8
+	// maxFailoverBackoff caps the delay between attempts on successive addresses.
+	maxFailoverBackoff = 5 * time.Second
+	// retiredClientTTL is how long a client replaced by a reconnect stays open, so calls already
+	// running on it finish instead of being cut off. It outlives the longest tool timeout.
+	retiredClientTTL = 10 * time.Minute
+)
+
+// clientOptions builds the SDK client options for connecting to address. It is the single
+// description of how the server connects to Temporal: the SDK clients use it, and DialGRPC derives
+// the raw gRPC connection used by diagnostics from it, so the two can't drift apart.
+func (o Options) clientOptions(address string) client.Options {
+	clientOpts := client.Options{
+		HostPort:       address,
+		Namespace:      o.Namespace,
+		Identity:       o.Identity,
+		MetricsHandler: o.MetricsHandler,
+		DataConverter:  o.DataConverter,
+		ConnectionOptions: client.ConnectionOptions{
+			KeepAliveTime:                       o.KeepAlive.Time,
+			KeepAliveTimeout:                    o.KeepAlive.Timeout,
+			DisableKeepAlivePermitWithoutStream: !o.KeepAlive.PermitWithoutStream,
+			DialOptions:                         o.DialOptions,
+		},
+	}
+	if o.Headers != nil {
+		clientOpts.HeadersProvider = o.Headers
+	}
+	if o.Logger != nil {
+		clientOpts.Logger = tlog.NewStructuredLogger(o.Logger)
+	}
+	return clientOpts
+}
+
+// DialGRPC opens a raw gRPC connection to address with the same transport security and dial
+// options as the SDK clients, sending the same headers. The caller must close it.
+func (o Options) DialGRPC(address string) (*grpc.ClientConn, error) {
+	connOpts := o.clientOptions(address).ConnectionOptions
+	creds := insecure.NewCredentials()
+	if connOpts.TLS != nil {
+		creds = credentials.NewTLS(connOpts.TLS)
+	}
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, connOpts.DialOptions...)
+	if o.Headers != nil {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(o.Headers.interceptor()))
+	}
+	return grpc.NewClient(address, dialOpts...)
+}
+
+// connection is the SDK connection shared by the clients of every namespace. With several frontend
+// addresses it connects to the first reachable one. When the active client keeps answering
+// Unavailable (e.g. its connection silently died behind a NAT or gateway) it reconnects: it fails
+// over to the next reachable address, or redials the active one when no other is reachable or
+// configured. Reconnects run in the background, one at a time: calls never wait for them, so a
+// redial can't stack on top of the SDK's own retries within a call's deadline.
+type connection struct {
+	opts   Options
+	logger *slog.Logger
+
+	mu     sync.RWMutex
+	active int
+	// epoch identifies the dial of base, telling its RPCs apart from those of replaced clients.
+	epoch uint64
+	base  client.Client
+	// derived holds the clients of namespaces other than the default one, created on first use
+	// over base.
+	derived map[string]client.Client
+
+	reconnects    int
+	lastReconnect time.Time
+
+	// generation changes whenever the connection is lost or reconnects.
+	generation  atomic.Uint64
+	dials       atomic.Uint64
+	unavailable atomic.Int32
+	switching   atomic.Bool
+}
+
+// connect dials the configured addresses in order until one accepts the connection.
+func connect(opts Options) (*connection, error) {
+	c := &connection{opts: opts, logger: opts.Logger, derived: make(map[string]client.Client)}
+	if c.logger == nil {
+		c.logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	var errs []error
+	for i, address := range opts.Addresses {
+		if i > 0 {
+			time.Sleep(failoverBackoff(i - 1))
+		}
+		base, epoch, err := c.dial(i)
+		if err == nil {
+			c.active, c.epoch, c.base = i, epoch, base
+			return c, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", address, err))
+		if i < len(opts.Addresses)-1 {
+			c.logger.Warn("Unable to connect to Temporal, trying the next address", "address", address, "next", opts.Addresses[i+1], "error", err)
+		}
+	}
+	if len(errs) == 1 {
+		return nil, errors.Unwrap(errs[0])
+	}
+	return nil, errors.Join(errs...)
+}
+
+// dial connects to the address at index i, observing its RPCs for reconnects. It returns the epoch
+// identifying this dial.
+func (c *connection) dial(i int) (client.Client, uint64, error) {
+	epoch := c.dials.Add(1)
+	clientOpts := c.opts.clientOptions(c.opts.Addresses[i])
+	clientOpts.ConnectionOptions.DialOptions = append(clientOpts.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(c.interceptor(epoch)))
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+	base, err := client.DialContext(ctx, clientOpts)
+	return base, epoch, err
+}
+
+// interceptor observes the outcome of every RPC of the client dialed in epoch, counting connection
+// losses and triggering a reconnect once the active client keeps answering Unavailable. RPCs still
+// running on a retired client are ignored.
+func (c *connection) interceptor(epoch uint64) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if epoch != c.activeEpoch() {
+			return err
+		}
+		if status.Code(err) != codes.Unavailable {
+			c.unavailable.Store(0)
+			return err
+		}
+		c.generation.Add(1)
+		if c.unavailable.Add(1) >= failoverThreshold && c.switching.CompareAndSwap(false, true) {
+			go c.reconnect()
+		}
+		return err
+	}
+}
+
+// reconnect dials the addresses after the active one in order, then the active one again, cycling
+// with backoff, and swaps in the first client that connects. Calls started before the swap finish
+// on the old client, which is closed once they can no longer be running. When no address becomes
+// reachable the active client is kept, and the next Unavailable streak tries again.
+func (c *connection) reconnect() {
+	defer c.switching.Store(false)
+	from := c.activeIndex()
+	n := len(c.opts.Addresses)
+	for attempt := 1; attempt <= max(n, maxReconnectAttempts); attempt++ {
+		if attempt > 1 {
+			time.Sleep(failoverBackoff(attempt - 2))
+		}
+		i := (from + attempt) % n
+		base, epoch, err := c.dial(i)
+		if err != nil {
+			c.logger.Warn("Unable to reconnect to Temporal address", "address", c.opts.Addresses[i], "error", err)
+			continue
+		}
+
+		c.mu.Lock()
+		retired := append([]client.Client{c.base}, mapValues(c.derived)...)
+		c.active, c.epoch, c.base, c.derived = i, epoch, base, make(map[string]client.Client)
+		c.reconnects++
+		c.lastReconnect = time.Now()
+		c.mu.Unlock()
+		c.generation.Add(1)
+		c.unavailable.Store(0)
+		if i == from {
+			c.logger.Warn("Reconnected to Temporal", "address", c.opts.Addresses[i])
+		} else {
+			c.logger.Warn("Failed over to another Temporal address", "from", c.opts.Addresses[from], "to", c.opts.Addresses[i])
+		}
+		time.AfterFunc(retiredClientTTL, func() {
+			for _, old := range retired {
+				old.Close()
+			}
+		})
+		return
+	}
+	c.unavailable.Store(0)
+	c.logger.Error("No Temporal address is reachable; keeping the current connection", "address", c.opts.Addresses[from])
+}
+
+// client returns the SDK client of namespace on the active address. Clients of other namespaces
+// are derived outside the lock, since deriving one may call the server.
+func (c *connection) client(namespace string) (client.Client, error) {
+	c.mu.RLock()
+	base, active := c.base, c.active
+	derived, ok := c.derived[namespace]
+	c.mu.RUnlock()
+	if namespace == c.opts.Namespace {
+		return base, nil
+	}
+	if ok {
+		return derived, nil
+	}
+
+	opts := c.opts.clientOptions(c.opts.Addresses[active])
+	opts.Namespace = namespace
+	derived, err := client.NewClientFromExisting(base, opts)
+	if err != nil {
+		return nil, fmt.Errorf("create client for namespace %s: %w", namespace, err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.derived[namespace]; ok {
+		derived.Close()
+		return existing, nil
+	}
+	// A client derived from a base retired meanwhile serves this call only, then retires too
+	if c.base != base {
+		time.AfterFunc(retiredClientTTL, derived.Close)
+		return derived, nil
+	}
+	c.derived[namespace] = derived
+	return derived, nil
+}
+
+// activeIndex returns the index of the active address.
+func (c *connection) activeIndex() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.active
+}
+
+// activeEpoch returns the epoch of the active client.
+func (c *connection) activeEpoch() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.epoch
+}
+
+// reconnected returns how many times the connection was rebuilt, and when it last was.
+func (c *connection) reconnected() (int, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.reconnects, c.lastReconnect
+}
+
+// address returns the active address.
+func (c *connection) address() string {
+	return c.opts.Addresses[c.activeIndex()]
+}
+
+// close closes the clients of the active address.
+func (c *connection) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, derived := range c.derived {
+		derived.Close()
+	}
+	c.base.Close()
+}
+
+// failoverBackoff returns the delay before the attempt following the given number of failed ones.
+func failoverBackoff(failed int) time.Duration {
+	return min(250*time.Millisecond<<failed, maxFailoverBackoff)
+}
+
+func mapValues(m map[string]client.Client) []client.Client {
+	values := make([]client.Client, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
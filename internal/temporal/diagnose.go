@@ -0,0 +1,146 @@
+package temporal
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+// diagnosticStageTimeout bounds each diagnostic stage, so one hanging stage can't use up the call.
+const diagnosticStageTimeout = 10 * time.Second
+
+// workflowServiceName is the gRPC health check service name of the Temporal frontend.
+const workflowServiceName = "temporal.api.workflowservice.v1.WorkflowService"
+
+// errSkipped marks a stage that didn't run because an earlier one failed.
+var errSkipped = errors.New("skipped: an earlier stage failed")
+
+// DiagnosticStage is the outcome of one connection check. Err is nil when the stage passed.
+type DiagnosticStage struct {
+	Name     string
+	Detail   string
+	Err      error
+	Duration time.Duration
+}
+
+// Skipped reports whether the stage didn't run because an earlier one failed.
+func (s DiagnosticStage) Skipped() bool {
+	return errors.Is(s.Err, errSkipped)
+}
+
+// Diagnosis is the result of Diagnose.
+type Diagnosis struct {
+	Address   string
+	Namespace string
+	Identity  string
+	// TLS describes the transport security negotiated by the gRPC connection, or why it is unknown.
+	TLS    string
+	Stages []DiagnosticStage
+}
+
+// Diagnose checks the connection to the frontend at address in stages, each building on the previous one:
+// DNS resolution, a TCP dial, the gRPC health check, GetSystemInfo, and DescribeNamespace for
+// namespace. Once a stage fails the remaining ones are reported as skipped. It bypasses the SDK
+// client, rate limiter, and RPC tracking, so it works even when the SDK client is unusable.
+func Diagnose(ctx context.Context, opts Options, address, namespace string) Diagnosis {
+	d := Diagnosis{Address: address, Namespace: namespace, Identity: opts.Identity, TLS: "unknown: the gRPC health check didn't complete"}
+	failed := false
+	stage := func(name string, check func(ctx context.Context) (string, error)) {
+		if failed {
+			d.Stages = append(d.Stages, DiagnosticStage{Name: name, Err: errSkipped})
+			return
+		}
+		stageCtx, cancel := context.WithTimeout(ctx, diagnosticStageTimeout)
+		defer cancel()
+		start := time.Now()
+		detail, err := check(stageCtx)
+		d.Stages = append(d.Stages, DiagnosticStage{Name: name, Detail: detail, Err: err, Duration: time.Since(start)})
+		failed = err != nil
+	}
+
+	host, port, splitErr := net.SplitHostPort(address)
+	stage("DNS resolution", func(ctx context.Context) (string, error) {
+		if splitErr != nil {
+			return "", fmt.Errorf("invalid address %q: %w", address, splitErr)
+		}
+		addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s resolves to %v", host, addrs), nil
+	})
+	stage("TCP dial", func(ctx context.Context) (string, error) {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+		if err != nil {
+			return "", err
+		}
+		defer conn.Close()
+		return fmt.Sprintf("connected to %s", conn.RemoteAddr()), nil
+	})
+
+	var conn *grpc.ClientConn
+	stage("gRPC health check", func(ctx context.Context) (string, error) {
+		c, err := opts.DialGRPC(address)
+		if err != nil {
+			return "", err
+		}
+		conn = c
+		var p peer.Peer
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: workflowServiceName}, grpc.Peer(&p))
+		if err != nil {
+			return "", err
+		}
+		d.TLS = tlsState(p.AuthInfo)
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return "", fmt.Errorf("frontend reports %s", resp.GetStatus())
+		}
+		return "frontend is SERVING", nil
+	})
+	if conn != nil {
+		defer conn.Close()
+	}
+
+	service := workflowservice.NewWorkflowServiceClient(conn)
+	stage("GetSystemInfo", func(ctx context.Context) (string, error) {
+		resp, err := service.GetSystemInfo(ctx, &workflowservice.GetSystemInfoRequest{})
+		if err != nil {
+			return "", err
+		}
+		return "server version " + resp.GetServerVersion(), nil
+	})
+	stage("DescribeNamespace", func(ctx context.Context) (string, error) {
+		resp, err := service.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: namespace})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("namespace %s is %s", namespace, resp.GetNamespaceInfo().GetState()), nil
+	})
+	return d
+}
+
+// tlsState describes the transport security of a connection from its peer's auth info.
+func tlsState(info credentials.AuthInfo) string {
+	tlsInfo, ok := info.(credentials.TLSInfo)
+	if !ok {
+		return "disabled (plaintext connection)"
+	}
+	state := tlsInfo.State
+	out := fmt.Sprintf("%s, cipher suite %s", tls.VersionName(state.Version), tls.CipherSuiteName(state.CipherSuite))
+	if state.ServerName != "" {
+		out += ", server name " + state.ServerName
+	}
+	if len(state.PeerCertificates) > 0 {
+		out += ", server certificate " + state.PeerCertificates[0].Subject.String()
+	}
+	return out
+}
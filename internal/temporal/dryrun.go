@@ -0,0 +1,24 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDryRun is returned by mutating RPCs attempted while the server runs in dry-run mode. Tools check
+// DryRun before writing, so seeing it means a tool skipped that check.
+var ErrDryRun = errors.New("mutating Temporal RPC refused: the server is in dry-run mode")
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context marking the tool call as a dry run: mutating RPCs made with it fail
+// with ErrDryRun instead of reaching Temporal.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// DryRun reports whether ctx belongs to a dry-run tool call.
+func DryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
@@ -0,0 +1,128 @@
+package temporal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// headersCommandTimeout bounds a run of the headers command.
+const headersCommandTimeout = 30 * time.Second
+
+// Headers supplies the gRPC headers sent with every RPC, e.g. the Authorization header an
+// authenticating proxy in front of the frontend requires. It combines static headers with ones
+// minted by a command, which is run again once its headers are older than TTL; minted headers win
+// over static ones of the same name. Header values are secrets: they never appear in errors or logs.
+type Headers struct {
+	static  map[string]string
+	command string
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	minted  map[string]string
+	expires time.Time
+}
+
+// NewHeaders returns a provider of the static headers and, when command is non-empty, the headers
+// printed by running it with sh -c, one "name: value" per line. It returns nil when there are no
+// headers to send.
+func NewHeaders(static map[string]string, command string, ttl time.Duration) *Headers {
+	if len(static) == 0 && command == "" {
+		return nil
+	}
+	return &Headers{static: static, command: command, ttl: ttl}
+}
+
+// GetHeaders implements client.HeadersProvider.
+func (h *Headers) GetHeaders(ctx context.Context) (map[string]string, error) {
+	if h.command == "" {
+		return h.static, nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.minted == nil || !time.Now().Before(h.expires) {
+		minted, err := h.mint(ctx)
+		if err != nil {
+			return nil, err
+		}
+		h.minted, h.expires = minted, time.Now().Add(h.ttl)
+	}
+	headers := maps.Clone(h.static)
+	if headers == nil {
+		headers = make(map[string]string, len(h.minted))
+	}
+	maps.Copy(headers, h.minted)
+	return headers, nil
+}
+
+// mint runs the headers command and parses its output. Errors name the offending line number,
+// never its content, since the output is made of secrets.
+func (h *Headers) mint(ctx context.Context) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, headersCommandTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", h.command)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := firstLine(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("headers command failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("headers command failed: %w", err)
+	}
+
+	minted := make(map[string]string)
+	scanner := bufio.NewScanner(&stdout)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(text, ":")
+		if name = strings.ToLower(strings.TrimSpace(name)); !ok || name == "" {
+			return nil, fmt.Errorf("headers command output line %d is not a \"name: value\" header", line)
+		}
+		minted[name] = strings.TrimSpace(value)
+	}
+	if len(minted) == 0 {
+		return nil, errors.New("headers command printed no headers")
+	}
+	return minted, nil
+}
+
+// interceptor adds the headers to the outgoing metadata of unary RPCs on connections not made by
+// the SDK, which adds them itself.
+func (h *Headers) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		headers, err := h.GetHeaders(ctx)
+		if err != nil {
+			return err
+		}
+		for name, value := range headers {
+			ctx = metadata.AppendToOutgoingContext(ctx, name, value)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// firstLine returns the first non-empty line of s, truncated to keep errors short.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			if len(line) > 200 {
+				line = line[:200] + "..."
+			}
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// outgoing calls the headers interceptor with an invoker standing in for the connection, and
+// returns the metadata the RPC would have carried.
+func outgoing(t *testing.T, h *Headers, ctx context.Context) (metadata.MD, error) {
+	t.Helper()
+	var sent metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	err := h.interceptor()(ctx, "/temporal.api.operatorservice.v1.OperatorService/ListNexusEndpoints", nil, nil, nil, invoker)
+	return sent, err
+}
+
+func TestHeadersReachOutgoingContext(t *testing.T) {
+	// runs counts the command's runs, so each minted token is distinct
+	runs := filepath.Join(t.TempDir(), "runs")
+	minting := fmt.Sprintf(`echo run >> %[1]s; echo "Authorization: Bearer token-$(wc -l < %[1]s | tr -d ' ')"; echo "x-tenant: acme"`, runs)
+	static := map[string]string{"authorization": "Bearer static", "x-region": "eu-west-1"}
+	tests := []struct {
+		name    string
+		static  map[string]string
+		command string
+		want    metadata.MD
+	}{
+		{
+			name:   "static",
+			static: static,
+			want:   metadata.Pairs("authorization", "Bearer static", "x-region", "eu-west-1"),
+		},
+		{
+			// Minted headers win over static ones of the same name, and names are lowercased
+			name:    "minted by a command",
+			static:  static,
+			command: minting,
+			want:    metadata.Pairs("authorization", "Bearer token-1", "x-region", "eu-west-1", "x-tenant", "acme"),
+		},
+		{
+			name:    "minted only",
+			command: `printf 'authorization: Bearer t\n\n'`,
+			want:    metadata.Pairs("authorization", "Bearer t"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHeaders(tt.static, tt.command, time.Hour)
+			// Metadata the call already carries is kept
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+			got, err := outgoing(t, h, ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := metadata.Join(tt.want, metadata.Pairs("x-request-id", "req-1"))
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("outgoing metadata = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestHeadersCommandRerunAfterTTL(t *testing.T) {
+	runs := filepath.Join(t.TempDir(), "runs")
+	command := fmt.Sprintf(`echo run >> %[1]s; echo "authorization: Bearer token-$(wc -l < %[1]s | tr -d ' ')"`, runs)
+	authorization := func(h *Headers) string {
+		t.Helper()
+		md, err := outgoing(t, h, context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.Join(md.Get("authorization"), ",")
+	}
+
+	cached := NewHeaders(nil, command, time.Hour)
+	for range 3 {
+		if got := authorization(cached); got != "Bearer token-1" {
+			t.Fatalf("authorization = %q, want the first token while it is fresh", got)
+		}
+	}
+
+	expiring := NewHeaders(nil, command, 0)
+	if got := authorization(expiring); got != "Bearer token-2" {
+		t.Errorf("authorization = %q, want a new token", got)
+	}
+	if got := authorization(expiring); got != "Bearer token-3" {
+		t.Errorf("authorization = %q, want a new token once the last expired", got)
+	}
+}
+
+func TestHeadersCommandErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{"failure", `echo "token service unreachable" >&2; exit 3`, "headers command failed: exit status 3: token service unreachable"},
+		{"malformed line", `echo "authorization: Bearer ok"; echo "Bearer secret-value"`, `headers command output line 2 is not a "name: value" header`},
+		{"no headers", `echo`, "headers command printed no headers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoked := false
+			invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+				invoked = true
+				return nil
+			}
+			err := NewHeaders(map[string]string{"x-region": "eu-west-1"}, tt.command, time.Hour).interceptor()(context.Background(), "/method", nil, nil, nil, invoker)
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("error = %v, want %q", err, tt.want)
+			}
+			// Header values are secrets and never appear in errors
+			if strings.Contains(err.Error(), "secret-value") || strings.Contains(err.Error(), "Bearer ok") {
+				t.Errorf("error leaks a header value: %v", err)
+			}
+			if invoked {
+				t.Error("RPC sent without its headers")
+			}
+		})
+	}
+}
+
+func TestNewHeadersWithoutHeaders(t *testing.T) {
+	if h := NewHeaders(nil, "", time.Hour); h != nil {
+		t.Errorf("NewHeaders = %+v, want nil when there is nothing to send", h)
+	}
+}
@@ -0,0 +1,23 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+)
+
+type callerKey struct{}
+
+// WithCaller returns a context whose RPCs are attributed to caller (e.g. the MCP client's name and
+// version) in addition to the client's own identity, so history records which agent acted.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallIdentity returns the identity recorded on RPCs made with ctx: base, followed by the caller
+// set with WithCaller, if any. Client implementations use it for Identity.
+func CallIdentity(ctx context.Context, base string) string {
+	if caller, _ := ctx.Value(callerKey{}).(string); caller != "" {
+		return fmt.Sprintf("%s (%s)", base, caller)
+	}
+	return base
+}
@@ -0,0 +1,59 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/wricardo/temporal-mcp/internal/metrics"
+)
+
+// Limiter is a token bucket shared by all tool calls and consulted before every Temporal RPC.
+// A nil *Limiter never limits.
+type Limiter struct {
+	limiter *rate.Limiter
+	maxWait time.Duration
+	metrics *metrics.Metrics
+}
+
+// NewLimiter creates a limiter allowing rps requests per second with the given burst. RPCs that
+// would have to queue longer than maxWait are rejected.
+func NewLimiter(rps float64, burst int, maxWait time.Duration, m *metrics.Metrics) *Limiter {
+	l := &Limiter{
+		limiter: rate.NewLimiter(rate.Limit(rps), burst),
+		maxWait: maxWait,
+		metrics: m,
+	}
+	m.ObserveRateLimiter(func() float64 { return l.limiter.Tokens() })
+	return l
+}
+
+// Wait blocks until the RPC may proceed. If that would take longer than maxWait, it gives the
+// token back and returns an error asking the agent to slow down instead of queueing indefinitely.
+func (l *Limiter) Wait(ctx context.Context, rpc string) error {
+	if l == nil {
+		return nil
+	}
+	reservation := l.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	if delay > l.maxWait {
+		reservation.Cancel()
+		l.metrics.RateLimited()
+		return fmt.Errorf("rate limit exceeded: %s would be delayed %s (limit %g requests/s); slow down and retry later", rpc, delay.Round(time.Millisecond), float64(l.limiter.Limit()))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
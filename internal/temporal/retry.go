@@ -0,0 +1,80 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy retries RPCs failing with transient gRPC errors (Unavailable, ResourceExhausted,
+// Aborted), with exponential backoff and jitter. The zero value makes a single attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles per retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Retryable reports whether err is a transient gRPC error worth retrying. The SDK returns the
+// errors of failed RPCs as serviceerror types, whose code status.Code doesn't see.
+func Retryable(err error) bool {
+	code := status.Code(err)
+	var serviceErr serviceerror.ServiceError
+	if errors.As(err, &serviceErr) {
+		code = serviceErr.Status().Code()
+	}
+	switch code {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Do calls fn until it succeeds, fails with a non-retryable error, or MaxAttempts is reached.
+// Retries stop early when ctx is done, since a retry couldn't complete within the call's deadline.
+// When retries are exhausted the error says how many attempts were made, wrapping the last one.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !Retryable(err) {
+			return err
+		}
+		if attempt >= p.MaxAttempts {
+			if attempt == 1 {
+				return err
+			}
+			return fmt.Errorf("%w (gave up after %d attempts)", err, attempt)
+		}
+		timer := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if attempt == 1 {
+				return fmt.Errorf("%w (not retried: %v)", err, ctx.Err())
+			}
+			return fmt.Errorf("%w (gave up after %d attempts: %v)", err, attempt, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay after the given failed attempt: exponential, capped at MaxBackoff,
+// with jitter in the upper half so concurrent callers don't retry in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff << (attempt - 1)
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
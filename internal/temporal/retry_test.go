@@ -0,0 +1,208 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flaky is an RPC that fails with err for its first failures calls, then succeeds.
+type flaky struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flaky) call() error {
+	f.calls++
+	if f.calls <= f.failures {
+		return f.err
+	}
+	return nil
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"service error", serviceerror.NewUnavailable("connection refused"), true},
+		{"wrapped service error", fmt.Errorf("list page 2: %w", serviceerror.NewResourceExhausted(0, "rate limited")), true},
+		{"gRPC status", status.Error(codes.Aborted, "conflict"), true},
+		{"not found", serviceerror.NewNotFound("workflow not found"), false},
+		{"gRPC status not found", status.Error(codes.NotFound, "workflow not found"), false},
+		{"deadline exceeded", context.DeadlineExceeded, false},
+		{"no code", errors.New("decode response"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Retryable(tt.err); got != tt.want {
+				t.Errorf("Retryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	unavailable := serviceerror.NewUnavailable("connection refused")
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		rpc       *flaky
+		wantCalls int
+		// wantErr is the expected error message, "" for success
+		wantErr string
+	}{
+		{
+			name:      "succeeds at once",
+			policy:    policy,
+			rpc:       &flaky{},
+			wantCalls: 1,
+		},
+		{
+			name:      "succeeds after transient failures",
+			policy:    policy,
+			rpc:       &flaky{failures: 3, err: unavailable},
+			wantCalls: 4,
+		},
+		{
+			name:      "rate limited",
+			policy:    policy,
+			rpc:       &flaky{failures: 1, err: serviceerror.NewResourceExhausted(0, "namespace rate limit exceeded")},
+			wantCalls: 2,
+		},
+		{
+			name:      "gives up after max attempts",
+			policy:    policy,
+			rpc:       &flaky{failures: 10, err: unavailable},
+			wantCalls: 4,
+			wantErr:   "connection refused (gave up after 4 attempts)",
+		},
+		{
+			name:      "non-retryable code",
+			policy:    policy,
+			rpc:       &flaky{failures: 10, err: serviceerror.NewNotFound("workflow not found")},
+			wantCalls: 1,
+			wantErr:   "workflow not found",
+		},
+		{
+			name:      "invalid argument",
+			policy:    policy,
+			rpc:       &flaky{failures: 10, err: serviceerror.NewInvalidArgument("invalid query")},
+			wantCalls: 1,
+			wantErr:   "invalid query",
+		},
+		{
+			name:      "error without a code",
+			policy:    policy,
+			rpc:       &flaky{failures: 10, err: errors.New("decode response")},
+			wantCalls: 1,
+			wantErr:   "decode response",
+		},
+		{
+			// The zero value makes a single attempt, and the error isn't annotated
+			name:      "zero policy",
+			rpc:       &flaky{failures: 10, err: unavailable},
+			wantCalls: 1,
+			wantErr:   "connection refused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Do(context.Background(), tt.rpc.call)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				// The last error stays inspectable behind the annotation
+				if !errors.Is(err, tt.rpc.err) {
+					t.Errorf("error %v doesn't wrap %v", err, tt.rpc.err)
+				}
+			}
+			if tt.rpc.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", tt.rpc.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDoStopsWhenContextDone(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: 20 * time.Millisecond, MaxBackoff: 20 * time.Millisecond}
+	unavailable := serviceerror.NewUnavailable("connection refused")
+	tests := []struct {
+		name string
+		// doneAfter is the call after which the context is done, so the backoff that follows stops
+		doneAfter int
+		deadline  bool
+		want      string
+	}{
+		{name: "cancelled during the first backoff", doneAfter: 1, want: "connection refused (not retried: context canceled)"},
+		{name: "cancelled during a later backoff", doneAfter: 3, want: "connection refused (gave up after 3 attempts: context canceled)"},
+		{name: "deadline during a backoff", doneAfter: 2, deadline: true, want: "connection refused (gave up after 2 attempts: context deadline exceeded)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.deadline {
+				ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+			}
+			defer cancel()
+			rpc := &flaky{failures: 10, err: unavailable}
+			call := func() error {
+				err := rpc.call()
+				if rpc.calls == tt.doneAfter {
+					if tt.deadline {
+						<-ctx.Done()
+					}
+					cancel()
+				}
+				return err
+			}
+
+			err := policy.Do(ctx, call)
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("error = %v, want %q", err, tt.want)
+			}
+			if !errors.Is(err, unavailable) {
+				t.Errorf("error %v doesn't wrap the RPC error", err)
+			}
+			if rpc.calls != tt.doneAfter {
+				t.Errorf("calls = %d, want %d", rpc.calls, tt.doneAfter)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		attempt int
+		// The jitter keeps each delay within the upper half of its exponential step
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{4, 400 * time.Millisecond, 800 * time.Millisecond},
+		{5, 500 * time.Millisecond, time.Second},
+		{60, 500 * time.Millisecond, time.Second},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			if d := policy.backoff(tt.attempt); d < tt.min || d > tt.max {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, d, tt.min, tt.max)
+			}
+		}
+	}
+}
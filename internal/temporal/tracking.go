@@ -0,0 +1,56 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// CallTracker records which Temporal RPC a tool call is currently waiting on, and how far a
+// multi-RPC handler has progressed, so an interrupted call can say where it stopped.
+type CallTracker struct {
+	mu       sync.Mutex
+	rpc      string
+	progress string
+}
+
+type callTrackerKey struct{}
+
+// WithCallTracker returns a context carrying a new tracker for one tool call.
+func WithCallTracker(ctx context.Context) (context.Context, *CallTracker) {
+	tracker := &CallTracker{}
+	return context.WithValue(ctx, callTrackerKey{}, tracker), tracker
+}
+
+// trackRPC marks rpc as in flight for the tool call owning ctx.
+func trackRPC(ctx context.Context, rpc string) {
+	if tracker, ok := ctx.Value(callTrackerKey{}).(*CallTracker); ok {
+		tracker.mu.Lock()
+		tracker.rpc = rpc
+		tracker.mu.Unlock()
+	}
+}
+
+// ReportProgress records a short description of the partial progress made so far
+// (e.g. "fetched 3 of ~12 history pages"), reported if the call is cancelled or times out.
+func ReportProgress(ctx context.Context, format string, args ...interface{}) {
+	if tracker, ok := ctx.Value(callTrackerKey{}).(*CallTracker); ok {
+		tracker.mu.Lock()
+		tracker.progress = fmt.Sprintf(format, args...)
+		tracker.mu.Unlock()
+	}
+}
+
+// Interruption describes where an interrupted call stopped: the RPC in flight and any recorded progress.
+func (t *CallTracker) Interruption() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rpc := t.rpc
+	if rpc == "" {
+		rpc = "unknown"
+	}
+	if t.progress == "" {
+		return "while waiting on " + rpc
+	}
+	return fmt.Sprintf("while waiting on %s (%s before interruption)", rpc, t.progress)
+}
@@ -0,0 +1,338 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc/codes"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// activityControlMinVersion is the first Temporal server release with the activity pause, unpause,
+// and reset APIs.
+var activityControlMinVersion = temporal.Version{1, 27}
+
+// activityAction is an operation of the activity management tools on one pending activity.
+type activityAction struct {
+	// name is pause, unpause, or reset.
+	name string
+	// options are the flags of the call that were set, and effect what the action does.
+	options []string
+	effect  string
+	// refuse returns why the action doesn't apply to the activity, or "" if it does.
+	refuse func(activity *workflowpb.PendingActivityInfo) string
+	// perform makes the RPC.
+	perform func(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, activityID string) error
+}
+
+// activityControlOptions returns the tool options shared by the activity management tools.
+func activityControlOptions(name, description string, extra ...mcp.ToolOption) []mcp.ToolOption {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(description),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithString("activity_id",
+			mcp.Required(),
+			mcp.Description("ID of the pending activity, as listed by describe_workflow"),
+		),
+	}
+	options = append(options, extra...)
+	return append(options,
+		mcp.WithBoolean("confirm",
+			mcp.Description(fmt.Sprintf("Set to true to %s the activity; otherwise only a preview is returned", name)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// controlActivity previews or performs action on a pending activity. An activity that stops being
+// pending between the preview (or the describe of this call) and the action is reported as gone
+// rather than as a failure.
+func controlActivity(ctx context.Context, req mcp.CallToolRequest, clients temporal.Provider, logger *slog.Logger, payloads *payload.Renderer, action activityAction) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	activityID, err := stringArgument(args, "activity_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if activityID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'activity_id' parameter (describe_workflow lists the pending activities)"), nil
+	}
+	confirm, err := boolArgument(args, "confirm")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Pin the run so the preview and the action refer to the same execution
+	resp, errResult := describeExecution(ctx, client, logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	execution := pinnedExecution(resp)
+	activity := pendingActivity(resp, activityID)
+	if activity == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Activity %s is not pending in workflow %s (run %s, %s): it completed, failed, or never existed; describe_workflow lists the pending activities",
+			activityID, wfID, execution.GetRunId(), format.WorkflowStatus(resp.GetWorkflowExecutionInfo().GetStatus()))), nil
+	}
+	if reason := action.refuse(activity); reason != "" {
+		return mcp.NewToolResultError(fmt.Sprintf("Can't %s activity %s of workflow %s: %s", action.name, activityID, wfID, reason)), nil
+	}
+	view := format.ActivityControl{
+		Action:     action.name,
+		WorkflowID: wfID,
+		RunID:      execution.GetRunId(),
+		Activity:   format.NewPendingActivity(activity, payloads),
+		Options:    action.options,
+		Effect:     action.effect,
+	}
+	if !confirm {
+		return renderResult(outFormat, view, format.ActivityControlText)
+	}
+	if temporal.DryRun(ctx) {
+		logger.Info("Dry run: skipped activity "+action.name, "workflow_id", wfID, "run_id", view.RunID, "activity_id", activityID)
+		view.DryRun = true
+		return renderResult(outFormat, view, format.ActivityControlText)
+	}
+
+	actionErr := action.perform(ctx, client, execution, activityID)
+	// Describe again, to report the activity's new state or find it gone
+	after, err := client.DescribeWorkflowExecution(ctx, wfID, execution.GetRunId())
+	if actionErr != nil {
+		if err == nil && pendingActivity(after, activityID) == nil {
+			logger.Info("Activity no longer pending; nothing to "+action.name, "workflow_id", wfID, "run_id", view.RunID, "activity_id", activityID, "error", actionErr)
+			view.Gone = true
+			return renderResult(outFormat, view, format.ActivityControlText)
+		}
+		logger.Error("Error performing activity "+action.name, "workflow_id", wfID, "run_id", view.RunID, "activity_id", activityID, "error", actionErr)
+		e := translateError(fmt.Sprintf("Failed to %s activity", action.name), actionErr)
+		if code, _ := errorCode(actionErr); code == codes.Unimplemented {
+			// Temporal 1.27 ships the activity APIs disabled behind a feature flag
+			e.Hint = "the server's activity APIs are disabled; enable them with the dynamic config frontend.activityAPIsEnabled=true"
+		}
+		return errorResult(e), nil
+	}
+	logger.Info("Activity "+action.name+" performed", "workflow_id", wfID, "run_id", view.RunID, "activity_id", activityID, "options", action.options)
+	view.Performed = true
+	if err != nil {
+		logger.Warn("Unable to describe workflow after activity "+action.name, "workflow_id", wfID, "error", err)
+	} else if activity := pendingActivity(after, activityID); activity != nil {
+		now := format.NewPendingActivity(activity, payloads)
+		view.After = &now
+	}
+	return renderResult(outFormat, view, format.ActivityControlText)
+}
+
+// pendingActivity returns the pending activity of resp with the given ID, or nil.
+func pendingActivity(resp *workflowservice.DescribeWorkflowExecutionResponse, activityID string) *workflowpb.PendingActivityInfo {
+	for _, activity := range resp.GetPendingActivities() {
+		if activity.GetActivityId() == activityID {
+			return activity
+		}
+	}
+	return nil
+}
+
+// PauseActivity implements the "pause_activity" tool.
+type PauseActivity struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *PauseActivity) Definition() mcp.Tool {
+	return mcp.NewTool("pause_activity", activityControlOptions("pause",
+		"Pause a pending activity, e.g. one hot-looping through retries: its running attempt may finish, but no new attempts are scheduled until unpause_activity, and the workflow is otherwise untouched. Without confirm=true, returns a preview and changes nothing",
+	)...)
+}
+
+// Mutating implements Mutating.
+func (t *PauseActivity) Mutating() {}
+
+// ServerRequirement implements RequiresServer.
+func (t *PauseActivity) ServerRequirement() ServerRequirement {
+	return ServerRequirement{MinVersion: activityControlMinVersion}
+}
+
+// Handle implements Tool.
+func (t *PauseActivity) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return controlActivity(ctx, req, t.Clients, t.Logger, t.Payloads, activityAction{
+		name:   "pause",
+		effect: "no new attempts are scheduled until the activity is unpaused; a running attempt may still complete it",
+		refuse: func(activity *workflowpb.PendingActivityInfo) string {
+			if activity.GetPaused() {
+				return "it is already paused"
+			}
+			return ""
+		},
+		perform: func(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, activityID string) error {
+			_, err := client.PauseActivity(ctx, &workflowservice.PauseActivityRequest{
+				Namespace: client.Namespace(),
+				Execution: execution,
+				Identity:  client.Identity(ctx),
+				Activity:  &workflowservice.PauseActivityRequest_Id{Id: activityID},
+			})
+			return err
+		},
+	})
+}
+
+// UnpauseActivity implements the "unpause_activity" tool.
+type UnpauseActivity struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *UnpauseActivity) Definition() mcp.Tool {
+	return mcp.NewTool("unpause_activity", activityControlOptions("unpause",
+		"Unpause an activity paused with pause_activity, so its attempts are scheduled again. Without confirm=true, returns a preview and changes nothing",
+		mcp.WithBoolean("reset_attempts",
+			mcp.Description("Also restart the activity's attempt count from 1"),
+		),
+		mcp.WithBoolean("reset_heartbeat",
+			mcp.Description("Also discard the activity's recorded heartbeat details"),
+		),
+	)...)
+}
+
+// Mutating implements Mutating.
+func (t *UnpauseActivity) Mutating() {}
+
+// ServerRequirement implements RequiresServer.
+func (t *UnpauseActivity) ServerRequirement() ServerRequirement {
+	return ServerRequirement{MinVersion: activityControlMinVersion}
+}
+
+// Handle implements Tool.
+func (t *UnpauseActivity) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	resetAttempts, err := boolArgument(args, "reset_attempts")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resetHeartbeat, err := boolArgument(args, "reset_heartbeat")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	action := activityAction{
+		name:   "unpause",
+		effect: "the activity's next attempt is scheduled again",
+		refuse: func(activity *workflowpb.PendingActivityInfo) string {
+			if !activity.GetPaused() {
+				return "it is not paused"
+			}
+			return ""
+		},
+		perform: func(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, activityID string) error {
+			_, err := client.UnpauseActivity(ctx, &workflowservice.UnpauseActivityRequest{
+				Namespace:      client.Namespace(),
+				Execution:      execution,
+				Identity:       client.Identity(ctx),
+				Activity:       &workflowservice.UnpauseActivityRequest_Id{Id: activityID},
+				ResetAttempts:  resetAttempts,
+				ResetHeartbeat: resetHeartbeat,
+			})
+			return err
+		},
+	}
+	if resetAttempts {
+		action.options = append(action.options, "reset_attempts")
+		action.effect += ", with its attempt count restarted from 1"
+	}
+	if resetHeartbeat {
+		action.options = append(action.options, "reset_heartbeat")
+	}
+	return controlActivity(ctx, req, t.Clients, t.Logger, t.Payloads, action)
+}
+
+// ResetActivity implements the "reset_activity" tool.
+type ResetActivity struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *ResetActivity) Definition() mcp.Tool {
+	return mcp.NewTool("reset_activity", activityControlOptions("reset",
+		"Reset a pending activity: its attempt count restarts from 1 and, unless an attempt is running, it is scheduled again right away. A paused activity is unpaused unless keep_paused=true. Without confirm=true, returns a preview and changes nothing",
+		mcp.WithBoolean("reset_heartbeat",
+			mcp.Description("Also discard the activity's recorded heartbeat details"),
+		),
+		mcp.WithBoolean("keep_paused",
+			mcp.Description("Keep a paused activity paused after the reset"),
+		),
+	)...)
+}
+
+// Mutating implements Mutating.
+func (t *ResetActivity) Mutating() {}
+
+// ServerRequirement implements RequiresServer.
+func (t *ResetActivity) ServerRequirement() ServerRequirement {
+	return ServerRequirement{MinVersion: activityControlMinVersion}
+}
+
+// Handle implements Tool.
+func (t *ResetActivity) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	resetHeartbeat, err := boolArgument(args, "reset_heartbeat")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	keepPaused, err := boolArgument(args, "keep_paused")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	action := activityAction{
+		name:   "reset",
+		effect: "the attempt count restarts from 1 and the activity is scheduled again unless an attempt is running",
+		refuse: func(*workflowpb.PendingActivityInfo) string { return "" },
+		perform: func(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, activityID string) error {
+			_, err := client.ResetActivity(ctx, &workflowservice.ResetActivityRequest{
+				Namespace:      client.Namespace(),
+				Execution:      execution,
+				Identity:       client.Identity(ctx),
+				Activity:       &workflowservice.ResetActivityRequest_Id{Id: activityID},
+				ResetHeartbeat: resetHeartbeat,
+				KeepPaused:     keepPaused,
+			})
+			return err
+		},
+	}
+	if resetHeartbeat {
+		action.options = append(action.options, "reset_heartbeat")
+	}
+	if keepPaused {
+		action.options = append(action.options, "keep_paused")
+		action.effect += "; a paused activity stays paused"
+	}
+	return controlActivity(ctx, req, t.Clients, t.Logger, t.Payloads, action)
+}
@@ -0,0 +1,266 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultAggregateLimit is how many values aggregate_search_attribute reports when limit isn't given.
+	defaultAggregateLimit = 20
+	// maxAggregateScan caps how many executions are listed to count values on servers that can't
+	// GROUP BY the attribute.
+	maxAggregateScan = 5000
+	// maxAttributeSuggestions caps the close matches suggested for an unknown attribute.
+	maxAttributeSuggestions = 3
+)
+
+// unaggregatableTypes explains why search attributes of some types can't be aggregated.
+var unaggregatableTypes = map[enumspb.IndexedValueType]string{
+	enumspb.INDEXED_VALUE_TYPE_TEXT:     "Text attributes are tokenized for full-text search, so their values can't be grouped; aggregate a Keyword attribute instead",
+	enumspb.INDEXED_VALUE_TYPE_DOUBLE:   "Double attributes hold continuous values that rarely repeat, so counting distinct values isn't meaningful",
+	enumspb.INDEXED_VALUE_TYPE_DATETIME: "Datetime attributes hold continuous values that rarely repeat, so counting distinct values isn't meaningful",
+}
+
+// AggregateSearchAttribute implements the "aggregate_search_attribute" tool.
+type AggregateSearchAttribute struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *AggregateSearchAttribute) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"aggregate_search_attribute",
+		mcp.WithDescription("Count executions per distinct value of a search attribute (e.g. which CustomerIds have running workflows), most frequent first. Works on Keyword, KeywordList, Int, and Bool attributes"),
+		mcp.WithString("attribute",
+			mcp.Required(),
+			mcp.Description("Search attribute to aggregate, e.g. CustomerId or WorkflowType"),
+		),
+		mcp.WithString("status",
+			mcp.Description("Only count executions in this status (e.g. Running)"),
+			mcp.Enum(executionStatuses...),
+		),
+		mcp.WithString("workflow_type",
+			mcp.Description("Only count executions of this workflow type"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Only count executions started within this Go duration, e.g. 24h"),
+		),
+		mcp.WithNumber("limit",
+			integer(),
+			mcp.Min(1),
+			mcp.Description(fmt.Sprintf("How many values to report, most frequent first (default %d)", defaultAggregateLimit)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *AggregateSearchAttribute) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// MaxTimeout implements LongRunning; without GROUP BY support thousands of executions are listed.
+func (t *AggregateSearchAttribute) MaxTimeout() time.Duration {
+	return 5 * time.Minute
+}
+
+// Handle implements Tool.
+func (t *AggregateSearchAttribute) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	attribute, err := stringArgument(args, "attribute")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if attribute == "" {
+		return mcp.NewToolResultError("Missing or invalid 'attribute' parameter"), nil
+	}
+	var filters []string
+	raw, err := stringArgument(args, "status")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if raw != "" {
+		status, ok := executionStatus(raw)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'status' parameter %q (use one of %s)", raw, strings.Join(executionStatuses, ", "))), nil
+		}
+		filters = append(filters, "ExecutionStatus = "+queryString(status))
+	}
+	workflowType, err := stringArgument(args, "workflow_type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if workflowType != "" {
+		filters = append(filters, "WorkflowType = "+queryString(workflowType))
+	}
+	window, err := durationArgument(args, "window", 0)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if window > 0 {
+		filters = append(filters, fmt.Sprintf("StartTime > %q", time.Now().Add(-window).UTC().Format(time.RFC3339)))
+	}
+	limit, err := intArgument(args, "limit", defaultAggregateLimit, 1, 0)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate the attribute first: visibility errors for unknown attributes don't suggest fixes
+	attrs, err := client.ListSearchAttributes(ctx)
+	if err != nil {
+		t.Logger.Error("Error listing search attributes", "error", err)
+		return rpcError("Failed to list search attributes", err), nil
+	}
+	valueType, ok := attrs.GetCustomAttributes()[attribute]
+	if !ok {
+		valueType, ok = attrs.GetSystemAttributes()[attribute]
+	}
+	if !ok {
+		return mcp.NewToolResultError(unknownAttributeMessage(attribute, attrs.GetSystemAttributes(), attrs.GetCustomAttributes())), nil
+	}
+	if reason, unsupported := unaggregatableTypes[valueType]; unsupported {
+		return mcp.NewToolResultError(fmt.Sprintf("Unable to aggregate %s: %s", attribute, reason)), nil
+	}
+
+	query := strings.Join(filters, " AND ")
+	view := format.AttributeAggregate{Attribute: attribute, Type: valueType.String(), Query: query, Values: []format.AttributeValueCount{}, Method: "group_by"}
+	// GROUP BY support varies per attribute (some servers only group by ExecutionStatus), so every
+	// call probes it
+	var support atomic.Int32
+	counts, err := countGrouped(ctx, client, t.Logger, query, attribute, &support)
+	if err == nil && counts == nil {
+		view.Method = "scan"
+		counts, err = t.scan(ctx, client, query, attribute, &view)
+	}
+	if err != nil {
+		t.Logger.Error("Error aggregating search attribute", "attribute", attribute, "query", query, "error", err)
+		return rpcError(fmt.Sprintf("Failed to aggregate %s", attribute), err), nil
+	}
+
+	for value, count := range counts {
+		view.Values = append(view.Values, format.AttributeValueCount{Value: value, Count: count})
+	}
+	sort.Slice(view.Values, func(i, j int) bool {
+		if view.Values[i].Count != view.Values[j].Count {
+			return view.Values[i].Count > view.Values[j].Count
+		}
+		return view.Values[i].Value < view.Values[j].Value
+	})
+	view.Distinct = len(view.Values)
+	if len(view.Values) > limit {
+		for _, v := range view.Values[limit:] {
+			view.Other += v.Count
+		}
+		view.Values = view.Values[:limit]
+	}
+	return renderResult(outFormat, view, format.AttributeAggregateText)
+}
+
+// scan counts the values of attribute across up to maxAggregateScan executions matching query,
+// recording the coverage in view. Each item of a KeywordList counts separately.
+func (t *AggregateSearchAttribute) scan(ctx context.Context, client temporal.Client, query, attribute string, view *format.AttributeAggregate) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	var token []byte
+	for {
+		resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     client.Namespace(),
+			PageSize:      int32(min(maxListPageSize, maxAggregateScan-view.Scanned)),
+			NextPageToken: token,
+			Query:         query,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range resp.GetExecutions() {
+			view.Scanned++
+			values := searchAttributeValues(info.GetSearchAttributes().GetIndexedFields()[attribute])
+			if field, ok := executionField(info, attribute); ok {
+				values = []string{field}
+			}
+			if len(values) == 0 {
+				view.Unset++
+			}
+			for _, value := range values {
+				counts[value]++
+			}
+		}
+		token = resp.GetNextPageToken()
+		if len(token) == 0 {
+			return counts, nil
+		}
+		if view.Scanned >= maxAggregateScan {
+			view.Truncated = true
+			return counts, nil
+		}
+		reportProgress(ctx, float64(view.Scanned), maxAggregateScan, "scanned %d executions", view.Scanned)
+	}
+}
+
+// executionField returns the value of a system search attribute that visibility records carry as
+// a field rather than among their search attributes.
+func executionField(info *workflowpb.WorkflowExecutionInfo, attribute string) (string, bool) {
+	switch attribute {
+	case "WorkflowType":
+		return info.GetType().GetName(), true
+	case "ExecutionStatus":
+		return format.WorkflowStatus(info.GetStatus()), true
+	case "TaskQueue":
+		return info.GetTaskQueue(), true
+	case "WorkflowId":
+		return info.GetExecution().GetWorkflowId(), true
+	case "RunId":
+		return info.GetExecution().GetRunId(), true
+	default:
+		return "", false
+	}
+}
+
+// unknownAttributeMessage explains that attribute doesn't exist, suggesting the closest known names.
+func unknownAttributeMessage(attribute string, known ...map[string]enumspb.IndexedValueType) string {
+	var names []string
+	for _, m := range known {
+		for name := range m {
+			names = append(names, name)
+		}
+	}
+	lower := strings.ToLower(attribute)
+	sort.Slice(names, func(i, j int) bool {
+		di, dj := editDistance(lower, strings.ToLower(names[i])), editDistance(lower, strings.ToLower(names[j]))
+		if di != dj {
+			return di < dj
+		}
+		return names[i] < names[j]
+	})
+	message := fmt.Sprintf("Unknown search attribute %q", attribute)
+	if len(names) > maxAttributeSuggestions {
+		names = names[:maxAttributeSuggestions]
+	}
+	if len(names) > 0 {
+		message += ". Did you mean: " + strings.Join(names, ", ") + "?"
+	}
+	return message
+}
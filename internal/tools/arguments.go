@@ -0,0 +1,195 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool arguments arrive as decoded JSON, and clients don't always send the type the schema
+// declares: numbers come as "20", booleans as "true", IDs as numbers. The helpers below coerce
+// these common representations, treat null and missing arguments alike, and report anything else
+// as "invalid value for 'x': expected integer, got array".
+
+// integer declares a number property as an integer in the tool's input schema.
+func integer() mcp.PropertyOption {
+	return func(schema map[string]any) {
+		schema["type"] = "integer"
+	}
+}
+
+// invalidArgument is the error for an argument value that isn't of the expected type.
+func invalidArgument(name, expected string, value interface{}) error {
+	if s, ok := value.(string); ok {
+		return fmt.Errorf("invalid value for '%s': expected %s, got string %q", name, expected, s)
+	}
+	return fmt.Errorf("invalid value for '%s': expected %s, got %s", name, expected, jsonType(value))
+}
+
+// jsonType names the JSON type of a decoded argument value.
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// stringArgument returns the optional string argument name, "" when not given. Numbers and
+// booleans are accepted in their JSON spelling, so a numeric ID sent as 42 reads as "42".
+func stringArgument(args map[string]interface{}, name string) (string, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", invalidArgument(name, "string", v)
+	}
+}
+
+// numberArgument parses the optional number argument name, accepting JSON numbers and numeric
+// strings; ok is false when it isn't given. expected names the type in errors.
+func numberArgument(args map[string]interface{}, name, expected string) (value float64, ok bool, err error) {
+	switch v := args[name].(type) {
+	case nil:
+		return 0, false, nil
+	case float64:
+		return v, true, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return 0, false, nil
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+			return 0, false, invalidArgument(name, expected, v)
+		}
+		return parsed, true, nil
+	default:
+		return 0, false, invalidArgument(name, expected, v)
+	}
+}
+
+// intArgument parses the optional integer argument name, returning def when it isn't given.
+// Values below minimum or, when maximum is positive, above maximum are rejected.
+func intArgument(args map[string]interface{}, name string, def, minimum, maximum int) (int, error) {
+	number, ok, err := numberArgument(args, name, "integer")
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return def, nil
+	}
+	if number != math.Trunc(number) {
+		return 0, fmt.Errorf("invalid value for '%s': expected integer, got %v", name, number)
+	}
+	if number < float64(minimum) || (maximum > 0 && number > float64(maximum)) {
+		if maximum > 0 {
+			return 0, fmt.Errorf("invalid value for '%s': must be between %d and %d", name, minimum, maximum)
+		}
+		return 0, fmt.Errorf("invalid value for '%s': must be at least %d", name, minimum)
+	}
+	return int(number), nil
+}
+
+// boolArgument parses the optional boolean argument name, accepting JSON booleans and strings
+// such as "true" or "false"; it is false when not given.
+func boolArgument(args map[string]interface{}, name string) (bool, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return false, nil
+		}
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, invalidArgument(name, "boolean", v)
+		}
+		return parsed, nil
+	default:
+		return false, invalidArgument(name, "boolean", v)
+	}
+}
+
+// durationArgument parses the optional positive Go duration argument name, such as 10m, returning
+// def when it isn't given.
+func durationArgument(args map[string]interface{}, name string, def time.Duration) (time.Duration, error) {
+	raw, ok := args[name].(string)
+	if !ok && args[name] != nil {
+		return 0, invalidArgument(name, "positive duration such as 10m", args[name])
+	}
+	if raw == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil || d <= 0 {
+		return 0, invalidArgument(name, "positive duration such as 10m", raw)
+	}
+	return d, nil
+}
+
+// stringListArgument returns the items of a list argument, given as a comma-separated string or a
+// JSON array of strings, trimmed, without empty items and duplicates.
+func stringListArgument(args map[string]interface{}, name string) ([]string, error) {
+	var raw []string
+	switch v := args[name].(type) {
+	case nil:
+	case string:
+		raw = strings.Split(v, ",")
+	case []interface{}:
+		for _, item := range v {
+			s, err := stringArgument(map[string]interface{}{name: item}, name)
+			if err != nil {
+				return nil, invalidArgument(name, "list of strings", v)
+			}
+			raw = append(raw, s)
+		}
+	default:
+		return nil, invalidArgument(name, "comma-separated string", v)
+	}
+
+	var items []string
+	for _, item := range raw {
+		if item = strings.TrimSpace(item); item != "" && !slices.Contains(items, item) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// executionArguments returns the required workflow_id and optional run_id arguments of tools
+// addressing one workflow execution.
+func executionArguments(args map[string]interface{}) (workflowID, runID string, err error) {
+	if workflowID, err = stringArgument(args, "workflow_id"); err != nil {
+		return "", "", err
+	}
+	if workflowID == "" {
+		return "", "", errors.New("Missing or invalid 'workflow_id' parameter")
+	}
+	if runID, err = stringArgument(args, "run_id"); err != nil {
+		return "", "", err
+	}
+	return workflowID, runID, nil
+}
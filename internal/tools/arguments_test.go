@@ -0,0 +1,283 @@
+package tools
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// argumentsWith builds the decoded arguments of a call with value under "x", or none when value
+// is missingArgument.
+func argumentsWith(value any) map[string]any {
+	if value == missingArgument {
+		return map[string]any{}
+	}
+	return map[string]any{"x": value}
+}
+
+// missingArgument stands for an argument that isn't in the call at all, as opposed to a null one.
+var missingArgument = &struct{}{}
+
+func TestStringArgument(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		wantErr string
+	}{
+		{"missing", missingArgument, "", ""},
+		{"null", nil, "", ""},
+		{"string", "order-1", "order-1", ""},
+		{"empty string", "", "", ""},
+		{"integer number", 42.0, "42", ""},
+		{"fractional number", 2.5, "2.5", ""},
+		{"large number without exponent", 12345678901.0, "12345678901", ""},
+		{"boolean", true, "true", ""},
+		{"array", []any{"a"}, "", "invalid value for 'x': expected string, got array"},
+		{"object", map[string]any{"a": 1.0}, "", "invalid value for 'x': expected string, got object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stringArgument(argumentsWith(tt.value), "x")
+			assertArgument(t, got, err, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestIntArgument(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    int
+		wantErr string
+	}{
+		{"missing uses the default", missingArgument, 50, ""},
+		{"null uses the default", nil, 50, ""},
+		{"number", 20.0, 20, ""},
+		{"numeric string", "20", 20, ""},
+		{"numeric string with spaces", " 20 ", 20, ""},
+		{"integral float string", "20.0", 20, ""},
+		{"empty string uses the default", "", 50, ""},
+		{"blank string uses the default", "  ", 50, ""},
+		{"minimum", 1.0, 1, ""},
+		{"maximum", 1000.0, 1000, ""},
+		{"fraction", 2.5, 0, "invalid value for 'x': expected integer, got 2.5"},
+		{"fractional string", "2.5", 0, "invalid value for 'x': expected integer, got 2.5"},
+		{"below minimum", 0.0, 0, "invalid value for 'x': must be between 1 and 1000"},
+		{"above maximum", 1001.0, 0, "invalid value for 'x': must be between 1 and 1000"},
+		{"non-numeric string", "twenty", 0, `invalid value for 'x': expected integer, got string "twenty"`},
+		{"NaN string", "NaN", 0, `invalid value for 'x': expected integer, got string "NaN"`},
+		{"infinite string", "Inf", 0, `invalid value for 'x': expected integer, got string "Inf"`},
+		{"boolean", true, 0, "invalid value for 'x': expected integer, got boolean"},
+		{"array", []any{1.0}, 0, "invalid value for 'x': expected integer, got array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := intArgument(argumentsWith(tt.value), "x", 50, 1, 1000)
+			assertArgument(t, got, err, tt.want, tt.wantErr)
+		})
+	}
+
+	t.Run("no maximum", func(t *testing.T) {
+		got, err := intArgument(argumentsWith(1e6), "x", 0, 0, 0)
+		assertArgument(t, got, err, 1000000, "")
+		_, err = intArgument(argumentsWith(-1.0), "x", 0, 0, 0)
+		assertArgument(t, 0, err, 0, "invalid value for 'x': must be at least 0")
+	})
+}
+
+func TestBoolArgument(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    bool
+		wantErr string
+	}{
+		{"missing", missingArgument, false, ""},
+		{"null", nil, false, ""},
+		{"true", true, true, ""},
+		{"false", false, false, ""},
+		{"string true", "true", true, ""},
+		{"string TRUE", "TRUE", true, ""},
+		{"string 1", "1", true, ""},
+		{"string false with spaces", " false ", false, ""},
+		{"empty string", "", false, ""},
+		{"unparsable string", "yes please", false, `invalid value for 'x': expected boolean, got string "yes please"`},
+		{"number", 1.0, false, "invalid value for 'x': expected boolean, got number"},
+		{"object", map[string]any{}, false, "invalid value for 'x': expected boolean, got object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := boolArgument(argumentsWith(tt.value), "x")
+			assertArgument(t, got, err, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestDurationArgument(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    time.Duration
+		wantErr string
+	}{
+		{"missing uses the default", missingArgument, time.Hour, ""},
+		{"null uses the default", nil, time.Hour, ""},
+		{"empty string uses the default", "", time.Hour, ""},
+		{"duration", "10m", 10 * time.Minute, ""},
+		{"compound duration", "1h30m", 90 * time.Minute, ""},
+		{"duration with spaces", " 2s ", 2 * time.Second, ""},
+		{"zero", "0s", 0, `invalid value for 'x': expected positive duration such as 10m, got string "0s"`},
+		{"negative", "-5m", 0, `invalid value for 'x': expected positive duration such as 10m, got string "-5m"`},
+		{"no unit", "10", 0, `invalid value for 'x': expected positive duration such as 10m, got string "10"`},
+		{"days are not a Go unit", "2d", 0, `invalid value for 'x': expected positive duration such as 10m, got string "2d"`},
+		{"number", 600.0, 0, "invalid value for 'x': expected positive duration such as 10m, got number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := durationArgument(argumentsWith(tt.value), "x", time.Hour)
+			assertArgument(t, got, err, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestStringListArgument(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    []string
+		wantErr string
+	}{
+		{"missing", missingArgument, nil, ""},
+		{"null", nil, nil, ""},
+		{"empty string", "", nil, ""},
+		{"one item", "OrderWorkflow", []string{"OrderWorkflow"}, ""},
+		{"comma-separated", "a, b ,c", []string{"a", "b", "c"}, ""},
+		{"empty items and duplicates dropped", "a,,b, ,a", []string{"a", "b"}, ""},
+		{"array", []any{"a", " b "}, []string{"a", "b"}, ""},
+		{"array of scalars", []any{"a", 42.0, true}, []string{"a", "42", "true"}, ""},
+		{"array with null", []any{"a", nil}, []string{"a"}, ""},
+		{"array with a nested array", []any{"a", []any{"b"}}, nil, "invalid value for 'x': expected list of strings, got array"},
+		{"number", 42.0, nil, "invalid value for 'x': expected comma-separated string, got number"},
+		{"object", map[string]any{"a": "b"}, nil, "invalid value for 'x': expected comma-separated string, got object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stringListArgument(argumentsWith(tt.value), "x")
+			assertArgument(t, got, err, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestExecutionArguments(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              map[string]any
+		workflowID, runID string
+		wantErr           string
+	}{
+		{"workflow only", map[string]any{"workflow_id": "order-1"}, "order-1", "", ""},
+		{"with run", map[string]any{"workflow_id": "order-1", "run_id": "r1"}, "order-1", "r1", ""},
+		{"numeric workflow ID", map[string]any{"workflow_id": 1042.0}, "1042", "", ""},
+		{"missing workflow ID", map[string]any{"run_id": "r1"}, "", "", "Missing or invalid 'workflow_id' parameter"},
+		{"null workflow ID", map[string]any{"workflow_id": nil}, "", "", "Missing or invalid 'workflow_id' parameter"},
+		{"invalid run ID", map[string]any{"workflow_id": "order-1", "run_id": []any{}}, "", "", "invalid value for 'run_id': expected string, got array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowID, runID, err := executionArguments(tt.args)
+			assertArgument(t, [2]string{workflowID, runID}, err, [2]string{tt.workflowID, tt.runID}, tt.wantErr)
+		})
+	}
+}
+
+// assertArgument checks a parsed argument against want, or its error against wantErr when set.
+func assertArgument[T any](t *testing.T, got T, err error, want T, wantErr string) {
+	t.Helper()
+	if wantErr != "" {
+		if err == nil || err.Error() != wantErr {
+			t.Fatalf("error = %v, want %q", err, wantErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+// The schema declares the types agents should send, but handlers also accept the common
+// representations of them in other JSON types.
+func TestArgumentSchemas(t *testing.T) {
+	schema := (&ListWorkflows{}).Definition().InputSchema.Properties
+	status, _ := schema["status"].(map[string]any)
+	if want := []string{"running", "completed", "failed"}; !reflect.DeepEqual(status["enum"], want) {
+		t.Errorf("status enum = %v, want %v", status["enum"], want)
+	}
+	pageSize, _ := schema["page_size"].(map[string]any)
+	if pageSize["type"] != "integer" || pageSize["minimum"] != 1.0 || pageSize["maximum"] != float64(maxListPageSize) {
+		t.Errorf("page_size schema = %v, want an integer between 1 and %d", pageSize, maxListPageSize)
+	}
+}
+
+func TestListWorkflowsCoercesArguments(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]any
+		pageSize int32
+	}{
+		{"page_size as a string", map[string]any{"status": "running", "page_size": "20"}, 20},
+		{"page_size as a number", map[string]any{"status": "running", "page_size": 20.0}, 20},
+		{"status in upper case", map[string]any{"status": "RUNNING", "page_size": 5.0}, 5},
+		{"null page_size uses the default", map[string]any{"status": "running", "page_size": nil}, defaultListPageSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{
+				listOpenWorkflow: func(_ context.Context, req *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+					if req.GetMaximumPageSize() != tt.pageSize {
+						t.Errorf("page size = %d, want %d", req.GetMaximumPageSize(), tt.pageSize)
+					}
+					return &workflowservice.ListOpenWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{
+						execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+					}}, nil
+				},
+			}
+			tool := &ListWorkflows{Clients: newFakeProvider(client), Logger: testLogger}
+
+			result := callTool(t, tool, tt.args)
+			if result.IsError {
+				t.Fatalf("unexpected error result: %s", resultText(result))
+			}
+			assertContains(t, resultText(result), "Found 1 running workflow(s)")
+		})
+	}
+}
+
+func TestDescribeWorkflowCoercesArguments(t *testing.T) {
+	info := execution("1042", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	client := &fakeClient{
+		describeWorkflowExecution: func(_ context.Context, workflowID, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+			if workflowID != "1042" {
+				t.Errorf("workflow ID = %q, want %q", workflowID, "1042")
+			}
+			return &workflowservice.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: info}, nil
+		},
+		getWorkflowExecutionHistory: completedHistory(t, "input", nil),
+	}
+	tool := &DescribeWorkflow{Clients: newFakeProvider(client), Logger: testLogger, Payloads: newTestRenderer(t)}
+
+	// A numeric workflow ID and a boolean spelled as a string
+	result := callTool(t, tool, map[string]any{"workflow_id": 1042.0, "raw": "true"})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", resultText(result))
+	}
+	assertContains(t, resultText(result), `"workflowId": "1042"`)
+}
@@ -0,0 +1,204 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// maxTaskFailuresShown caps the failures check_workflow_task_failures returns; the rest are counted.
+	maxTaskFailuresShown = 10
+	// maxStackTraceLines caps the lines of a failure's stack trace shown.
+	maxStackTraceLines = 20
+)
+
+// taskFailureExplanations tells what each class of workflow task failure means and how to get the
+// workflow moving again.
+var taskFailureExplanations = map[string]string{
+	"nondeterminism":  "replaying the history with the current workflow code produced different commands than the ones recorded: a deployment changed the workflow's logic (the order or kind of activities, timers, or child workflows) without versioning it. Roll the workers back to the previous build, or guard the change with a version check (workflow.GetVersion or patched), and the task retries on its own; or reset the workflow to a point before the change",
+	"panic":           "the workflow code panicked while processing the task, and the task is retried until fixed code is deployed; the workflow itself doesn't fail. Fix the bug and deploy: the stuck task then completes without intervention",
+	"unhandled_error": "the worker failed the task with an error from the workflow code or the SDK, and the task is retried until fixed code is deployed; the workflow itself doesn't fail",
+	"bad_command":     "the worker sent an invalid command (e.g. activity or child workflow options the server rejects, a duplicate ID, or oversized input); fix the call in the workflow code",
+	"limit_exceeded":  "the workflow hit a server limit on pending activities, child workflows, signals, cancel requests, or Nexus operations; it must wait for some to complete, or the workflow code must start fewer at once",
+	"bad_binary":      "the worker's build was marked bad in the namespace, so its tasks are failed; deploy another build",
+	"other":           "the worker or server failed the workflow task; see the cause and message",
+}
+
+// CheckWorkflowTaskFailures implements the "check_workflow_task_failures" tool.
+type CheckWorkflowTaskFailures struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *CheckWorkflowTaskFailures) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"check_workflow_task_failures",
+		mcp.WithDescription("Explain why a workflow shows as Running but makes no progress: finds WorkflowTaskFailed events in its history, classifies the cause (nondeterminism after a deployment, a panic, an invalid command, a server limit), reports whether the pending workflow task is stuck retrying and on which attempt, and names the worker identities and builds whose attempts failed, with the panic or nondeterminism message and stack trace"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// Handle implements Tool.
+func (t *CheckWorkflowTaskFailures) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, errResult := describeExecution(ctx, client, t.Logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	info := resp.GetWorkflowExecutionInfo()
+	execution := pinnedExecution(resp)
+
+	check := format.WorkflowTaskCheck{
+		WorkflowID: wfID,
+		RunID:      execution.GetRunId(),
+		Status:     format.WorkflowStatus(info.GetStatus()),
+		Failures:   []format.WorkflowTaskFailure{},
+	}
+	if task := resp.GetPendingWorkflowTask(); task != nil {
+		check.PendingWorkflowTask = format.NewPendingWorkflowTask(task, time.Now())
+	}
+
+	var failures []format.WorkflowTaskFailure
+	err = forEachEvent(ctx, client, execution, info.GetHistoryLength(), func(event *historypb.HistoryEvent) bool {
+		switch {
+		case event.GetWorkflowTaskCompletedEventAttributes() != nil:
+			check.Recovered = len(failures) > 0
+		case event.GetWorkflowTaskFailedEventAttributes() != nil:
+			attrs := event.GetWorkflowTaskFailedEventAttributes()
+			if benignTaskFailure(attrs.GetCause()) {
+				return true
+			}
+			check.Recovered = false
+			failures = append(failures, format.WorkflowTaskFailure{
+				EventID:        event.GetEventId(),
+				Time:           format.Time(event.GetEventTime()),
+				Cause:          attrs.GetCause().String(),
+				Class:          classifyTaskFailure(attrs.GetCause(), attrs.GetFailure()),
+				Message:        attrs.GetFailure().GetMessage(),
+				StackTrace:     firstLines(attrs.GetFailure().GetStackTrace(), maxStackTraceLines),
+				Identity:       attrs.GetIdentity(),
+				BuildID:        attrs.GetWorkerVersion().GetBuildId(),
+				BinaryChecksum: attrs.GetBinaryChecksum(),
+			})
+		}
+		return true
+	})
+	partial := partialHistory(err)
+	if partial != "" {
+		t.Logger.Warn("Timed out reading workflow history; returning partial results", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+	} else if err != nil {
+		t.Logger.Error("Error reading workflow history", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+		return rpcError("Failed to read workflow history", err), nil
+	}
+
+	check.Total = len(failures)
+	workers := make(map[format.FailingWorker]int)
+	var order []format.FailingWorker
+	for i := len(failures) - 1; i >= 0; i-- {
+		f := failures[i]
+		if len(check.Failures) < maxTaskFailuresShown {
+			check.Failures = append(check.Failures, f)
+		}
+		key := format.FailingWorker{Identity: f.Identity, BuildID: f.BuildID, BinaryChecksum: f.BinaryChecksum}
+		if _, ok := workers[key]; !ok {
+			order = append(order, key)
+		}
+		workers[key]++
+	}
+	for _, key := range order {
+		key.Failures = workers[key]
+		check.Workers = append(check.Workers, key)
+	}
+	if len(failures) > 0 {
+		latest := failures[len(failures)-1]
+		check.Class, check.Explanation = latest.Class, taskFailureExplanations[latest.Class]
+		// The server records the first failure of a run of retries only; the attempt counts the rest
+		check.Stuck = !check.Recovered && check.PendingWorkflowTask != nil && check.PendingWorkflowTask.Attempt > 1
+	}
+	result, err := renderResult(outFormat, check, format.WorkflowTaskCheckText)
+	return partialResult(partial, outFormat, result, err)
+}
+
+// benignTaskFailure reports whether a workflow task failure cause is routine server bookkeeping
+// (a reset, a sticky queue reset, a command racing a new event) rather than a problem.
+func benignTaskFailure(cause enumspb.WorkflowTaskFailedCause) bool {
+	switch cause {
+	case enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_WORKFLOW,
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_UNHANDLED_COMMAND,
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_UNHANDLED_UPDATE,
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_STICKY_TASK_QUEUE,
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_FORCE_CLOSE_COMMAND,
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_FAILOVER_CLOSE_COMMAND:
+		return true
+	}
+	return false
+}
+
+// classifyTaskFailure returns the class of a workflow task failure, from its cause and, for
+// failures the worker reported as unhandled, its message: SDKs don't all report nondeterminism
+// with its own cause.
+func classifyTaskFailure(cause enumspb.WorkflowTaskFailedCause, failure *failurepb.Failure) string {
+	message := strings.ToLower(failure.GetMessage())
+	switch {
+	case cause == enumspb.WORKFLOW_TASK_FAILED_CAUSE_NON_DETERMINISTIC_ERROR,
+		strings.Contains(message, "nondetermini"), strings.Contains(message, "non-determini"), strings.Contains(message, "tmprl1100"):
+		return "nondeterminism"
+	case cause == enumspb.WORKFLOW_TASK_FAILED_CAUSE_WORKFLOW_WORKER_UNHANDLED_FAILURE:
+		// Go SDK stack traces of panics start with "coroutine root [panic]:"
+		if stack := failure.GetStackTrace(); strings.Contains(message, "panic") || strings.Contains(stack, "[panic]") || strings.Contains(stack, "panic(") {
+			return "panic"
+		}
+		return "unhandled_error"
+	case cause == enumspb.WORKFLOW_TASK_FAILED_CAUSE_BAD_BINARY:
+		return "bad_binary"
+	case strings.HasPrefix(cause.String(), "Bad"),
+		cause == enumspb.WORKFLOW_TASK_FAILED_CAUSE_START_TIMER_DUPLICATE_ID,
+		cause == enumspb.WORKFLOW_TASK_FAILED_CAUSE_SCHEDULE_ACTIVITY_DUPLICATE_ID:
+		return "bad_command"
+	case strings.HasSuffix(cause.String(), "LimitExceeded"):
+		return "limit_exceeded"
+	}
+	return "other"
+}
+
+// firstLines returns the first n lines of s, noting how many were cut.
+func firstLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) <= n {
+		return strings.TrimRight(s, "\n")
+	}
+	return fmt.Sprintf("%s\n[%d more lines]", strings.Join(lines[:n], "\n"), len(lines)-n)
+}
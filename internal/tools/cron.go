@@ -0,0 +1,36 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// nextCronTime returns the first time after the given one that a Temporal cron schedule fires. The
+// schedule is a standard five-field expression or a descriptor such as @hourly, evaluated in UTC
+// unless prefixed with CRON_TZ=<zone> (or TZ=<zone>) as Temporal allows.
+func nextCronTime(schedule string, after time.Time) (time.Time, error) {
+	spec, location := strings.TrimSpace(schedule), time.UTC
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if rest, ok := strings.CutPrefix(spec, prefix); ok {
+			zone, expr, _ := strings.Cut(rest, " ")
+			loc, err := time.LoadLocation(zone)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("unknown time zone %q: %w", zone, err)
+			}
+			spec, location = strings.TrimSpace(expr), loc
+			break
+		}
+	}
+	parsed, err := cron.ParseStandard(spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := parsed.Next(after.In(location))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("schedule %q never fires", schedule)
+	}
+	return next.UTC(), nil
+}
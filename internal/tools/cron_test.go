@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextCronTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		after    time.Time
+		want     time.Time
+		// wantErr is a substring of the expected error, for schedules that don't parse
+		wantErr string
+	}{
+		{
+			name:     "every five minutes",
+			schedule: "*/5 * * * *",
+			after:    testTime,
+			want:     time.Date(2024, 5, 1, 9, 35, 0, 0, time.UTC),
+		},
+		{
+			// 09:30 UTC is 05:30 in New York (EDT), so the next firing is the same morning
+			name:     "weekdays in another time zone",
+			schedule: "CRON_TZ=America/New_York 0 9 * * 1-5",
+			after:    testTime,
+			want:     time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekdays skip the weekend",
+			schedule: "CRON_TZ=America/New_York 0 9 * * 1-5",
+			after:    time.Date(2024, 5, 3, 14, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, 5, 6, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			// Standard time: New York is UTC-5 in January
+			name:     "TZ prefix",
+			schedule: "TZ=America/New_York 0 9 * * *",
+			after:    time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "descriptor",
+			schedule: "@hourly",
+			after:    testTime,
+			want:     time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "surrounding whitespace",
+			schedule: "  0 0 * * *  ",
+			after:    testTime,
+			want:     time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "invalid expression",
+			schedule: "61 * * * *",
+			after:    testTime,
+			wantErr:  "End of range (61) above maximum (59)",
+		},
+		{
+			name:     "wrong number of fields",
+			schedule: "* * *",
+			after:    testTime,
+			wantErr:  "Expected exactly 5 fields, found 3",
+		},
+		{
+			name:     "unknown time zone",
+			schedule: "CRON_TZ=Mars/Olympus 0 9 * * *",
+			after:    testTime,
+			wantErr:  `unknown time zone "Mars/Olympus"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextCronTime(tt.schedule, tt.after)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("nextCronTime(%q) error = %v, want one containing %q", tt.schedule, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("nextCronTime(%q): %v", tt.schedule, err)
+			}
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("nextCronTime(%q, %s) = %s, want %s", tt.schedule, tt.after.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
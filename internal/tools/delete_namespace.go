@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	operatorservice "go.temporal.io/api/operatorservice/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// DeleteNamespace implements the "delete_namespace" tool. It is registered only when namespace
+// deletion is enabled in the configuration.
+type DeleteNamespace struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *DeleteNamespace) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"delete_namespace",
+		mcp.WithDescription("Permanently delete a namespace and every workflow execution in it, e.g. to decommission an environment. The server renames the namespace and deletes its executions in the background. The server's default namespace and temporal-system can't be deleted. Without confirm=true and the namespace name repeated in confirm_namespace, returns a preview with the namespace's workflow counts and deletes nothing"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("Namespace to delete"),
+		),
+		mcp.WithString("confirm_namespace",
+			mcp.Description("The namespace name again, typed exactly; required with confirm=true"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Description("Set to true to delete the namespace; otherwise only a preview is returned"),
+		),
+		withFormatArgument(),
+	)
+}
+
+// Mutating implements Mutating.
+func (t *DeleteNamespace) Mutating() {}
+
+// Handle implements Tool.
+func (t *DeleteNamespace) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	namespace, err := stringArgument(args, "namespace")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if namespace == "" {
+		return mcp.NewToolResultError("Missing or invalid 'namespace' parameter"), nil
+	}
+	confirmNamespace, err := stringArgument(args, "confirm_namespace")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	confirm, err := boolArgument(args, "confirm")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	switch namespace {
+	case t.Clients.DefaultNamespace(), "default":
+		return mcp.NewToolResultError(fmt.Sprintf("Refusing to delete namespace %s: it is the server's default namespace", namespace)), nil
+	case "temporal-system":
+		return mcp.NewToolResultError("Refusing to delete namespace temporal-system: the Temporal server itself runs in it"), nil
+	}
+	if confirm && confirmNamespace != namespace {
+		return mcp.NewToolResultError(fmt.Sprintf("'confirm_namespace' must repeat the namespace name exactly (%q); got %q", namespace, confirmNamespace)), nil
+	}
+	// The client of the namespace itself counts its workflows, and enforces the allowlist
+	client, err := t.Clients.Client(namespace)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, err := client.DescribeNamespace(ctx, namespace)
+	if err != nil {
+		t.Logger.Error("Error describing namespace", "namespace", namespace, "error", err)
+		return rpcError("Failed to describe namespace", err), nil
+	}
+	view := format.NamespaceDeletion{Namespace: format.NewNamespaceSummary(resp)}
+	if resp.GetNamespaceInfo().GetState() == enumspb.NAMESPACE_STATE_DELETED {
+		view.AlreadyDeleted = true
+		return renderResult(outFormat, view, format.NamespaceDeletionText)
+	}
+	view.Workflows, view.Running = -1, -1
+	if total, err := countWorkflows(ctx, client, ""); err != nil {
+		t.Logger.Warn("Unable to count workflows of namespace to delete", "namespace", namespace, "error", err)
+		view.CountError = translateError("Failed to count workflows", err).Error
+	} else if running, err := countWorkflows(ctx, client, "ExecutionStatus = 'Running'"); err != nil {
+		t.Logger.Warn("Unable to count running workflows of namespace to delete", "namespace", namespace, "error", err)
+		view.CountError = translateError("Failed to count running workflows", err).Error
+	} else {
+		view.Workflows, view.Running = total, running
+	}
+	if !confirm {
+		return renderResult(outFormat, view, format.NamespaceDeletionText)
+	}
+	if temporal.DryRun(ctx) {
+		t.Logger.Info("Dry run: skipped deleting namespace", "namespace", namespace)
+		view.DryRun = true
+		return renderResult(outFormat, view, format.NamespaceDeletionText)
+	}
+
+	deleted, err := client.DeleteNamespace(ctx, &operatorservice.DeleteNamespaceRequest{Namespace: namespace})
+	if err != nil {
+		t.Logger.Error("Error deleting namespace", "namespace", namespace, "error", err)
+		return rpcError("Failed to delete namespace", err), nil
+	}
+	t.Logger.Warn("Namespace deletion started", "namespace", namespace, "deleted_namespace", deleted.GetDeletedNamespace(), "workflows", view.Workflows)
+	view.Performed = true
+	view.DeletedNamespace = deleted.GetDeletedNamespace()
+	if after, err := client.DescribeNamespace(ctx, view.DeletedNamespace); err != nil {
+		t.Logger.Warn("Unable to describe namespace after starting its deletion", "namespace", view.DeletedNamespace, "error", err)
+	} else {
+		view.DeletedState = format.NamespaceState(after.GetNamespaceInfo().GetState())
+	}
+	return renderResult(outFormat, view, format.NamespaceDeletionText)
+}
+
+// countWorkflows counts the executions of client's namespace matching query.
+func countWorkflows(ctx context.Context, client temporal.Client, query string) (int64, error) {
+	resp, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: client.Namespace(),
+		Query:     query,
+	})
+	return resp.GetCount(), err
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	operatorservice "go.temporal.io/api/operatorservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// DescribeNexusEndpoint implements the "describe_nexus_endpoint" tool.
+type DescribeNexusEndpoint struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *DescribeNexusEndpoint) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"describe_nexus_endpoint",
+		mcp.WithDescription("Describe one Nexus endpoint, by name or ID: its target namespace and task queue (or external URL), full description, URL prefix, version, and creation and modification times"),
+		mcp.WithString("name",
+			mcp.Description("Name of the endpoint, as listed by list_nexus_endpoints"),
+		),
+		mcp.WithString("id",
+			mcp.Description("Server-generated ID of the endpoint, instead of name"),
+		),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *DescribeNexusEndpoint) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *DescribeNexusEndpoint) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := stringArgument(args, "name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	id, err := stringArgument(args, "id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if (name == "") == (id == "") {
+		return mcp.NewToolResultError("Pass exactly one of 'name' and 'id' (list_nexus_endpoints lists the endpoints)"), nil
+	}
+	client, err := t.Clients.Client("")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var endpoint format.NexusEndpoint
+	if id != "" {
+		resp, err := client.GetNexusEndpoint(ctx, id)
+		if err != nil {
+			t.Logger.Error("Error getting Nexus endpoint", "id", id, "error", err)
+			return errorResult(nexusError("Failed to describe Nexus endpoint", err)), nil
+		}
+		endpoint = format.NewNexusEndpoint(resp.GetEndpoint(), t.Payloads)
+	} else {
+		// Filtering the listing by name yields the endpoint or nothing
+		resp, err := client.ListNexusEndpoints(ctx, &operatorservice.ListNexusEndpointsRequest{PageSize: 1, Name: name})
+		if err != nil {
+			t.Logger.Error("Error listing Nexus endpoints", "name", name, "error", err)
+			return errorResult(nexusError("Failed to describe Nexus endpoint", err)), nil
+		}
+		if len(resp.GetEndpoints()) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("No Nexus endpoint named %q (list_nexus_endpoints lists the endpoints)", name)), nil
+		}
+		endpoint = format.NewNexusEndpoint(resp.GetEndpoints()[0], t.Payloads)
+	}
+	if endpoint.Target == "worker" && !t.Clients.Allowed(endpoint.TargetNamespace) {
+		return mcp.NewToolResultError(fmt.Sprintf("%v: Nexus endpoint %s routes to namespace %q", temporal.ErrNamespaceNotAllowed, endpoint.Name, endpoint.TargetNamespace)), nil
+	}
+	return renderResult(outFormat, endpoint, format.NexusEndpointText)
+}
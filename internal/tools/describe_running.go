@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// describeRunning lists up to scanCap running workflows matching query, then describes them
+// concurrently through p, at most maxItems of them (0 for all), for tools that need more than
+// visibility records. Each description is passed to visit along with the listed execution; visit
+// calls are serialized, so visit may update the caller's state without locking. Workflows that
+// closed or were deleted since listing are skipped, as describing them fails with NotFound; other
+// failures are logged and skipped too. It returns how many workflows were listed and the fan-out's
+// stats, or the error of the listing.
+func describeRunning(ctx context.Context, client temporal.Client, logger *slog.Logger, p *pool.Pool, query string, scanCap, maxItems int,
+	visit func(info *workflowpb.WorkflowExecutionInfo, resp *workflowservice.DescribeWorkflowExecutionResponse)) (listed int, stats pool.Stats, err error) {
+	var (
+		executions []*workflowpb.WorkflowExecutionInfo
+		token      []byte
+	)
+	for len(executions) < scanCap {
+		resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     client.Namespace(),
+			PageSize:      int32(min(maxListPageSize, scanCap-len(executions))),
+			NextPageToken: token,
+			Query:         query,
+		})
+		if err != nil {
+			logger.Error("Error listing running workflows", "query", query, "error", err)
+			return 0, pool.Stats{}, err
+		}
+		executions = append(executions, resp.GetExecutions()...)
+		if token = resp.GetNextPageToken(); len(token) == 0 {
+			break
+		}
+	}
+
+	total := len(executions)
+	if maxItems > 0 {
+		total = min(total, maxItems)
+	}
+	var (
+		mu   sync.Mutex
+		done atomic.Int64
+	)
+	stats = p.Run(ctx, len(executions), maxItems, func(ctx context.Context, i int) {
+		// The last item isn't reported: the response itself signals completion
+		defer func() {
+			if n := done.Add(1); n < int64(total) {
+				reportProgress(ctx, float64(n), float64(total), "described %d of %d running workflows", n, total)
+			}
+		}()
+		execution := executions[i].GetExecution()
+		resp, err := client.DescribeWorkflowExecution(ctx, execution.GetWorkflowId(), execution.GetRunId())
+		if err != nil {
+			if !isNotFound(err) {
+				logger.Warn("Error describing workflow", "workflow_id", execution.GetWorkflowId(), "run_id", execution.GetRunId(), "error", err)
+			}
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		visit(executions[i], resp)
+	})
+	return len(executions), stats, nil
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/pool"
+)
+
+func TestDescribeRunning(t *testing.T) {
+	client := &fakeClient{
+		listWorkflow: func(_ context.Context, req *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+			if len(req.GetNextPageToken()) == 0 {
+				return &workflowservice.ListWorkflowExecutionsResponse{
+					Executions: []*workflowpb.WorkflowExecutionInfo{
+						execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+						execution("order-2", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+						execution("order-3", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+					},
+					NextPageToken: []byte("page-2"),
+				}, nil
+			}
+			return &workflowservice.ListWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{
+				execution("order-4", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+				execution("order-5", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+			}}, nil
+		},
+		describeWorkflowExecution: func(_ context.Context, workflowID, _ string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+			switch workflowID {
+			case "order-2":
+				// It closed and aged out since the listing
+				return nil, serviceerror.NewNotFound("workflow not found")
+			case "order-3":
+				return nil, serviceerror.NewUnavailable("connection reset")
+			}
+			return &workflowservice.DescribeWorkflowExecutionResponse{
+				WorkflowExecutionInfo: execution(workflowID, "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+			}, nil
+		},
+	}
+
+	// The describe failures are skipped; the item cap leaves the last workflow out
+	var visited []string
+	listed, stats, err := describeRunning(context.Background(), client, testLogger, pool.New(2), "ExecutionStatus = 'Running'", 10, 4,
+		func(info *workflowpb.WorkflowExecutionInfo, resp *workflowservice.DescribeWorkflowExecutionResponse) {
+			if info.GetExecution().GetWorkflowId() != resp.GetWorkflowExecutionInfo().GetExecution().GetWorkflowId() {
+				t.Errorf("visit of %s got the description of another workflow", info.GetExecution().GetWorkflowId())
+			}
+			visited = append(visited, info.GetExecution().GetWorkflowId())
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(visited)
+	if want := []string{"order-1", "order-4"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("visited %v, want %v", visited, want)
+	}
+	if listed != 5 {
+		t.Errorf("listed = %d, want 5", listed)
+	}
+	if want := (pool.Stats{Total: 5, Processed: 4, Capped: 1}); stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+}
@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/cache"
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// chainStartTTL is how long describe_workflow keeps the start times of first runs. They never
+	// change, so this only bounds how long a deleted run's start time lingers.
+	chainStartTTL = time.Hour
+	// chainStartEntries bounds the first-run start times kept.
+	chainStartEntries = 1024
+)
+
+// DescribeWorkflow implements the "describe_workflow" tool.
+type DescribeWorkflow struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+	// HistoryThresholds are the history sizes past which running workflows are flagged.
+	HistoryThresholds format.HistoryThresholds
+
+	once        sync.Once
+	chainStarts *cache.Cache
+}
+
+// Definition implements Tool.
+func (t *DescribeWorkflow) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"describe_workflow",
+		mcp.WithDescription("Retrieve detailed information about a specific workflow execution, including its history size, flagged when a running workflow nears the server's history limits"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution to describe"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithBoolean("raw",
+			mcp.Description("Return the full DescribeWorkflowExecution response as JSON instead of the summary, for fields the summary doesn't show"),
+		),
+		withNamespaceArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *DescribeWorkflow) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *DescribeWorkflow) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Validate and get required workflow_id
+	wfID, runID, err := executionArguments(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	raw, err := boolArgument(req.GetArguments(), "raw")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := callClient(t.Clients, req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Describe the workflow execution via Temporal
+	resp, err := client.DescribeWorkflowExecution(ctx, wfID, runID)
+	if err != nil {
+		t.Logger.Error("Error describing workflow", "workflow_id", wfID, "run_id", runID, "error", err)
+		return describeFailed(ctx, client, wfID, err), nil
+	}
+	info := resp.GetWorkflowExecutionInfo()
+	if info == nil {
+		// This case is unlikely if no error, but handle defensively
+		return mcp.NewToolResultError("No information available for the specified workflow"), nil
+	}
+	noteWorkflow(ctx, client.Namespace(), info)
+
+	if raw {
+		out, err := format.ProtoJSON(resp, t.Payloads)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %v", err)), nil
+		}
+		return mcp.NewToolResultText(out), nil
+	}
+
+	details := format.NewWorkflowDetails(resp, t.Payloads)
+	if info.GetStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		details.HistoryWarnings = t.HistoryThresholds.Warnings(info.GetHistoryLength(), info.GetHistorySizeBytes())
+	}
+
+	if details.FirstRunID != "" {
+		if start, err := t.chainStart(ctx, client, wfID, details.FirstRunID); err != nil {
+			t.Logger.Warn("Unable to describe the first run of the chain", "workflow_id", wfID, "first_run_id", details.FirstRunID, "error", err)
+		} else {
+			end := time.Now()
+			if info.GetCloseTime() != nil {
+				end = info.GetCloseTime().AsTime()
+			}
+			details.ChainStartTime = start.UTC().Format(time.RFC3339)
+			details.ChainAge = end.Sub(start).Round(time.Second).String()
+		}
+	}
+
+	// Input and result live in history; they are informational, so lookup failures only drop them
+	execution := info.GetExecution()
+	if err := t.addHistoryPayloads(ctx, client, execution, info.GetCloseTime() != nil, &details); err != nil {
+		t.Logger.Warn("Unable to read workflow input and result from history", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+	}
+	return mcp.NewToolResultText(format.WorkflowDetailsText(details)), nil
+}
+
+// addHistoryPayloads fills in the workflow input, first workflow task backoff, and cron schedule
+// from the first history event and, for closed workflows, the result or failure from the close event.
+func (t *DescribeWorkflow) addHistoryPayloads(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, closed bool, d *format.WorkflowDetails) error {
+	first, err := client.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace:       client.Namespace(),
+		Execution:       execution,
+		MaximumPageSize: 1,
+	})
+	if err != nil {
+		return err
+	}
+	if events := first.GetHistory().GetEvents(); len(events) > 0 {
+		started := events[0].GetWorkflowExecutionStartedEventAttributes()
+		d.Input = t.Payloads.RenderAll(started.GetInput())
+		if backoff := started.GetFirstWorkflowTaskBackoff().AsDuration(); backoff > 0 {
+			d.FirstWorkflowTaskBackoff = backoff.String()
+			d.FirstWorkflowTask = events[0].GetEventTime().AsTime().Add(backoff).UTC().Format(time.RFC3339)
+		}
+		if schedule := started.GetCronSchedule(); schedule != "" {
+			d.Cron = &format.CronDetails{Schedule: schedule}
+			if next, err := nextCronTime(schedule, time.Now()); err != nil {
+				t.Logger.Warn("Unable to evaluate cron schedule", "schedule", schedule, "error", err)
+			} else {
+				d.Cron.NextExecution = next.Format(time.RFC3339)
+			}
+		}
+	}
+	if !closed {
+		return nil
+	}
+
+	last, err := client.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace:              client.Namespace(),
+		Execution:              execution,
+		HistoryEventFilterType: enumspb.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT,
+	})
+	if err != nil {
+		return err
+	}
+	for _, event := range last.GetHistory().GetEvents() {
+		switch {
+		case event.GetWorkflowExecutionCompletedEventAttributes() != nil:
+			d.Result = t.Payloads.RenderAll(event.GetWorkflowExecutionCompletedEventAttributes().GetResult())
+		case event.GetWorkflowExecutionFailedEventAttributes() != nil:
+			d.Failure = format.Failure(event.GetWorkflowExecutionFailedEventAttributes().GetFailure())
+		case event.GetWorkflowExecutionCanceledEventAttributes() != nil:
+			d.Result = t.Payloads.RenderAll(event.GetWorkflowExecutionCanceledEventAttributes().GetDetails())
+		case event.GetWorkflowExecutionTerminatedEventAttributes() != nil:
+			d.Failure = "terminated: " + event.GetWorkflowExecutionTerminatedEventAttributes().GetReason()
+		case event.GetWorkflowExecutionTimedOutEventAttributes() != nil:
+			d.Failure = "workflow execution timed out"
+		}
+	}
+	return nil
+}
+
+// chainStart returns the start time of firstRunID, the first run of workflowID's chain of runs,
+// from the first-run cache when it has it.
+func (t *DescribeWorkflow) chainStart(ctx context.Context, client temporal.Client, workflowID, firstRunID string) (time.Time, error) {
+	t.once.Do(func() {
+		t.chainStarts = cache.New(chainStartEntries, chainStartTTL)
+	})
+	key := client.Namespace() + "/" + firstRunID
+	if cached, ok := t.chainStarts.Get(key); ok {
+		return cached.(time.Time), nil
+	}
+	resp, err := client.DescribeWorkflowExecution(ctx, workflowID, firstRunID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	start := resp.GetWorkflowExecutionInfo().GetStartTime().AsTime()
+	t.chainStarts.Set(key, start)
+	return start, nil
+}
@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wricardo/temporal-mcp/internal/payload"
+)
+
+// newTestRenderer returns a payload renderer with the default options.
+func newTestRenderer(t *testing.T) *payload.Renderer {
+	t.Helper()
+	r, err := payload.NewRenderer(payload.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// payloadsOf encodes values with the default data converter.
+func payloadsOf(t *testing.T, values ...any) *commonpb.Payloads {
+	t.Helper()
+	ps, err := converter.GetDefaultDataConverter().ToPayloads(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ps
+}
+
+// completedHistory serves the started and completed events of a workflow run with input and result.
+func completedHistory(t *testing.T, input, result any) func(context.Context, *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+	started := &historypb.HistoryEvent{
+		EventId:   1,
+		EventTime: timestamppb.New(testTime),
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &historypb.WorkflowExecutionStartedEventAttributes{
+			Input: payloadsOf(t, input),
+		}},
+	}
+	completed := &historypb.HistoryEvent{
+		EventId:   11,
+		EventTime: timestamppb.New(testTime.Add(time.Hour)),
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionCompletedEventAttributes{WorkflowExecutionCompletedEventAttributes: &historypb.WorkflowExecutionCompletedEventAttributes{
+			Result: payloadsOf(t, result),
+		}},
+	}
+	return func(_ context.Context, req *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+		event := started
+		if req.GetHistoryEventFilterType() == enumspb.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT {
+			event = completed
+		}
+		return &workflowservice.GetWorkflowExecutionHistoryResponse{History: &historypb.History{Events: []*historypb.HistoryEvent{event}}}, nil
+	}
+}
+
+func TestDescribeWorkflowFormatsTimestamps(t *testing.T) {
+	info := execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	// Sub-second precision and the server's zone don't reach the output: times are RFC 3339 in UTC
+	info.StartTime = timestamppb.New(testTime.Add(250 * time.Millisecond).In(time.FixedZone("EST", -5*3600)))
+	info.FirstRunId = info.GetExecution().GetRunId()
+	info.HistoryLength, info.HistorySizeBytes = 11, 2048
+	client := &fakeClient{
+		describeWorkflowExecution: func(_ context.Context, workflowID, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+			return &workflowservice.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: info}, nil
+		},
+		getWorkflowExecutionHistory: completedHistory(t, map[string]string{"order": "A-17"}, "shipped"),
+	}
+	tool := &DescribeWorkflow{Clients: newFakeProvider(client), Logger: testLogger, Payloads: newTestRenderer(t)}
+
+	result := callTool(t, tool, map[string]any{"workflow_id": "order-1"})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", resultText(result))
+	}
+	assertContains(t, resultText(result),
+		"Workflow ID: order-1\n",
+		"Run ID: order-1-run\n",
+		"Type: OrderWorkflow\n",
+		"Status: Completed\n",
+		"Start Time: 2024-05-01T09:30:00Z\n",
+		"End Time: 2024-05-01T10:30:00Z\n",
+		"History: 11 events, 2.0 KiB\n",
+		"Input: {\n  \"order\": \"A-17\"\n}\n",
+		"Result: \"shipped\"\n",
+	)
+}
+
+func TestDescribeWorkflowPendingNexusOperations(t *testing.T) {
+	info := execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	client := &fakeClient{
+		describeWorkflowExecution: func(context.Context, string, string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+			return &workflowservice.DescribeWorkflowExecutionResponse{
+				WorkflowExecutionInfo: info,
+				PendingNexusOperations: []*workflowpb.PendingNexusOperationInfo{
+					{
+						Endpoint:                "payments-endpoint",
+						Service:                 "payments",
+						Operation:               "charge",
+						State:                   enumspb.PENDING_NEXUS_OPERATION_STATE_BACKING_OFF,
+						Attempt:                 3,
+						ScheduledTime:           timestamppb.New(testTime.Add(time.Minute)),
+						ScheduledEventId:        5,
+						NextAttemptScheduleTime: timestamppb.New(testTime.Add(3 * time.Minute)),
+						LastAttemptFailure: &failurepb.Failure{
+							Message:     "handler unavailable",
+							FailureInfo: &failurepb.Failure_ApplicationFailureInfo{ApplicationFailureInfo: &failurepb.ApplicationFailureInfo{Type: "HandlerError"}},
+						},
+						CancellationInfo: &workflowpb.NexusOperationCancellationInfo{State: enumspb.NEXUS_OPERATION_CANCELLATION_STATE_SCHEDULED},
+					},
+					{
+						Endpoint:      "shipping-endpoint",
+						Service:       "shipping",
+						Operation:     "book",
+						State:         enumspb.PENDING_NEXUS_OPERATION_STATE_BLOCKED,
+						BlockedReason: "The circuit breaker is open.",
+						Attempt:       1,
+					},
+				},
+			}, nil
+		},
+		getWorkflowExecutionHistory: completedHistory(t, map[string]string{"order": "A-17"}, nil),
+	}
+	tool := &DescribeWorkflow{Clients: newFakeProvider(client), Logger: testLogger, Payloads: newTestRenderer(t)}
+
+	result := callTool(t, tool, map[string]any{"workflow_id": "order-1"})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", resultText(result))
+	}
+	assertContains(t, resultText(result),
+		"Pending Nexus Operations (2):\n"+
+			"- Operation: payments/charge | Endpoint: payments-endpoint | State: BackingOff | Attempt: 3 | Scheduled: 2024-05-01T09:31:00Z"+
+			" | Last Failure: handler unavailable (type HandlerError) | Next Attempt: 2024-05-01T09:33:00Z | Cancellation: Scheduled\n"+
+			"- Operation: shipping/book | Endpoint: shipping-endpoint | State: Blocked (The circuit breaker is open.) | Attempt: 1\n",
+	)
+
+	// The raw response carries them too, with the fields the summary leaves out
+	result = callTool(t, tool, map[string]any{"workflow_id": "order-1", "raw": true})
+	assertContains(t, resultText(result), `"pendingNexusOperations"`, `"scheduledEventId": "5"`, `"state": "NEXUS_OPERATION_CANCELLATION_STATE_SCHEDULED"`)
+}
+
+func TestDescribeWorkflowNotFound(t *testing.T) {
+	client := &fakeClient{
+		describeWorkflowExecution: func(context.Context, string, string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+			return nil, serviceerror.NewNotFound("workflow not found for ID: order-A17-2024")
+		},
+		listWorkflow: func(_ context.Context, req *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+			if want := `WorkflowId STARTS_WITH "order-A17-2024"`; req.GetQuery() != want {
+				t.Errorf("suggestion query = %q, want %q", req.GetQuery(), want)
+			}
+			return &workflowservice.ListWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{
+				execution("order-A17-2024-05-01", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+				execution("order-A17-2024-05", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED),
+			}}, nil
+		},
+		namespaceSettings: func(context.Context) (*workflowservice.DescribeNamespaceResponse, error) {
+			return &workflowservice.DescribeNamespaceResponse{Config: &namespacepb.NamespaceConfig{
+				WorkflowExecutionRetentionTtl: durationpb.New(72 * time.Hour),
+			}}, nil
+		},
+	}
+	tool := &DescribeWorkflow{Clients: newFakeProvider(client), Logger: testLogger, Payloads: newTestRenderer(t)}
+
+	result := callTool(t, tool, map[string]any{"workflow_id": "order-A17-2024"})
+	if !result.IsError {
+		t.Fatalf("want an error result, got: %s", resultText(result))
+	}
+	assertContains(t, resultText(result),
+		"Failed to describe workflow: workflow not found for ID: order-A17-2024\n",
+		"Code: NotFound (the workflow, run, or other resource doesn't exist, or was deleted after its retention period)\n",
+		"Hint: check the workflow_id (and run_id), or find it with list_workflows\n",
+		"Retention: namespace default keeps closed executions for 3 days",
+		// The closest ID comes first
+		"Did you mean one of these workflow IDs?\n- order-A17-2024-05\n- order-A17-2024-05-01",
+	)
+}
+
+func TestDescribeWorkflowArgumentValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"missing workflow_id", map[string]any{}, "Missing or invalid 'workflow_id' parameter"},
+		{"empty workflow_id", map[string]any{"workflow_id": ""}, "Missing or invalid 'workflow_id' parameter"},
+		{"workflow_id of the wrong type", map[string]any{"workflow_id": []any{"a"}}, "invalid value for 'workflow_id': expected string, got array"},
+		{"raw not a boolean", map[string]any{"workflow_id": "order-1", "raw": "yes please"}, `invalid value for 'raw': expected boolean, got string "yes please"`},
+		{"namespace not allowed", map[string]any{"workflow_id": "order-1", "namespace": "payments"}, `namespace not allowed by this server's namespace policy: "payments"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{}
+			tool := &DescribeWorkflow{Clients: newFakeProvider(client), Logger: testLogger, Payloads: newTestRenderer(t)}
+
+			result := callTool(t, tool, tt.args)
+			if !result.IsError {
+				t.Fatalf("want an error result, got: %s", resultText(result))
+			}
+			if got := resultText(result); got != tt.want {
+				t.Errorf("error = %q, want %q", got, tt.want)
+			}
+			if calls := client.called(); len(calls) > 0 {
+				t.Errorf("invalid arguments made RPCs: %v", calls)
+			}
+		})
+	}
+}
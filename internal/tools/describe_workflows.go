@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// maxBatchDescribe caps how many workflows a single describe_workflows call accepts.
+const maxBatchDescribe = 50
+
+// DescribeWorkflows implements the "describe_workflows" tool, describing several executions
+// concurrently through the shared fan-out pool.
+type DescribeWorkflows struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Pool    *pool.Pool
+	// MaxItems caps how many executions a call processes; the rest are reported as skipped.
+	MaxItems int
+}
+
+// workflowRef identifies one execution to describe; an empty RunID means the latest run.
+type workflowRef struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+}
+
+// Definition implements Tool.
+func (t *DescribeWorkflows) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"describe_workflows",
+		mcp.WithDescription("Describe several workflow executions in one call, returning a compact summary per workflow (status, duration, pending and failing activities)"),
+		mcp.WithArray("workflows",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Workflows to describe (at most %d): objects with a workflow_id and an optional run_id, or plain workflow ID strings", maxBatchDescribe)),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"workflow_id": map[string]any{"type": "string"},
+					"run_id":      map[string]any{"type": "string"},
+				},
+				"required": []string{"workflow_id"},
+			}),
+		),
+		withNamespaceArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *DescribeWorkflows) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *DescribeWorkflows) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	refs, err := workflowRefs(req.GetArguments()["workflows"])
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(refs) == 0 {
+		return mcp.NewToolResultError("Missing or invalid 'workflows' parameter"), nil
+	}
+	if len(refs) > maxBatchDescribe {
+		return mcp.NewToolResultError(fmt.Sprintf("Too many workflows: %d requested, at most %d per call", len(refs), maxBatchDescribe)), nil
+	}
+
+	client, err := callClient(t.Clients, req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	entries := make([]format.BatchEntry, len(refs))
+	total := len(refs)
+	if t.MaxItems > 0 {
+		total = min(total, t.MaxItems)
+	}
+	var done atomic.Int64
+	stats := t.Pool.Run(ctx, len(refs), t.MaxItems, func(ctx context.Context, i int) {
+		// The last item isn't reported: the response itself signals completion
+		defer func() {
+			if n := done.Add(1); n < int64(total) {
+				reportProgress(ctx, float64(n), float64(total), "described %d of %d workflows", n, total)
+			}
+		}()
+		ref := refs[i]
+		resp, err := client.DescribeWorkflowExecution(ctx, ref.WorkflowID, ref.RunID)
+		switch {
+		case isNotFound(err):
+			entries[i] = format.BatchEntry{WorkflowID: ref.WorkflowID, RunID: ref.RunID, Error: "not found"}
+		case err != nil:
+			t.Logger.Error("Error describing workflow", "workflow_id", ref.WorkflowID, "run_id", ref.RunID, "error", err)
+			entries[i] = format.BatchEntry{WorkflowID: ref.WorkflowID, RunID: ref.RunID, Error: err.Error()}
+		default:
+			entries[i] = format.NewBatchEntry(resp)
+			noteWorkflow(ctx, client.Namespace(), resp.GetWorkflowExecutionInfo())
+		}
+	})
+
+	// Entries skipped by the item cap or cancellation are left out; the stats line accounts for them
+	described := make([]format.BatchEntry, 0, stats.Processed)
+	for _, entry := range entries {
+		if entry.WorkflowID != "" {
+			described = append(described, entry)
+		}
+	}
+	return partialResult(partialFanout(ctx, stats, "workflows"), "text", mcp.NewToolResultText(format.BatchDescribeText(described, stats.String())), nil)
+}
+
+// workflowRefs parses the workflows argument. Besides an array of objects it accepts plain ID
+// strings, and the whole array encoded as a JSON string, since clients vary in how they send arrays.
+func workflowRefs(raw interface{}) ([]workflowRef, error) {
+	if encoded, ok := raw.(string); ok {
+		if err := json.Unmarshal([]byte(encoded), &raw); err != nil {
+			return nil, fmt.Errorf("Invalid 'workflows' parameter: %v", err)
+		}
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	refs := make([]workflowRef, 0, len(items))
+	for i, item := range items {
+		var ref workflowRef
+		switch v := item.(type) {
+		case string:
+			ref.WorkflowID = v
+		case map[string]interface{}:
+			var err error
+			if ref.WorkflowID, ref.RunID, err = executionArguments(v); err != nil {
+				return nil, fmt.Errorf("Invalid 'workflows' entry %d: %v", i, err)
+			}
+		}
+		if ref.WorkflowID == "" {
+			return nil, fmt.Errorf("Invalid 'workflows' entry %d: a workflow_id is required", i)
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
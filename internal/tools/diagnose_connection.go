@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// DiagnoseConnection implements the "diagnose_connection" tool.
+type DiagnoseConnection struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	// Connection is how the server connects to Temporal; the checks dial with the same settings.
+	Connection temporal.Options
+}
+
+// Definition implements Tool.
+func (t *DiagnoseConnection) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"diagnose_connection",
+		mcp.WithDescription("Diagnose the connection to Temporal in stages (DNS resolution, TCP dial, gRPC health check, GetSystemInfo, DescribeNamespace), reporting each stage's result with the specific error, the negotiated TLS state, and the client identity in use"),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// MaxTimeout implements LongRunning; each of the five stages may take up to its own timeout.
+func (t *DiagnoseConnection) MaxTimeout() time.Duration {
+	return time.Minute
+}
+
+// Handle implements Tool.
+func (t *DiagnoseConnection) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// Only the namespace and active address are taken from the client: the checks bypass it
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// The active endpoint is checked; with failover addresses the others are listed for context
+	d := temporal.Diagnose(ctx, t.Connection, client.Address(), client.Namespace())
+	view := format.ConnectionDiagnosis{Address: d.Address, Namespace: d.Namespace, Identity: d.Identity, TLS: d.TLS, OK: true}
+	if len(t.Connection.Addresses) > 1 {
+		view.Addresses = t.Connection.Addresses
+	}
+	if count, last := client.Reconnects(); count > 0 {
+		view.Reconnects, view.LastReconnect = count, &last
+	}
+	for _, s := range d.Stages {
+		stage := format.DiagnosticStage{Name: s.Name, Result: "pass", Detail: s.Detail}
+		switch {
+		case s.Skipped():
+			stage.Result = "skipped"
+		case s.Err != nil:
+			stage.Result = "fail"
+			stage.Error = s.Err.Error()
+			t.Logger.Warn("Connection check failed", "stage", s.Name, "address", d.Address, "error", s.Err)
+		}
+		if !s.Skipped() {
+			stage.Duration = s.Duration.Round(time.Millisecond).String()
+		}
+		view.OK = view.OK && s.Err == nil
+		view.Stages = append(view.Stages, stage)
+	}
+	return renderResult(outFormat, view, format.ConnectionDiagnosisText)
+}
@@ -0,0 +1,215 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wricardo/temporal-mcp/internal/audit"
+	"github.com/wricardo/temporal-mcp/internal/config"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// allTools returns every tool main registers, including the optional ones, backed by clients.
+func allTools(clients temporal.Provider, capabilities *temporal.Capabilities, payloads *payload.Renderer) []Tool {
+	fanout := pool.New(4)
+	return []Tool{
+		&ListWorkflows{Clients: clients, Logger: testLogger, Pool: fanout},
+		&ListScheduleRuns{Clients: clients, Logger: testLogger},
+		&PreviewScheduleSpec{Clients: clients, Logger: testLogger},
+		&ScheduleRecentActions{Clients: clients, Logger: testLogger, Pool: fanout},
+		&RunSavedQuery{Clients: clients, Logger: testLogger, Queries: []config.SavedQuery{{Name: "stuck", Description: "Stuck workflows", Query: "ExecutionStatus = 'Running'"}}},
+		&DescribeWorkflow{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&DescribeWorkflows{Clients: clients, Logger: testLogger, Pool: fanout},
+		&FindWorkflowsPendingActivity{Clients: clients, Logger: testLogger, Pool: fanout},
+		&FindExpiringWorkflows{Clients: clients, Logger: testLogger, Pool: fanout},
+		&FindLargeHistories{Clients: clients, Logger: testLogger, Pool: fanout},
+		&FindOrphanedTaskQueues{Clients: clients, Logger: testLogger, Pool: fanout},
+		&VersionReport{Clients: clients, Logger: testLogger},
+		&TaskLatencyReport{Clients: clients, Logger: testLogger, Pool: fanout},
+		&GetHistoryEvent{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&ListActivities{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&ListTimers{Clients: clients, Logger: testLogger},
+		&CheckWorkflowTaskFailures{Clients: clients, Logger: testLogger},
+		&ListSignals{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&ListMarkers{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&GetUpdateResult{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&SummarizeHistory{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&SearchHistory{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&ListResetPoints{Clients: clients, Logger: testLogger},
+		&ResetWorkflow{Clients: clients, Logger: testLogger, Capabilities: capabilities},
+		&PauseActivity{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&UnpauseActivity{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&ResetActivity{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&SignalMatching{Clients: clients, Logger: testLogger, Pool: fanout},
+		&StopBatchOperation{Clients: clients, Logger: testLogger},
+		&NamespaceSummary{Clients: clients, Logger: testLogger, Pool: fanout},
+		&WorkflowStats{Clients: clients, Logger: testLogger},
+		&WorkflowHistogram{Clients: clients, Logger: testLogger, Pool: fanout},
+		&AggregateSearchAttribute{Clients: clients, Logger: testLogger},
+		&QuerySyntaxHelp{Clients: clients, Logger: testLogger},
+		&ListWorkflowTypes{Clients: clients, Logger: testLogger},
+		&ListNamespaces{Clients: clients, Logger: testLogger},
+		&NamespaceReplication{Clients: clients, Logger: testLogger},
+		&UpdateNamespaceArchival{Clients: clients, Logger: testLogger},
+		&DeleteNamespace{Clients: clients, Logger: testLogger},
+		&ListNexusEndpoints{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&DescribeNexusEndpoint{Clients: clients, Logger: testLogger, Payloads: payloads},
+		&ServerInfo{Clients: clients, Logger: testLogger},
+		// Port 1 refuses connections, so the checks fail fast without leaving the host
+		&DiagnoseConnection{Clients: clients, Logger: testLogger, Connection: temporal.Options{Addresses: []string{"127.0.0.1:1"}, Namespace: "default"}},
+		&RecentWorkflowsTool{Recent: &RecentWorkflows{}},
+	}
+}
+
+// mutatingRPCs are the prefixes of the Client methods that change Temporal state.
+var mutatingRPCs = []string{"Create", "Delete", "Reset", "Signal", "Pause", "Unpause", "Stop", "Update"}
+
+// dryRunClient is a fake serving the reads the mutating tools preview with, in namespace, that
+// fails the test on any mutating RPC.
+func dryRunClient(t *testing.T, namespace string) *fakeClient {
+	workflow := execution("wf-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	workflow.HistoryLength = 5
+	return &fakeClient{
+		namespace: namespace,
+		onCall: func(method string) {
+			for _, prefix := range mutatingRPCs {
+				if strings.HasPrefix(method, prefix) {
+					t.Errorf("mutating RPC %s issued in dry-run mode", method)
+				}
+			}
+		},
+		getSystemInfo: func(context.Context) (*workflowservice.GetSystemInfoResponse, error) {
+			return &workflowservice.GetSystemInfoResponse{ServerVersion: "1.27.1", Capabilities: &workflowservice.GetSystemInfoResponse_Capabilities{SupportsSchedules: true}}, nil
+		},
+		describeWorkflowExecution: func(context.Context, string, string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+			return &workflowservice.DescribeWorkflowExecutionResponse{
+				WorkflowExecutionInfo: workflow,
+				PendingActivities: []*workflowpb.PendingActivityInfo{
+					{ActivityId: "act-1", ActivityType: &commonpb.ActivityType{Name: "Charge"}, State: enumspb.PENDING_ACTIVITY_STATE_SCHEDULED, Attempt: 3},
+					{ActivityId: "act-2", ActivityType: &commonpb.ActivityType{Name: "Ship"}, State: enumspb.PENDING_ACTIVITY_STATE_SCHEDULED, Attempt: 1, Paused: true},
+				},
+			}, nil
+		},
+		getWorkflowExecutionHistory: func(context.Context, *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+			var events []*historypb.HistoryEvent
+			for i, eventType := range []enumspb.EventType{
+				enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+				enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED,
+				enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED,
+				enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED,
+				enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+			} {
+				events = append(events, &historypb.HistoryEvent{EventId: int64(i + 1), EventType: eventType, EventTime: timestamppb.New(testTime)})
+			}
+			return &workflowservice.GetWorkflowExecutionHistoryResponse{History: &historypb.History{Events: events}}, nil
+		},
+		listWorkflow: func(context.Context, *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+			return &workflowservice.ListWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{workflow}}, nil
+		},
+		countWorkflow: func(context.Context, *workflowservice.CountWorkflowExecutionsRequest) (*workflowservice.CountWorkflowExecutionsResponse, error) {
+			return &workflowservice.CountWorkflowExecutionsResponse{Count: 1}, nil
+		},
+		describeBatchOperation: func(_ context.Context, req *workflowservice.DescribeBatchOperationRequest) (*workflowservice.DescribeBatchOperationResponse, error) {
+			return &workflowservice.DescribeBatchOperationResponse{
+				JobId:               req.GetJobId(),
+				OperationType:       enumspb.BATCH_OPERATION_TYPE_SIGNAL,
+				State:               enumspb.BATCH_OPERATION_STATE_RUNNING,
+				StartTime:           timestamppb.New(testTime),
+				TotalOperationCount: 10,
+			}, nil
+		},
+		describeNamespace: func(_ context.Context, name string) (*workflowservice.DescribeNamespaceResponse, error) {
+			return &workflowservice.DescribeNamespaceResponse{
+				NamespaceInfo: &namespacepb.NamespaceInfo{Name: name, State: enumspb.NAMESPACE_STATE_REGISTERED},
+				Config: &namespacepb.NamespaceConfig{
+					WorkflowExecutionRetentionTtl: durationpb.New(72 * time.Hour),
+					HistoryArchivalState:          enumspb.ARCHIVAL_STATE_DISABLED,
+					VisibilityArchivalState:       enumspb.ARCHIVAL_STATE_DISABLED,
+				},
+			}, nil
+		},
+	}
+}
+
+func TestDryRunIssuesNoMutatingRPC(t *testing.T) {
+	base, staging := dryRunClient(t, "default"), dryRunClient(t, "staging")
+	clients := newFakeProvider(base, staging)
+	srv := server.NewMCPServer("test", "0.0.0")
+	registry := &Registry{
+		Server:    srv,
+		Logger:    testLogger,
+		Auditor:   audit.NopSink{},
+		Namespace: "default",
+		Timeout:   10 * time.Second,
+		DryRun:    true,
+		Clients:   clients,
+		Calls:     &InflightCalls{},
+	}
+	tools := allTools(clients, temporal.NewCapabilities(base, nil), newTestRenderer(t))
+	for _, tool := range tools {
+		registry.Register(tool)
+	}
+
+	// The arguments of each mutating tool reach its write: a valid target and confirm=true
+	mutating := map[string]map[string]any{
+		"reset_workflow":            {"workflow_id": "wf-1", "event_id": 4.0},
+		"pause_activity":            {"workflow_id": "wf-1", "activity_id": "act-1"},
+		"unpause_activity":          {"workflow_id": "wf-1", "activity_id": "act-2"},
+		"reset_activity":            {"workflow_id": "wf-1", "activity_id": "act-1"},
+		"signal_matching":           {"query": "WorkflowType = 'OrderWorkflow'", "signal_name": "cancel"},
+		"stop_batch_operation":      {"job_id": "job-1", "reason": "rehearsal"},
+		"update_namespace_archival": {"namespace": "default", "history_archival": "enabled"},
+		"delete_namespace":          {"namespace": "staging", "confirm_namespace": "staging"},
+		"preview_schedule_spec":     {"cron": "0 9 * * *"},
+	}
+	for _, tool := range tools {
+		name := tool.Definition().Name
+		t.Run(name, func(t *testing.T) {
+			registered := srv.GetTool(name)
+			if registered == nil {
+				t.Fatalf("%s not registered", name)
+			}
+			_, isMutating := tool.(Mutating)
+			if _, listed := mutating[name]; listed != isMutating {
+				t.Fatalf("%s: Mutating implemented = %v, but listed as mutating = %v", name, isMutating, listed)
+			}
+
+			args := map[string]any{"workflow_id": "wf-1", "status": "running", "query": "ExecutionStatus = 'Running'"}
+			if isMutating {
+				args = mutating[name]
+			}
+			args["confirm"] = true
+			req := mcp.CallToolRequest{}
+			req.Params.Name, req.Params.Arguments = name, args
+			result, err := registered.Handler(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !isMutating {
+				return
+			}
+			if !strings.Contains(registered.Tool.Description, "[DRY RUN: this server only previews changes; nothing is modified]") {
+				t.Errorf("description lacks the dry-run note: %s", registered.Tool.Description)
+			}
+			if result.IsError {
+				t.Fatalf("want a dry-run preview, got an error: %s", resultText(result))
+			}
+			assertContains(t, resultText(result), "[DRY RUN]")
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// errorHint explains a gRPC status code to agents: what it means and what to do about it.
+type errorHint struct {
+	explanation, hint string
+}
+
+// errorHints covers the codes Temporal calls commonly fail with.
+var errorHints = map[codes.Code]errorHint{
+	codes.NotFound:           {"the workflow, run, or other resource doesn't exist, or was deleted after its retention period", "check the workflow_id (and run_id), or find it with list_workflows"},
+	codes.PermissionDenied:   {"the server refused the call for this client's identity", "namespace or credentials issue: check the namespace and the API key or mTLS certificate's permissions"},
+	codes.Unauthenticated:    {"the server couldn't authenticate this client", "credentials issue: check the API key or mTLS certificate"},
+	codes.Unavailable:        {"the Temporal frontend couldn't be reached or is overloaded", "transient, retry; if it persists, run diagnose_connection"},
+	codes.DeadlineExceeded:   {"the call didn't finish before its deadline", "transient, retry; for slow calls pass a larger timeout_seconds or narrow the query"},
+	codes.ResourceExhausted:  {"the server is rate limiting this namespace or is out of capacity", "transient, retry after a short wait"},
+	codes.Canceled:           {"the call was cancelled before it finished", "retry if it wasn't cancelled on purpose"},
+	codes.InvalidArgument:    {"the server rejected the request as invalid", "check the arguments, e.g. the syntax of a visibility query (query_syntax_help validates one)"},
+	codes.FailedPrecondition: {"the request isn't valid in the current state of the execution or namespace", "check the workflow's state with describe_workflow before retrying"},
+	codes.AlreadyExists:      {"a resource with this ID already exists", "use a different ID, or describe the existing one"},
+	codes.Unimplemented:      {"the connected server doesn't support this call", "check server_info; the server or its visibility store may be too old for this tool"},
+	codes.Internal:           {"the Temporal server failed internally", "retry; if it persists, check the Temporal server logs"},
+}
+
+// errorCode returns the gRPC status code of a failed Temporal call, looking through wrapped errors,
+// or false when err carries none.
+func errorCode(err error) (codes.Code, bool) {
+	var serviceErr serviceerror.ServiceError
+	switch {
+	case errors.As(err, &serviceErr):
+		return serviceErr.Status().Code(), true
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded, true
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled, true
+	}
+	if s, ok := status.FromError(err); ok && s.Code() != codes.OK && s.Code() != codes.Unknown {
+		return s.Code(), true
+	}
+	return codes.OK, false
+}
+
+// translateError describes a failed Temporal call for a tool error: action (e.g. "Failed to
+// describe workflow") and the error, with the error's gRPC code, explanation, and hint when known.
+func translateError(action string, err error) format.ToolError {
+	var notFound *serviceerror.NamespaceNotFound
+	if errors.As(err, &notFound) {
+		e := format.ToolError{
+			Error:     fmt.Sprintf("%s: namespace '%s' does not exist on this cluster", action, notFound.Namespace),
+			Namespace: notFound.Namespace,
+		}
+		withCode(&e, codes.NotFound)
+		e.Explanation = "the namespace isn't registered on the connected Temporal cluster"
+		e.Hint = "check the namespace argument or TEMPORAL_NAMESPACE; list_namespaces shows the registered ones"
+		return e
+	}
+
+	e := format.ToolError{Error: action + ": " + err.Error()}
+	if code, ok := errorCode(err); ok {
+		withCode(&e, code)
+	}
+	return e
+}
+
+// argumentMessage matches the messages of argument validation failures, which start by naming the
+// argument, e.g. "Missing or invalid 'workflow_id' parameter" or "invalid value for 'limit': ...".
+var argumentMessage = regexp.MustCompile(`^(?i:missing or invalid|missing|invalid value for|invalid) '([a-z_]+)'`)
+
+// translateMessage describes a tool error reported as a bare message. Argument validation failures
+// get the InvalidArgument code, with the argument named.
+func translateMessage(message string) format.ToolError {
+	e := format.ToolError{Error: message}
+	if m := argumentMessage.FindStringSubmatch(message); m != nil {
+		e.Code, e.Argument = codes.InvalidArgument.String(), m[1]
+		e.Explanation = "an argument of the call is missing or invalid"
+		e.Hint = "fix the argument named in the error; the tool's input schema lists the accepted values"
+	}
+	return e
+}
+
+// withCode sets the code of e, with its explanation and hint.
+func withCode(e *format.ToolError, code codes.Code) {
+	e.Code = code.String()
+	if hint, ok := errorHints[code]; ok {
+		e.Explanation, e.Hint = hint.explanation, hint.hint
+	}
+}
+
+// rpcError is the tool error for a failed Temporal call; see translateError. Every handler reports
+// failed RPCs through it, so agents always get the code and hint.
+func rpcError(action string, err error) *mcp.CallToolResult {
+	return errorResult(translateError(action, err))
+}
+
+// errorResult renders e as a tool error, carrying its fields as structured content too.
+func errorResult(e format.ToolError) *mcp.CallToolResult {
+	result := mcp.NewToolResultError(format.ToolErrorText(e))
+	result.StructuredContent = e
+	return result
+}
+
+// withNamespaceNotFound completes the errors of calls that named a namespace missing from the
+// cluster with the namespaces that do exist, from the provider's cached list. A failed lookup only
+// leaves them out.
+func withNamespaceNotFound(clients temporal.Provider, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, req)
+		if err != nil || result == nil || !result.IsError {
+			return result, err
+		}
+		e, ok := result.StructuredContent.(format.ToolError)
+		if !ok || e.Namespace == "" {
+			return result, err
+		}
+		available, listErr := clients.Namespaces(ctx)
+		if listErr != nil || len(available) == 0 {
+			return result, err
+		}
+		e.Error += "; available: " + strings.Join(available, ", ")
+		e.AvailableNamespaces = available
+		return errorResult(e), nil
+	}
+}
+
+// withErrorFormat renders the errors of a tool with a format argument as a JSON error envelope
+// when the call asks for JSON output, so both successful and failed calls can be parsed.
+func withErrorFormat(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, req)
+		if err != nil || result == nil || !result.IsError {
+			return result, err
+		}
+		if outFormat, _ := outputFormat(req.GetArguments()); outFormat != "json" {
+			return result, err
+		}
+		e, ok := result.StructuredContent.(format.ToolError)
+		if !ok {
+			e = translateMessage(resultText(result))
+		}
+		envelope := e.Envelope()
+		out, jsonErr := format.JSON(envelope)
+		if jsonErr != nil {
+			return result, err
+		}
+		result.Content = []mcp.Content{mcp.NewTextContent(out)}
+		result.StructuredContent = envelope
+		return result, err
+	}
+}
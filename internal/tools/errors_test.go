@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+)
+
+func TestTranslateError(t *testing.T) {
+	hinted := func(message string, code codes.Code) format.ToolError {
+		return format.ToolError{Error: message, Code: code.String(), Explanation: errorHints[code].explanation, Hint: errorHints[code].hint}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want format.ToolError
+	}{
+		{
+			name: "not found",
+			err:  serviceerror.NewNotFound("workflow not found for ID: order-1"),
+			want: hinted("Failed to describe workflow: workflow not found for ID: order-1", codes.NotFound),
+		},
+		{
+			name: "permission denied",
+			err:  serviceerror.NewPermissionDenied("Request unauthorized.", ""),
+			want: hinted("Failed to describe workflow: Request unauthorized.", codes.PermissionDenied),
+		},
+		{
+			name: "unavailable",
+			err:  serviceerror.NewUnavailable("connection refused"),
+			want: hinted("Failed to describe workflow: connection refused", codes.Unavailable),
+		},
+		{
+			name: "server deadline exceeded",
+			err:  serviceerror.NewDeadlineExceeded("context deadline exceeded"),
+			want: hinted("Failed to describe workflow: context deadline exceeded", codes.DeadlineExceeded),
+		},
+		{
+			// The tool's own deadline passing surfaces as a context error, possibly wrapped
+			name: "local deadline exceeded",
+			err:  fmt.Errorf("list page 2: %w", context.DeadlineExceeded),
+			want: hinted("Failed to describe workflow: list page 2: context deadline exceeded", codes.DeadlineExceeded),
+		},
+		{
+			name: "wrapped service error",
+			err:  fmt.Errorf("after 3 attempts: %w", serviceerror.NewResourceExhausted(0, "namespace rate limit exceeded")),
+			want: hinted("Failed to describe workflow: after 3 attempts: namespace rate limit exceeded", codes.ResourceExhausted),
+		},
+		{
+			name: "bare gRPC status",
+			err:  status.Error(codes.Unimplemented, "unknown method"),
+			want: hinted("Failed to describe workflow: rpc error: code = Unimplemented desc = unknown method", codes.Unimplemented),
+		},
+		{
+			name: "code without a hint",
+			err:  status.Error(codes.Aborted, "transaction conflict"),
+			want: format.ToolError{Error: "Failed to describe workflow: rpc error: code = Aborted desc = transaction conflict", Code: "Aborted"},
+		},
+		{
+			name: "no code",
+			err:  fmt.Errorf("decode payload: unexpected end of JSON input"),
+			want: format.ToolError{Error: "Failed to describe workflow: decode payload: unexpected end of JSON input"},
+		},
+		{
+			name: "namespace not found",
+			err:  fmt.Errorf("describe: %w", serviceerror.NewNamespaceNotFound("payments")),
+			want: format.ToolError{
+				Error:       "Failed to describe workflow: namespace 'payments' does not exist on this cluster",
+				Code:        "NotFound",
+				Explanation: "the namespace isn't registered on the connected Temporal cluster",
+				Hint:        "check the namespace argument or TEMPORAL_NAMESPACE; list_namespaces shows the registered ones",
+				Namespace:   "payments",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := translateError("Failed to describe workflow", tt.err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("translateError:\ngot  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorHintsComplete(t *testing.T) {
+	for code, hint := range errorHints {
+		if hint.explanation == "" || hint.hint == "" {
+			t.Errorf("%s: explanation %q, hint %q: want both", code, hint.explanation, hint.hint)
+		}
+	}
+}
+
+func TestTranslateMessage(t *testing.T) {
+	tests := []struct {
+		message        string
+		code, argument string
+	}{
+		{"Missing or invalid 'workflow_id' parameter", "InvalidArgument", "workflow_id"},
+		{"invalid value for 'page_size': must be between 1 and 1000", "InvalidArgument", "page_size"},
+		{"Invalid 'id_regex' parameter: error parsing regexp", "InvalidArgument", "id_regex"},
+		{"Exports are disabled on this server", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			e := translateMessage(tt.message)
+			if e.Argument != tt.argument {
+				t.Errorf("argument = %q, want %q", e.Argument, tt.argument)
+			}
+			if e.Code != tt.code {
+				t.Errorf("code = %q, want %q", e.Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestNamespaceNotFoundListsAvailable(t *testing.T) {
+	clients := newFakeProvider(&fakeClient{namespace: "default"}, &fakeClient{namespace: "orders"})
+	handler := withNamespaceNotFound(clients, func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return rpcError("Failed to list workflows", serviceerror.NewNamespaceNotFound("payments")), nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Failed to list workflows: namespace 'payments' does not exist on this cluster; available: default, orders\n" +
+		"Code: NotFound (the namespace isn't registered on the connected Temporal cluster)\n" +
+		"Hint: check the namespace argument or TEMPORAL_NAMESPACE; list_namespaces shows the registered ones"
+	if got := resultText(result); got != want {
+		t.Errorf("error:\n%s\nwant:\n%s", got, want)
+	}
+	e, ok := result.StructuredContent.(format.ToolError)
+	if !ok {
+		t.Fatalf("structured content is %T, want format.ToolError", result.StructuredContent)
+	}
+	if want := []string{"default", "orders"}; !reflect.DeepEqual(e.AvailableNamespaces, want) {
+		t.Errorf("available namespaces = %v, want %v", e.AvailableNamespaces, want)
+	}
+}
+
+func TestErrorFormatJSON(t *testing.T) {
+	const message = "invalid value for 'page_size': must be between 1 and 1000"
+	handler := withErrorFormat(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError(message), nil
+	})
+	call := func(outFormat string) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"format": outFormat}
+		result, err := handler(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	// The envelope sent to clients is the one pinned by the format package's golden file
+	want, err := os.ReadFile("../format/testdata/error_envelope_invalid_argument.json.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := call("json")
+	if !result.IsError {
+		t.Error("JSON error result isn't marked as an error")
+	}
+	if got := resultText(result); got != string(want) {
+		t.Errorf("JSON error:\n%s\nwant:\n%s", got, want)
+	}
+	if _, ok := result.StructuredContent.(format.ErrorEnvelope); !ok {
+		t.Errorf("structured content is %T, want format.ErrorEnvelope", result.StructuredContent)
+	}
+
+	if got := resultText(call("text")); got != message {
+		t.Errorf("text error = %q, want it unchanged", got)
+	}
+}
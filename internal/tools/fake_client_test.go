@@ -0,0 +1,396 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	operatorservice "go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// fakeClient is a temporal.Client serving canned responses, for handler tests that need no
+// Temporal server. Each RPC calls the function field of the same name; RPCs whose field is unset
+// fail with Unimplemented, so a test sees any RPC it didn't set up. Every RPC is recorded in calls,
+// in the order made.
+type fakeClient struct {
+	namespace string
+	identity  string
+	// onCall, when set, sees each RPC's name before it is served, e.g. to fail the test on writes.
+	onCall func(method string)
+
+	mu    sync.Mutex
+	calls []string
+
+	listOpenWorkflow            func(ctx context.Context, request *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error)
+	listClosedWorkflow          func(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest) (*workflowservice.ListClosedWorkflowExecutionsResponse, error)
+	listWorkflow                func(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error)
+	countWorkflow               func(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest) (*workflowservice.CountWorkflowExecutionsResponse, error)
+	describeWorkflowExecution   func(ctx context.Context, workflowID, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, error)
+	getWorkflowExecutionHistory func(ctx context.Context, request *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error)
+	getSystemInfo               func(ctx context.Context) (*workflowservice.GetSystemInfoResponse, error)
+	getClusterInfo              func(ctx context.Context) (*workflowservice.GetClusterInfoResponse, error)
+	describeTaskQueue           func(ctx context.Context, request *workflowservice.DescribeTaskQueueRequest) (*workflowservice.DescribeTaskQueueResponse, error)
+	getWorkerVersioningRules    func(ctx context.Context, request *workflowservice.GetWorkerVersioningRulesRequest) (*workflowservice.GetWorkerVersioningRulesResponse, error)
+	listSchedules               func(ctx context.Context, request *workflowservice.ListSchedulesRequest) (*workflowservice.ListSchedulesResponse, error)
+	describeSchedule            func(ctx context.Context, request *workflowservice.DescribeScheduleRequest) (*workflowservice.DescribeScheduleResponse, error)
+	listScheduleMatchingTimes   func(ctx context.Context, request *workflowservice.ListScheduleMatchingTimesRequest) (*workflowservice.ListScheduleMatchingTimesResponse, error)
+	createSchedule              func(ctx context.Context, request *workflowservice.CreateScheduleRequest) (*workflowservice.CreateScheduleResponse, error)
+	deleteSchedule              func(ctx context.Context, request *workflowservice.DeleteScheduleRequest) (*workflowservice.DeleteScheduleResponse, error)
+	resetWorkflowExecution      func(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest) (*workflowservice.ResetWorkflowExecutionResponse, error)
+	signalWorkflow              func(ctx context.Context, workflowID, runID, signalName string, input interface{}) error
+	pauseActivity               func(ctx context.Context, request *workflowservice.PauseActivityRequest) (*workflowservice.PauseActivityResponse, error)
+	unpauseActivity             func(ctx context.Context, request *workflowservice.UnpauseActivityRequest) (*workflowservice.UnpauseActivityResponse, error)
+	resetActivity               func(ctx context.Context, request *workflowservice.ResetActivityRequest) (*workflowservice.ResetActivityResponse, error)
+	describeBatchOperation      func(ctx context.Context, request *workflowservice.DescribeBatchOperationRequest) (*workflowservice.DescribeBatchOperationResponse, error)
+	stopBatchOperation          func(ctx context.Context, request *workflowservice.StopBatchOperationRequest) (*workflowservice.StopBatchOperationResponse, error)
+	pollWorkflowExecutionUpdate func(ctx context.Context, request *workflowservice.PollWorkflowExecutionUpdateRequest) (*workflowservice.PollWorkflowExecutionUpdateResponse, error)
+	listNamespaces              func(ctx context.Context, request *workflowservice.ListNamespacesRequest) (*workflowservice.ListNamespacesResponse, error)
+	namespaceSettings           func(ctx context.Context) (*workflowservice.DescribeNamespaceResponse, error)
+	describeNamespace           func(ctx context.Context, namespace string) (*workflowservice.DescribeNamespaceResponse, error)
+	updateNamespace             func(ctx context.Context, request *workflowservice.UpdateNamespaceRequest) (*workflowservice.UpdateNamespaceResponse, error)
+	deleteNamespace             func(ctx context.Context, request *operatorservice.DeleteNamespaceRequest) (*operatorservice.DeleteNamespaceResponse, error)
+	listSearchAttributes        func(ctx context.Context) (*operatorservice.ListSearchAttributesResponse, error)
+	listNexusEndpoints          func(ctx context.Context, request *operatorservice.ListNexusEndpointsRequest) (*operatorservice.ListNexusEndpointsResponse, error)
+	getNexusEndpoint            func(ctx context.Context, id string) (*operatorservice.GetNexusEndpointResponse, error)
+}
+
+var _ temporal.Client = (*fakeClient)(nil)
+
+// record notes a call of method.
+func (f *fakeClient) record(method string) {
+	f.mu.Lock()
+	f.calls = append(f.calls, method)
+	f.mu.Unlock()
+	if f.onCall != nil {
+		f.onCall(method)
+	}
+}
+
+// called returns the RPCs made so far.
+func (f *fakeClient) called() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+// unimplemented is the error of an RPC the test didn't set up.
+func unimplemented(method string) error {
+	return serviceerror.NewUnimplemented("fake client: " + method + " not set up")
+}
+
+func (f *fakeClient) Namespace() string {
+	if f.namespace == "" {
+		return "default"
+	}
+	return f.namespace
+}
+
+func (f *fakeClient) Address() string { return "fake:7233" }
+
+func (f *fakeClient) Reconnects() (int, time.Time) { return 0, time.Time{} }
+
+func (f *fakeClient) Identity(ctx context.Context) string {
+	if f.identity == "" {
+		return temporal.CallIdentity(ctx, "temporal-mcp@test")
+	}
+	return temporal.CallIdentity(ctx, f.identity)
+}
+
+func (f *fakeClient) ListOpenWorkflow(ctx context.Context, request *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+	f.record("ListOpenWorkflow")
+	if f.listOpenWorkflow == nil {
+		return nil, unimplemented("ListOpenWorkflow")
+	}
+	return f.listOpenWorkflow(ctx, request)
+}
+
+func (f *fakeClient) ListClosedWorkflow(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest) (*workflowservice.ListClosedWorkflowExecutionsResponse, error) {
+	f.record("ListClosedWorkflow")
+	if f.listClosedWorkflow == nil {
+		return nil, unimplemented("ListClosedWorkflow")
+	}
+	return f.listClosedWorkflow(ctx, request)
+}
+
+func (f *fakeClient) ListWorkflow(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	f.record("ListWorkflow")
+	if f.listWorkflow == nil {
+		return nil, unimplemented("ListWorkflow")
+	}
+	return f.listWorkflow(ctx, request)
+}
+
+func (f *fakeClient) CountWorkflow(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest) (*workflowservice.CountWorkflowExecutionsResponse, error) {
+	f.record("CountWorkflow")
+	if f.countWorkflow == nil {
+		return nil, unimplemented("CountWorkflow")
+	}
+	return f.countWorkflow(ctx, request)
+}
+
+func (f *fakeClient) DescribeWorkflowExecution(ctx context.Context, workflowID, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+	f.record("DescribeWorkflowExecution")
+	if f.describeWorkflowExecution == nil {
+		return nil, unimplemented("DescribeWorkflowExecution")
+	}
+	return f.describeWorkflowExecution(ctx, workflowID, runID)
+}
+
+func (f *fakeClient) GetWorkflowExecutionHistory(ctx context.Context, request *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+	f.record("GetWorkflowExecutionHistory")
+	if f.getWorkflowExecutionHistory == nil {
+		return nil, unimplemented("GetWorkflowExecutionHistory")
+	}
+	return f.getWorkflowExecutionHistory(ctx, request)
+}
+
+func (f *fakeClient) GetSystemInfo(ctx context.Context) (*workflowservice.GetSystemInfoResponse, error) {
+	f.record("GetSystemInfo")
+	if f.getSystemInfo == nil {
+		return nil, unimplemented("GetSystemInfo")
+	}
+	return f.getSystemInfo(ctx)
+}
+
+func (f *fakeClient) GetClusterInfo(ctx context.Context) (*workflowservice.GetClusterInfoResponse, error) {
+	f.record("GetClusterInfo")
+	if f.getClusterInfo == nil {
+		return nil, unimplemented("GetClusterInfo")
+	}
+	return f.getClusterInfo(ctx)
+}
+
+func (f *fakeClient) DescribeTaskQueue(ctx context.Context, request *workflowservice.DescribeTaskQueueRequest) (*workflowservice.DescribeTaskQueueResponse, error) {
+	f.record("DescribeTaskQueue")
+	if f.describeTaskQueue == nil {
+		return nil, unimplemented("DescribeTaskQueue")
+	}
+	return f.describeTaskQueue(ctx, request)
+}
+
+func (f *fakeClient) GetWorkerVersioningRules(ctx context.Context, request *workflowservice.GetWorkerVersioningRulesRequest) (*workflowservice.GetWorkerVersioningRulesResponse, error) {
+	f.record("GetWorkerVersioningRules")
+	if f.getWorkerVersioningRules == nil {
+		return nil, unimplemented("GetWorkerVersioningRules")
+	}
+	return f.getWorkerVersioningRules(ctx, request)
+}
+
+func (f *fakeClient) ListSchedules(ctx context.Context, request *workflowservice.ListSchedulesRequest) (*workflowservice.ListSchedulesResponse, error) {
+	f.record("ListSchedules")
+	if f.listSchedules == nil {
+		return nil, unimplemented("ListSchedules")
+	}
+	return f.listSchedules(ctx, request)
+}
+
+func (f *fakeClient) DescribeSchedule(ctx context.Context, request *workflowservice.DescribeScheduleRequest) (*workflowservice.DescribeScheduleResponse, error) {
+	f.record("DescribeSchedule")
+	if f.describeSchedule == nil {
+		return nil, unimplemented("DescribeSchedule")
+	}
+	return f.describeSchedule(ctx, request)
+}
+
+func (f *fakeClient) ListScheduleMatchingTimes(ctx context.Context, request *workflowservice.ListScheduleMatchingTimesRequest) (*workflowservice.ListScheduleMatchingTimesResponse, error) {
+	f.record("ListScheduleMatchingTimes")
+	if f.listScheduleMatchingTimes == nil {
+		return nil, unimplemented("ListScheduleMatchingTimes")
+	}
+	return f.listScheduleMatchingTimes(ctx, request)
+}
+
+func (f *fakeClient) CreateSchedule(ctx context.Context, request *workflowservice.CreateScheduleRequest) (*workflowservice.CreateScheduleResponse, error) {
+	f.record("CreateSchedule")
+	if f.createSchedule == nil {
+		return nil, unimplemented("CreateSchedule")
+	}
+	return f.createSchedule(ctx, request)
+}
+
+func (f *fakeClient) DeleteSchedule(ctx context.Context, request *workflowservice.DeleteScheduleRequest) (*workflowservice.DeleteScheduleResponse, error) {
+	f.record("DeleteSchedule")
+	if f.deleteSchedule == nil {
+		return nil, unimplemented("DeleteSchedule")
+	}
+	return f.deleteSchedule(ctx, request)
+}
+
+func (f *fakeClient) ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest) (*workflowservice.ResetWorkflowExecutionResponse, error) {
+	f.record("ResetWorkflowExecution")
+	if f.resetWorkflowExecution == nil {
+		return nil, unimplemented("ResetWorkflowExecution")
+	}
+	return f.resetWorkflowExecution(ctx, request)
+}
+
+func (f *fakeClient) SignalWorkflow(ctx context.Context, workflowID, runID, signalName string, input interface{}) error {
+	f.record("SignalWorkflow")
+	if f.signalWorkflow == nil {
+		return unimplemented("SignalWorkflow")
+	}
+	return f.signalWorkflow(ctx, workflowID, runID, signalName, input)
+}
+
+func (f *fakeClient) PauseActivity(ctx context.Context, request *workflowservice.PauseActivityRequest) (*workflowservice.PauseActivityResponse, error) {
+	f.record("PauseActivity")
+	if f.pauseActivity == nil {
+		return nil, unimplemented("PauseActivity")
+	}
+	return f.pauseActivity(ctx, request)
+}
+
+func (f *fakeClient) UnpauseActivity(ctx context.Context, request *workflowservice.UnpauseActivityRequest) (*workflowservice.UnpauseActivityResponse, error) {
+	f.record("UnpauseActivity")
+	if f.unpauseActivity == nil {
+		return nil, unimplemented("UnpauseActivity")
+	}
+	return f.unpauseActivity(ctx, request)
+}
+
+func (f *fakeClient) ResetActivity(ctx context.Context, request *workflowservice.ResetActivityRequest) (*workflowservice.ResetActivityResponse, error) {
+	f.record("ResetActivity")
+	if f.resetActivity == nil {
+		return nil, unimplemented("ResetActivity")
+	}
+	return f.resetActivity(ctx, request)
+}
+
+func (f *fakeClient) DescribeBatchOperation(ctx context.Context, request *workflowservice.DescribeBatchOperationRequest) (*workflowservice.DescribeBatchOperationResponse, error) {
+	f.record("DescribeBatchOperation")
+	if f.describeBatchOperation == nil {
+		return nil, unimplemented("DescribeBatchOperation")
+	}
+	return f.describeBatchOperation(ctx, request)
+}
+
+func (f *fakeClient) StopBatchOperation(ctx context.Context, request *workflowservice.StopBatchOperationRequest) (*workflowservice.StopBatchOperationResponse, error) {
+	f.record("StopBatchOperation")
+	if f.stopBatchOperation == nil {
+		return nil, unimplemented("StopBatchOperation")
+	}
+	return f.stopBatchOperation(ctx, request)
+}
+
+func (f *fakeClient) PollWorkflowExecutionUpdate(ctx context.Context, request *workflowservice.PollWorkflowExecutionUpdateRequest) (*workflowservice.PollWorkflowExecutionUpdateResponse, error) {
+	f.record("PollWorkflowExecutionUpdate")
+	if f.pollWorkflowExecutionUpdate == nil {
+		return nil, unimplemented("PollWorkflowExecutionUpdate")
+	}
+	return f.pollWorkflowExecutionUpdate(ctx, request)
+}
+
+func (f *fakeClient) ListNamespaces(ctx context.Context, request *workflowservice.ListNamespacesRequest) (*workflowservice.ListNamespacesResponse, error) {
+	f.record("ListNamespaces")
+	if f.listNamespaces == nil {
+		return nil, unimplemented("ListNamespaces")
+	}
+	return f.listNamespaces(ctx, request)
+}
+
+func (f *fakeClient) NamespaceSettings(ctx context.Context) (*workflowservice.DescribeNamespaceResponse, error) {
+	f.record("NamespaceSettings")
+	if f.namespaceSettings == nil {
+		return nil, unimplemented("NamespaceSettings")
+	}
+	return f.namespaceSettings(ctx)
+}
+
+func (f *fakeClient) DescribeNamespace(ctx context.Context, namespace string) (*workflowservice.DescribeNamespaceResponse, error) {
+	f.record("DescribeNamespace")
+	if f.describeNamespace == nil {
+		return nil, unimplemented("DescribeNamespace")
+	}
+	return f.describeNamespace(ctx, namespace)
+}
+
+func (f *fakeClient) UpdateNamespace(ctx context.Context, request *workflowservice.UpdateNamespaceRequest) (*workflowservice.UpdateNamespaceResponse, error) {
+	f.record("UpdateNamespace")
+	if f.updateNamespace == nil {
+		return nil, unimplemented("UpdateNamespace")
+	}
+	return f.updateNamespace(ctx, request)
+}
+
+func (f *fakeClient) DeleteNamespace(ctx context.Context, request *operatorservice.DeleteNamespaceRequest) (*operatorservice.DeleteNamespaceResponse, error) {
+	f.record("DeleteNamespace")
+	if f.deleteNamespace == nil {
+		return nil, unimplemented("DeleteNamespace")
+	}
+	return f.deleteNamespace(ctx, request)
+}
+
+func (f *fakeClient) ListSearchAttributes(ctx context.Context) (*operatorservice.ListSearchAttributesResponse, error) {
+	f.record("ListSearchAttributes")
+	if f.listSearchAttributes == nil {
+		return nil, unimplemented("ListSearchAttributes")
+	}
+	return f.listSearchAttributes(ctx)
+}
+
+func (f *fakeClient) ListNexusEndpoints(ctx context.Context, request *operatorservice.ListNexusEndpointsRequest) (*operatorservice.ListNexusEndpointsResponse, error) {
+	f.record("ListNexusEndpoints")
+	if f.listNexusEndpoints == nil {
+		return nil, unimplemented("ListNexusEndpoints")
+	}
+	return f.listNexusEndpoints(ctx, request)
+}
+
+func (f *fakeClient) GetNexusEndpoint(ctx context.Context, id string) (*operatorservice.GetNexusEndpointResponse, error) {
+	f.record("GetNexusEndpoint")
+	if f.getNexusEndpoint == nil {
+		return nil, unimplemented("GetNexusEndpoint")
+	}
+	return f.getNexusEndpoint(ctx, id)
+}
+
+// fakeProvider is a temporal.Provider handing out fake clients by namespace; the first client's
+// namespace is the default.
+type fakeProvider struct {
+	clients          map[string]*fakeClient
+	defaultNamespace string
+}
+
+var _ temporal.Provider = (*fakeProvider)(nil)
+
+// newFakeProvider returns a provider of clients, each allowed in its own namespace.
+func newFakeProvider(clients ...*fakeClient) *fakeProvider {
+	p := &fakeProvider{clients: make(map[string]*fakeClient), defaultNamespace: clients[0].Namespace()}
+	for _, c := range clients {
+		p.clients[c.Namespace()] = c
+	}
+	return p
+}
+
+func (p *fakeProvider) Client(namespace string) (temporal.Client, error) {
+	if namespace == "" {
+		namespace = p.defaultNamespace
+	}
+	c, ok := p.clients[namespace]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", temporal.ErrNamespaceNotAllowed, namespace)
+	}
+	return c, nil
+}
+
+func (p *fakeProvider) Allowed(namespace string) bool {
+	_, ok := p.clients[namespace]
+	return ok
+}
+
+func (p *fakeProvider) DefaultNamespace() string { return p.defaultNamespace }
+
+func (p *fakeProvider) Namespaces(context.Context) ([]string, error) {
+	namespaces := make([]string, 0, len(p.clients))
+	for namespace := range p.clients {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
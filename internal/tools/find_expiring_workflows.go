@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// maxExpiringScan caps how many running workflows find_expiring_workflows lists.
+	maxExpiringScan = 1000
+	// defaultExpiringWindow is how far ahead find_expiring_workflows looks by default.
+	defaultExpiringWindow = 6 * time.Hour
+)
+
+// FindExpiringWorkflows implements the "find_expiring_workflows" tool, describing running
+// workflows through the shared fan-out pool to find those about to hit their execution timeout.
+type FindExpiringWorkflows struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Pool    *pool.Pool
+	// MaxItems caps how many executions a call describes; the rest are reported as skipped.
+	MaxItems int
+}
+
+// Definition implements Tool.
+func (t *FindExpiringWorkflows) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"find_expiring_workflows",
+		mcp.WithDescription("Find running workflows that will hit their workflow execution timeout soon, and be timed out by the server: lists running workflows, describes them concurrently, and reports those expiring within the window, most urgent first, with the time remaining. Workflows without an execution timeout are skipped. Reports how many workflows were scanned versus matched"),
+		mcp.WithString("within",
+			mcp.Description("How far ahead to look, as a Go duration (default 6h)"),
+		),
+		mcp.WithString("workflow_type",
+			mcp.Description("Only consider workflows of this type"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// MaxTimeout implements LongRunning; describing hundreds of executions can take a while.
+func (t *FindExpiringWorkflows) MaxTimeout() time.Duration {
+	return 10 * time.Minute
+}
+
+// Handle implements Tool.
+func (t *FindExpiringWorkflows) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	within, err := durationArgument(args, "within", defaultExpiringWindow)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if within <= 0 {
+		return mcp.NewToolResultError("Invalid 'within' parameter: must be a positive duration, e.g. 6h"), nil
+	}
+	filters := []string{`ExecutionStatus = "Running"`}
+	workflowType, err := stringArgument(args, "workflow_type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if workflowType != "" {
+		filters = append(filters, fmt.Sprintf("WorkflowType = %q", workflowType))
+	}
+	query := strings.Join(filters, " AND ")
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// The count makes partial coverage explicit; it is informational, so failures only drop it
+	search := format.ExpiringWorkflowSearch{Within: within.String(), Running: -1, Workflows: []format.ExpiringWorkflow{}}
+	if count, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{Namespace: client.Namespace(), Query: query}); err != nil {
+		t.Logger.Warn("Unable to count running workflows", "query", query, "error", err)
+	} else {
+		search.Running = count.GetCount()
+	}
+
+	now := time.Now()
+	listed, stats, err := describeRunning(ctx, client, t.Logger, t.Pool, query, maxExpiringScan, t.MaxItems, func(info *workflowpb.WorkflowExecutionInfo, resp *workflowservice.DescribeWorkflowExecutionResponse) {
+		timeout := resp.GetExecutionConfig().GetWorkflowExecutionTimeout().AsDuration()
+		if timeout <= 0 {
+			search.WithoutTimeout++
+			return
+		}
+		// The timeout spans the whole chain of runs, so the server's expiration time is authoritative;
+		// servers that don't report it get one counted from this run's start
+		var expiration time.Time
+		if ts := resp.GetWorkflowExtendedInfo().GetExecutionExpirationTime(); ts != nil {
+			expiration = ts.AsTime()
+		} else {
+			expiration = resp.GetWorkflowExecutionInfo().GetStartTime().AsTime().Add(timeout)
+		}
+		remaining := expiration.Sub(now).Round(time.Second)
+		if remaining > within {
+			return
+		}
+		search.Workflows = append(search.Workflows, format.ExpiringWorkflow{
+			WorkflowID:       info.GetExecution().GetWorkflowId(),
+			RunID:            info.GetExecution().GetRunId(),
+			WorkflowType:     info.GetType().GetName(),
+			StartTime:        format.Time(info.GetStartTime()),
+			ExecutionTimeout: timeout.String(),
+			ExpirationTime:   expiration.UTC().Format(time.RFC3339),
+			Remaining:        remaining.String(),
+			RemainingSeconds: int64(remaining / time.Second),
+		})
+	})
+	if err != nil {
+		return rpcError("Failed to list running workflows", err), nil
+	}
+	search.Listed, search.Coverage = listed, stats.String()
+
+	// Most urgent first
+	sort.Slice(search.Workflows, func(i, j int) bool {
+		a, b := search.Workflows[i], search.Workflows[j]
+		if a.RemainingSeconds != b.RemainingSeconds {
+			return a.RemainingSeconds < b.RemainingSeconds
+		}
+		return a.WorkflowID < b.WorkflowID
+	})
+	result, err := renderResult(outFormat, search, format.ExpiringWorkflowsText)
+	return partialResult(partialFanout(ctx, stats, "running workflows"), outFormat, result, err)
+}
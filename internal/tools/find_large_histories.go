@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// maxLargeHistoryScan caps how many running workflows find_large_histories lists.
+	maxLargeHistoryScan = 1000
+	// defaultLargeHistoryLimit is how many workflows find_large_histories returns by default.
+	defaultLargeHistoryLimit = 20
+)
+
+// FindLargeHistories implements the "find_large_histories" tool, describing running workflows
+// through the shared fan-out pool to rank them by history length. Visibility only records
+// HistoryLength when a workflow upserts search attributes or closes, so running workflows' counts
+// have to come from Describe.
+type FindLargeHistories struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Pool    *pool.Pool
+	// MaxItems caps how many executions a call describes; the rest are reported as skipped.
+	MaxItems int
+	// Thresholds are the history sizes past which workflows are flagged.
+	Thresholds format.HistoryThresholds
+}
+
+// Definition implements Tool.
+func (t *FindLargeHistories) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"find_large_histories",
+		mcp.WithDescription("Find running workflows with the largest histories, before the server terminates them at its history limits (51200 events or 50 MiB by default): lists running workflows, describes them concurrently, and ranks them by event count with their history size, flagging those over the warning thresholds. These workflows need to continue-as-new. Reports how many workflows were scanned versus returned"),
+		mcp.WithString("workflow_type",
+			mcp.Description("Only consider workflows of this type"),
+		),
+		mcp.WithNumber("limit",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxLargeHistoryScan),
+			mcp.Description(fmt.Sprintf("Maximum number of workflows to return, largest first (default %d)", defaultLargeHistoryLimit)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// MaxTimeout implements LongRunning; describing hundreds of executions can take a while.
+func (t *FindLargeHistories) MaxTimeout() time.Duration {
+	return 10 * time.Minute
+}
+
+// Handle implements Tool.
+func (t *FindLargeHistories) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	limit, err := intArgument(args, "limit", defaultLargeHistoryLimit, 1, maxLargeHistoryScan)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	filters := []string{`ExecutionStatus = "Running"`}
+	workflowType, err := stringArgument(args, "workflow_type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if workflowType != "" {
+		filters = append(filters, fmt.Sprintf("WorkflowType = %q", workflowType))
+	}
+	query := strings.Join(filters, " AND ")
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// The count makes partial coverage explicit; it is informational, so failures only drop it
+	search := format.LargeHistorySearch{Running: -1, Limit: limit, Workflows: []format.LargeHistory{}}
+	if count, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{Namespace: client.Namespace(), Query: query}); err != nil {
+		t.Logger.Warn("Unable to count running workflows", "query", query, "error", err)
+	} else {
+		search.Running = count.GetCount()
+	}
+
+	listed, stats, err := describeRunning(ctx, client, t.Logger, t.Pool, query, maxLargeHistoryScan, t.MaxItems, func(info *workflowpb.WorkflowExecutionInfo, resp *workflowservice.DescribeWorkflowExecutionResponse) {
+		described := resp.GetWorkflowExecutionInfo()
+		large := format.LargeHistory{
+			WorkflowID:       info.GetExecution().GetWorkflowId(),
+			RunID:            info.GetExecution().GetRunId(),
+			WorkflowType:     info.GetType().GetName(),
+			StartTime:        format.Time(info.GetStartTime()),
+			HistoryLength:    described.GetHistoryLength(),
+			HistorySizeBytes: described.GetHistorySizeBytes(),
+			Warnings:         t.Thresholds.Warnings(described.GetHistoryLength(), described.GetHistorySizeBytes()),
+		}
+		search.Workflows = append(search.Workflows, large)
+		if len(large.Warnings) > 0 {
+			search.Flagged++
+		}
+	})
+	if err != nil {
+		return rpcError("Failed to list running workflows", err), nil
+	}
+	search.Listed, search.Coverage = listed, stats.String()
+
+	// Largest first
+	sort.Slice(search.Workflows, func(i, j int) bool {
+		a, b := search.Workflows[i], search.Workflows[j]
+		if a.HistoryLength != b.HistoryLength {
+			return a.HistoryLength > b.HistoryLength
+		}
+		if a.HistorySizeBytes != b.HistorySizeBytes {
+			return a.HistorySizeBytes > b.HistorySizeBytes
+		}
+		return a.WorkflowID < b.WorkflowID
+	})
+	search.Workflows = search.Workflows[:min(len(search.Workflows), limit)]
+	result, err := renderResult(outFormat, search, format.LargeHistoriesText)
+	return partialResult(partialFanout(ctx, stats, "running workflows"), outFormat, result, err)
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// maxOrphanScan caps how many running workflows find_orphaned_task_queues lists.
+	maxOrphanScan = 5000
+	// orphanExamples is how many affected workflow IDs are shown per task queue.
+	orphanExamples = 3
+)
+
+// FindOrphanedTaskQueues implements the "find_orphaned_task_queues" tool.
+type FindOrphanedTaskQueues struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Pool    *pool.Pool
+	// MaxItems caps how many task queues a call describes; the rest are reported as skipped.
+	MaxItems int
+}
+
+// Definition implements Tool.
+func (t *FindOrphanedTaskQueues) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"find_orphaned_task_queues",
+		mcp.WithDescription("Find task queues that have running workflows but no workflow pollers (e.g. a worker deployment that died), with how many executions each affects, most affected first"),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// MaxTimeout implements LongRunning; listing thousands of executions can take a while.
+func (t *FindOrphanedTaskQueues) MaxTimeout() time.Duration {
+	return 10 * time.Minute
+}
+
+// Handle implements Tool.
+func (t *FindOrphanedTaskQueues) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Group the running workflows by task queue, keeping a few IDs per queue as examples
+	var (
+		result = format.OrphanedTaskQueues{Orphaned: []format.OrphanedTaskQueue{}}
+		queues = make(map[string]*format.OrphanedTaskQueue)
+		token  []byte
+	)
+	for {
+		resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     client.Namespace(),
+			PageSize:      int32(min(maxListPageSize, maxOrphanScan-result.Scanned)),
+			NextPageToken: token,
+			Query:         `ExecutionStatus = "Running"`,
+		})
+		if err != nil {
+			t.Logger.Error("Error listing running workflows", "error", err)
+			return rpcError("Failed to list running workflows", err), nil
+		}
+		for _, info := range resp.GetExecutions() {
+			result.Scanned++
+			q, ok := queues[info.GetTaskQueue()]
+			if !ok {
+				q = &format.OrphanedTaskQueue{Name: info.GetTaskQueue()}
+				queues[info.GetTaskQueue()] = q
+			}
+			q.RunningWorkflows++
+			if len(q.Examples) < orphanExamples {
+				q.Examples = append(q.Examples, info.GetExecution().GetWorkflowId())
+			}
+		}
+		token = resp.GetNextPageToken()
+		if len(token) == 0 {
+			break
+		}
+		if result.Scanned >= maxOrphanScan {
+			result.Truncated = true
+			break
+		}
+		reportProgress(ctx, float64(result.Scanned), maxOrphanScan, "scanned %d running workflows", result.Scanned)
+	}
+
+	// Check the most affected queues first, so the item cap drops the least important ones
+	candidates := make([]*format.OrphanedTaskQueue, 0, len(queues))
+	for _, q := range queues {
+		candidates = append(candidates, q)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].RunningWorkflows != candidates[j].RunningWorkflows {
+			return candidates[i].RunningWorkflows > candidates[j].RunningWorkflows
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	result.Queues = len(candidates)
+
+	orphaned := make([]bool, len(candidates))
+	var mu sync.Mutex
+	stats := t.Pool.Run(ctx, len(candidates), t.MaxItems, func(ctx context.Context, i int) {
+		name := candidates[i].Name
+		resp, err := client.DescribeTaskQueue(ctx, &workflowservice.DescribeTaskQueueRequest{
+			Namespace:     client.Namespace(),
+			TaskQueue:     &taskqueuepb.TaskQueue{Name: name, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+			TaskQueueType: enumspb.TASK_QUEUE_TYPE_WORKFLOW,
+		})
+		if err != nil {
+			t.Logger.Warn("Error describing task queue", "task_queue", name, "error", err)
+			mu.Lock()
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", name, err))
+			mu.Unlock()
+			return
+		}
+		orphaned[i] = len(resp.GetPollers()) == 0
+	})
+	result.Coverage = stats.String()
+	for i, q := range candidates {
+		if orphaned[i] {
+			result.Orphaned = append(result.Orphaned, *q)
+		}
+	}
+	sort.Strings(result.Errors)
+	rendered, err := renderResult(outFormat, result, format.OrphanedTaskQueuesText)
+	return partialResult(partialFanout(ctx, stats, "task queues"), outFormat, rendered, err)
+}
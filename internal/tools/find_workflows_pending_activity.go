@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// maxPendingScan caps how many running workflows find_workflows_pending_activity lists.
+const maxPendingScan = 1000
+
+// FindWorkflowsPendingActivity implements the "find_workflows_pending_activity" tool, describing
+// running workflows through the shared fan-out pool to find those blocked on an activity type.
+type FindWorkflowsPendingActivity struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Pool    *pool.Pool
+	// MaxItems caps how many executions a call describes; the rest are reported as skipped.
+	MaxItems int
+}
+
+// Definition implements Tool.
+func (t *FindWorkflowsPendingActivity) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"find_workflows_pending_activity",
+		mcp.WithDescription("Find running workflows blocked on an activity type (e.g. during a downstream outage): lists running workflows, describes them concurrently, and reports those with a pending activity of that type, with attempt counts and last failures. Reports how many workflows were scanned versus matched"),
+		mcp.WithString("activity_type",
+			mcp.Required(),
+			mcp.Description("Activity type to look for among pending activities"),
+		),
+		mcp.WithString("workflow_type",
+			mcp.Description("Only consider workflows of this type"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Only consider workflows started within this Go duration, e.g. 24h"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// MaxTimeout implements LongRunning; describing hundreds of executions can take a while.
+func (t *FindWorkflowsPendingActivity) MaxTimeout() time.Duration {
+	return 10 * time.Minute
+}
+
+// Handle implements Tool.
+func (t *FindWorkflowsPendingActivity) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	activityType, err := stringArgument(args, "activity_type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if activityType == "" {
+		return mcp.NewToolResultError("Missing or invalid 'activity_type' parameter"), nil
+	}
+	filters := []string{`ExecutionStatus = "Running"`}
+	workflowType, err := stringArgument(args, "workflow_type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if workflowType != "" {
+		filters = append(filters, fmt.Sprintf("WorkflowType = %q", workflowType))
+	}
+	window, err := durationArgument(args, "window", 0)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if window > 0 {
+		filters = append(filters, fmt.Sprintf("StartTime > %q", time.Now().Add(-window).UTC().Format(time.RFC3339)))
+	}
+	query := strings.Join(filters, " AND ")
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// The count makes partial coverage explicit; it is informational, so failures only drop it
+	search := format.BlockedWorkflowSearch{ActivityType: activityType, Running: -1, Workflows: []format.BlockedWorkflow{}}
+	if count, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{Namespace: client.Namespace(), Query: query}); err != nil {
+		t.Logger.Warn("Unable to count running workflows", "query", query, "error", err)
+	} else {
+		search.Running = count.GetCount()
+	}
+
+	listed, stats, err := describeRunning(ctx, client, t.Logger, t.Pool, query, maxPendingScan, t.MaxItems, func(info *workflowpb.WorkflowExecutionInfo, resp *workflowservice.DescribeWorkflowExecutionResponse) {
+		for _, activity := range resp.GetPendingActivities() {
+			if activity.GetActivityType().GetName() != activityType {
+				continue
+			}
+			search.Workflows = append(search.Workflows, format.BlockedWorkflow{
+				WorkflowID:   info.GetExecution().GetWorkflowId(),
+				RunID:        info.GetExecution().GetRunId(),
+				WorkflowType: info.GetType().GetName(),
+				StartTime:    format.Time(info.GetStartTime()),
+				ActivityID:   activity.GetActivityId(),
+				State:        format.ActivityState(activity.GetState()),
+				Attempt:      activity.GetAttempt(),
+				LastFailure:  format.Failure(activity.GetLastFailure()),
+			})
+		}
+	})
+	if err != nil {
+		return rpcError("Failed to list running workflows", err), nil
+	}
+	search.Listed, search.Coverage = listed, stats.String()
+
+	// Most retried first: those are hitting the broken dependency hardest
+	sort.Slice(search.Workflows, func(i, j int) bool {
+		a, b := search.Workflows[i], search.Workflows[j]
+		if a.Attempt != b.Attempt {
+			return a.Attempt > b.Attempt
+		}
+		if a.WorkflowID != b.WorkflowID {
+			return a.WorkflowID < b.WorkflowID
+		}
+		return a.ActivityID < b.ActivityID
+	})
+	result, err := renderResult(outFormat, search, format.BlockedWorkflowsText)
+	return partialResult(partialFanout(ctx, stats, "running workflows"), outFormat, result, err)
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// GetHistoryEvent implements the "get_history_event" tool.
+type GetHistoryEvent struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *GetHistoryEvent) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"get_history_event",
+		mcp.WithDescription("Retrieve a single history event of a workflow execution with all of its attributes (as JSON, with payloads decoded), e.g. the exact input of an ActivityTaskScheduled event"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithNumber("event_id",
+			integer(),
+			mcp.Min(1),
+			mcp.Required(),
+			mcp.Description("ID of the history event to retrieve"),
+		),
+		withNamespaceArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *GetHistoryEvent) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *GetHistoryEvent) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	rawEventID, err := intArgument(args, "event_id", 0, 1, 0)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if rawEventID == 0 {
+		return mcp.NewToolResultError("Missing or invalid 'event_id' parameter"), nil
+	}
+	eventID := int64(rawEventID)
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, errResult := describeExecution(ctx, client, t.Logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	execution := pinnedExecution(resp)
+
+	event, lastEventID, err := t.findEvent(ctx, client, execution, resp.GetWorkflowExecutionInfo().GetHistoryLength(), eventID)
+	if err != nil {
+		t.Logger.Error("Error reading workflow history", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+		return rpcError("Failed to read workflow history", err), nil
+	}
+	if event == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Event %d is out of range: workflow %s (run %s) has events 1-%d", eventID, wfID, execution.GetRunId(), lastEventID)), nil
+	}
+
+	attributes, err := format.ProtoJSON(event, t.Payloads)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode event: %v", err)), nil
+	}
+	return mcp.NewToolResultText(format.HistoryEventText(wfID, execution.GetRunId(), event, attributes)), nil
+}
+
+// findEvent reads the execution's history until it reaches eventID. It returns a nil event and the
+// ID of the last event in the history when eventID is past the end.
+func (t *GetHistoryEvent) findEvent(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, historyLength, eventID int64) (*historypb.HistoryEvent, int64, error) {
+	var (
+		found       *historypb.HistoryEvent
+		lastEventID int64
+	)
+	err := forEachEvent(ctx, client, execution, historyLength, func(event *historypb.HistoryEvent) bool {
+		lastEventID = event.GetEventId()
+		if lastEventID == eventID {
+			found = event
+		}
+		return lastEventID < eventID
+	})
+	return found, lastEventID, err
+}
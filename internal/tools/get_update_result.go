@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	updatepb "go.temporal.io/api/update/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc/codes"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// defaultUpdateWait and maxUpdateWait bound how long get_update_result waits for an update to
+// complete.
+const (
+	defaultUpdateWait = 10 * time.Second
+	maxUpdateWait     = time.Minute
+)
+
+// GetUpdateResult implements the "get_update_result" tool.
+type GetUpdateResult struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *GetUpdateResult) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"get_update_result",
+		mcp.WithDescription("Get the outcome of a workflow update: waits a bounded time for it to complete, then returns its lifecycle stage (Admitted, Accepted, Completed) and, once completed, its decoded result or failure. An update ID the workflow doesn't know is reported as not found, distinctly from one still running. Use it to follow up on updates sent without waiting for completion"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution the update was sent to"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithString("update_id",
+			mcp.Required(),
+			mcp.Description("ID of the update"),
+		),
+		mcp.WithString("wait",
+			mcp.Description(fmt.Sprintf("How long to wait for the update to complete, as a Go duration (default %s, maximum %s)", defaultUpdateWait, maxUpdateWait)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// Handle implements Tool.
+func (t *GetUpdateResult) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	updateID, err := stringArgument(args, "update_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if updateID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'update_id' parameter"), nil
+	}
+	wait, err := durationArgument(args, "wait", defaultUpdateWait)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if wait > maxUpdateWait {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'wait' parameter %s (maximum %s)", wait, maxUpdateWait)), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Describe first so an unknown workflow isn't mistaken for an unknown update
+	resp, errResult := describeExecution(ctx, client, t.Logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	execution := pinnedExecution(resp)
+	result := format.UpdateResult{WorkflowID: wfID, RunID: execution.GetRunId(), UpdateID: updateID}
+
+	// The first poll returns at once with the stage reached so far
+	start := time.Now()
+	poll, err := t.poll(ctx, client, execution, updateID, enumspb.UPDATE_WORKFLOW_EXECUTION_LIFECYCLE_STAGE_ADMITTED)
+	if code, _ := errorCode(err); code == codes.NotFound {
+		return renderResult(outFormat, result, format.UpdateResultText)
+	}
+	if err != nil {
+		t.Logger.Error("Error polling workflow update", "workflow_id", wfID, "run_id", result.RunID, "update_id", updateID, "error", err)
+		return rpcError("Failed to get update result", err), nil
+	}
+	if poll.GetOutcome() == nil {
+		waitCtx, cancel := context.WithTimeout(ctx, wait)
+		completed, err := t.poll(waitCtx, client, execution, updateID, enumspb.UPDATE_WORKFLOW_EXECUTION_LIFECYCLE_STAGE_COMPLETED)
+		cancel()
+		switch {
+		case err == nil:
+			poll = completed
+		case waitCtx.Err() != nil && ctx.Err() == nil:
+			// Still running: report the stage of the first poll
+		default:
+			t.Logger.Error("Error waiting for workflow update", "workflow_id", wfID, "run_id", result.RunID, "update_id", updateID, "error", err)
+			return rpcError("Failed to get update result", err), nil
+		}
+	}
+
+	result.Found = true
+	result.Stage = poll.GetStage().String()
+	switch outcome := poll.GetOutcome(); {
+	case outcome == nil:
+		result.Waited = time.Since(start).Round(100 * time.Millisecond).String()
+	case outcome.GetFailure() != nil:
+		result.Outcome, result.Failure = "failure", format.Failure(outcome.GetFailure())
+	case outcome.GetSuccess() != nil:
+		result.Outcome, result.Result = "success", t.Payloads.RenderAll(outcome.GetSuccess())
+	}
+	return renderResult(outFormat, result, format.UpdateResultText)
+}
+
+// poll asks for the update's state once it reaches stage, or at the server's long-poll timeout.
+func (t *GetUpdateResult) poll(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, updateID string, stage enumspb.UpdateWorkflowExecutionLifecycleStage) (*workflowservice.PollWorkflowExecutionUpdateResponse, error) {
+	return client.PollWorkflowExecutionUpdate(ctx, &workflowservice.PollWorkflowExecutionUpdateRequest{
+		Namespace:  client.Namespace(),
+		UpdateRef:  &updatepb.UpdateRef{WorkflowExecution: execution, UpdateId: updateID},
+		Identity:   client.Identity(ctx),
+		WaitPolicy: &updatepb.WaitPolicy{LifecycleStage: stage},
+	})
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testLogger discards the logs of the tools under test.
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// testTime is the fixed instant fixtures are built around, so outputs are deterministic.
+var testTime = time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+// callTool calls tool's handler directly with args, under a deadline like the registry's.
+func callTool(t *testing.T, tool Tool, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return callToolContext(t, ctx, tool, args)
+}
+
+// callToolContext calls tool's handler with ctx and args, failing the test on a Go error.
+func callToolContext(t *testing.T, ctx context.Context, tool Tool, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	req := mcp.CallToolRequest{}
+	req.Params.Name = tool.Definition().Name
+	req.Params.Arguments = args
+	result, err := tool.Handle(ctx, req)
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", req.Params.Name, err)
+	}
+	if result == nil {
+		t.Fatalf("%s: nil result", req.Params.Name)
+	}
+	return result
+}
+
+// assertContains fails the test unless text contains each of want.
+func assertContains(t *testing.T, text string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(text, w) {
+			t.Errorf("output doesn't contain %q:\n%s", w, text)
+		}
+	}
+}
+
+// execution is a fixture of a workflow execution's visibility record, started at testTime and,
+// unless running, closed an hour later.
+func execution(id, workflowType string, status enumspb.WorkflowExecutionStatus) *workflowpb.WorkflowExecutionInfo {
+	info := &workflowpb.WorkflowExecutionInfo{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: id, RunId: id + "-run"},
+		Type:      &commonpb.WorkflowType{Name: workflowType},
+		Status:    status,
+		StartTime: timestamppb.New(testTime),
+	}
+	if status != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		info.CloseTime = timestamppb.New(testTime.Add(time.Hour))
+	}
+	return info
+}
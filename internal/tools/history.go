@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// describeExecution describes a workflow execution for a history tool, returning the error result
+// to send back (with suggestions for mistyped IDs) if it can't. The run ID in the response pins the
+// execution, so every history page read afterwards comes from the same run.
+func describeExecution(ctx context.Context, client temporal.Client, logger *slog.Logger, wfID, runID string) (*workflowservice.DescribeWorkflowExecutionResponse, *mcp.CallToolResult) {
+	resp, err := client.DescribeWorkflowExecution(ctx, wfID, runID)
+	if err != nil {
+		logger.Error("Error describing workflow", "workflow_id", wfID, "run_id", runID, "error", err)
+		return nil, describeFailed(ctx, client, wfID, err)
+	}
+	noteWorkflow(ctx, client.Namespace(), resp.GetWorkflowExecutionInfo())
+	return resp, nil
+}
+
+// pinnedExecution returns the execution described by resp, with its run ID set.
+func pinnedExecution(resp *workflowservice.DescribeWorkflowExecutionResponse) *commonpb.WorkflowExecution {
+	return resp.GetWorkflowExecutionInfo().GetExecution()
+}
+
+// historyTimeout is returned by forEachEvent when the call's deadline passed after some history
+// pages were read, so the caller can report what those pages held as partial results.
+type historyTimeout struct {
+	err           error
+	pages, read   int
+	historyLength int64
+}
+
+func (e *historyTimeout) Error() string { return e.err.Error() }
+
+func (e *historyTimeout) Unwrap() error { return e.err }
+
+// banner describes how far the history was read, e.g. "partial results: timed out after 3 of ~10
+// pages (3000 of 9500 events)".
+func (e *historyTimeout) banner() string {
+	if e.historyLength <= 0 {
+		return fmt.Sprintf("partial results: timed out after %d history pages (%d events)", e.pages, e.read)
+	}
+	perPage := float64(e.read) / float64(e.pages)
+	return fmt.Sprintf("partial results: timed out after %d of ~%d history pages (%d of %d events)", e.pages, int(math.Ceil(float64(e.historyLength)/perPage)), e.read, e.historyLength)
+}
+
+// partialHistory returns the banner of a history read cut short by the call's deadline, or "" if
+// err isn't a historyTimeout.
+func partialHistory(err error) string {
+	var timeout *historyTimeout
+	if errors.As(err, &timeout) {
+		return timeout.banner()
+	}
+	return ""
+}
+
+// forEachEvent pages through the history of execution, calling fn for every event in order until
+// fn returns false. Progress is reported against historyLength (0 if unknown) between pages. When
+// the call's deadline passes after the first page it returns a *historyTimeout.
+func forEachEvent(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, historyLength int64, fn func(*historypb.HistoryEvent) bool) error {
+	var (
+		token []byte
+		read  int
+		pages int
+	)
+	for {
+		page, err := client.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+			Namespace:     client.Namespace(),
+			Execution:     execution,
+			NextPageToken: token,
+		})
+		if err != nil {
+			if pages > 0 && timedOut(ctx) {
+				return &historyTimeout{err: err, pages: pages, read: read, historyLength: historyLength}
+			}
+			return err
+		}
+		pages++
+		for _, event := range page.GetHistory().GetEvents() {
+			read++
+			if !fn(event) {
+				return nil
+			}
+		}
+		token = page.GetNextPageToken()
+		if len(token) == 0 {
+			return nil
+		}
+		reportProgress(ctx, float64(read), float64(historyLength), "read %d of %d history events", read, historyLength)
+	}
+}
@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// IdentityHeader is the HTTP header of the initialize request naming the session's identity,
+	// for sessions reaching the server over an HTTP transport.
+	IdentityHeader = "X-Temporal-MCP-Identity"
+	// identityCapability is the experimental client capability whose "identity" field names the
+	// session's identity, e.g. {"experimental": {"temporal-mcp": {"identity": "deploy-bot"}}}.
+	identityCapability = "temporal-mcp"
+	// maxIdentityLength bounds an identity given by a session; longer ones are cut.
+	maxIdentityLength = 128
+)
+
+// SessionIdentities remembers the identity each MCP session names for itself in its initialize
+// request, through IdentityHeader or the experimental identity capability. A session's calls are
+// attributed to that identity instead of its client's name and version, so several agents using
+// the same client can be told apart in histories and the audit log. Forget drops a session's
+// identity when it ends.
+type SessionIdentities struct {
+	mu        sync.Mutex
+	bySession map[string]string
+}
+
+// Register records the identity of each session as it initializes, and forgets it when the
+// session ends.
+func (s *SessionIdentities) Register(hooks *server.Hooks) {
+	hooks.AddAfterInitialize(func(ctx context.Context, _ any, message *mcp.InitializeRequest, _ *mcp.InitializeResult) {
+		if identity := requestedIdentity(message); identity != "" {
+			s.set(sessionID(ctx), identity)
+		}
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		s.Forget(session)
+	})
+}
+
+// Forget drops the identity of session.
+func (s *SessionIdentities) Forget(session server.ClientSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.bySession, session.SessionID())
+}
+
+// set records identity for session.
+func (s *SessionIdentities) set(session, identity string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.bySession == nil {
+		s.bySession = make(map[string]string)
+	}
+	s.bySession[session] = identity
+}
+
+// get returns the identity the session of ctx named, or "".
+func (s *SessionIdentities) get(ctx context.Context) string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bySession[sessionID(ctx)]
+}
+
+// requestedIdentity returns the identity an initialize request names, the header winning over the
+// capability, cleaned of control characters and bounded in length.
+func requestedIdentity(message *mcp.InitializeRequest) string {
+	identity := message.Header.Get(IdentityHeader)
+	if identity == "" {
+		fields, _ := message.Params.Capabilities.Experimental[identityCapability].(map[string]any)
+		identity, _ = fields["identity"].(string)
+	}
+	identity = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, identity))
+	if len(identity) > maxIdentityLength {
+		identity = strings.ToValidUTF8(identity[:maxIdentityLength], "")
+	}
+	return identity
+}
+
+// callerIdentity returns what a call's Temporal RPCs are attributed to besides this server: the
+// identity its session named, or else the MCP client's name and version. It is "" for sessions
+// that reported neither, whose RPCs carry the server's identity alone.
+func callerIdentity(ctx context.Context, identities *SessionIdentities) string {
+	if identity := identities.get(ctx); identity != "" {
+		return identity
+	}
+	name, version := clientInfo(ctx)
+	return strings.TrimSpace(name + " " + version)
+}
+
+// attributedReason suffixes reason with the identity of the call's RPCs, for requests recording a
+// reason but no identity, such as resets.
+func attributedReason(ctx context.Context, client temporal.Client, reason string) string {
+	return fmt.Sprintf("%s (by %s)", reason, client.Identity(ctx))
+}
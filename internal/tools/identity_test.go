@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/wricardo/temporal-mcp/internal/audit"
+)
+
+// testSession is an MCP session reporting a fixed ID and client.
+type testSession struct {
+	id     string
+	client mcp.Implementation
+}
+
+var _ server.SessionWithClientInfo = (*testSession)(nil)
+
+func (s *testSession) Initialize()                                         {}
+func (s *testSession) Initialized() bool                                   { return true }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s *testSession) SessionID() string                                   { return s.id }
+func (s *testSession) GetClientInfo() mcp.Implementation                   { return s.client }
+func (s *testSession) SetClientInfo(client mcp.Implementation)             { s.client = client }
+func (s *testSession) GetClientCapabilities() mcp.ClientCapabilities       { return mcp.ClientCapabilities{} }
+func (s *testSession) SetClientCapabilities(_ mcp.ClientCapabilities)      {}
+
+func TestSessionIdentitiesAttributeConcurrentSessions(t *testing.T) {
+	identities := &SessionIdentities{}
+	hooks := &server.Hooks{}
+	identities.Register(hooks)
+	srv := server.NewMCPServer("test", "1.0")
+
+	// Every session runs the same client; two of them name an identity while initializing, one
+	// through the header and one through the experimental capability
+	client := mcp.Implementation{Name: "inspector", Version: "0.9"}
+	deployBot := &testSession{id: "session-1", client: client}
+	triage := &testSession{id: "session-2", client: client}
+	anonymous := &testSession{id: "session-3", client: client}
+	initialize := func(session server.ClientSession, message *mcp.InitializeRequest) {
+		for _, hook := range hooks.OnAfterInitialize {
+			hook(srv.WithContext(context.Background(), session), 1, message, &mcp.InitializeResult{})
+		}
+	}
+	byHeader := &mcp.InitializeRequest{Header: http.Header{}}
+	byHeader.Header.Set(IdentityHeader, "deploy-bot")
+	initialize(deployBot, byHeader)
+	byCapability := &mcp.InitializeRequest{}
+	byCapability.Params.Capabilities.Experimental = map[string]any{identityCapability: map[string]any{"identity": "triage-agent"}}
+	initialize(triage, byCapability)
+	initialize(anonymous, &mcp.InitializeRequest{})
+
+	// The handler reports the identity its RPCs would carry; the calls of all sessions are in flight
+	// at once, so a shared or leaked identity would show up as a mismatch
+	fake := &fakeClient{namespace: "default"}
+	const callsPerSession = 20
+	var inFlight sync.WaitGroup
+	inFlight.Add(3 * callsPerSession)
+	handler := withCaller(identities, func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		inFlight.Done()
+		inFlight.Wait()
+		return mcp.NewToolResultText(fake.Identity(ctx)), nil
+	})
+	sessions := map[server.ClientSession]string{
+		deployBot: "temporal-mcp@test (deploy-bot)",
+		triage:    "temporal-mcp@test (triage-agent)",
+		anonymous: "temporal-mcp@test (inspector 0.9)",
+	}
+	errs := make(chan error, 3*callsPerSession)
+	var done sync.WaitGroup
+	for session, want := range sessions {
+		for range callsPerSession {
+			done.Add(1)
+			go func() {
+				defer done.Done()
+				result, err := handler(srv.WithContext(context.Background(), session), mcp.CallToolRequest{})
+				if err != nil {
+					errs <- err
+					return
+				}
+				if got := resultText(result); got != want {
+					errs <- fmt.Errorf("%s: identity = %q, want %q", session.SessionID(), got, want)
+				}
+			}()
+		}
+	}
+	done.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// An ended session's identity is forgotten; a later session reusing its ID falls back to its client
+	for _, hook := range hooks.OnUnregisterSession {
+		hook(context.Background(), deployBot)
+	}
+	if got := callerIdentity(srv.WithContext(context.Background(), deployBot), identities); got != "inspector 0.9" {
+		t.Errorf("identity after the session ended = %q, want the client's", got)
+	}
+	if got := callerIdentity(srv.WithContext(context.Background(), triage), identities); got != "triage-agent" {
+		t.Errorf("identity of the remaining session = %q, want triage-agent", got)
+	}
+}
+
+// recordingSink is an audit sink keeping the events it records.
+type recordingSink struct {
+	events []audit.Event
+}
+
+func (s *recordingSink) Record(event audit.Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingSink) Close() error { return nil }
+
+func TestAuditIdentityOfCallNamespace(t *testing.T) {
+	clients := newFakeProvider(
+		&fakeClient{namespace: "default", identity: "temporal-mcp@default"},
+		&fakeClient{namespace: "payments", identity: "temporal-mcp@payments"},
+	)
+	sink := &recordingSink{}
+	handler := withAudit(sink, testLogger, clients, "default", "list_workflows", func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	tests := []struct {
+		namespace    any
+		wantIdentity string
+	}{
+		{nil, "temporal-mcp@default"},
+		{"payments", "temporal-mcp@payments"},
+		{"payments,", "temporal-mcp@payments"},
+		// A call across several namespaces carries the default namespace's
+		{"payments,default", "temporal-mcp@default"},
+		// A namespace the provider rejects made no RPC
+		{"orders", ""},
+	}
+	for _, tt := range tests {
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"namespace": tt.namespace}
+		if _, err := handler(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+		if event := sink.events[len(sink.events)-1]; event.Identity != tt.wantIdentity {
+			t.Errorf("namespace %v: audited identity = %q, want %q", tt.namespace, event.Identity, tt.wantIdentity)
+		}
+	}
+}
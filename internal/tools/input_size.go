@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+)
+
+// InputLimits bound the size of caller-supplied payloads a tool sends to Temporal, such as signal
+// input, checked before any RPC; 0 disables a limit.
+type InputLimits struct {
+	// WarnBytes is the size past which a payload is still sent, with a warning.
+	WarnBytes int
+	// MaxBytes is the size past which a payload is refused.
+	MaxBytes int
+}
+
+// check measures the JSON encoding of the values of the argument name, summed when they are the
+// arguments of one multi-argument payload. It returns an error stating the size and the limit when
+// they exceed MaxBytes, or a warning when they exceed WarnBytes.
+func (l InputLimits) check(name string, values ...interface{}) (warning string, err error) {
+	size := 0
+	for _, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("Invalid '%s' parameter: %v", name, err)
+		}
+		size += len(encoded)
+	}
+	switch {
+	case l.MaxBytes > 0 && size > l.MaxBytes:
+		return "", fmt.Errorf("'%s' is %s encoded, over the limit of %s on payloads sent to Temporal (TEMPORAL_MCP_INPUT_MAX_BYTES); pass a reference to the data instead, such as an ID, URL, or storage key the workflow can fetch it by",
+			name, format.ByteSize(int64(size)), format.ByteSize(int64(l.MaxBytes)))
+	case l.WarnBytes > 0 && size > l.WarnBytes:
+		return fmt.Sprintf("'%s' is %s encoded, over the warning threshold of %s (TEMPORAL_MCP_INPUT_WARN_BYTES); large payloads are stored in every recipient's history, so consider passing a reference to the data instead",
+			name, format.ByteSize(int64(size)), format.ByteSize(int64(l.WarnBytes))), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInputLimitsCheck(t *testing.T) {
+	limits := InputLimits{WarnBytes: 16, MaxBytes: 32}
+	tests := []struct {
+		name        string
+		values      []interface{}
+		wantWarning string
+		wantErr     string
+	}{
+		{"small", []interface{}{"ok"}, "", ""},
+		{"at the limit", []interface{}{strings.Repeat("x", 30)}, "'input' is 32 B encoded, over the warning threshold of 16 B", ""},
+		{"over the limit", []interface{}{strings.Repeat("x", 31)}, "", "'input' is 33 B encoded, over the limit of 32 B"},
+		// The arguments of one payload count together, though each is under the limit
+		{"multiple arguments", []interface{}{strings.Repeat("x", 14), strings.Repeat("y", 14)}, "'input' is 32 B encoded, over the warning threshold of 16 B", ""},
+		{"multiple arguments over", []interface{}{strings.Repeat("x", 15), strings.Repeat("y", 15)}, "", "'input' is 34 B encoded, over the limit of 32 B"},
+		{"not encodable", []interface{}{func() {}}, "", "Invalid 'input' parameter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning, err := limits.check("input", tt.values...)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one starting with %q", err, tt.wantErr)
+				}
+				return
+			}
+			if !strings.HasPrefix(warning, tt.wantWarning) || (tt.wantWarning == "") != (warning == "") {
+				t.Errorf("warning = %q, want one starting with %q", warning, tt.wantWarning)
+			}
+		})
+	}
+
+	// Zero disables both limits
+	if warning, err := (InputLimits{}).check("input", strings.Repeat("x", 1<<20)); warning != "" || err != nil {
+		t.Errorf("check with no limits = %q, %v; want neither a warning nor an error", warning, err)
+	}
+}
@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// toolGuidance maps tools to the questions they answer, in the order they are suggested. Only
+// registered tools are mentioned in the server instructions.
+var toolGuidance = []struct {
+	tool, question string
+}{
+	{"namespace_summary", "How is the namespace doing overall?"},
+	{"workflow_stats", "How many workflows are running, failed, ... (by status or type)?"},
+	{"workflow_histogram", "How many workflows started per hour (or other bucket) over a time window?"},
+	{"aggregate_search_attribute", "Which values of a search attribute (e.g. CustomerId) do executions have, and how often?"},
+	{"list_workflows", "Which workflows are running, completed, or failed (optionally by type, duration, parent, or ID pattern, across several namespaces)?"},
+	{"query_syntax_help", "How do I write a visibility query, and is this one valid?"},
+	{"run_saved_query", "Which workflows match one of the operator's saved queries (listed below)?"},
+	{"list_schedule_runs", "Which workflows did a schedule start, and did they start on time?"},
+	{"schedule_recent_actions", "Did a schedule's last firings actually succeed?"},
+	{"preview_schedule_spec", "When would this cron or interval spec (or an existing schedule) fire?"},
+	{"describe_workflow", "What is the state of one workflow and its pending activities?"},
+	{"describe_workflows", "What is the state of several workflows at once?"},
+	{"find_workflows_pending_activity", "Which workflows are stuck on a given activity?"},
+	{"find_expiring_workflows", "Which workflows are about to hit their execution timeout?"},
+	{"find_large_histories", "Which workflows are nearing the history limits and need to continue-as-new?"},
+	{"task_latency_report", "How long do workflow tasks and activities wait for a worker on a task queue?"},
+	{"find_orphaned_task_queues", "Are workflows stuck because no worker polls their task queue?"},
+	{"version_report", "During a versioned rollout, are old builds still polling, or workflows stranded on builds no worker runs?"},
+	{"summarize_history", "What happened in a workflow, in brief?"},
+	{"list_activities", "Which activities ran, with their attempts, results, and failures?"},
+	{"list_timers", "What is a workflow waiting on, and when will it wake up?"},
+	{"check_workflow_task_failures", "Why is a Running workflow not progressing (panic, nondeterminism after a deploy)?"},
+	{"list_signals", "Which signals did a workflow receive?"},
+	{"list_markers", "What did local activities, side effects, and version checks (patches) record?"},
+	{"get_update_result", "Did a workflow update complete, and with what result?"},
+	{"search_history", "Where in a workflow's history does a value appear?"},
+	{"get_history_event", "What exactly is in one history event?"},
+	{"list_reset_points", "Where can a workflow be reset to?"},
+	{"reset_workflow", "How do I re-run a workflow from an earlier point (e.g. after a bad deploy)?"},
+	{"pause_activity", "How do I stop an activity hot-looping through retries without touching the workflow?"},
+	{"unpause_activity", "How do I resume a paused activity?"},
+	{"reset_activity", "How do I restart an activity's retries from the first attempt?"},
+	{"signal_matching", "How do I send a signal to every workflow matching a query?"},
+	{"stop_batch_operation", "A batch operation is doing the wrong thing; how do I stop it, and how much did it already do?"},
+	{"recent_workflows", "Which workflows did I look at earlier in this session?"},
+	{"list_workflow_types", "Which workflow types exist?"},
+	{"list_namespaces", "Which namespaces can I query?"},
+	{"namespace_replication", "Which cluster is active for this multi-region namespace, and can I write to it from here?"},
+	{"update_namespace_archival", "How do I turn history or visibility archival on or off for a namespace?"},
+	{"delete_namespace", "How do I decommission a namespace, and what would deleting it take with it?"},
+	{"list_nexus_endpoints", "Which Nexus endpoints exist, and where do they route?"},
+	{"describe_nexus_endpoint", "What is one Nexus endpoint's target and description?"},
+	{"server_info", "Which versions of this server and Temporal are running?"},
+	{"diagnose_connection", "Why are calls to Temporal failing?"},
+}
+
+// Instructions builds the MCP server instructions from the live environment: the Temporal
+// address, the namespaces tools may use, the server's mode, which registered tool answers which
+// question, and the sections added by tools implementing Instructed. It must be called after every
+// tool is registered. Namespaces that can't be listed are left out rather than failing startup.
+func (r *Registry) Instructions(ctx context.Context, clients temporal.Provider, address string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This server inspects the Temporal cluster at %s. Tools operate on the %q namespace unless a call passes a namespace argument.\n", address, clients.DefaultNamespace())
+	if namespaces := r.namespaces(ctx, clients); len(namespaces) > 0 {
+		fmt.Fprintf(&b, "Available namespaces: %s.\n", strings.Join(namespaces, ", "))
+	}
+
+	var mutating []string
+	for _, tool := range r.registered {
+		if tool.mutating {
+			mutating = append(mutating, tool.name)
+		}
+	}
+	switch {
+	case len(mutating) == 0:
+		b.WriteString("This server is read-only: no tool changes Temporal state.\n")
+	case r.DryRun:
+		fmt.Fprintf(&b, "This server runs in dry-run mode: mutating tools (%s) only preview changes; nothing is modified.\n", strings.Join(mutating, ", "))
+	default:
+		fmt.Fprintf(&b, "Mutating tools (%s) change Temporal state; they preview first and require confirm=true to act.\n", strings.Join(mutating, ", "))
+	}
+
+	registered := make(map[string]bool, len(r.registered))
+	for _, tool := range r.registered {
+		registered[tool.name] = true
+	}
+	b.WriteString("Which tool to use:\n")
+	for _, g := range toolGuidance {
+		if registered[g.tool] {
+			fmt.Fprintf(&b, "- %s: %s\n", g.tool, g.question)
+		}
+	}
+	for _, tool := range r.registered {
+		b.WriteString(tool.instructions)
+	}
+	return b.String()
+}
+
+// namespaces lists the registered namespaces tools may use, with their active cluster when the
+// server has more than one cluster.
+func (r *Registry) namespaces(ctx context.Context, clients temporal.Provider) []string {
+	client, err := clients.Client("")
+	if err != nil {
+		return nil
+	}
+	var (
+		names    []string
+		clusters = make(map[string]string)
+		token    []byte
+	)
+	for {
+		resp, err := client.ListNamespaces(ctx, &workflowservice.ListNamespacesRequest{
+			PageSize:      100,
+			NextPageToken: token,
+		})
+		if err != nil {
+			r.Logger.Warn("Unable to list namespaces for the server instructions", "error", err)
+			return nil
+		}
+		for _, ns := range resp.GetNamespaces() {
+			name := ns.GetNamespaceInfo().GetName()
+			if !clients.Allowed(name) {
+				continue
+			}
+			names = append(names, name)
+			clusters[name] = ns.GetReplicationConfig().GetActiveClusterName()
+		}
+		if token = resp.GetNextPageToken(); len(token) == 0 {
+			break
+		}
+	}
+	sort.Strings(names)
+
+	distinct := make(map[string]bool)
+	for _, cluster := range clusters {
+		distinct[cluster] = true
+	}
+	if len(distinct) > 1 {
+		for i, name := range names {
+			names[i] = fmt.Sprintf("%s (cluster %s)", name, clusters[name])
+		}
+	}
+	return names
+}
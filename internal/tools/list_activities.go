@@ -0,0 +1,204 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	historypb "go.temporal.io/api/history/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultMaxActivities and maxMaxActivities bound the activities returned by list_activities.
+	defaultMaxActivities = 100
+	maxMaxActivities     = 1000
+)
+
+// ListActivities implements the "list_activities" tool.
+type ListActivities struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *ListActivities) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_activities",
+		mcp.WithDescription("List the activity executions of a workflow from its history, one row per activity: activity ID, type, status, attempts, result or failure, and duration. Local activities are included and flagged"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithString("activity_type",
+			mcp.Description("Only list activities of this type"),
+		),
+		mcp.WithBoolean("failed_only",
+			mcp.Description("Only list activities that failed or timed out"),
+		),
+		mcp.WithBoolean("include_input",
+			mcp.Description("Include each activity's input (default false)"),
+		),
+		mcp.WithNumber("max_activities",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxMaxActivities),
+			mcp.Description(fmt.Sprintf("Maximum number of activities to return (default %d, maximum %d)", defaultMaxActivities, maxMaxActivities)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ListActivities) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *ListActivities) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	activityType, err := stringArgument(args, "activity_type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	failedOnly, err := boolArgument(args, "failed_only")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	includeInput, err := boolArgument(args, "include_input")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	maxActivities, err := intArgument(args, "max_activities", defaultMaxActivities, 1, maxMaxActivities)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, errResult := describeExecution(ctx, client, t.Logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	execution := pinnedExecution(resp)
+
+	// Rows are keyed by scheduled event ID; local activities use their marker's event ID
+	var (
+		activities []*format.ActivityExecution
+		byID       = make(map[int64]*format.ActivityExecution)
+		scheduled  = make(map[int64]time.Time)
+		markers    = markerDecoder{payloads: t.Payloads}
+	)
+	closeActivity := func(event *historypb.HistoryEvent, scheduledID int64, status string) *format.ActivityExecution {
+		a, ok := byID[scheduledID]
+		if !ok {
+			return &format.ActivityExecution{}
+		}
+		a.Status = status
+		a.Duration = event.GetEventTime().AsTime().Sub(scheduled[scheduledID]).Round(time.Millisecond).String()
+		return a
+	}
+	err = forEachEvent(ctx, client, execution, resp.GetWorkflowExecutionInfo().GetHistoryLength(), func(event *historypb.HistoryEvent) bool {
+		markers.observe(event)
+		switch {
+		case event.GetActivityTaskScheduledEventAttributes() != nil:
+			attrs := event.GetActivityTaskScheduledEventAttributes()
+			a := &format.ActivityExecution{
+				ScheduledEventID: event.GetEventId(),
+				ActivityID:       attrs.GetActivityId(),
+				Type:             attrs.GetActivityType().GetName(),
+				Status:           "Scheduled",
+			}
+			if includeInput {
+				a.Input = t.Payloads.RenderAll(attrs.GetInput())
+			}
+			activities = append(activities, a)
+			byID[event.GetEventId()] = a
+			scheduled[event.GetEventId()] = event.GetEventTime().AsTime()
+		case event.GetActivityTaskStartedEventAttributes() != nil:
+			attrs := event.GetActivityTaskStartedEventAttributes()
+			if a, ok := byID[attrs.GetScheduledEventId()]; ok {
+				a.Status = "Started"
+				a.Attempt = attrs.GetAttempt()
+				if attrs.GetLastFailure() != nil {
+					a.Failure = "last attempt: " + format.Failure(attrs.GetLastFailure())
+				}
+			}
+		case event.GetActivityTaskCompletedEventAttributes() != nil:
+			attrs := event.GetActivityTaskCompletedEventAttributes()
+			a := closeActivity(event, attrs.GetScheduledEventId(), "Completed")
+			a.Result = t.Payloads.RenderAll(attrs.GetResult())
+			a.Failure = ""
+		case event.GetActivityTaskFailedEventAttributes() != nil:
+			attrs := event.GetActivityTaskFailedEventAttributes()
+			closeActivity(event, attrs.GetScheduledEventId(), "Failed").Failure = format.Failure(attrs.GetFailure())
+		case event.GetActivityTaskTimedOutEventAttributes() != nil:
+			attrs := event.GetActivityTaskTimedOutEventAttributes()
+			closeActivity(event, attrs.GetScheduledEventId(), "TimedOut").Failure = format.Failure(attrs.GetFailure())
+		case event.GetActivityTaskCanceledEventAttributes() != nil:
+			closeActivity(event, event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId(), "Canceled")
+		case markerKinds[event.GetMarkerRecordedEventAttributes().GetMarkerName()] == "local_activity":
+			activities = append(activities, localActivityExecution(markers.decode(event)))
+		}
+		return true
+	})
+	partial := partialHistory(err)
+	if partial != "" {
+		t.Logger.Warn("Timed out reading workflow history; returning partial results", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+	} else if err != nil {
+		t.Logger.Error("Error reading workflow history", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+		return rpcError("Failed to read workflow history", err), nil
+	}
+
+	list := format.ActivityList{WorkflowID: wfID, RunID: execution.GetRunId(), Activities: []format.ActivityExecution{}}
+	for _, a := range activities {
+		if (activityType != "" && a.Type != activityType) || (failedOnly && !a.Failed()) {
+			continue
+		}
+		if len(list.Activities) == maxActivities {
+			list.More = true
+			break
+		}
+		list.Activities = append(list.Activities, *a)
+	}
+	result, err := renderResult(outFormat, list, format.ActivityListText)
+	return partialResult(partial, outFormat, result, err)
+}
+
+// localActivityExecution builds a row from a decoded local activity marker.
+func localActivityExecution(m format.Marker) *format.ActivityExecution {
+	a := &format.ActivityExecution{
+		ScheduledEventID: m.EventID,
+		ActivityID:       m.ActivityID,
+		Type:             m.ActivityType,
+		Local:            true,
+		Status:           "Completed",
+		Attempt:          m.Attempt,
+		Result:           m.Result,
+		Duration:         m.Duration,
+	}
+	if m.Failure != "" {
+		a.Status, a.Failure = "Failed", m.Failure
+	}
+	return a
+}
@@ -0,0 +1,341 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// defaultMaxMarkers and maxMaxMarkers bound the markers returned by list_markers.
+const (
+	defaultMaxMarkers = 100
+	maxMaxMarkers     = 1000
+)
+
+// Marker names recorded by the SDKs: the Go and Java SDKs use the plain names, the SDKs built on the
+// shared Rust core (TypeScript, Python, .NET, Ruby) the core_ ones.
+const (
+	localActivityMarker     = "LocalActivity"
+	coreLocalActivityMarker = "core_local_activity"
+	versionMarker           = "Version"
+	corePatchMarker         = "core_patch"
+	sideEffectMarker        = "SideEffect"
+	mutableSideEffectMarker = "MutableSideEffect"
+)
+
+// Notes on how a local activity's duration was reconstructed from its marker.
+const (
+	durationSinceScheduled = "since first scheduled, including retries"
+	durationAtMost         = "at most: since the workflow task that scheduled it started"
+)
+
+// markerKinds maps marker names to the kinds list_markers filters by.
+var markerKinds = map[string]string{
+	localActivityMarker:     "local_activity",
+	coreLocalActivityMarker: "local_activity",
+	versionMarker:           "version",
+	corePatchMarker:         "version",
+	sideEffectMarker:        "side_effect",
+	mutableSideEffectMarker: "mutable_side_effect",
+}
+
+// ListMarkers implements the "list_markers" tool.
+type ListMarkers struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *ListMarkers) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_markers",
+		mcp.WithDescription("List the markers a workflow execution recorded, decoded: local activities (type, ID, attempts, result or failure, completion time, and approximate duration), version markers from GetVersion or patched (change ID and version, key when debugging patched workflows), and side effects (ID and value). These are MarkerRecorded events, which other history views show only as opaque events"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithString("kind",
+			mcp.Enum("local_activity", "version", "side_effect", "mutable_side_effect", "other"),
+			mcp.Description("Only list markers of this kind"),
+		),
+		mcp.WithNumber("max_markers",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxMaxMarkers),
+			mcp.Description(fmt.Sprintf("Maximum number of markers to return (default %d, maximum %d)", defaultMaxMarkers, maxMaxMarkers)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ListMarkers) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *ListMarkers) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	kind, err := stringArgument(args, "kind")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	switch kind {
+	case "", "local_activity", "version", "side_effect", "mutable_side_effect", "other":
+	default:
+		return mcp.NewToolResultError("Invalid 'kind' parameter: must be local_activity, version, side_effect, mutable_side_effect, or other"), nil
+	}
+	maxMarkers, err := intArgument(args, "max_markers", defaultMaxMarkers, 1, maxMaxMarkers)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, errResult := describeExecution(ctx, client, t.Logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	execution := pinnedExecution(resp)
+
+	list := format.MarkerList{WorkflowID: wfID, RunID: execution.GetRunId(), Kind: kind, Markers: []format.Marker{}}
+	markers := markerDecoder{payloads: t.Payloads}
+	err = forEachEvent(ctx, client, execution, resp.GetWorkflowExecutionInfo().GetHistoryLength(), func(event *historypb.HistoryEvent) bool {
+		markers.observe(event)
+		if event.GetMarkerRecordedEventAttributes() == nil {
+			return true
+		}
+		m := markers.decode(event)
+		if kind != "" && m.Kind != kind {
+			return true
+		}
+		if len(list.Markers) == maxMarkers {
+			list.More = true
+			return false
+		}
+		list.Markers = append(list.Markers, m)
+		return true
+	})
+	partial := partialHistory(err)
+	if partial != "" {
+		t.Logger.Warn("Timed out reading workflow history; returning partial results", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+	} else if err != nil {
+		t.Logger.Error("Error reading workflow history", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+		return rpcError("Failed to read workflow history", err), nil
+	}
+	result, err := renderResult(outFormat, list, format.MarkerListText)
+	return partialResult(partial, outFormat, result, err)
+}
+
+// markerDecoder decodes the MarkerRecorded events of a history. It has to observe every event, in
+// order, to time local activities: the Go SDK records only when a local activity completed, and it
+// started no earlier than the workflow task that scheduled it, so the time since then bounds its
+// duration.
+type markerDecoder struct {
+	payloads *payload.Renderer
+
+	// taskStart is when the current workflow task started, or the first of a series of workflow task
+	// heartbeats, which keep a task open while local activities run.
+	taskStart time.Time
+	// completed is set from a WorkflowTaskCompleted event through the markers it recorded, and
+	// heartbeat when the task then scheduled the next one right away: a heartbeat records the local
+	// activities completed so far, and version markers their search attribute, but nothing else.
+	completed, heartbeat bool
+}
+
+// observe tracks workflow task timing; it must be called with every event before decoding it.
+func (d *markerDecoder) observe(event *historypb.HistoryEvent) {
+	switch event.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED:
+		if !d.heartbeat {
+			d.taskStart = event.GetEventTime().AsTime()
+		}
+		d.heartbeat = false
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED:
+		d.completed = true
+		return
+	case enumspb.EVENT_TYPE_MARKER_RECORDED, enumspb.EVENT_TYPE_UPSERT_WORKFLOW_SEARCH_ATTRIBUTES:
+		return
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED:
+		d.heartbeat = d.completed
+	}
+	d.completed = false
+}
+
+// decode decodes a MarkerRecorded event by its marker name.
+func (d *markerDecoder) decode(event *historypb.HistoryEvent) format.Marker {
+	attrs := event.GetMarkerRecordedEventAttributes()
+	m := format.Marker{
+		EventID: event.GetEventId(),
+		Name:    attrs.GetMarkerName(),
+		Kind:    markerKinds[attrs.GetMarkerName()],
+		Time:    format.Time(event.GetEventTime()),
+	}
+	if m.Kind == "" {
+		m.Kind = "other"
+	}
+	if attrs.GetFailure() != nil {
+		m.Failure = format.Failure(attrs.GetFailure())
+	}
+	details := attrs.GetDetails()
+	switch attrs.GetMarkerName() {
+	case localActivityMarker, coreLocalActivityMarker:
+		d.localActivity(&m, details)
+	case versionMarker:
+		// Java names the keys changeId and version
+		m.ChangeID = d.detailString(details, "change-id", "changeId")
+		m.Version = d.detailString(details, "version")
+	case corePatchMarker:
+		var data struct {
+			ID         string `json:"id"`
+			Deprecated bool   `json:"deprecated"`
+		}
+		d.detailJSON(details["patch_data"], &data)
+		m.ChangeID, m.Version = data.ID, "patched"
+		if data.Deprecated {
+			m.Version = "patched (deprecated)"
+		}
+	case sideEffectMarker:
+		m.SideEffectID = d.detailString(details, "side-effect-id")
+		m.Data = d.payloads.RenderAll(details["data"])
+	case mutableSideEffectMarker:
+		// The Go SDK records the ID and the value's own payloads, encoded as JSON, as the data
+		m.SideEffectID = d.detailString(details, "side-effect-id")
+		m.Data = d.payloads.RenderAll(details["data"])
+		var value commonpb.Payloads
+		if data := details["data"].GetPayloads(); len(data) == 2 && protojson.Unmarshal(data[1].GetData(), &value) == nil {
+			m.SideEffectID = d.detailString(map[string]*commonpb.Payloads{"id": {Payloads: data[:1]}}, "id")
+			m.Data = d.payloads.RenderAll(&value)
+		}
+	default:
+		m.Details = make(map[string][]string, len(details))
+		for key, value := range details {
+			m.Details[key] = d.payloads.RenderAll(value)
+		}
+	}
+	return m
+}
+
+// localActivity decodes a local activity marker. Its "data" detail is JSON whose fields vary between
+// SDKs: the Go SDK records when the activity completed, the core SDKs also when it was first
+// scheduled.
+func (d *markerDecoder) localActivity(m *format.Marker, details map[string]*commonpb.Payloads) {
+	var data struct {
+		ActivityID           string
+		ActivityType         string
+		Attempt              int32
+		ReplayTime           json.RawMessage
+		CoreActivityID       string          `json:"activity_id"`
+		CoreActivityType     string          `json:"activity_type"`
+		CompleteTime         json.RawMessage `json:"complete_time"`
+		OriginalScheduleTime json.RawMessage `json:"original_schedule_time"`
+	}
+	d.detailJSON(details["data"], &data)
+	m.ActivityID, m.ActivityType, m.Attempt = data.ActivityID, data.ActivityType, data.Attempt
+	if data.CoreActivityID != "" {
+		m.ActivityID = data.CoreActivityID
+	}
+	if data.CoreActivityType != "" {
+		m.ActivityType = data.CoreActivityType
+	}
+	if m.ActivityType == "" {
+		m.ActivityType = "unknown"
+	}
+	if m.Failure == "" {
+		m.Result = d.payloads.RenderAll(details["result"])
+	}
+
+	completed := markerTime(data.ReplayTime)
+	if completed.IsZero() {
+		completed = markerTime(data.CompleteTime)
+	}
+	if completed.IsZero() {
+		return
+	}
+	m.CompleteTime = completed.UTC().Format(time.RFC3339)
+	if scheduled := markerTime(data.OriginalScheduleTime); !scheduled.IsZero() {
+		m.Duration, m.DurationNote = completed.Sub(scheduled).Round(time.Millisecond).String(), durationSinceScheduled
+	} else if !d.taskStart.IsZero() && completed.After(d.taskStart) {
+		m.Duration, m.DurationNote = completed.Sub(d.taskStart).Round(time.Millisecond).String(), durationAtMost
+	}
+}
+
+// detailString returns the first of the given detail keys present, as a plain string: JSON strings
+// are unquoted.
+func (d *markerDecoder) detailString(details map[string]*commonpb.Payloads, keys ...string) string {
+	for _, key := range keys {
+		rendered := d.payloads.RenderAll(details[key])
+		if len(rendered) == 0 {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal([]byte(rendered[0]), &s); err == nil {
+			return s
+		}
+		return rendered[0]
+	}
+	return ""
+}
+
+// detailJSON decodes the first payload of a detail into v, leaving v unchanged when it isn't JSON.
+func (d *markerDecoder) detailJSON(detail *commonpb.Payloads, v any) {
+	if rendered := d.payloads.RenderAll(detail); len(rendered) > 0 {
+		_ = json.Unmarshal([]byte(rendered[0]), v)
+	}
+}
+
+// markerTime parses a time recorded in marker data, either an RFC 3339 string or a protobuf
+// Timestamp as JSON; it returns the zero time when there is none.
+func markerTime(raw json.RawMessage) time.Time {
+	if len(raw) == 0 {
+		return time.Time{}
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		t, _ := time.Parse(time.RFC3339Nano, s)
+		if t.Year() <= 1 {
+			return time.Time{}
+		}
+		return t
+	}
+	var ts struct {
+		Seconds json.Number `json:"seconds"`
+		Nanos   int64       `json:"nanos"`
+	}
+	if err := json.Unmarshal(raw, &ts); err != nil {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(ts.Seconds.String(), 10, 64)
+	if err != nil || seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, ts.Nanos)
+}
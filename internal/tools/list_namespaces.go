@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// ListNamespaces implements the "list_namespaces" tool.
+type ListNamespaces struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *ListNamespaces) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_namespaces",
+		mcp.WithDescription("List the Temporal namespaces this server may access, for use as the namespace argument of other tools"),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ListNamespaces) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *ListNamespaces) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outFormat, err := outputFormat(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := t.Clients.Client("")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Namespaces outside the allowlist are hidden, not just marked
+	var (
+		namespaces []format.NamespaceSummary
+		token      []byte
+	)
+	for {
+		resp, err := client.ListNamespaces(ctx, &workflowservice.ListNamespacesRequest{
+			PageSize:      100,
+			NextPageToken: token,
+		})
+		if err != nil {
+			t.Logger.Error("Error listing namespaces", "error", err)
+			return rpcError("Failed to list namespaces", err), nil
+		}
+		for _, ns := range resp.GetNamespaces() {
+			summary := format.NewNamespaceSummary(ns)
+			if !t.Clients.Allowed(summary.Name) {
+				continue
+			}
+			summary.Default = summary.Name == t.Clients.DefaultNamespace()
+			namespaces = append(namespaces, summary)
+		}
+		if token = resp.GetNextPageToken(); len(token) == 0 {
+			break
+		}
+	}
+	return renderResult(outFormat, namespaces, format.NamespaceListText)
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	operatorservice "go.temporal.io/api/operatorservice/v1"
+	"google.golang.org/grpc/codes"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// nexusPageSize is the page size of Nexus endpoint listings.
+const nexusPageSize = 100
+
+// ListNexusEndpoints implements the "list_nexus_endpoints" tool.
+type ListNexusEndpoints struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *ListNexusEndpoints) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_nexus_endpoints",
+		mcp.WithDescription("List the cluster's Nexus endpoints with the namespace and task queue (or external URL) each routes operations to, and their description. Endpoints belong to the cluster, not a namespace; those routing to namespaces this server may not access are left out"),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ListNexusEndpoints) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *ListNexusEndpoints) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outFormat, err := outputFormat(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := t.Clients.Client("")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	endpoints := []format.NexusEndpoint{}
+	var token []byte
+	for {
+		resp, err := client.ListNexusEndpoints(ctx, &operatorservice.ListNexusEndpointsRequest{
+			PageSize:      nexusPageSize,
+			NextPageToken: token,
+		})
+		if err != nil {
+			t.Logger.Error("Error listing Nexus endpoints", "error", err)
+			return errorResult(nexusError("Failed to list Nexus endpoints", err)), nil
+		}
+		for _, e := range resp.GetEndpoints() {
+			endpoint := format.NewNexusEndpoint(e, t.Payloads)
+			if endpoint.Target == "worker" && !t.Clients.Allowed(endpoint.TargetNamespace) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+		if token = resp.GetNextPageToken(); len(token) == 0 {
+			break
+		}
+	}
+	return renderResult(outFormat, endpoints, format.NexusEndpointListText)
+}
+
+// nexusError translates the error of a Nexus endpoint RPC. Servers without Nexus answer
+// Unimplemented, and servers with Nexus disabled NotFound; both are reported as such.
+func nexusError(action string, err error) format.ToolError {
+	e := translateError(action, err)
+	switch code, _ := errorCode(err); {
+	case code == codes.Unimplemented || (code == codes.NotFound && strings.Contains(serverMessage(err), "Nexus APIs are disabled")):
+		e.Error = action + ": Nexus is not enabled on this cluster"
+		e.Explanation = "the connected Temporal server doesn't serve the Nexus APIs: " + serverMessage(err)
+		e.Hint = "Nexus needs Temporal server 1.25 or later with the dynamic config system.enableNexus=true; check server_info"
+	case code == codes.NotFound:
+		e.Hint = "check the endpoint name or ID; list_nexus_endpoints lists the endpoints"
+	}
+	return e
+}
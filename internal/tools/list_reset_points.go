@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// ListResetPoints implements the "list_reset_points" tool.
+type ListResetPoints struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *ListResetPoints) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_reset_points",
+		mcp.WithDescription("List the auto-reset points the server recorded for a workflow execution (worker build ID, event ID, created time, and whether it is resettable), to pick a safe reset target"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ListResetPoints) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *ListResetPoints) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, err := client.DescribeWorkflowExecution(ctx, wfID, runID)
+	if err != nil {
+		t.Logger.Error("Error describing workflow", "workflow_id", wfID, "run_id", runID, "error", err)
+		return describeFailed(ctx, client, wfID, err), nil
+	}
+	noteWorkflow(ctx, client.Namespace(), resp.GetWorkflowExecutionInfo())
+
+	points := []format.ResetPoint{}
+	for _, point := range resp.GetWorkflowExecutionInfo().GetAutoResetPoints().GetPoints() {
+		points = append(points, format.NewResetPoint(point))
+	}
+	return renderResult(outFormat, points, func(points []format.ResetPoint) string {
+		return format.ResetPointsText(wfID, points)
+	})
+}
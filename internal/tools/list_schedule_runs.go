@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// ListScheduleRuns implements the "list_schedule_runs" tool, finding the workflows a schedule
+// started through the TemporalScheduledById search attribute.
+type ListScheduleRuns struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	// DefaultPageSize is the page_size of calls that don't pass one; 0 means defaultListPageSize.
+	DefaultPageSize int
+}
+
+// Definition implements Tool.
+func (t *ListScheduleRuns) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_schedule_runs",
+		mcp.WithDescription("List the workflows started by a schedule, most recently scheduled first, with their scheduled and actual start times so drift is visible. Requires advanced visibility"),
+		mcp.WithString("schedule_id",
+			mcp.Required(),
+			mcp.Description("ID of the schedule whose workflows to list"),
+		),
+		mcp.WithNumber("page_size",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxListPageSize),
+			mcp.Description(fmt.Sprintf("Maximum number of workflows to return (default %d, maximum %d)", listPageSize(t.DefaultPageSize), maxListPageSize)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ListScheduleRuns) CachePolicy() CachePolicy {
+	return CacheListing
+}
+
+// ServerRequirement implements RequiresServer.
+func (t *ListScheduleRuns) ServerRequirement() ServerRequirement {
+	return ServerRequirement{
+		Capability:     (*workflowservice.GetSystemInfoResponse_Capabilities).GetSupportsSchedules,
+		CapabilityName: "schedules",
+	}
+}
+
+// Handle implements Tool.
+func (t *ListScheduleRuns) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	scheduleID, err := stringArgument(args, "schedule_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if scheduleID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'schedule_id' parameter"), nil
+	}
+	pageSize, err := intArgument(args, "page_size", listPageSize(t.DefaultPageSize), 1, maxListPageSize)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Visibility returns the most recently started workflows first, which for a schedule are the
+	// most recently scheduled ones; the page is then sorted by scheduled time exactly
+	query := "TemporalScheduledById = " + queryString(scheduleID)
+	resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Namespace: client.Namespace(),
+		PageSize:  int32(pageSize),
+		Query:     query,
+	})
+	if err != nil {
+		t.Logger.Error("Error listing schedule runs", "schedule_id", scheduleID, "error", err)
+		if isQueryUnsupported(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Unsupported on connected server: listing schedule runs requires advanced visibility (the TemporalScheduledById search attribute): %v", err)), nil
+		}
+		return rpcError(fmt.Sprintf("Failed to list runs of schedule %s", scheduleID), err), nil
+	}
+
+	type run struct {
+		view      format.ScheduleRun
+		scheduled time.Time
+	}
+	runs := make([]run, 0, len(resp.GetExecutions()))
+	for _, info := range resp.GetExecutions() {
+		r := run{view: format.ScheduleRun{
+			WorkflowID: info.GetExecution().GetWorkflowId(),
+			RunID:      info.GetExecution().GetRunId(),
+			Type:       info.GetType().GetName(),
+			Status:     format.WorkflowStatus(info.GetStatus()),
+			StartTime:  format.Time(info.GetStartTime()),
+			CloseTime:  format.Time(info.GetCloseTime()),
+		}}
+		if scheduled, ok := searchAttributeTime(info, "TemporalScheduledStartTime"); ok {
+			r.scheduled = scheduled
+			r.view.ScheduledTime = scheduled.UTC().Format(time.RFC3339)
+			if info.GetStartTime() != nil {
+				r.view.Drift = info.GetStartTime().AsTime().Sub(scheduled).Round(time.Millisecond).String()
+			}
+		}
+		runs = append(runs, r)
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].scheduled.After(runs[j].scheduled) })
+
+	view := format.ScheduleRuns{ScheduleID: scheduleID, Runs: make([]format.ScheduleRun, len(runs)), Truncated: len(resp.GetNextPageToken()) > 0}
+	for i, r := range runs {
+		view.Runs[i] = r.view
+	}
+	return renderResult(outFormat, view, format.ScheduleRunsText)
+}
+
+// searchAttributeTime decodes a Datetime search attribute of a visibility record.
+func searchAttributeTime(info *workflowpb.WorkflowExecutionInfo, name string) (time.Time, bool) {
+	p, ok := info.GetSearchAttributes().GetIndexedFields()[name]
+	if !ok {
+		return time.Time{}, false
+	}
+	var t time.Time
+	if err := converter.GetDefaultDataConverter().FromPayload(p, &t); err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
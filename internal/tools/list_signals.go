@@ -0,0 +1,213 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// defaultMaxSignals and maxMaxSignals bound the signals returned by list_signals.
+const (
+	defaultMaxSignals = 100
+	maxMaxSignals     = 1000
+	// defaultSignalPages and maxSignalPages bound the history pages list_signals counts in summary
+	// mode; at 256 events per page, the default covers a history at the server's event limit.
+	defaultSignalPages = 200
+	maxSignalPages     = 1000
+)
+
+// ListSignals implements the "list_signals" tool.
+type ListSignals struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *ListSignals) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_signals",
+		mcp.WithDescription("List the signals a workflow execution has received, in order, with each signal's name, time, sender identity, decoded payload, and event ID. With summary=true, counts them instead: the total and a per-signal-name breakdown with the first and last time each was received, without payloads, e.g. to tell how many approvals a workflow got or whether signals are piling up toward the history limit"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithString("signal_name",
+			mcp.Description("Only list signals with this name"),
+		),
+		mcp.WithNumber("max_signals",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxMaxSignals),
+			mcp.Description(fmt.Sprintf("Maximum number of signals to return (default %d, maximum %d)", defaultMaxSignals, maxMaxSignals)),
+		),
+		mcp.WithBoolean("summary",
+			mcp.Description("Count the signals per name instead of listing them (default false)"),
+		),
+		mcp.WithNumber("max_pages",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxSignalPages),
+			mcp.Description(fmt.Sprintf("In summary mode, maximum number of history pages to scan (default %d, maximum %d)", defaultSignalPages, maxSignalPages)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ListSignals) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *ListSignals) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	signalName, err := stringArgument(args, "signal_name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	maxSignals, err := intArgument(args, "max_signals", defaultMaxSignals, 1, maxMaxSignals)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	summary, err := boolArgument(args, "summary")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	maxPages, err := intArgument(args, "max_pages", defaultSignalPages, 1, maxSignalPages)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, errResult := describeExecution(ctx, client, t.Logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	info := resp.GetWorkflowExecutionInfo()
+	execution := pinnedExecution(resp)
+	if summary {
+		return t.count(ctx, client, execution, info.GetHistoryLength(), signalName, maxPages, outFormat)
+	}
+
+	list := format.SignalList{WorkflowID: wfID, RunID: execution.GetRunId(), SignalName: signalName, Signals: []format.Signal{}}
+	err = forEachEvent(ctx, client, execution, info.GetHistoryLength(), func(event *historypb.HistoryEvent) bool {
+		attrs := event.GetWorkflowExecutionSignaledEventAttributes()
+		if attrs == nil || (signalName != "" && attrs.GetSignalName() != signalName) {
+			return true
+		}
+		if len(list.Signals) == maxSignals {
+			list.More = true
+			return false
+		}
+		list.Signals = append(list.Signals, format.Signal{
+			EventID:  event.GetEventId(),
+			Name:     attrs.GetSignalName(),
+			Time:     format.Time(event.GetEventTime()),
+			Identity: attrs.GetIdentity(),
+			Input:    t.Payloads.RenderAll(attrs.GetInput()),
+		})
+		return true
+	})
+	partial := partialHistory(err)
+	if partial != "" {
+		t.Logger.Warn("Timed out reading workflow history; returning partial results", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+	} else if err != nil {
+		t.Logger.Error("Error reading workflow history", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+		return rpcError("Failed to read workflow history", err), nil
+	}
+	result, err := renderResult(outFormat, list, format.SignalListText)
+	return partialResult(partial, outFormat, result, err)
+}
+
+// count pages through the history up to maxPages, counting signals per name. The server can't
+// filter history by event type, so every event is read, but no payload is decoded.
+func (t *ListSignals) count(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, historyLength int64, signalName string, maxPages int, outFormat string) (*mcp.CallToolResult, error) {
+	counts := format.SignalCounts{WorkflowID: execution.GetWorkflowId(), RunID: execution.GetRunId(), SignalName: signalName}
+	names := make(map[string]*format.SignalSummary)
+	var (
+		token   []byte
+		partial string
+	)
+	for pages := 0; ; {
+		page, err := client.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+			Namespace:     client.Namespace(),
+			Execution:     execution,
+			NextPageToken: token,
+		})
+		if err != nil && pages > 0 && timedOut(ctx) {
+			t.Logger.Warn("Timed out reading workflow history; returning partial results", "workflow_id", execution.GetWorkflowId(), "run_id", execution.GetRunId(), "error", err)
+			partial = (&historyTimeout{err: err, pages: pages, read: counts.Scanned, historyLength: historyLength}).banner()
+			break
+		}
+		if err != nil {
+			t.Logger.Error("Error reading workflow history", "workflow_id", execution.GetWorkflowId(), "run_id", execution.GetRunId(), "error", err)
+			return rpcError("Failed to read workflow history", err), nil
+		}
+		pages++
+		for _, event := range page.GetHistory().GetEvents() {
+			counts.Scanned++
+			attrs := event.GetWorkflowExecutionSignaledEventAttributes()
+			if attrs == nil || (signalName != "" && attrs.GetSignalName() != signalName) {
+				continue
+			}
+			eventTime := format.Time(event.GetEventTime())
+			sig, ok := names[attrs.GetSignalName()]
+			if !ok {
+				sig = &format.SignalSummary{Name: attrs.GetSignalName(), First: eventTime}
+				names[attrs.GetSignalName()] = sig
+			}
+			sig.Count++
+			sig.Last = eventTime
+			counts.Total++
+		}
+		if token = page.GetNextPageToken(); len(token) == 0 {
+			break
+		}
+		if pages >= maxPages {
+			counts.PageLimit = true
+			break
+		}
+		reportProgress(ctx, float64(counts.Scanned), float64(historyLength), "scanned %d of %d events, %d signals", counts.Scanned, historyLength, counts.Total)
+	}
+
+	// Most frequent first
+	counts.Signals = []format.SignalSummary{}
+	for _, sig := range names {
+		counts.Signals = append(counts.Signals, *sig)
+	}
+	sort.Slice(counts.Signals, func(i, j int) bool {
+		a, b := counts.Signals[i], counts.Signals[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Name < b.Name
+	})
+	result, err := renderResult(outFormat, counts, format.SignalCountsText)
+	return partialResult(partial, outFormat, result, err)
+}
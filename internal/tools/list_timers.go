@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	historypb "go.temporal.io/api/history/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// ListTimers implements the "list_timers" tool.
+type ListTimers struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *ListTimers) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_timers",
+		mcp.WithDescription("List the durable timers (including workflow sleeps) of a workflow execution: pending timers with their expected fire time, soonest first, then fired and canceled timers, and when the workflow will next wake up"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// Handle implements Tool.
+func (t *ListTimers) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, errResult := describeExecution(ctx, client, t.Logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	info := resp.GetWorkflowExecutionInfo()
+	execution := pinnedExecution(resp)
+
+	list := format.TimerList{
+		WorkflowID: wfID,
+		RunID:      execution.GetRunId(),
+		Status:     format.WorkflowStatus(info.GetStatus()),
+		Timers:     []format.Timer{},
+	}
+	if task := resp.GetPendingWorkflowTask(); task != nil {
+		list.PendingWorkflowTask = format.NewPendingWorkflowTask(task, time.Now())
+	}
+
+	// Timers are keyed by their started event ID; timer IDs may be reused once a timer closes
+	byStartedID := make(map[int64]int)
+	err = forEachEvent(ctx, client, execution, info.GetHistoryLength(), func(event *historypb.HistoryEvent) bool {
+		switch {
+		case event.GetTimerStartedEventAttributes() != nil:
+			attrs := event.GetTimerStartedEventAttributes()
+			timeout := attrs.GetStartToFireTimeout().AsDuration()
+			byStartedID[event.GetEventId()] = len(list.Timers)
+			list.Timers = append(list.Timers, format.Timer{
+				TimerID:        attrs.GetTimerId(),
+				StartedEventID: event.GetEventId(),
+				StartTime:      format.Time(event.GetEventTime()),
+				Duration:       timeout.String(),
+				FireTime:       event.GetEventTime().AsTime().Add(timeout).UTC().Format(time.RFC3339),
+				Status:         "Pending",
+			})
+		case event.GetTimerFiredEventAttributes() != nil:
+			if i, ok := byStartedID[event.GetTimerFiredEventAttributes().GetStartedEventId()]; ok {
+				list.Timers[i].Status = "Fired"
+				list.Timers[i].FireTime = format.Time(event.GetEventTime())
+			}
+		case event.GetTimerCanceledEventAttributes() != nil:
+			if i, ok := byStartedID[event.GetTimerCanceledEventAttributes().GetStartedEventId()]; ok {
+				list.Timers[i].Status = "Canceled"
+				list.Timers[i].FireTime = format.Time(event.GetEventTime())
+			}
+		}
+		return true
+	})
+	partial := partialHistory(err)
+	if partial != "" {
+		t.Logger.Warn("Timed out reading workflow history; returning partial results", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+	} else if err != nil {
+		t.Logger.Error("Error reading workflow history", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+		return rpcError("Failed to read workflow history", err), nil
+	}
+
+	// Timers of a closed workflow never fire, so only an open workflow has pending ones
+	if info.GetCloseTime() != nil {
+		for i := range list.Timers {
+			if list.Timers[i].Status == "Pending" {
+				list.Timers[i].Status = "Canceled"
+				list.Timers[i].FireTime = format.Time(info.GetCloseTime())
+			}
+		}
+	}
+	sort.SliceStable(list.Timers, func(i, j int) bool {
+		a, b := list.Timers[i], list.Timers[j]
+		if (a.Status == "Pending") != (b.Status == "Pending") {
+			return a.Status == "Pending"
+		}
+		if a.Status == "Pending" {
+			return a.FireTime < b.FireTime
+		}
+		return a.StartedEventID < b.StartedEventID
+	})
+	result, err := renderResult(outFormat, list, func(l format.TimerList) string {
+		return format.TimerListText(l, time.Now())
+	})
+	return partialResult(partial, outFormat, result, err)
+}
@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/config"
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// maxTypeScan caps how many recent executions list_workflow_types inspects.
+const maxTypeScan = 2000
+
+// ListWorkflowTypes implements the "list_workflow_types" tool.
+type ListWorkflowTypes struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	// Catalog lists the known workflow types and their descriptions; it may be empty.
+	Catalog []config.WorkflowType
+}
+
+// Definition implements Tool.
+func (t *ListWorkflowTypes) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_workflow_types",
+		mcp.WithDescription(fmt.Sprintf("List the distinct workflow types in this namespace, derived from the %d most recent executions, with their execution count and most recent start time. Types from the operator's catalog are included with their descriptions", maxTypeScan)),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ListWorkflowTypes) CachePolicy() CachePolicy {
+	return CacheListing
+}
+
+// Handle implements Tool.
+func (t *ListWorkflowTypes) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outFormat, err := outputFormat(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := callClient(t.Clients, req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Executions are listed newest first, so the first start time seen per type is its latest
+	var (
+		result format.WorkflowTypeList
+		byType = make(map[string]*format.WorkflowTypeSummary)
+		token  []byte
+	)
+	for {
+		resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     client.Namespace(),
+			PageSize:      int32(min(maxListPageSize, maxTypeScan-result.Scanned)),
+			NextPageToken: token,
+		})
+		if err != nil {
+			t.Logger.Error("Error listing workflows", "error", err)
+			return rpcError("Failed to list workflows", err), nil
+		}
+		for _, info := range resp.GetExecutions() {
+			result.Scanned++
+			name := info.GetType().GetName()
+			summary, ok := byType[name]
+			if !ok {
+				summary = &format.WorkflowTypeSummary{Type: name, LatestStart: format.Time(info.GetStartTime())}
+				byType[name] = summary
+			}
+			summary.Executions++
+		}
+		token = resp.GetNextPageToken()
+		if len(token) == 0 {
+			break
+		}
+		if result.Scanned >= maxTypeScan {
+			result.Truncated = true
+			break
+		}
+		reportProgress(ctx, float64(result.Scanned), maxTypeScan, "scanned %d executions", result.Scanned)
+	}
+
+	for _, known := range t.Catalog {
+		summary, ok := byType[known.Name]
+		if !ok {
+			summary = &format.WorkflowTypeSummary{Type: known.Name}
+			byType[known.Name] = summary
+		}
+		summary.Description = known.Description
+		summary.Cataloged = true
+	}
+
+	for _, summary := range byType {
+		result.Types = append(result.Types, *summary)
+	}
+	sort.Slice(result.Types, func(i, j int) bool {
+		if result.Types[i].Executions != result.Types[j].Executions {
+			return result.Types[i].Executions > result.Types[j].Executions
+		}
+		return result.Types[i].Type < result.Types[j].Type
+	})
+	return renderResult(outFormat, result, format.WorkflowTypeListText)
+}
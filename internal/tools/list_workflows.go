@@ -0,0 +1,714 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	filterpb "go.temporal.io/api/filter/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/export"
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultListPageSize is the number of workflows listed when page_size isn't given and no
+	// default is configured.
+	defaultListPageSize = 100
+	// maxListPageSize caps page_size.
+	maxListPageSize = 1000
+	// maxRegexScan caps how many workflows a listing filtered client-side (by id_regex, or by
+	// workflow type and duration when the server can't) scans while looking for matches.
+	maxRegexScan = 5000
+)
+
+// ListWorkflows implements the "list_workflows" tool.
+type ListWorkflows struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	// Export is where listings may be exported with file_path; nil disables exports.
+	Export *export.Dir
+	// DefaultPageSize is the page_size of calls that don't pass one; 0 means defaultListPageSize.
+	DefaultPageSize int
+	// Pool bounds the concurrent listings of calls naming several namespaces.
+	Pool *pool.Pool
+}
+
+// Definition implements Tool.
+func (t *ListWorkflows) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"list_workflows",
+		mcp.WithDescription("List Temporal workflows filtered by status (running, completed, or failed)"),
+		mcp.WithString("status",
+			mcp.Required(),
+			mcp.Description("Workflow status to filter by (running, completed, failed)"),
+			mcp.Enum("running", "completed", "failed"),
+		),
+		withNamespacesArgument(),
+		mcp.WithNumber("page_size",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxListPageSize),
+			mcp.Description(fmt.Sprintf("Maximum number of workflows to return (default %d, maximum %d)", listPageSize(t.DefaultPageSize), maxListPageSize)),
+		),
+		mcp.WithString("workflow_type",
+			mcp.Description("Optional workflow type to list, or several comma-separated ones"),
+		),
+		mcp.WithString("exclude_workflow_types",
+			mcp.Description("Optional comma-separated workflow types to leave out, e.g. a noisy heartbeat workflow"),
+		),
+		mcp.WithString("parent_workflow_id",
+			mcp.Description("Optional workflow ID whose child workflows to list"),
+		),
+		mcp.WithString("min_duration",
+			mcp.Description("Optional minimum execution duration (Go duration, e.g. 10m) of completed or failed workflows"),
+		),
+		mcp.WithString("max_duration",
+			mcp.Description("Optional maximum execution duration (Go duration, e.g. 1h) of completed or failed workflows"),
+		),
+		mcp.WithString("id_regex",
+			mcp.Description(fmt.Sprintf("Optional regular expression (Go syntax) that workflow IDs must match. Applied after fetching: pages are scanned until page_size matches are found or %d workflows have been scanned", maxRegexScan)),
+		),
+		mcp.WithString("file_path",
+			mcp.Description("Optional file in the server's export directory to write every matching workflow to instead of returning them: CSV for .csv, JSON lines for .jsonl or .ndjson. page_size is ignored; the export stops at the server's row cap"),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace file_path if it already exists (default false)"),
+		),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ListWorkflows) CachePolicy() CachePolicy {
+	return CacheListing
+}
+
+// Handle implements Tool.
+func (t *ListWorkflows) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Validate and retrieve the status parameter
+	statusVal, err := stringArgument(req.GetArguments(), "status")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if statusVal == "" {
+		return mcp.NewToolResultError("Missing or invalid 'status' parameter"), nil
+	}
+	status, _ := executionStatus(statusVal)
+	statusFilter := strings.ToLower(status)
+	if statusFilter != "running" && statusFilter != "completed" && statusFilter != "failed" {
+		// Unsupported status filter
+		return mcp.NewToolResultError(fmt.Sprintf("Unsupported status '%s' (use running, completed, or failed)", statusVal)), nil
+	}
+
+	pageSize, err := intArgument(req.GetArguments(), "page_size", listPageSize(t.DefaultPageSize), 1, maxListPageSize)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var idRegex *regexp.Regexp
+	pattern, err := stringArgument(req.GetArguments(), "id_regex")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if pattern != "" {
+		if idRegex, err = regexp.Compile(pattern); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'id_regex' parameter: %v", err)), nil
+		}
+	}
+
+	var types typeFilter
+	if types.include, err = stringListArgument(req.GetArguments(), "workflow_type"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if types.exclude, err = stringListArgument(req.GetArguments(), "exclude_workflow_types"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	for _, excluded := range types.exclude {
+		if slices.Contains(types.include, excluded) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'exclude_workflow_types' parameter: %q is also listed in 'workflow_type'", excluded)), nil
+		}
+	}
+
+	var durations durationFilter
+	if durations.min, err = durationArgument(req.GetArguments(), "min_duration", 0); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if durations.max, err = durationArgument(req.GetArguments(), "max_duration", 0); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if durations.active() && statusFilter == "running" {
+		return mcp.NewToolResultError("Invalid 'min_duration'/'max_duration' parameters: running workflows have no execution duration yet (use completed or failed)"), nil
+	}
+	if durations.min > 0 && durations.max > 0 && durations.min > durations.max {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'min_duration' parameter: %s is greater than 'max_duration' %s", durations.min, durations.max)), nil
+	}
+
+	parentID, err := stringArgument(req.GetArguments(), "parent_workflow_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	filePath, err := stringArgument(req.GetArguments(), "file_path")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if filePath != "" && t.Export == nil {
+		return mcp.NewToolResultError("Exports are disabled on this server (set TEMPORAL_MCP_EXPORT_DIR to enable them)"), nil
+	}
+
+	filter := listFilter{status: statusFilter, parentID: parentID, idRegex: idRegex, types: types, durations: durations}
+	namespaces, skipped, err := callNamespaces(ctx, t.Clients, req.GetArguments())
+	if err != nil {
+		t.Logger.Error("Error listing namespaces", "error", err)
+		return rpcError("Failed to list namespaces", err), nil
+	}
+	if namespaces != nil {
+		if filePath != "" {
+			return mcp.NewToolResultError("Invalid 'file_path' parameter: exports cover a single namespace"), nil
+		}
+		return t.listAcross(ctx, namespaces, skipped, filter, pageSize)
+	}
+
+	client, err := callClient(t.Clients, req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// An export streams every matching workflow to the file, page by page, up to the row cap
+	// instead of page_size. A failed export removes its partial file
+	var out *export.Writer
+	limit, scanCap := pageSize, maxRegexScan
+	if filePath != "" {
+		overwrite, err := boolArgument(req.GetArguments(), "overwrite")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if out, err = t.Export.Create(filePath, overwrite); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'file_path' parameter: %v", err)), nil
+		}
+		defer out.Abort()
+		limit, scanCap = t.Export.MaxRows, max(maxRegexScan, t.Export.MaxRows)
+	}
+
+	// Without client-side filtering a single page is all we need; with it, keep paging until enough
+	// workflows match, fetching full pages so small page sizes don't multiply the number of RPCs.
+	// Exports page through everything with the largest pages
+	fetchSize := pageSize
+	switch {
+	case out != nil:
+		fetchSize = maxListPageSize
+	case idRegex != nil:
+		fetchSize = max(pageSize, defaultListPageSize)
+	}
+	var (
+		workflows []format.WorkflowSummary
+		writeErr  error
+	)
+	scan := &listScan{filter: filter, limit: limit, scanCap: scanCap, fetchSize: fetchSize, progress: true}
+	scan.emit = func(summary format.WorkflowSummary) error {
+		if out != nil {
+			writeErr = out.Write(summary)
+			return writeErr
+		}
+		workflows = append(workflows, summary)
+		return nil
+	}
+	if out != nil {
+		scan.flush = func() error {
+			writeErr = out.Flush()
+			return writeErr
+		}
+	}
+	err = t.scan(ctx, client, scan)
+	switch {
+	case writeErr != nil:
+		return t.exportFailed(out, writeErr), nil
+	case err != nil && parentID != "" && isQueryUnsupported(err):
+		t.Logger.Info("Temporal server doesn't support the listing query; reading children from the parent's history", "parent_workflow_id", parentID, "error", err)
+		children, runID, errResult := t.childrenFromHistory(ctx, client, parentID)
+		if errResult != nil {
+			return errResult, nil
+		}
+		var matched int
+		truncated := false
+		for _, child := range children {
+			if matched >= limit {
+				truncated = true
+				break
+			}
+			if (idRegex == nil || idRegex.MatchString(child.GetExecution().GetWorkflowId())) && types.matches(child.GetType().GetName()) {
+				if err := scan.emit(format.NewWorkflowSummary(child)); err != nil {
+					return t.exportFailed(out, err), nil
+				}
+				matched++
+			}
+		}
+		note := format.ChildrenFromHistoryText(parentID, runID)
+		if out != nil {
+			return t.exported(out, "child", truncated, note)
+		}
+		return mcp.NewToolResultText(format.WorkflowListText("child", workflows, truncated) + note), nil
+	case err != nil && statusFilter == "running":
+		t.Logger.Error("Error listing running workflows", "error", err)
+		return rpcError("Failed to list running workflows", err), nil
+	case err != nil:
+		t.Logger.Error("Error listing workflows", "status", statusFilter, "error", err)
+		return rpcError(fmt.Sprintf("Failed to list %s workflows", statusFilter), err), nil
+	}
+
+	var notes string
+	scanCapped := len(scan.token) > 0 && scan.scanned >= scanCap
+	if idRegex != nil {
+		notes += format.RegexScanText(idRegex.String(), scan.scanned, scan.matched, scanCapped)
+	}
+	if scan.clientSide {
+		notes += format.ClientSideFilterText(scan.scanned, scanCapped)
+	}
+	// The page is full when it stopped at page_size with workflows left unscanned
+	more := scan.truncated || (len(scan.token) > 0 && scan.matched >= limit)
+	if out != nil {
+		result, err := t.exported(out, statusFilter, more, notes)
+		return partialResult(scan.partial, "text", result, err)
+	}
+	result := mcp.NewToolResultText(format.WorkflowListText(statusFilter, workflows, more) + notes)
+	return partialResult(scan.partial, "text", result, nil)
+}
+
+// listFilter holds the filters of a list_workflows call.
+type listFilter struct {
+	// status is running, completed, or failed
+	status    string
+	parentID  string
+	idRegex   *regexp.Regexp
+	types     typeFilter
+	durations durationFilter
+}
+
+// listScan pages through the workflows of one namespace that match a filter. The fields up to
+// progress configure the scan; the others report how far it got.
+type listScan struct {
+	filter listFilter
+	// limit stops the scan once that many workflows matched, and scanCap once that many were
+	// scanned while filtering client-side; fetchSize is the page size requested
+	limit, scanCap, fetchSize int
+	// emit receives each matching workflow; flush, when set, is called after each page
+	emit  func(format.WorkflowSummary) error
+	flush func() error
+	// progress reports the scan as the call's progress; concurrent scans leave it off
+	progress bool
+
+	scanned, matched, pages int
+	// token is the page token of the first unscanned page
+	token []byte
+	// truncated is set when workflows were left unscanned because enough matched
+	truncated bool
+	// clientSide is set when the filters ran client-side because the server can't run the query
+	clientSide bool
+	// partial describes how far the scan got when the call's deadline cut it short
+	partial string
+}
+
+// scan runs s against client. Parents, workflow types, and durations are filtered by the server
+// through a visibility query, unless it turns out not to support one (e.g. IN or the
+// ParentWorkflowId and ExecutionDuration search attributes). Then the other filters run
+// client-side like id_regex, along with the status the legacy listing ignores, while an
+// unsupported parent filter is returned as an error for the caller to read children from the
+// parent's history instead. The call's deadline passing after the first page ends the scan with
+// partial set rather than an error.
+func (t *ListWorkflows) scan(ctx context.Context, client temporal.Client, s *listScan) error {
+	f := s.filter
+	var query string
+	if f.parentID != "" || f.types.active() || f.durations.active() {
+		clauses := []string{"ExecutionStatus = " + queryString(statusNames[f.status])}
+		if f.parentID != "" {
+			clauses = append(clauses, "ParentWorkflowId = "+queryString(f.parentID))
+		}
+		clauses = append(clauses, f.types.clauses()...)
+		query = strings.Join(append(clauses, f.durations.clauses()...), " AND ")
+	}
+	matches := func(info *workflowpb.WorkflowExecutionInfo) bool {
+		if f.idRegex != nil && !f.idRegex.MatchString(info.GetExecution().GetWorkflowId()) {
+			return false
+		}
+		if !s.clientSide {
+			return true
+		}
+		return format.WorkflowStatus(info.GetStatus()) == statusNames[f.status] &&
+			f.types.matches(info.GetType().GetName()) && f.durations.matches(executionDuration(info))
+	}
+
+	fetchSize := s.fetchSize
+	for {
+		executions, next, err := t.listPage(ctx, client, f.status, query, fetchSize, s.token)
+		if err != nil && s.pages > 0 && timedOut(ctx) {
+			t.Logger.Warn("Timed out listing workflows; returning partial results", "status", f.status, "pages", s.pages, "error", err)
+			s.partial = fmt.Sprintf("partial results: timed out after %d pages (%d workflows scanned)", s.pages, s.scanned)
+			return nil
+		}
+		if err != nil && query != "" && f.parentID == "" && isQueryUnsupported(err) {
+			t.Logger.Info("Temporal server doesn't support the listing query; filtering client-side", "query", query, "error", err)
+			query, s.clientSide = "", true
+			fetchSize = max(fetchSize, defaultListPageSize)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		s.pages++
+		filtered := f.idRegex != nil || s.clientSide
+		for _, info := range executions {
+			if s.matched >= s.limit {
+				s.truncated = true
+				break
+			}
+			s.scanned++
+			if !matches(info) {
+				continue
+			}
+			summary := format.NewWorkflowSummary(info)
+			if f.durations.active() {
+				summary.Duration = executionDuration(info).Round(time.Millisecond).String()
+			}
+			if err := s.emit(summary); err != nil {
+				return err
+			}
+			s.matched++
+		}
+		s.token = next
+		if s.flush != nil {
+			if err := s.flush(); err != nil {
+				return err
+			}
+			if s.progress {
+				reportProgress(ctx, float64(s.matched), float64(s.limit), "exported %d workflows, %d scanned", s.matched, s.scanned)
+			}
+		}
+		if (s.flush == nil && !filtered) || s.matched >= s.limit || len(s.token) == 0 || (filtered && s.scanned >= s.scanCap) {
+			return nil
+		}
+		if s.flush == nil && s.progress {
+			reportProgress(ctx, float64(s.scanned), float64(s.scanCap), "scanned %d workflows, %d matched", s.scanned, s.matched)
+		}
+	}
+}
+
+// listAcross lists the workflows matching filter in each of namespaces concurrently, through the
+// shared pool, and merges them most recently started first, up to pageSize. A namespace that can't
+// be listed is reported along with the others' workflows rather than failing the call; skipped
+// counts the namespaces "*" matched beyond maxNamespaceFanout.
+func (t *ListWorkflows) listAcross(ctx context.Context, namespaces []string, skipped int, filter listFilter, pageSize int) (*mcp.CallToolResult, error) {
+	fetchSize := pageSize
+	if filter.idRegex != nil {
+		fetchSize = max(pageSize, defaultListPageSize)
+	}
+	var (
+		scans     = make([]*listScan, len(namespaces))
+		errs      = make([]error, len(namespaces))
+		workflows []format.WorkflowSummary
+		mu        sync.Mutex
+		done      atomic.Int64
+	)
+	stats := t.Pool.Run(ctx, len(namespaces), 0, func(ctx context.Context, i int) {
+		// The last item isn't reported: the response itself signals completion
+		defer func() {
+			if n := done.Add(1); n < int64(len(namespaces)) {
+				reportProgress(ctx, float64(n), float64(len(namespaces)), "listed %d of %d namespaces", n, len(namespaces))
+			}
+		}()
+		namespace := namespaces[i]
+		scan := &listScan{filter: filter, limit: pageSize, scanCap: maxRegexScan, fetchSize: fetchSize}
+		scan.emit = func(summary format.WorkflowSummary) error {
+			summary.Namespace = namespace
+			mu.Lock()
+			workflows = append(workflows, summary)
+			mu.Unlock()
+			return nil
+		}
+		client, err := t.Clients.Client(namespace)
+		if err == nil {
+			err = t.scan(ctx, client, scan)
+		}
+		if err != nil {
+			t.Logger.Warn("Error listing workflows", "namespace", namespace, "status", filter.status, "error", err)
+		}
+		scans[i], errs[i] = scan, err
+	})
+
+	var (
+		failed                       []format.NamespaceError
+		scanned, matched             int
+		more, scanCapped, clientSide bool
+	)
+	for i, scan := range scans {
+		if errs[i] != nil {
+			failed = append(failed, format.NamespaceError{
+				Namespace: namespaces[i],
+				Error:     translateError(fmt.Sprintf("Failed to list %s workflows", filter.status), errs[i]).Error,
+			})
+			continue
+		}
+		if scan == nil {
+			continue
+		}
+		scanned += scan.scanned
+		matched += scan.matched
+		more = more || scan.truncated || (len(scan.token) > 0 && scan.matched >= pageSize)
+		scanCapped = scanCapped || (len(scan.token) > 0 && scan.scanned >= maxRegexScan)
+		clientSide = clientSide || scan.clientSide
+	}
+	if len(failed) == len(namespaces) {
+		return rpcError(fmt.Sprintf("Failed to list %s workflows in any of %d namespaces", filter.status, len(namespaces)), errs[0]), nil
+	}
+
+	sort.Slice(workflows, func(i, j int) bool {
+		a, b := workflows[i], workflows[j]
+		if a.StartTime != b.StartTime {
+			return a.StartTime > b.StartTime
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.WorkflowID < b.WorkflowID
+	})
+	if len(workflows) > pageSize {
+		workflows, more = workflows[:pageSize], true
+	}
+	notes := format.NamespacesText(namespaces, skipped, failed)
+	if filter.idRegex != nil {
+		notes += format.RegexScanText(filter.idRegex.String(), scanned, matched, scanCapped)
+	}
+	if clientSide {
+		notes += format.ClientSideFilterText(scanned, scanCapped)
+	}
+	result := mcp.NewToolResultText(format.WorkflowListText(filter.status, workflows, more) + notes)
+	return partialResult(partialFanout(ctx, stats, "namespaces"), "text", result, nil)
+}
+
+// exported closes a finished export and reports it, with notes on how the listing was filtered.
+func (t *ListWorkflows) exported(out *export.Writer, statusFilter string, capped bool, notes string) (*mcp.CallToolResult, error) {
+	if err := out.Close(); err != nil {
+		return t.exportFailed(out, err), nil
+	}
+	t.Logger.Info("Exported workflow listing", "path", out.Path(), "rows", out.Rows())
+	return mcp.NewToolResultText(format.WorkflowExportText(statusFilter, out.Rows(), out.Path(), out.Format(), t.Export.MaxRows, capped) + notes), nil
+}
+
+// exportFailed reports an export that couldn't be written; its partial file is removed on return.
+func (t *ListWorkflows) exportFailed(out *export.Writer, err error) *mcp.CallToolResult {
+	t.Logger.Error("Error writing workflow export", "path", out.Path(), "error", err)
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to write export %s: %v", out.Path(), err))
+}
+
+// childrenFromHistory returns the child workflows started by the latest run of parentID, as read
+// from its history, with the ID of that run. Their status is the one the parent's history records,
+// so children of any state are listed regardless of the status filter.
+func (t *ListWorkflows) childrenFromHistory(ctx context.Context, client temporal.Client, parentID string) ([]*workflowpb.WorkflowExecutionInfo, string, *mcp.CallToolResult) {
+	resp, errResult := describeExecution(ctx, client, t.Logger, parentID, "")
+	if errResult != nil {
+		return nil, "", errResult
+	}
+	var (
+		children []*workflowpb.WorkflowExecutionInfo
+		// byInitiated indexes children by the ID of the event that initiated them, which their
+		// closing events refer to
+		byInitiated = make(map[int64]*workflowpb.WorkflowExecutionInfo)
+	)
+	closed := func(initiatedID int64, status enumspb.WorkflowExecutionStatus, event *historypb.HistoryEvent) {
+		if child, ok := byInitiated[initiatedID]; ok {
+			child.Status, child.CloseTime = status, event.GetEventTime()
+		}
+	}
+	execution := pinnedExecution(resp)
+	err := forEachEvent(ctx, client, execution, resp.GetWorkflowExecutionInfo().GetHistoryLength(), func(event *historypb.HistoryEvent) bool {
+		switch {
+		case event.GetChildWorkflowExecutionStartedEventAttributes() != nil:
+			attrs := event.GetChildWorkflowExecutionStartedEventAttributes()
+			child := &workflowpb.WorkflowExecutionInfo{
+				Execution: attrs.GetWorkflowExecution(),
+				Type:      attrs.GetWorkflowType(),
+				StartTime: event.GetEventTime(),
+				Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			}
+			children = append(children, child)
+			byInitiated[attrs.GetInitiatedEventId()] = child
+		case event.GetChildWorkflowExecutionCompletedEventAttributes() != nil:
+			closed(event.GetChildWorkflowExecutionCompletedEventAttributes().GetInitiatedEventId(), enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, event)
+		case event.GetChildWorkflowExecutionFailedEventAttributes() != nil:
+			closed(event.GetChildWorkflowExecutionFailedEventAttributes().GetInitiatedEventId(), enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, event)
+		case event.GetChildWorkflowExecutionCanceledEventAttributes() != nil:
+			closed(event.GetChildWorkflowExecutionCanceledEventAttributes().GetInitiatedEventId(), enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED, event)
+		case event.GetChildWorkflowExecutionTimedOutEventAttributes() != nil:
+			closed(event.GetChildWorkflowExecutionTimedOutEventAttributes().GetInitiatedEventId(), enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT, event)
+		case event.GetChildWorkflowExecutionTerminatedEventAttributes() != nil:
+			closed(event.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventId(), enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED, event)
+		}
+		return true
+	})
+	if err != nil {
+		t.Logger.Error("Error reading parent workflow history", "workflow_id", parentID, "error", err)
+		return nil, "", rpcError(fmt.Sprintf("Failed to read the history of parent workflow %s", parentID), err)
+	}
+	return children, execution.GetRunId(), nil
+}
+
+// listPage fetches one page of workflows with the given status, or matching query when it is set.
+func (t *ListWorkflows) listPage(ctx context.Context, client temporal.Client, statusFilter, query string, pageSize int, token []byte) ([]*workflowpb.WorkflowExecutionInfo, []byte, error) {
+	if query != "" {
+		resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     client.Namespace(),
+			PageSize:      int32(pageSize),
+			NextPageToken: token,
+			Query:         query,
+		})
+		return resp.GetExecutions(), resp.GetNextPageToken(), err
+	}
+	if statusFilter == "running" {
+		// List open (running) workflows
+		resp, err := client.ListOpenWorkflow(ctx, &workflowservice.ListOpenWorkflowExecutionsRequest{
+			Namespace:       client.Namespace(),
+			MaximumPageSize: int32(pageSize),
+			NextPageToken:   token,
+		})
+		return resp.GetExecutions(), resp.GetNextPageToken(), err
+	}
+
+	// List closed workflows filtered by close status (Completed or Failed)
+	closeStatus := enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+	if statusFilter == "failed" {
+		closeStatus = enumspb.WORKFLOW_EXECUTION_STATUS_FAILED
+	}
+	resp, err := client.ListClosedWorkflow(ctx, &workflowservice.ListClosedWorkflowExecutionsRequest{
+		Namespace:       client.Namespace(),
+		MaximumPageSize: int32(pageSize),
+		NextPageToken:   token,
+		Filters: &workflowservice.ListClosedWorkflowExecutionsRequest_StatusFilter{
+			StatusFilter: &filterpb.StatusFilter{Status: closeStatus},
+		},
+	})
+	return resp.GetExecutions(), resp.GetNextPageToken(), err
+}
+
+// statusNames maps the status filters of list_workflows to visibility ExecutionStatus values.
+var statusNames = map[string]string{
+	"running":   "Running",
+	"completed": "Completed",
+	"failed":    "Failed",
+}
+
+// typeFilter selects workflows by type: those of any included type (all when none are), except
+// those of an excluded type.
+type typeFilter struct {
+	include, exclude []string
+}
+
+// active reports whether the filter leaves any workflow out.
+func (f typeFilter) active() bool {
+	return len(f.include) > 0 || len(f.exclude) > 0
+}
+
+// clauses returns the visibility query clauses of the filter. Single types use = and != rather
+// than IN, which more visibility stores support.
+func (f typeFilter) clauses() []string {
+	var clauses []string
+	switch len(f.include) {
+	case 0:
+	case 1:
+		clauses = append(clauses, "WorkflowType = "+queryString(f.include[0]))
+	default:
+		clauses = append(clauses, fmt.Sprintf("WorkflowType IN (%s)", queryStrings(f.include)))
+	}
+	switch len(f.exclude) {
+	case 0:
+	case 1:
+		clauses = append(clauses, "WorkflowType != "+queryString(f.exclude[0]))
+	default:
+		clauses = append(clauses, fmt.Sprintf("WorkflowType NOT IN (%s)", queryStrings(f.exclude)))
+	}
+	return clauses
+}
+
+// matches reports whether a workflow of workflowType passes the filter.
+func (f typeFilter) matches(workflowType string) bool {
+	if len(f.include) > 0 && !slices.Contains(f.include, workflowType) {
+		return false
+	}
+	return !slices.Contains(f.exclude, workflowType)
+}
+
+// durationFilter selects closed workflows by execution duration; zero bounds are unset.
+type durationFilter struct {
+	min, max time.Duration
+}
+
+// active reports whether either bound is set.
+func (f durationFilter) active() bool {
+	return f.min > 0 || f.max > 0
+}
+
+// clauses returns the visibility query clauses of the filter, on the ExecutionDuration search
+// attribute.
+func (f durationFilter) clauses() []string {
+	var clauses []string
+	if f.min > 0 {
+		clauses = append(clauses, "ExecutionDuration >= "+queryString(f.min.String()))
+	}
+	if f.max > 0 {
+		clauses = append(clauses, "ExecutionDuration <= "+queryString(f.max.String()))
+	}
+	return clauses
+}
+
+// matches reports whether a workflow that ran for d passes the filter.
+func (f durationFilter) matches(d time.Duration) bool {
+	return (f.min == 0 || d >= f.min) && (f.max == 0 || d <= f.max)
+}
+
+// executionDuration returns how long a closed workflow ran. Visibility records without the
+// ExecutionDuration field (e.g. from legacy listings) fall back to close minus start time.
+func executionDuration(info *workflowpb.WorkflowExecutionInfo) time.Duration {
+	if d := info.GetExecutionDuration(); d != nil {
+		return d.AsDuration()
+	}
+	if info.GetCloseTime() == nil || info.GetStartTime() == nil {
+		return 0
+	}
+	return info.GetCloseTime().AsTime().Sub(info.GetStartTime().AsTime())
+}
+
+// listPageSize returns the configured default page_size, or defaultListPageSize when none is.
+func listPageSize(configured int) int {
+	if configured > 0 {
+		return configured
+	}
+	return defaultListPageSize
+}
+
+// queryString quotes s as a string literal of a visibility query, escaping backslashes and double
+// quotes. Unlike %q it leaves non-ASCII characters as they are, which the query parser expects.
+func queryString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// queryStrings quotes each value with queryString and joins them for an IN list.
+func queryStrings(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = queryString(v)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,262 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/cache"
+)
+
+func TestListWorkflowsStatusFilter(t *testing.T) {
+	tests := []struct {
+		status string
+		// rpc is the listing expected; closeStatus the status filter of closed listings
+		rpc         string
+		closeStatus enumspb.WorkflowExecutionStatus
+		want        string
+	}{
+		{
+			status: "running",
+			rpc:    "ListOpenWorkflow",
+			want: "Found 1 running workflow(s):\n" +
+				"- ID: order-1 | Run: order-1-run | Type: OrderWorkflow | Status: Running | Start: 2024-05-01T09:30:00Z\n",
+		},
+		{
+			status:      "completed",
+			rpc:         "ListClosedWorkflow",
+			closeStatus: enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			want: "Found 1 completed workflow(s):\n" +
+				"- ID: order-1 | Run: order-1-run | Type: OrderWorkflow | Status: Completed | Start: 2024-05-01T09:30:00Z | End: 2024-05-01T10:30:00Z\n",
+		},
+		{
+			status:      "failed",
+			rpc:         "ListClosedWorkflow",
+			closeStatus: enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+			want: "Found 1 failed workflow(s):\n" +
+				"- ID: order-1 | Run: order-1-run | Type: OrderWorkflow | Status: Failed | Start: 2024-05-01T09:30:00Z | End: 2024-05-01T10:30:00Z\n",
+		},
+		{
+			// Statuses are matched case-insensitively
+			status:      "COMPLETED",
+			rpc:         "ListClosedWorkflow",
+			closeStatus: enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			want: "Found 1 completed workflow(s):\n" +
+				"- ID: order-1 | Run: order-1-run | Type: OrderWorkflow | Status: Completed | Start: 2024-05-01T09:30:00Z | End: 2024-05-01T10:30:00Z\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			client := &fakeClient{
+				listOpenWorkflow: func(_ context.Context, req *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+					return &workflowservice.ListOpenWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{
+						execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+					}}, nil
+				},
+				listClosedWorkflow: func(_ context.Context, req *workflowservice.ListClosedWorkflowExecutionsRequest) (*workflowservice.ListClosedWorkflowExecutionsResponse, error) {
+					if got := req.GetStatusFilter().GetStatus(); got != tt.closeStatus {
+						t.Errorf("status filter = %s, want %s", got, tt.closeStatus)
+					}
+					return &workflowservice.ListClosedWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{
+						execution("order-1", "OrderWorkflow", req.GetStatusFilter().GetStatus()),
+					}}, nil
+				},
+			}
+			tool := &ListWorkflows{Clients: newFakeProvider(client), Logger: testLogger}
+
+			result := callTool(t, tool, map[string]any{"status": tt.status})
+			if result.IsError {
+				t.Fatalf("unexpected error result: %s", resultText(result))
+			}
+			if got := resultText(result); got != tt.want {
+				t.Errorf("output:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if calls := client.called(); len(calls) != 1 || calls[0] != tt.rpc {
+				t.Errorf("RPCs = %v, want [%s]", calls, tt.rpc)
+			}
+		})
+	}
+}
+
+func TestListWorkflowsEmpty(t *testing.T) {
+	client := &fakeClient{
+		listOpenWorkflow: func(context.Context, *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+			return &workflowservice.ListOpenWorkflowExecutionsResponse{}, nil
+		},
+	}
+	tool := &ListWorkflows{Clients: newFakeProvider(client), Logger: testLogger}
+
+	result := callTool(t, tool, map[string]any{"status": "running"})
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", resultText(result))
+	}
+	if got, want := resultText(result), "No running workflows found."; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestListWorkflowsArgumentValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"missing status", map[string]any{}, "Missing or invalid 'status' parameter"},
+		{"null status", map[string]any{"status": nil}, "Missing or invalid 'status' parameter"},
+		{"unsupported status", map[string]any{"status": "paused"}, "Unsupported status 'paused' (use running, completed, or failed)"},
+		{"page_size too large", map[string]any{"status": "running", "page_size": 5000.0}, "invalid value for 'page_size': must be between 1 and 1000"},
+		{"page_size not an integer", map[string]any{"status": "running", "page_size": 2.5}, "invalid value for 'page_size': expected integer, got 2.5"},
+		{"invalid id_regex", map[string]any{"status": "running", "id_regex": "("}, "Invalid 'id_regex' parameter: error parsing regexp: missing closing ): `(`"},
+		{"durations of running workflows", map[string]any{"status": "running", "min_duration": "1h"}, "Invalid 'min_duration'/'max_duration' parameters: running workflows have no execution duration yet (use completed or failed)"},
+		{"min_duration above max_duration", map[string]any{"status": "completed", "min_duration": "2h", "max_duration": "1h"}, "Invalid 'min_duration' parameter: 2h0m0s is greater than 'max_duration' 1h0m0s"},
+		{"export without export directory", map[string]any{"status": "running", "file_path": "out.csv"}, "Exports are disabled on this server (set TEMPORAL_MCP_EXPORT_DIR to enable them)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{}
+			tool := &ListWorkflows{Clients: newFakeProvider(client), Logger: testLogger}
+
+			result := callTool(t, tool, tt.args)
+			if !result.IsError {
+				t.Fatalf("want an error result, got: %s", resultText(result))
+			}
+			if got := resultText(result); got != tt.want {
+				t.Errorf("error = %q, want %q", got, tt.want)
+			}
+			if calls := client.called(); len(calls) > 0 {
+				t.Errorf("invalid arguments made RPCs: %v", calls)
+			}
+		})
+	}
+}
+
+func TestListWorkflowsTranslatesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "unavailable",
+			err:  serviceerror.NewUnavailable("connection refused"),
+			want: "Failed to list running workflows: connection refused\n" +
+				"Code: Unavailable (the Temporal frontend couldn't be reached or is overloaded)\n" +
+				"Hint: transient, retry; if it persists, run diagnose_connection",
+		},
+		{
+			name: "namespace not found",
+			err:  serviceerror.NewNamespaceNotFound("payments"),
+			want: "Failed to list running workflows: namespace 'payments' does not exist on this cluster\n" +
+				"Code: NotFound (the namespace isn't registered on the connected Temporal cluster)\n" +
+				"Hint: check the namespace argument or TEMPORAL_NAMESPACE; list_namespaces shows the registered ones",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{
+				listOpenWorkflow: func(context.Context, *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+					return nil, tt.err
+				},
+			}
+			tool := &ListWorkflows{Clients: newFakeProvider(client), Logger: testLogger}
+
+			result := callTool(t, tool, map[string]any{"status": "running"})
+			if !result.IsError {
+				t.Fatalf("want an error result, got: %s", resultText(result))
+			}
+			if got := resultText(result); got != tt.want {
+				t.Errorf("error:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListWorkflowsPartialScan(t *testing.T) {
+	// The first page comes back at once; the second hangs until the call's deadline while slow is set
+	var slow atomic.Bool
+	slow.Store(true)
+	client := &fakeClient{
+		listOpenWorkflow: func(ctx context.Context, req *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+			if len(req.GetNextPageToken()) == 0 {
+				return &workflowservice.ListOpenWorkflowExecutionsResponse{
+					Executions: []*workflowpb.WorkflowExecutionInfo{
+						execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+						execution("refund-1", "RefundWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+						execution("order-2", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+					},
+					NextPageToken: []byte("page-2"),
+				}, nil
+			}
+			if slow.Load() {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return &workflowservice.ListOpenWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{
+				execution("order-3", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+			}}, nil
+		},
+	}
+	tool := &ListWorkflows{Clients: newFakeProvider(client), Logger: testLogger}
+	// The registry's order: the cache sees what the timeout middleware lets through
+	handler := withCache(cache.New(10, time.Minute), testLogger, "default", "list_workflows",
+		withTimeout(100*time.Millisecond, time.Minute, tool.Handle))
+	call := func() *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Name = "list_workflows"
+		// id_regex keeps the scan paging until page_size workflows match
+		req.Params.Arguments = map[string]any{"status": "running", "id_regex": "^order-", "page_size": 10.0}
+		result, err := handler(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	result := call()
+	if result.IsError {
+		t.Fatalf("want the first page's workflows, got an error: %s", resultText(result))
+	}
+	text := resultText(result)
+	banner := "[partial results: timed out after 1 pages (3 workflows scanned); pass a larger timeout_seconds for the rest]\n"
+	if !strings.HasPrefix(text, banner) {
+		t.Errorf("output doesn't start with the partial banner:\n%s", text)
+	}
+	assertContains(t, text, "ID: order-1 |", "ID: order-2 |")
+	if strings.Contains(text, "refund-1") {
+		t.Errorf("output lists a workflow id_regex excludes:\n%s", text)
+	}
+	if !isPartial(result) {
+		t.Errorf("_meta = %+v, want partial: true", result.Meta)
+	}
+
+	// A partial result isn't cached, so the same call scans again
+	if result := call(); !isPartial(result) {
+		t.Errorf("second call wasn't scanned again: %s", resultText(result))
+	}
+	if calls := len(client.called()); calls != 4 {
+		t.Errorf("RPCs = %d, want 4: both calls list both pages", calls)
+	}
+
+	// Once the scan completes, the result is complete and cached
+	slow.Store(false)
+	result = call()
+	if isPartial(result) || result.IsError {
+		t.Fatalf("want a complete result, got: %s", resultText(result))
+	}
+	assertContains(t, resultText(result), "ID: order-3 |")
+	if cached := call(); cached != result {
+		t.Errorf("complete result wasn't served from the cache")
+	}
+	if calls := len(client.called()); calls != 6 {
+		t.Errorf("RPCs = %d, want 6: the cached call makes none", calls)
+	}
+}
@@ -0,0 +1,250 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wricardo/temporal-mcp/internal/audit"
+	"github.com/wricardo/temporal-mcp/internal/cache"
+	"github.com/wricardo/temporal-mcp/internal/metrics"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// payloadArguments lists tool arguments that carry user payloads; their values are never logged.
+var payloadArguments = map[string]bool{
+	"input":   true,
+	"args":    true,
+	"payload": true,
+	"details": true,
+}
+
+// redactArguments returns a copy of the tool arguments with payload values replaced by a size marker.
+func redactArguments(args map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if payloadArguments[key] {
+			redacted[key] = fmt.Sprintf("[REDACTED %d bytes]", len(fmt.Sprint(value)))
+			continue
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// withCallLogging wraps a tool handler so every call is logged at debug level with its
+// arguments, duration, and outcome.
+func withCallLogging(logger *slog.Logger, name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		started := time.Now()
+		result, err := handler(ctx, req)
+
+		outcome := "success"
+		if err != nil {
+			outcome = "error"
+		} else if result != nil && result.IsError {
+			outcome = "tool_error"
+		}
+		logger.Debug("Tool call",
+			"tool", name,
+			"arguments", redactArguments(req.GetArguments()),
+			"duration", time.Since(started),
+			"outcome", outcome,
+		)
+		return result, err
+	}
+}
+
+// withAudit wraps a tool handler so every call, successful or not, is recorded in the audit sink
+// with the session and the identity its Temporal RPCs carry, resolved through clients.
+func withAudit(sink audit.Sink, logger *slog.Logger, clients temporal.Provider, defaultNamespace, name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, req)
+
+		event := audit.Event{
+			Time:      time.Now().UTC(),
+			Tool:      name,
+			Arguments: redactArguments(req.GetArguments()),
+			Session:   sessionID(ctx),
+			Namespace: callNamespace(req.GetArguments(), defaultNamespace),
+			Success:   err == nil && (result == nil || !result.IsError),
+		}
+		event.ClientName, event.ClientVersion = clientInfo(ctx)
+		if clients != nil {
+			// The identity of the namespace the call used, or, for a call across several, of the
+			// default namespace's connection; a rejected namespace made no RPC and has none
+			client, clientErr := callClient(clients, req.GetArguments())
+			if namespace := namespaceArgument(req.GetArguments()); clientErr != nil && (namespace == "*" || strings.Contains(namespace, ",")) {
+				client, clientErr = clients.Client("")
+			}
+			if clientErr == nil {
+				event.Identity = client.Identity(ctx)
+			}
+		}
+		if err != nil {
+			event.Error = err.Error()
+		} else if result != nil && result.IsError {
+			event.Error = resultText(result)
+		}
+		if auditErr := sink.Record(event); auditErr != nil {
+			logger.Warn("Unable to write audit record", "tool", name, "error", auditErr)
+		}
+		return result, err
+	}
+}
+
+// withCache wraps a read tool handler so identical calls within the cache TTL are served from
+// memory. Entries are keyed by tool, namespace, and the normalized arguments; only complete,
+// successful results are cached, and the no_cache argument and exports to a file_path bypass the cache.
+func withCache(c *cache.Cache, logger *slog.Logger, defaultNamespace, name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.GetArguments()
+		if noCache, _ := boolArgument(args, "no_cache"); noCache {
+			return handler(ctx, req)
+		}
+		// Exports write a file, which a cached result would skip
+		if path, _ := stringArgument(args, "file_path"); path != "" {
+			return handler(ctx, req)
+		}
+
+		key, err := cacheKey(name, callNamespace(args, defaultNamespace), args)
+		if err != nil {
+			return handler(ctx, req)
+		}
+		if cached, ok := c.Get(key); ok {
+			logger.Debug("Serving cached tool result", "tool", name, "cached", true)
+			return cached.(*mcp.CallToolResult), nil
+		}
+
+		result, err := handler(ctx, req)
+		if err == nil && result != nil && !result.IsError && !isPartial(result) {
+			c.Set(key, result)
+		}
+		return result, err
+	}
+}
+
+// cacheKey builds the cache key for a call. Control arguments that don't affect the result are
+// dropped, the namespace argument is replaced by the resolved namespace in the key prefix, and JSON
+// encoding sorts the remaining keys so argument order doesn't matter.
+func cacheKey(name, namespace string, args map[string]interface{}) (string, error) {
+	normalized := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if key == "no_cache" || key == "timeout_seconds" || key == "namespace" {
+			continue
+		}
+		normalized[key] = value
+	}
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	return name + "|" + namespace + "|" + string(encoded), nil
+}
+
+// withDryRun marks every call of a mutating tool as a dry run, so the tool skips its write and the
+// client refuses any mutating RPC.
+func withDryRun(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handler(temporal.WithDryRun(ctx), req)
+	}
+}
+
+// withServerRequirement short-circuits calls of a tool the connected server doesn't support, with
+// the reason. The check uses the cached capabilities, so a reconnect to an upgraded server takes
+// effect without a restart; when they can't be fetched the call goes through.
+func withServerRequirement(capabilities *temporal.Capabilities, logger *slog.Logger, requirement ServerRequirement, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		connected, err := capabilities.Get(ctx)
+		if err != nil {
+			logger.Warn("Unable to fetch Temporal server capabilities", "error", err)
+			return handler(ctx, req)
+		}
+		if supported, reason := requirement.Supported(connected); !supported {
+			return mcp.NewToolResultError(fmt.Sprintf("Unsupported on connected server: this tool %s", reason)), nil
+		}
+		return handler(ctx, req)
+	}
+}
+
+// withTracing runs every call in a span carrying the tool name and the namespace it targets, under
+// which the Temporal RPCs of the call nest. Arguments and results aren't recorded, as they may hold
+// payloads.
+func withTracing(tracer trace.Tracer, defaultNamespace, name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, span := tracer.Start(ctx, "tools/call "+name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("mcp.tool.name", name),
+				attribute.String("temporal.namespace", callNamespace(req.GetArguments(), defaultNamespace)),
+			),
+		)
+		defer span.End()
+		result, err := handler(ctx, req)
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, err.Error())
+		case result != nil && result.IsError:
+			span.SetStatus(otelcodes.Error, "tool returned an error")
+		case result != nil && isPartial(result):
+			span.SetAttributes(attribute.Bool("mcp.result.partial", true))
+		}
+		return result, err
+	}
+}
+
+// withMetrics wraps a tool handler so every call is counted and timed per tool and outcome.
+func withMetrics(m *metrics.Metrics, name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	if m == nil {
+		return handler
+	}
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		started := time.Now()
+		result, err := handler(ctx, req)
+		m.ObserveToolCall(name, err == nil && (result == nil || !result.IsError), time.Since(started))
+		return result, err
+	}
+}
+
+// withCaller attributes a call's Temporal RPCs to the session making it: the identity the session
+// named in identities, or else its MCP client, so histories record which agent acted and not just
+// this server.
+func withCaller(identities *SessionIdentities, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if caller := callerIdentity(ctx, identities); caller != "" {
+			ctx = temporal.WithCaller(ctx, caller)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// clientInfo returns the MCP client's name and version as reported in the initialize handshake.
+func clientInfo(ctx context.Context) (name, version string) {
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok {
+		return "", ""
+	}
+	info := session.GetClientInfo()
+	return info.Name, info.Version
+}
+
+// resultText concatenates the text content of a tool result.
+func resultText(result *mcp.CallToolResult) string {
+	var text string
+	for _, content := range result.Content {
+		if tc, ok := content.(mcp.TextContent); ok {
+			text += tc.Text
+		}
+	}
+	return text
+}
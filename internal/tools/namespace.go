@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// maxNamespaceFanout caps how many namespaces "*" expands to in tools that aggregate across
+// namespaces.
+const maxNamespaceFanout = 20
+
+// withNamespaceArgument adds the optional "namespace" argument to namespace-scoped tools.
+func withNamespaceArgument() mcp.ToolOption {
+	return mcp.WithString("namespace",
+		mcp.Description("Temporal namespace to use instead of the server's default namespace"),
+	)
+}
+
+// withNamespacesArgument adds the "namespace" argument to tools that can aggregate across several
+// namespaces (see callNamespaces).
+func withNamespacesArgument() mcp.ToolOption {
+	return mcp.WithString("namespace",
+		mcp.Description(fmt.Sprintf("Temporal namespace to use instead of the server's default namespace. Several comma-separated namespaces, or * for every namespace this server may access (at most %d), are queried concurrently and their results merged", maxNamespaceFanout)),
+	)
+}
+
+// namespaceArgument returns the namespace named by the call, or "" for the default namespace. It
+// is read as a list, so "payments," names payments alone, and several namespaces are returned
+// comma-separated, trimmed, without empty items and duplicates.
+func namespaceArgument(args map[string]interface{}) string {
+	namespaces, err := stringListArgument(args, "namespace")
+	if err != nil {
+		// Not a list: a scalar still names a namespace, which the provider then rejects if unknown
+		namespace, _ := stringArgument(args, "namespace")
+		return namespace
+	}
+	return strings.Join(namespaces, ",")
+}
+
+// callNamespace returns the namespace a call targets, falling back to defaultNamespace.
+func callNamespace(args map[string]interface{}, defaultNamespace string) string {
+	if namespace := namespaceArgument(args); namespace != "" {
+		return namespace
+	}
+	return defaultNamespace
+}
+
+// callClient returns the client for the call's namespace; the provider rejects namespaces
+// outside the allowlist before any RPC is made.
+func callClient(clients temporal.Provider, args map[string]interface{}) (temporal.Client, error) {
+	namespace := namespaceArgument(args)
+	if namespace == "*" || strings.Contains(namespace, ",") {
+		return nil, errors.New("Invalid 'namespace' parameter: this tool queries a single namespace; only list_workflows aggregates across several")
+	}
+	return clients.Client(namespace)
+}
+
+// callNamespaces returns the namespaces of a call whose namespace argument names several,
+// comma-separated, or "*" for every namespace the provider allows, of which the first
+// maxNamespaceFanout are kept and the number left out is returned as skipped. It returns nil when
+// the call names a single namespace, or none, so the tool runs against callClient as usual.
+func callNamespaces(ctx context.Context, clients temporal.Provider, args map[string]interface{}) (namespaces []string, skipped int, err error) {
+	namespace := namespaceArgument(args)
+	if namespace == "*" {
+		all, err := clients.Namespaces(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(all) > maxNamespaceFanout {
+			return all[:maxNamespaceFanout], len(all) - maxNamespaceFanout, nil
+		}
+		return all, 0, nil
+	}
+	if !strings.Contains(namespace, ",") {
+		return nil, 0, nil
+	}
+	return strings.Split(namespace, ","), 0, nil
+}
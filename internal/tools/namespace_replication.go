@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// NamespaceReplication implements the "namespace_replication" tool.
+type NamespaceReplication struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *NamespaceReplication) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"namespace_replication",
+		mcp.WithDescription("Show the replication of a multi-region (global) namespace: the clusters it is replicated to, the active cluster, the failover version and history, and whether the connected cluster is the active one. Check it before interpreting or changing a global namespace: on a standby cluster writes fail and data can lag"),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// Handle implements Tool.
+func (t *NamespaceReplication) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, err := client.DescribeNamespace(ctx, client.Namespace())
+	if err != nil {
+		t.Logger.Error("Error describing namespace", "namespace", client.Namespace(), "error", err)
+		return rpcError("Failed to describe namespace", err), nil
+	}
+	view := format.NamespaceReplication{Namespace: client.Namespace(), Global: resp.GetIsGlobalNamespace()}
+	if !view.Global {
+		return renderResult(outFormat, view, format.NamespaceReplicationText)
+	}
+	replication := resp.GetReplicationConfig()
+	view.ActiveCluster = replication.GetActiveClusterName()
+	for _, cluster := range replication.GetClusters() {
+		view.Clusters = append(view.Clusters, cluster.GetClusterName())
+	}
+	view.FailoverVersion = resp.GetFailoverVersion()
+	view.State = replication.GetState().String()
+	failovers := resp.GetFailoverHistory()
+	sort.SliceStable(failovers, func(i, j int) bool {
+		return failovers[i].GetFailoverTime().AsTime().After(failovers[j].GetFailoverTime().AsTime())
+	})
+	for _, f := range failovers {
+		view.Failover = append(view.Failover, format.Failover{Time: format.Time(f.GetFailoverTime()), Version: f.GetFailoverVersion()})
+	}
+
+	// Without the connected cluster's name the active cluster can't be compared; report that inline
+	info, err := client.GetClusterInfo(ctx)
+	if err != nil {
+		t.Logger.Warn("Unable to fetch cluster info", "error", err)
+		view.ClusterError = translateError("Failed to get cluster info", err).Error
+	} else {
+		view.ConnectedCluster = info.GetClusterName()
+		view.Standby = view.ConnectedCluster != view.ActiveCluster
+	}
+	return renderResult(outFormat, view, format.NamespaceReplicationText)
+}
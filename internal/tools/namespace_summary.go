@@ -0,0 +1,233 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultSummaryWindow is the time range of the failure and termination counts when window isn't given.
+	defaultSummaryWindow = 24 * time.Hour
+	// maxScheduleScan caps how many schedules namespace_summary lists.
+	maxScheduleScan = 1000
+	// maxOldestScan caps how many running workflows are sampled for the oldest one on servers that
+	// can't sort by start time.
+	maxOldestScan = 5000
+)
+
+// NamespaceSummary implements the "namespace_summary" tool.
+type NamespaceSummary struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Pool    *pool.Pool
+	// TaskQueues lists the task queues expected to have pollers; it may be empty.
+	TaskQueues []string
+}
+
+// Definition implements Tool.
+func (t *NamespaceSummary) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"namespace_summary",
+		mcp.WithDescription("One-call health check of a namespace: running workflows, failures and terminations in a recent window, paused schedules, known task queues without pollers, and the oldest running workflow. Sections that can't be fetched are reported as unavailable instead of failing the summary"),
+		mcp.WithString("window",
+			mcp.Description(fmt.Sprintf("Count failures and terminations that closed within this time window, as a Go duration such as 1h or 168h (default %s)", defaultSummaryWindow)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *NamespaceSummary) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *NamespaceSummary) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	window, err := durationArgument(args, "window", defaultSummaryWindow)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Every section writes only its own field, so they can run concurrently without locking
+	since := time.Now().Add(-window).UTC().Format(time.RFC3339)
+	health := format.NamespaceHealth{Namespace: client.Namespace(), Window: "in the last " + window.String()}
+	sections := []func(ctx context.Context){
+		func(ctx context.Context) {
+			health.Running = t.count(ctx, client, `ExecutionStatus = "Running"`)
+		},
+		func(ctx context.Context) {
+			health.Failed = t.count(ctx, client, fmt.Sprintf(`ExecutionStatus = "Failed" AND CloseTime > %q`, since))
+		},
+		func(ctx context.Context) {
+			health.Terminated = t.count(ctx, client, fmt.Sprintf(`ExecutionStatus = "Terminated" AND CloseTime > %q`, since))
+		},
+		func(ctx context.Context) { health.Schedules = t.schedules(ctx, client) },
+		func(ctx context.Context) { health.TaskQueues = t.taskQueues(ctx, client) },
+		func(ctx context.Context) { health.Oldest = t.oldestRunning(ctx, client) },
+	}
+	t.Pool.Run(ctx, len(sections), 0, func(ctx context.Context, i int) {
+		sections[i](ctx)
+	})
+	if err := ctx.Err(); err != nil {
+		return rpcError("Failed to summarize namespace", err), nil
+	}
+	return renderResult(outFormat, health, format.NamespaceHealthText)
+}
+
+// count counts the executions matching query.
+func (t *NamespaceSummary) count(ctx context.Context, client temporal.Client, query string) format.HealthCount {
+	resp, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: client.Namespace(),
+		Query:     query,
+	})
+	if err != nil {
+		t.Logger.Warn("Error counting workflows", "query", query, "error", err)
+		return format.HealthCount{Error: err.Error()}
+	}
+	return format.HealthCount{Count: resp.GetCount()}
+}
+
+// schedules lists the namespace's schedules, collecting the IDs of the paused ones.
+func (t *NamespaceSummary) schedules(ctx context.Context, client temporal.Client) format.ScheduleHealth {
+	health := format.ScheduleHealth{Paused: []string{}}
+	var token []byte
+	for {
+		resp, err := client.ListSchedules(ctx, &workflowservice.ListSchedulesRequest{
+			Namespace:       client.Namespace(),
+			MaximumPageSize: int32(min(maxListPageSize, maxScheduleScan-health.Total)),
+			NextPageToken:   token,
+		})
+		if err != nil {
+			t.Logger.Warn("Error listing schedules", "error", err)
+			return format.ScheduleHealth{Error: err.Error()}
+		}
+		for _, entry := range resp.GetSchedules() {
+			health.Total++
+			if entry.GetInfo().GetPaused() {
+				health.Paused = append(health.Paused, entry.GetScheduleId())
+			}
+		}
+		token = resp.GetNextPageToken()
+		if len(token) == 0 {
+			break
+		}
+		if health.Total >= maxScheduleScan {
+			health.Truncated = true
+			break
+		}
+	}
+	sort.Strings(health.Paused)
+	return health
+}
+
+// taskQueues checks the known task queues for workflow pollers, one at a time: the list is short,
+// and this already runs as a task of the shared pool.
+func (t *NamespaceSummary) taskQueues(ctx context.Context, client temporal.Client) format.TaskQueueHealth {
+	health := format.TaskQueueHealth{Known: len(t.TaskQueues), Unpolled: []string{}}
+	for _, name := range t.TaskQueues {
+		resp, err := client.DescribeTaskQueue(ctx, &workflowservice.DescribeTaskQueueRequest{
+			Namespace:     client.Namespace(),
+			TaskQueue:     &taskqueuepb.TaskQueue{Name: name, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+			TaskQueueType: enumspb.TASK_QUEUE_TYPE_WORKFLOW,
+		})
+		switch {
+		case err != nil:
+			t.Logger.Warn("Error describing task queue", "task_queue", name, "error", err)
+			health.Errors = append(health.Errors, fmt.Sprintf("%s: %v", name, err))
+		case len(resp.GetPollers()) == 0:
+			health.Unpolled = append(health.Unpolled, name)
+		}
+	}
+	return health
+}
+
+// oldestRunning finds the running workflow with the earliest start time. Servers whose visibility
+// store can't sort by start time get the oldest of up to maxOldestScan running workflows instead.
+func (t *NamespaceSummary) oldestRunning(ctx context.Context, client temporal.Client) format.OldestRunning {
+	resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Namespace: client.Namespace(),
+		PageSize:  1,
+		Query:     `ExecutionStatus = "Running" ORDER BY StartTime ASC`,
+	})
+	if err == nil {
+		if len(resp.GetExecutions()) == 0 {
+			return format.OldestRunning{}
+		}
+		return oldestRunningView(resp.GetExecutions()[0], 0)
+	}
+	if !isQueryUnsupported(err) {
+		t.Logger.Warn("Error listing oldest running workflow", "error", err)
+		return format.OldestRunning{Error: err.Error()}
+	}
+
+	var (
+		oldest  *workflowpb.WorkflowExecutionInfo
+		sampled int
+		token   []byte
+	)
+	for {
+		resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     client.Namespace(),
+			PageSize:      int32(min(maxListPageSize, maxOldestScan-sampled)),
+			NextPageToken: token,
+			Query:         `ExecutionStatus = "Running"`,
+		})
+		if err != nil {
+			t.Logger.Warn("Error listing running workflows", "error", err)
+			return format.OldestRunning{Error: err.Error()}
+		}
+		for _, info := range resp.GetExecutions() {
+			sampled++
+			if oldest == nil || info.GetStartTime().AsTime().Before(oldest.GetStartTime().AsTime()) {
+				oldest = info
+			}
+		}
+		token = resp.GetNextPageToken()
+		if len(token) == 0 || sampled >= maxOldestScan {
+			break
+		}
+	}
+	if oldest == nil {
+		return format.OldestRunning{}
+	}
+	if len(token) == 0 {
+		// Every running workflow was seen, so the answer is exact
+		sampled = 0
+	}
+	return oldestRunningView(oldest, sampled)
+}
+
+// oldestRunningView builds the oldest-running section from an execution.
+func oldestRunningView(info *workflowpb.WorkflowExecutionInfo, sampled int) format.OldestRunning {
+	return format.OldestRunning{
+		WorkflowID:   info.GetExecution().GetWorkflowId(),
+		RunID:        info.GetExecution().GetRunId(),
+		WorkflowType: info.GetType().GetName(),
+		StartTime:    format.Time(info.GetStartTime()),
+		Running:      format.RunDuration(info),
+		Sampled:      sampled,
+	}
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNamespaceArgumentNormalized(t *testing.T) {
+	clients := newFakeProvider(&fakeClient{namespace: "default"}, &fakeClient{namespace: "foo"}, &fakeClient{namespace: "bar"})
+	tests := []struct {
+		name  string
+		value any
+		// client is the namespace callClient selects, or "" when it rejects the call
+		client string
+		// namespaces are what callNamespaces returns: nil when the call names a single namespace
+		namespaces []string
+	}{
+		{"missing", missingArgument, "default", nil},
+		{"single", "foo", "foo", nil},
+		// A one-item list selects that namespace in every tool, not only in those aggregating
+		{"trailing comma", "foo,", "foo", nil},
+		{"padded", " foo , ", "foo", nil},
+		{"duplicated", "foo,foo", "foo", nil},
+		{"several", "foo, bar,", "", []string{"foo", "bar"}},
+		{"array", []any{"bar", "foo"}, "", []string{"bar", "foo"}},
+		{"all", "*", "", []string{"bar", "default", "foo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]any{"namespace": tt.value}
+			if tt.value == missingArgument {
+				args = map[string]any{}
+			}
+
+			client, err := callClient(clients, args)
+			switch {
+			case tt.client == "" && err == nil:
+				t.Errorf("callClient selected %s, want the call rejected", client.Namespace())
+			case tt.client != "" && err != nil:
+				t.Errorf("callClient: %v", err)
+			case tt.client != "" && client.Namespace() != tt.client:
+				t.Errorf("callClient selected %s, want %s", client.Namespace(), tt.client)
+			}
+
+			namespaces, _, err := callNamespaces(context.Background(), clients, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(namespaces, tt.namespaces) {
+				t.Errorf("callNamespaces = %q, want %q", namespaces, tt.namespaces)
+			}
+		})
+	}
+}
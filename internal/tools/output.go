@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+)
+
+// withFormatArgument adds the "format" argument to tools that can render JSON as well as text.
+func withFormatArgument() mcp.ToolOption {
+	return mcp.WithString("format",
+		mcp.Description("Output format: text (default) or json"),
+		mcp.Enum("text", "json"),
+	)
+}
+
+// outputFormat returns the requested output format, "text" or "json".
+func outputFormat(args map[string]interface{}) (string, error) {
+	raw, err := stringArgument(args, "format")
+	if err != nil {
+		return "", err
+	}
+	switch f := strings.ToLower(raw); f {
+	case "":
+		return "text", nil
+	case "text", "json":
+		return f, nil
+	default:
+		return "", fmt.Errorf("Invalid 'format' parameter %q (use text or json)", raw)
+	}
+}
+
+// renderResult returns view rendered as JSON or, for the text format, with renderText.
+func renderResult[T any](outFormat string, view T, renderText func(T) string) (*mcp.CallToolResult, error) {
+	if outFormat != "json" {
+		return mcp.NewToolResultText(renderText(view)), nil
+	}
+	out, err := format.JSON(view)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(out), nil
+}
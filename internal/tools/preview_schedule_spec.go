@@ -0,0 +1,291 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	schedulepb "go.temporal.io/api/schedule/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultPreviewWindow is the window of preview_schedule_spec calls that don't pass one.
+	defaultPreviewWindow = 7 * 24 * time.Hour
+	// maxPreviewTimes caps the matching times listed; the rest are only counted.
+	maxPreviewTimes = 100
+	// previewSchedulePrefix prefixes the IDs of the temporary schedules created to preview a spec.
+	previewSchedulePrefix = "temporal-mcp-spec-preview-"
+)
+
+// PreviewScheduleSpec implements the "preview_schedule_spec" tool. The server computes matching
+// times only for an existing schedule, so a spec given in the call is previewed through a
+// temporary paused schedule, deleted right after. Creating it is a write, so the tool is mutating
+// and a spec is only evaluated with confirm=true.
+type PreviewScheduleSpec struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *PreviewScheduleSpec) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"preview_schedule_spec",
+		mcp.WithDescription(fmt.Sprintf("List the times a schedule spec (cron expressions, intervals, jitter, time zone) matches within a window, as computed by the server, to check a spec before creating or changing a schedule. Pass schedule_id instead to preview an existing schedule. A spec given in the call is evaluated through a temporary paused schedule that never runs and is deleted right away; without confirm=true, no schedule is created and nothing is evaluated. Lists at most %d times, with the total count", maxPreviewTimes)),
+		mcp.WithString("schedule_id",
+			mcp.Description("ID of an existing schedule to preview instead of a spec"),
+		),
+		mcp.WithArray("cron",
+			mcp.Description("Cron expressions, e.g. [\"0 9 * * MON-FRI\"]; a single expression can be given as a string"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithArray("intervals",
+			mcp.Description("Intervals as every or every/offset Go durations, e.g. [\"1h\", \"24h/2h\"] for hourly and daily at 02:00 UTC"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("jitter",
+			mcp.Description("Maximum random delay applied to each time, as a Go duration such as 5m"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA time zone of the cron expressions, e.g. America/New_York (default UTC)"),
+		),
+		mcp.WithString("start_time",
+			mcp.Description("Start of the window, RFC 3339 (default now)"),
+		),
+		mcp.WithString("window",
+			mcp.Description("Length of the window, as a Go duration (default 168h)"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Description("Set to true to evaluate a spec given in the call, which creates and deletes a temporary paused schedule; not needed with schedule_id"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// Mutating implements Mutating.
+func (t *PreviewScheduleSpec) Mutating() {}
+
+// ServerRequirement implements RequiresServer.
+func (t *PreviewScheduleSpec) ServerRequirement() ServerRequirement {
+	return ServerRequirement{
+		Capability:     (*workflowservice.GetSystemInfoResponse_Capabilities).GetSupportsSchedules,
+		CapabilityName: "schedules",
+	}
+}
+
+// Handle implements Tool.
+func (t *PreviewScheduleSpec) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	scheduleID, err := stringArgument(args, "schedule_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	spec, described, err := scheduleSpecArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	switch {
+	case scheduleID != "" && len(described) > 0:
+		return mcp.NewToolResultError("Pass either 'schedule_id' or a spec, not both"), nil
+	case scheduleID == "" && len(spec.GetCronString()) == 0 && len(spec.GetInterval()) == 0:
+		return mcp.NewToolResultError("Nothing to preview: pass 'cron' or 'intervals', or the 'schedule_id' of an existing schedule"), nil
+	}
+	start := time.Now()
+	if raw, err := stringArgument(args, "start_time"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	} else if raw != "" {
+		if start, err = time.Parse(time.RFC3339, raw); err != nil {
+			return mcp.NewToolResultError(invalidArgument("start_time", "RFC 3339 time such as 2024-05-01T09:00:00Z", raw).Error()), nil
+		}
+	}
+	window, err := durationArgument(args, "window", defaultPreviewWindow)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	confirm, err := boolArgument(args, "confirm")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	view := format.SchedulePreview{
+		ScheduleID: scheduleID,
+		Spec:       described,
+		Start:      start.UTC().Format(time.RFC3339),
+		End:        start.Add(window).UTC().Format(time.RFC3339),
+	}
+	if scheduleID == "" {
+		if !confirm {
+			view.NotEvaluated = true
+			return renderResult(outFormat, view, format.SchedulePreviewText)
+		}
+		if temporal.DryRun(ctx) {
+			t.Logger.Info("Dry run: skipped creating schedule to preview spec", "spec", described)
+			view.DryRun = true
+			return renderResult(outFormat, view, format.SchedulePreviewText)
+		}
+		scheduleID = previewSchedulePrefix + uuid.NewString()
+		if _, err := client.CreateSchedule(ctx, &workflowservice.CreateScheduleRequest{
+			Namespace:  client.Namespace(),
+			ScheduleId: scheduleID,
+			Schedule:   previewSchedule(spec),
+			Identity:   client.Identity(ctx),
+			RequestId:  uuid.NewString(),
+		}); err != nil {
+			t.Logger.Error("Error creating schedule to preview spec", "error", err)
+			return rpcError("Failed to evaluate the schedule spec", err), nil
+		}
+	}
+
+	resp, err := client.ListScheduleMatchingTimes(ctx, &workflowservice.ListScheduleMatchingTimesRequest{
+		Namespace:  client.Namespace(),
+		ScheduleId: scheduleID,
+		StartTime:  timestamppb.New(start),
+		EndTime:    timestamppb.New(start.Add(window)),
+	})
+	if view.ScheduleID == "" {
+		// Delete even when the call was cancelled, so the temporary schedule doesn't linger
+		cleanup, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		_, deleteErr := client.DeleteSchedule(cleanup, &workflowservice.DeleteScheduleRequest{
+			Namespace:  client.Namespace(),
+			ScheduleId: scheduleID,
+			Identity:   client.Identity(ctx),
+		})
+		cancel()
+		if deleteErr != nil && !isNotFound(deleteErr) {
+			t.Logger.Error("Error deleting schedule created to preview spec", "schedule_id", scheduleID, "error", deleteErr)
+			view.Leftover = scheduleID
+		}
+	}
+	if err != nil {
+		t.Logger.Error("Error listing schedule matching times", "schedule_id", scheduleID, "error", err)
+		if view.ScheduleID != "" && isNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Schedule %s not found in namespace %s", scheduleID, client.Namespace())), nil
+		}
+		return rpcError("Failed to list schedule matching times", err), nil
+	}
+	loc := time.UTC
+	if spec.GetTimezoneName() != "" {
+		// The server accepted the zone, so it loads here too unless the local zoneinfo lacks it
+		if l, err := time.LoadLocation(spec.GetTimezoneName()); err == nil {
+			loc = l
+		}
+	}
+	view.Total = len(resp.GetStartTime())
+	view.Times = []string{}
+	for i, ts := range resp.GetStartTime() {
+		if i == maxPreviewTimes {
+			break
+		}
+		view.Times = append(view.Times, ts.AsTime().In(loc).Format(time.RFC3339))
+	}
+	return renderResult(outFormat, view, format.SchedulePreviewText)
+}
+
+// scheduleSpecArguments builds a schedule spec from the cron, intervals, jitter and timezone
+// arguments, returning it with a description of each part given.
+func scheduleSpecArguments(args map[string]interface{}) (*schedulepb.ScheduleSpec, []string, error) {
+	spec := &schedulepb.ScheduleSpec{}
+	var described []string
+	// Cron expressions contain commas, so a string is a single expression rather than a list
+	var crons []string
+	switch v := args["cron"].(type) {
+	case nil:
+	case string:
+		crons = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, nil, invalidArgument("cron", "list of cron expressions", v)
+			}
+			crons = append(crons, s)
+		}
+	default:
+		return nil, nil, invalidArgument("cron", "list of cron expressions", v)
+	}
+	for _, c := range crons {
+		if c = strings.TrimSpace(c); c != "" {
+			spec.CronString = append(spec.CronString, c)
+			described = append(described, "cron "+c)
+		}
+	}
+	intervals, err := stringListArgument(args, "intervals")
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, raw := range intervals {
+		every, offset, _ := strings.Cut(raw, "/")
+		interval := &schedulepb.IntervalSpec{}
+		d, err := time.ParseDuration(strings.TrimSpace(every))
+		if err != nil || d <= 0 {
+			return nil, nil, invalidArgument("intervals", "every or every/offset durations such as 24h/2h", raw)
+		}
+		interval.Interval = durationpb.New(d)
+		if offset != "" {
+			o, err := time.ParseDuration(strings.TrimSpace(offset))
+			if err != nil || o < 0 || o >= d {
+				return nil, nil, invalidArgument("intervals", "every/offset durations with an offset shorter than the interval, such as 24h/2h", raw)
+			}
+			interval.Phase = durationpb.New(o)
+		}
+		spec.Interval = append(spec.Interval, interval)
+		described = append(described, "every "+raw)
+	}
+	jitter, err := durationArgument(args, "jitter", 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if jitter > 0 {
+		spec.Jitter = durationpb.New(jitter)
+		described = append(described, "jitter "+jitter.String())
+	}
+	timezone, err := stringArgument(args, "timezone")
+	if err != nil {
+		return nil, nil, err
+	}
+	if timezone != "" {
+		spec.TimezoneName = timezone
+		described = append(described, "time zone "+timezone)
+	}
+	return spec, described, nil
+}
+
+// previewSchedule is a paused schedule with spec, only there for the server to compute its
+// matching times. Its action is never taken.
+func previewSchedule(spec *schedulepb.ScheduleSpec) *schedulepb.Schedule {
+	return &schedulepb.Schedule{
+		Spec: spec,
+		Action: &schedulepb.ScheduleAction{Action: &schedulepb.ScheduleAction_StartWorkflow{
+			StartWorkflow: &workflowpb.NewWorkflowExecutionInfo{
+				WorkflowId:   previewSchedulePrefix + "workflow",
+				WorkflowType: &commonpb.WorkflowType{Name: "temporal-mcp-spec-preview"},
+				TaskQueue:    &taskqueuepb.TaskQueue{Name: "temporal-mcp-spec-preview"},
+			},
+		}},
+		State: &schedulepb.ScheduleState{
+			Paused: true,
+			Notes:  "Temporary schedule created by temporal-mcp to preview a spec; safe to delete",
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// progressNotifier sends MCP progress notifications for one tool call, tagged with the progress
+// token the client supplied in the request metadata.
+type progressNotifier struct {
+	server *server.MCPServer
+	logger *slog.Logger
+	token  mcp.ProgressToken
+}
+
+type progressNotifierKey struct{}
+
+// withProgress attaches a progress notifier to calls whose client asked for progress updates. Calls
+// without a progress token get none, and reportProgress then only records the progress locally.
+func withProgress(srv *server.MCPServer, logger *slog.Logger, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if req.Params.Meta != nil && req.Params.Meta.ProgressToken != nil {
+			notifier := &progressNotifier{server: srv, logger: logger, token: req.Params.Meta.ProgressToken}
+			ctx = context.WithValue(ctx, progressNotifierKey{}, notifier)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// reportProgress records how far a long-running handler has got: the message is kept for the
+// timeout error (see temporal.ReportProgress) and, when the client supplied a progress token, sent
+// as a progress notification. progress must increase between calls; total is 0 when unknown.
+func reportProgress(ctx context.Context, progress, total float64, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	temporal.ReportProgress(ctx, "%s", message)
+
+	notifier, ok := ctx.Value(progressNotifierKey{}).(*progressNotifier)
+	if !ok {
+		return
+	}
+	params := map[string]any{
+		"progressToken": notifier.token,
+		"progress":      progress,
+		"message":       message,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	// Progress is best effort: a client that went away shouldn't fail the call
+	if err := notifier.server.SendNotificationToClient(ctx, "notifications/progress", params); err != nil {
+		notifier.logger.Debug("Unable to send progress notification", "error", err)
+	}
+}
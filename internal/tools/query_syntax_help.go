@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc/codes"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/cache"
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// queryAttributesTTL is how long query_syntax_help reuses a namespace's search attributes;
+	// adding one is rare, so agents iterating on a query don't refetch them every call.
+	queryAttributesTTL = 5 * time.Minute
+	// queryAttributesNamespaces bounds the namespaces whose search attributes are kept.
+	queryAttributesNamespaces = 64
+)
+
+// queryOperators are the visibility query operators, as supported by both SQL and Elasticsearch
+// visibility.
+var queryOperators = []format.QueryOperator{
+	{Syntax: "=, !=", Applies: "all types; on Text it's a full-text match, on KeywordList it matches any item"},
+	{Syntax: ">, >=, <, <=", Applies: "Int, Double, Datetime, and Keyword"},
+	{Syntax: "BETWEEN x AND y", Applies: "Int, Double, and Datetime (inclusive)"},
+	{Syntax: "IN (x, y, ...)", Applies: "Keyword, KeywordList, Int, and Double"},
+	{Syntax: "STARTS_WITH 'prefix'", Applies: "Keyword (e.g. WorkflowId)"},
+	{Syntax: "IS NULL, IS NOT NULL", Applies: "all types; tests whether the attribute is set"},
+	{Syntax: "AND, OR, ( )", Applies: "combine conditions; AND binds tighter than OR"},
+}
+
+// queryExamples are worked queries covering the common agent questions.
+var queryExamples = []format.QueryExample{
+	{Query: "ExecutionStatus = 'Running'", Description: "Running workflows"},
+	{Query: "WorkflowType = 'OrderWorkflow' AND ExecutionStatus = 'Failed'", Description: "Failed workflows of one type"},
+	{Query: "ExecutionStatus IN ('Failed', 'TimedOut', 'Terminated')", Description: "Workflows that closed unsuccessfully"},
+	{Query: "StartTime > '2024-06-01T00:00:00Z'", Description: "Workflows started after a time (RFC3339, quoted)"},
+	{Query: "CloseTime BETWEEN '2024-06-01T00:00:00Z' AND '2024-06-02T00:00:00Z'", Description: "Workflows that closed within a day"},
+	{Query: "ExecutionStatus = 'Running' AND StartTime < '2024-06-01T00:00:00Z'", Description: "Workflows running since before a time, e.g. stuck ones"},
+	{Query: "WorkflowId STARTS_WITH 'order-'", Description: "Workflows whose ID has a prefix"},
+	{Query: "WorkflowId = 'order-42'", Description: "Every run of one workflow ID"},
+	{Query: "TaskQueue = 'payments' AND ExecutionStatus = 'Running'", Description: "Running workflows on a task queue"},
+	{Query: "ParentWorkflowId = 'batch-7'", Description: "Children of a workflow (not supported by every visibility store)"},
+	{Query: "WorkflowType = 'OrderWorkflow' AND (ExecutionStatus = 'Failed' OR ExecutionStatus = 'TimedOut')", Description: "Parentheses group OR conditions"},
+	{Query: "CustomerId = 'c-42'", Description: "Custom search attributes are queried like built-in ones (CustomerId is illustrative)"},
+}
+
+// queryNotes are the mistakes agents make most often.
+var queryNotes = []string{
+	"Quote strings and times with single or double quotes; numbers and booleans are unquoted.",
+	"Status values are capitalized: Running, Completed, Failed, Canceled, Terminated, ContinuedAsNew, TimedOut.",
+	"Times are RFC3339 (e.g. '2024-06-01T00:00:00Z'); there is no NOW() or relative time.",
+	"There is no LIKE or wildcard; use STARTS_WITH for prefixes.",
+	"Column names are case-sensitive and must be search attributes listed above; memo fields and payloads can't be queried.",
+	"Use =, not ==; use !=, not <>.",
+}
+
+// QuerySyntaxHelp implements the "query_syntax_help" tool.
+type QuerySyntaxHelp struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+
+	once       sync.Once
+	attributes *cache.Cache
+}
+
+// Definition implements Tool.
+func (t *QuerySyntaxHelp) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"query_syntax_help",
+		mcp.WithDescription("Reference of the visibility query language: the namespace's queryable columns (built-in and custom search attributes), operators, and worked examples. Pass query to validate a candidate query instead: the server parses it and either counts the matching executions or returns its parse error verbatim. Use it before writing a query and to fix a rejected one"),
+		mcp.WithString("query",
+			mcp.Description("Candidate visibility query to validate, e.g. ExecutionStatus = 'Running' AND StartTime > '2024-06-01T00:00:00Z'"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// Handle implements Tool.
+func (t *QuerySyntaxHelp) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	query, err := stringArgument(args, "query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	view := format.QuerySyntax{Namespace: client.Namespace()}
+	if query != "" {
+		view.Validation = &format.QueryValidation{Query: query}
+		resp, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+			Namespace: client.Namespace(),
+			Query:     query,
+		})
+		switch code, _ := errorCode(err); {
+		case err == nil:
+			view.Validation.Valid, view.Validation.Count = true, resp.GetCount()
+			return renderResult(outFormat, view, format.QuerySyntaxText)
+		case code == codes.InvalidArgument:
+			view.Validation.Error = serverMessage(err)
+		default:
+			t.Logger.Error("Error validating visibility query", "query", query, "error", err)
+			return rpcError("Failed to validate query", err), nil
+		}
+	}
+
+	columns, err := t.columns(ctx, client)
+	if err != nil {
+		t.Logger.Error("Error listing search attributes", "error", err)
+		return rpcError("Failed to list search attributes", err), nil
+	}
+	view.Columns, view.Operators, view.Examples, view.Notes = columns, queryOperators, queryExamples, queryNotes
+	return renderResult(outFormat, view, format.QuerySyntaxText)
+}
+
+// columns returns the search attributes of the client's namespace, built-in ones first, from the
+// attribute cache when it has them.
+func (t *QuerySyntaxHelp) columns(ctx context.Context, client temporal.Client) ([]format.QueryColumn, error) {
+	t.once.Do(func() {
+		t.attributes = cache.New(queryAttributesNamespaces, queryAttributesTTL)
+	})
+	if cached, ok := t.attributes.Get(client.Namespace()); ok {
+		return cached.([]format.QueryColumn), nil
+	}
+	attrs, err := client.ListSearchAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var columns []format.QueryColumn
+	for name, valueType := range attrs.GetSystemAttributes() {
+		columns = append(columns, format.QueryColumn{Name: name, Type: valueType.String()})
+	}
+	for name, valueType := range attrs.GetCustomAttributes() {
+		columns = append(columns, format.QueryColumn{Name: name, Type: valueType.String(), Custom: true})
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		if columns[i].Custom != columns[j].Custom {
+			return !columns[i].Custom
+		}
+		return columns[i].Name < columns[j].Name
+	})
+	t.attributes.Set(client.Namespace(), columns)
+	return columns, nil
+}
+
+// serverMessage returns the message of a Temporal service error as the server sent it, without
+// any wrapping, or err's text when it carries none.
+func serverMessage(err error) string {
+	var serviceErr serviceerror.ServiceError
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Status().Message()
+	}
+	return err.Error()
+}
@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	workflowpb "go.temporal.io/api/workflow/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+)
+
+const (
+	// defaultRecentSize is how many workflows RecentWorkflows keeps per session when Size isn't set.
+	defaultRecentSize = 20
+	// recentURI is the URI of the recent workflows resource.
+	recentURI = "temporal://recent"
+)
+
+// RecentWorkflows remembers, per MCP session, the workflows its tool calls described or read the
+// history of, most recent first, with the status they had then. Each session keeps at most Size
+// workflows; Forget drops a session's list when it ends.
+type RecentWorkflows struct {
+	// Size bounds each session's list; 0 means defaultRecentSize.
+	Size int
+
+	mu        sync.Mutex
+	bySession map[string][]format.RecentWorkflow
+}
+
+// touched collects the workflows noted during one tool call, to be recorded once it succeeds.
+type touched struct {
+	mu        sync.Mutex
+	workflows []format.RecentWorkflow
+}
+
+type touchedKey struct{}
+
+// noteWorkflow records that the current tool call looked at the workflow execution described by
+// info in namespace. Calls outside withRecent note nothing.
+func noteWorkflow(ctx context.Context, namespace string, info *workflowpb.WorkflowExecutionInfo) {
+	t, ok := ctx.Value(touchedKey{}).(*touched)
+	if !ok || info == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.workflows = append(t.workflows, format.RecentWorkflow{
+		Namespace:  namespace,
+		WorkflowID: info.GetExecution().GetWorkflowId(),
+		RunID:      info.GetExecution().GetRunId(),
+		Type:       info.GetType().GetName(),
+		Status:     format.WorkflowStatus(info.GetStatus()),
+	})
+}
+
+// withRecent adds the workflows a successful call noted with noteWorkflow to its session's list.
+// A call answered from the response cache notes nothing, so the workflow named by its workflow_id
+// argument is recorded instead, keeping the status last seen.
+func withRecent(recent *RecentWorkflows, defaultNamespace, toolName string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		t := &touched{}
+		result, err := handler(context.WithValue(ctx, touchedKey{}, t), req)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if len(t.workflows) == 0 {
+			if workflowID, runID, err := executionArguments(req.GetArguments()); err == nil {
+				t.workflows = append(t.workflows, format.RecentWorkflow{
+					Namespace:  callNamespace(req.GetArguments(), defaultNamespace),
+					WorkflowID: workflowID,
+					RunID:      runID,
+				})
+			}
+		}
+		now := time.Now().UTC().Format(time.RFC3339)
+		for _, w := range t.workflows {
+			w.Tool, w.AccessedAt = toolName, now
+			recent.record(sessionID(ctx), w)
+		}
+		return result, err
+	}
+}
+
+// record moves w to the front of the session's list, replacing an earlier entry for the same
+// workflow whose type and status it keeps unless w has its own, and drops the oldest entries
+// beyond the size bound.
+func (r *RecentWorkflows) record(session string, w format.RecentWorkflow) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.bySession == nil {
+		r.bySession = make(map[string][]format.RecentWorkflow)
+	}
+	list := slices.DeleteFunc(r.bySession[session], func(e format.RecentWorkflow) bool {
+		if e.Namespace != w.Namespace || e.WorkflowID != w.WorkflowID {
+			return false
+		}
+		if w.Status == "" {
+			w.RunID, w.Type, w.Status = cmp.Or(w.RunID, e.RunID), e.Type, e.Status
+		}
+		return true
+	})
+	list = append([]format.RecentWorkflow{w}, list...)
+	if len(list) > r.size() {
+		list = list[:r.size()]
+	}
+	r.bySession[session] = list
+}
+
+// List returns the workflows recently touched by the session of ctx, most recent first.
+func (r *RecentWorkflows) List(ctx context.Context) []format.RecentWorkflow {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.bySession[sessionID(ctx)])
+}
+
+// Forget drops the list of session; it is meant for the server's session unregister hook.
+func (r *RecentWorkflows) Forget(session server.ClientSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.bySession, session.SessionID())
+}
+
+// Resource returns the temporal://recent resource, which lists the calling session's recent
+// workflows as JSON, for registration with the MCP server.
+func (r *RecentWorkflows) Resource() (mcp.Resource, server.ResourceHandlerFunc) {
+	resource := mcp.NewResource(recentURI, "Recent workflows",
+		mcp.WithResourceDescription(fmt.Sprintf("The last %d workflows this session described or read the history of, most recent first, with their last-known status", r.size())),
+		mcp.WithMIMEType("application/json"),
+	)
+	return resource, func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		out, err := format.JSON(format.RecentWorkflowList{Workflows: r.List(ctx)})
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: recentURI, MIMEType: "application/json", Text: out}}, nil
+	}
+}
+
+// size returns the bound of each session's list.
+func (r *RecentWorkflows) size() int {
+	if r.Size > 0 {
+		return r.Size
+	}
+	return defaultRecentSize
+}
+
+// sessionID identifies the MCP session of ctx; calls without one share the "" session.
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// RecentWorkflowsTool implements the "recent_workflows" tool, the tool counterpart of the
+// temporal://recent resource for clients that don't read resources.
+type RecentWorkflowsTool struct {
+	Recent *RecentWorkflows
+}
+
+// Definition implements Tool.
+func (t *RecentWorkflowsTool) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"recent_workflows",
+		mcp.WithDescription(fmt.Sprintf("List the last %d workflows this session described or read the history of, most recent first, with their last-known status (as of that call). Use it to get back to a workflow looked at earlier", t.Recent.size())),
+		withFormatArgument(),
+	)
+}
+
+// Handle implements Tool.
+func (t *RecentWorkflowsTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outFormat, err := outputFormat(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return renderResult(outFormat, format.RecentWorkflowList{Workflows: t.Recent.List(ctx)}, format.RecentWorkflowsText)
+}
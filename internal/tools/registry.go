@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wricardo/temporal-mcp/internal/audit"
+	"github.com/wricardo/temporal-mcp/internal/cache"
+	"github.com/wricardo/temporal-mcp/internal/metrics"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// defaultMaxTimeout caps per-call timeout_seconds overrides for tools that don't implement LongRunning.
+const defaultMaxTimeout = 2 * time.Minute
+
+// Registry registers tools with the MCP server, wrapping every handler in the shared middleware
+// chain so newly added tools are logged, audited, and measured automatically.
+type Registry struct {
+	Server  *server.MCPServer
+	Logger  *slog.Logger
+	Auditor audit.Sink
+	Metrics *metrics.Metrics
+	// Tracer traces every call; nil disables tracing.
+	Tracer    trace.Tracer
+	Namespace string
+	// Target describes the cluster and namespace the tools talk to, appended to every description;
+	// empty leaves it out.
+	Target string
+	// Timeout is the default deadline of a tool call's Temporal RPCs.
+	Timeout time.Duration
+
+	// Cache holds recent read-tool results; nil disables caching.
+	Cache *cache.Cache
+	// CacheListings opts listing tools into the response cache.
+	CacheListings bool
+
+	// DryRun makes mutating tools report what they would do without doing it. It is server-wide and
+	// cannot be overridden by a call.
+	DryRun bool
+
+	// Clients lists the cluster's namespaces for errors naming a missing one, and gives the
+	// identity audit records attribute calls to; nil leaves both out.
+	Clients temporal.Provider
+
+	// Capabilities reports the connected server's capabilities for tools implementing
+	// RequiresServer; nil skips the checks.
+	Capabilities *temporal.Capabilities
+
+	// MaxOutputBytes bounds the text blocks of a result; 0 disables the bound.
+	MaxOutputBytes int
+	// Results holds the full text of larger blocks as resources; nil truncates them instead.
+	Results *ResultStore
+
+	// Recent records the workflows each session's calls look at; nil disables it.
+	Recent *RecentWorkflows
+	// Identities holds the identities sessions named for themselves; nil attributes every call to
+	// its MCP client's name and version.
+	Identities *SessionIdentities
+
+	// Calls tracks in-flight tool calls for graceful shutdown.
+	Calls *InflightCalls
+
+	// registered lists the tools added so far, in registration order, for the server instructions.
+	registered []registeredTool
+}
+
+// registeredTool records a registered tool for the server instructions.
+type registeredTool struct {
+	name     string
+	mutating bool
+	// instructions is the tool's own section of the instructions, if it implements Instructed.
+	instructions string
+}
+
+// Register adds a tool to the MCP server with its handler wrapped in the middleware chain.
+func (r *Registry) Register(t Tool) {
+	tool := t.Definition()
+
+	maxTimeout := max(defaultMaxTimeout, r.Timeout)
+	if lr, ok := t.(LongRunning); ok {
+		maxTimeout = max(maxTimeout, lr.MaxTimeout())
+	}
+	mcp.WithNumber("timeout_seconds",
+		mcp.Max(maxTimeout.Seconds()),
+		mcp.Description(fmt.Sprintf("Optional timeout for the Temporal calls made by this tool, in seconds (default %s, maximum %s)", r.Timeout, maxTimeout)),
+	)(&tool)
+
+	// notes are appended to the description, each in brackets
+	var notes []string
+	handler := server.ToolHandlerFunc(t.Handle)
+	if rs, ok := t.(RequiresServer); ok && r.Capabilities != nil {
+		requirement := rs.ServerRequirement()
+		if supported, reason := r.checkServer(requirement); !supported {
+			notes = append(notes, fmt.Sprintf("unsupported on connected server (%s)", reason))
+			r.Logger.Info("Tool is unsupported on the connected Temporal server", "tool", tool.Name, "reason", reason)
+		}
+		handler = withServerRequirement(r.Capabilities, r.Logger, requirement, handler)
+	}
+	handler = withTimeout(r.Timeout, maxTimeout, handler)
+	_, mutating := t.(Mutating)
+	if mutating && r.DryRun {
+		notes = append(notes, "DRY RUN: this server only previews changes; nothing is modified")
+		handler = withDryRun(handler)
+	}
+	if r.Target != "" {
+		notes = append(notes, "Target: "+r.Target)
+	}
+	for _, note := range notes {
+		tool.Description += " [" + note + "]"
+	}
+	if r.Clients != nil {
+		handler = withNamespaceNotFound(r.Clients, handler)
+	}
+	if _, ok := tool.InputSchema.Properties["format"]; ok {
+		handler = withErrorFormat(handler)
+	}
+	if policy := cachePolicy(t); r.Cache != nil && (policy == CacheAlways || (policy == CacheListing && r.CacheListings)) {
+		mcp.WithBoolean("no_cache",
+			mcp.Description("Bypass the short-lived response cache and query Temporal directly"),
+		)(&tool)
+		handler = withCache(r.Cache, r.Logger, r.Namespace, tool.Name, handler)
+	}
+	if r.MaxOutputBytes > 0 {
+		handler = withLargeOutput(r.Results, r.Logger, r.MaxOutputBytes, tool.Name, handler)
+	}
+	if r.Recent != nil {
+		handler = withRecent(r.Recent, r.Namespace, tool.Name, handler)
+	}
+	handler = withProgress(r.Server, r.Logger, handler)
+	handler = withAudit(r.Auditor, r.Logger, r.Clients, r.Namespace, tool.Name, handler)
+	handler = withCaller(r.Identities, handler)
+	handler = withMetrics(r.Metrics, tool.Name, handler)
+	handler = withCallLogging(r.Logger, tool.Name, handler)
+	if r.Tracer != nil {
+		handler = withTracing(r.Tracer, r.Namespace, tool.Name, handler)
+	}
+	handler = withDrain(r.Calls, handler)
+	r.Server.AddTool(tool, handler)
+	registered := registeredTool{name: tool.Name, mutating: mutating}
+	if i, ok := t.(Instructed); ok {
+		registered.instructions = i.Instructions()
+	}
+	r.registered = append(r.registered, registered)
+}
+
+// checkServer checks requirement against the connected server at registration. When the
+// capabilities can't be fetched the tool is assumed supported; calls are checked again anyway.
+func (r *Registry) checkServer(requirement ServerRequirement) (bool, string) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
+	connected, err := r.Capabilities.Get(ctx)
+	if err != nil {
+		r.Logger.Warn("Unable to fetch Temporal server capabilities", "error", err)
+		return true, ""
+	}
+	return requirement.Supported(connected)
+}
+
+func cachePolicy(t Tool) CachePolicy {
+	if c, ok := t.(Cacheable); ok {
+		return c.CachePolicy()
+	}
+	return CacheNever
+}
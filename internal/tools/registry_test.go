@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/wricardo/temporal-mcp/internal/audit"
+	"github.com/wricardo/temporal-mcp/internal/config"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+func TestRegistryTargetNote(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "cluster name",
+			env:  map[string]string{"TEMPORAL_MCP_CLUSTER_NAME": "prod-us", "TEMPORAL_NAMESPACE": "payments"},
+			want: " [Target: prod-us, namespace 'payments']",
+		},
+		{
+			// Without a cluster name the addresses name the cluster
+			name: "addresses",
+			env:  map[string]string{"TEMPORAL_ADDRESS": "frontend-a:7233,frontend-b:7233"},
+			want: " [Target: frontend-a:7233, frontend-b:7233, namespace 'default']",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"TEMPORAL_ADDRESS", "TEMPORAL_NAMESPACE", "TEMPORAL_MCP_CLUSTER_NAME", "TEMPORAL_MCP_CONFIG"} {
+				t.Setenv(name, tt.env[name])
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			base := dryRunClient(t, cfg.Namespace)
+			clients := newFakeProvider(base)
+			srv := server.NewMCPServer("test", "0.0.0")
+			registry := &Registry{
+				Server:    srv,
+				Logger:    testLogger,
+				Auditor:   audit.NopSink{},
+				Namespace: cfg.Namespace,
+				Target:    cfg.Target(),
+				Timeout:   10 * time.Second,
+				DryRun:    true,
+				Clients:   clients,
+				Calls:     &InflightCalls{},
+			}
+			for _, tool := range allTools(clients, temporal.NewCapabilities(base, nil), newTestRenderer(t)) {
+				registry.Register(tool)
+			}
+
+			// Every description ends with the target, after any other note such as dry run's
+			for name, registered := range srv.ListTools() {
+				description := registered.Tool.Description
+				if !strings.HasSuffix(description, tt.want) {
+					t.Errorf("%s: description doesn't end with %q: %s", name, tt.want, description)
+				}
+				if strings.Count(description, "[Target: ") != 1 {
+					t.Errorf("%s: description has the target more than once: %s", name, description)
+				}
+			}
+			if description := srv.GetTool("reset_workflow").Tool.Description; !strings.HasSuffix(description, " [DRY RUN: this server only previews changes; nothing is modified]"+tt.want) {
+				t.Errorf("reset_workflow: want the dry-run note before the target: %s", description)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// defaultResetReason is recorded on resets when the caller doesn't give a reason.
+const defaultResetReason = "Reset via temporal-mcp"
+
+// After a reset, the new run is polled every resetVerifyInterval for up to resetVerifyWindow until
+// its first workflow task completes or fails.
+const (
+	resetVerifyWindow   = 5 * time.Second
+	resetVerifyInterval = 500 * time.Millisecond
+)
+
+// Reapply modes accepted by reset_workflow.
+const (
+	reapplyAll            = "all"
+	reapplyExcludeSignals = "exclude_signals"
+	reapplyExcludeUpdates = "exclude_updates"
+	reapplyExcludeAll     = "exclude_all"
+)
+
+// ResetWorkflow implements the "reset_workflow" tool.
+type ResetWorkflow struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	// Capabilities tells which reapply options the connected server supports.
+	Capabilities *temporal.Capabilities
+}
+
+// Definition implements Tool.
+func (t *ResetWorkflow) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"reset_workflow",
+		mcp.WithDescription("Reset a workflow execution to a workflow task event, starting a new run from that point. Use list_reset_points to find a safe event ID. Without confirm=true, returns a preview (including how many signals and updates follow the reset point) and changes nothing. After the reset, the new run is watched for a few seconds to report whether its first workflow task completed, and on which worker build; a task that fails right away means the reset target was wrong"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution to reset"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithNumber("event_id",
+			integer(),
+			mcp.Min(1),
+			mcp.Required(),
+			mcp.Description("ID of the WorkflowTaskCompleted (or failed/timed out) event to reset to"),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Reason recorded on the reset"),
+		),
+		mcp.WithString("reapply",
+			mcp.Description("Which events after the reset point are reapplied to the new run: all (default), exclude_signals, exclude_updates, or exclude_all"),
+			mcp.Enum(reapplyAll, reapplyExcludeSignals, reapplyExcludeUpdates, reapplyExcludeAll),
+		),
+		mcp.WithString("expected_build_id",
+			mcp.Description("Worker build ID that should pick up the new run (e.g. the build with the fix); the reset is reported as unverified if another build handles its first workflow task"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Description("Set to true to perform the reset; otherwise only a preview is returned"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// Mutating implements Mutating.
+func (t *ResetWorkflow) Mutating() {}
+
+// Handle implements Tool.
+func (t *ResetWorkflow) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	rawEventID, err := intArgument(args, "event_id", 0, 1, 0)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if rawEventID == 0 {
+		return mcp.NewToolResultError("Missing or invalid 'event_id' parameter (use list_reset_points to find one)"), nil
+	}
+	eventID := int64(rawEventID)
+	reason, err := stringArgument(args, "reason")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if reason == "" {
+		reason = defaultResetReason
+	}
+	reapply, err := stringArgument(args, "reapply")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if reapply == "" {
+		reapply = reapplyAll
+	}
+	switch reapply {
+	case reapplyAll, reapplyExcludeSignals, reapplyExcludeUpdates, reapplyExcludeAll:
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'reapply' parameter %q (use all, exclude_signals, exclude_updates, or exclude_all)", reapply)), nil
+	}
+	expectedBuildID, err := stringArgument(args, "expected_build_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	confirm, err := boolArgument(args, "confirm")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Pin the run so the preview, the history scan, and the reset all refer to the same execution
+	resp, err := client.DescribeWorkflowExecution(ctx, wfID, runID)
+	if err != nil {
+		t.Logger.Error("Error describing workflow", "workflow_id", wfID, "run_id", runID, "error", err)
+		return describeFailed(ctx, client, wfID, err), nil
+	}
+	info := resp.GetWorkflowExecutionInfo()
+	execution := &commonpb.WorkflowExecution{WorkflowId: wfID, RunId: info.GetExecution().GetRunId()}
+	plan := format.ResetPlan{
+		WorkflowID:       wfID,
+		RunID:            execution.GetRunId(),
+		WorkflowType:     info.GetType().GetName(),
+		Status:           format.WorkflowStatus(info.GetStatus()),
+		Duration:         format.RunDuration(info),
+		EventID:          eventID,
+		Reapply:          reapply,
+		ExpectedBuildID:  expectedBuildID,
+		SignalsReapplied: reapply == reapplyAll || reapply == reapplyExcludeUpdates,
+		UpdatesReapplied: reapply == reapplyAll || reapply == reapplyExcludeSignals,
+	}
+
+	eventType, err := t.scanHistory(ctx, client, execution, info.GetHistoryLength(), &plan)
+	if err != nil {
+		t.Logger.Error("Error reading workflow history", "workflow_id", wfID, "run_id", plan.RunID, "error", err)
+		return rpcError("Failed to read workflow history", err), nil
+	}
+	switch eventType {
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED, enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED, enumspb.EVENT_TYPE_WORKFLOW_TASK_TIMED_OUT:
+	case enumspb.EVENT_TYPE_UNSPECIFIED:
+		return mcp.NewToolResultError(fmt.Sprintf("Event %d does not exist in the history of workflow %s (run %s)", eventID, wfID, plan.RunID)), nil
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Event %d is a %s event; reset requires a WorkflowTaskCompleted, WorkflowTaskFailed, or WorkflowTaskTimedOut event (use list_reset_points to find one)", eventID, eventType)), nil
+	}
+	plan.EventType = eventType.String()
+
+	request := &workflowservice.ResetWorkflowExecutionRequest{
+		Namespace:                 client.Namespace(),
+		WorkflowExecution:         execution,
+		Reason:                    attributedReason(ctx, client, reason),
+		WorkflowTaskFinishEventId: eventID,
+		// The request ID makes the reset idempotent, so the client may retry it on transient errors
+		RequestId: uuid.NewString(),
+	}
+	if err := t.applyReapply(ctx, reapply, request); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !confirm {
+		return renderResult(outFormat, plan, format.ResetPlanText)
+	}
+
+	if temporal.DryRun(ctx) {
+		t.Logger.Info("Dry run: skipped workflow reset", "workflow_id", wfID, "run_id", plan.RunID, "event_id", eventID, "reapply", reapply)
+		plan.DryRun = true
+		return renderResult(outFormat, plan, format.ResetPlanText)
+	}
+	reset, err := client.ResetWorkflowExecution(ctx, request)
+	if err != nil {
+		t.Logger.Error("Error resetting workflow", "workflow_id", wfID, "run_id", plan.RunID, "event_id", eventID, "error", err)
+		return rpcError("Failed to reset workflow", err), nil
+	}
+	t.Logger.Info("Reset workflow", "workflow_id", wfID, "run_id", plan.RunID, "event_id", eventID, "reapply", reapply, "new_run_id", reset.GetRunId())
+	plan.NewRunID = reset.GetRunId()
+	plan.Verification = t.verify(ctx, client, &commonpb.WorkflowExecution{WorkflowId: wfID, RunId: plan.NewRunID}, expectedBuildID)
+	if !plan.Verification.Verified {
+		t.Logger.Warn("Reset not verified", "workflow_id", wfID, "new_run_id", plan.NewRunID, "outcome", plan.Verification.Outcome, "build_id", plan.Verification.BuildID)
+	}
+	return renderResult(outFormat, plan, format.ResetPlanText)
+}
+
+// verify watches the new run of a reset until its first workflow task after the reset point
+// completes or fails, the run closes, or resetVerifyWindow (or the call's deadline) passes.
+func (t *ResetWorkflow) verify(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, expectedBuildID string) *format.ResetVerification {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, resetVerifyWindow)
+	defer cancel()
+	var (
+		v      format.ResetVerification
+		closed bool
+	)
+	for {
+		v = format.ResetVerification{Outcome: "pending"}
+		resp, err := client.DescribeWorkflowExecution(ctx, execution.GetWorkflowId(), execution.GetRunId())
+		if err == nil {
+			info := resp.GetWorkflowExecutionInfo()
+			v.Status = format.WorkflowStatus(info.GetStatus())
+			closed = info.GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING
+			err = forEachEvent(ctx, client, execution, info.GetHistoryLength(), firstTaskAfterReset(&v))
+		}
+		if err != nil && ctx.Err() == nil {
+			t.Logger.Warn("Unable to verify reset", "workflow_id", execution.GetWorkflowId(), "new_run_id", execution.GetRunId(), "error", err)
+			v.Error = translateError("Failed to read the new run", err).Error
+			break
+		}
+		if v.Outcome != "pending" || closed || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(resetVerifyInterval):
+		}
+	}
+	v.Waited = time.Since(start).Round(100 * time.Millisecond).String()
+	v.Verified = v.Outcome == "completed" && (expectedBuildID == "" || v.BuildID == expectedBuildID)
+	return &v
+}
+
+// firstTaskAfterReset returns a history callback recording into v the outcome of the first
+// workflow task after the reset point, which the server marks with a WorkflowTaskFailed event of
+// cause ResetWorkflow. The callback stops the scan once the outcome is known.
+func firstTaskAfterReset(v *format.ResetVerification) func(*historypb.HistoryEvent) bool {
+	var afterReset bool
+	return func(event *historypb.HistoryEvent) bool {
+		switch event.GetEventType() {
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED:
+			if afterReset {
+				attrs := event.GetWorkflowTaskStartedEventAttributes()
+				v.BuildID, v.Identity = attrs.GetWorkerVersion().GetBuildId(), attrs.GetIdentity()
+			}
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED:
+			if afterReset {
+				attrs := event.GetWorkflowTaskCompletedEventAttributes()
+				v.Outcome, v.Identity = "completed", attrs.GetIdentity()
+				if buildID := workerBuildID(attrs); buildID != "" {
+					v.BuildID = buildID
+				}
+				return false
+			}
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED:
+			attrs := event.GetWorkflowTaskFailedEventAttributes()
+			if attrs.GetCause() == enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_WORKFLOW {
+				// The tasks before the marker belong to the original run
+				afterReset = true
+			} else if afterReset {
+				v.Outcome, v.Identity = "failed", attrs.GetIdentity()
+				v.Failure = fmt.Sprintf("%s: %s", attrs.GetCause(), attrs.GetFailure().GetMessage())
+				if buildID := attrs.GetWorkerVersion().GetBuildId(); buildID != "" {
+					v.BuildID = buildID
+				}
+				return false
+			}
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_TIMED_OUT:
+			if afterReset {
+				v.Outcome, v.Failure = "timed_out", "no worker completed the task before its start-to-close timeout"
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// workerBuildID returns the build of the worker that completed a workflow task: its deployment
+// version, build ID, or deprecated binary checksum, whichever it reported.
+func workerBuildID(attrs *historypb.WorkflowTaskCompletedEventAttributes) string {
+	if buildID := attrs.GetWorkerVersion().GetBuildId(); buildID != "" {
+		return buildID
+	}
+	if version := attrs.GetWorkerDeploymentVersion(); version != "" {
+		return version
+	}
+	return attrs.GetBinaryChecksum()
+}
+
+// scanHistory reads the execution's history, counting the signals and updates after the reset
+// point into plan, and returns the type of the reset point event (unspecified if it doesn't exist).
+func (t *ResetWorkflow) scanHistory(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, historyLength int64, plan *format.ResetPlan) (enumspb.EventType, error) {
+	eventType := enumspb.EVENT_TYPE_UNSPECIFIED
+	var (
+		pageToken []byte
+		scanned   int
+	)
+	for {
+		resp, err := client.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+			Namespace:     client.Namespace(),
+			Execution:     execution,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return eventType, err
+		}
+		for _, event := range resp.GetHistory().GetEvents() {
+			scanned++
+			switch {
+			case event.GetEventId() == plan.EventID:
+				eventType = event.GetEventType()
+			case event.GetEventId() < plan.EventID:
+			case event.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+				plan.SignalsAfter++
+			case event.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ADMITTED,
+				event.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ACCEPTED:
+				plan.UpdatesAfter++
+			}
+		}
+		pageToken = resp.GetNextPageToken()
+		if len(pageToken) == 0 {
+			return eventType, nil
+		}
+		reportProgress(ctx, float64(scanned), float64(historyLength), "read %d of %d history events", scanned, historyLength)
+	}
+}
+
+// resetExcludeMinVersion is the first Temporal server release that honors reset reapply exclusions.
+// Older servers only understand the legacy reapply type, which reapplies signals or nothing.
+var resetExcludeMinVersion = temporal.Version{1, 24}
+
+// applyReapply sets the reapply options of request for the given mode, using the exclusion list
+// where the connected server supports it and the legacy reapply type otherwise.
+func (t *ResetWorkflow) applyReapply(ctx context.Context, reapply string, request *workflowservice.ResetWorkflowExecutionRequest) error {
+	server, err := t.Capabilities.Get(ctx)
+	if err != nil {
+		t.Logger.Error("Error fetching Temporal system info", "error", err)
+		return fmt.Errorf("Failed to check the Temporal server version: %v", err)
+	}
+	if server.AtLeast(resetExcludeMinVersion) {
+		switch reapply {
+		case reapplyExcludeSignals:
+			request.ResetReapplyExcludeTypes = []enumspb.ResetReapplyExcludeType{enumspb.RESET_REAPPLY_EXCLUDE_TYPE_SIGNAL}
+		case reapplyExcludeUpdates:
+			request.ResetReapplyExcludeTypes = []enumspb.ResetReapplyExcludeType{enumspb.RESET_REAPPLY_EXCLUDE_TYPE_UPDATE}
+		case reapplyExcludeAll:
+			request.ResetReapplyExcludeTypes = []enumspb.ResetReapplyExcludeType{enumspb.RESET_REAPPLY_EXCLUDE_TYPE_SIGNAL, enumspb.RESET_REAPPLY_EXCLUDE_TYPE_UPDATE}
+		}
+		return nil
+	}
+
+	switch reapply {
+	case reapplyAll:
+		request.ResetReapplyType = enumspb.RESET_REAPPLY_TYPE_SIGNAL
+	case reapplyExcludeSignals, reapplyExcludeAll:
+		request.ResetReapplyType = enumspb.RESET_REAPPLY_TYPE_NONE
+	default:
+		return fmt.Errorf("reapply=%s requires Temporal server %s or later (connected server is %s); use all, exclude_signals, or exclude_all",
+			reapply, resetExcludeMinVersion, server.Version)
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// namespacesURI is the root of the namespace resource hierarchy.
+	namespacesURI = "temporal://namespaces"
+	// workflowTemplate and scheduleTemplate are the URI templates of a workflow and a schedule.
+	workflowTemplate = namespacesURI + "/{namespace}/workflows/{workflow_id}"
+	scheduleTemplate = namespacesURI + "/{namespace}/schedules/{schedule_id}"
+	// maxResourceChildren caps the workflows and the schedules listed under each namespace.
+	maxResourceChildren = 10
+	// maxCompletions caps the values of one completion, as MCP allows no more.
+	maxCompletions = 100
+)
+
+// NamespaceResources exposes the namespaces this server may access as a hierarchy of MCP
+// resources, for clients that browse resources rather than call tools:
+//
+//	temporal://namespaces                                       the namespaces, as list_namespaces
+//	temporal://namespaces/{namespace}                           links to its recent workflows and schedules
+//	temporal://namespaces/{namespace}/workflows/{workflow_id}   a workflow, as describe_workflow raw=true
+//	temporal://namespaces/{namespace}/schedules/{schedule_id}   a schedule's runs, as list_schedule_runs
+//
+// resources/list returns the first two levels and the children of each namespace, at most
+// maxResourceChildren of each kind. Reads run the named tools' handlers and return their JSON. The
+// templates' variables are completable (see CompleteResourceArgument).
+type NamespaceResources struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	// Pool bounds the concurrent child listings of resources/list.
+	Pool *pool.Pool
+	// Timeout is the deadline of the Temporal calls made for one list or read.
+	Timeout time.Duration
+
+	Namespaces   *ListNamespaces
+	Workflow     *DescribeWorkflow
+	ScheduleRuns *ListScheduleRuns
+}
+
+// Register adds the resources to srv, and to hooks the listing of the namespaces' nodes, which
+// resources/list can't otherwise return as they aren't known in advance. It also makes r the
+// server's provider of resource argument completions.
+func (r *NamespaceResources) Register(srv *server.MCPServer, hooks *server.Hooks) {
+	server.WithCompletions()(srv)
+	server.WithResourceCompletionProvider(r)(srv)
+	srv.AddResource(mcp.NewResource(namespacesURI, "Temporal namespaces",
+		mcp.WithResourceDescription("The namespaces this server may access; each has its own resource with links to its recent workflows and schedules"),
+		mcp.WithMIMEType("application/json"),
+	), r.read)
+	for _, template := range []mcp.ResourceTemplate{
+		mcp.NewResourceTemplate(namespacesURI+"/{namespace}", "Temporal namespace",
+			mcp.WithTemplateDescription(fmt.Sprintf("Links to the namespace's %d most recent workflows and its schedules", maxResourceChildren)),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		mcp.NewResourceTemplate(workflowTemplate, "Temporal workflow",
+			mcp.WithTemplateDescription("The latest run of a workflow, as the full DescribeWorkflowExecution response"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		mcp.NewResourceTemplate(scheduleTemplate, "Temporal schedule",
+			mcp.WithTemplateDescription("The workflows a schedule started, most recent first"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+	} {
+		srv.AddResourceTemplate(template, r.read)
+	}
+	hooks.AddAfterListResources(func(ctx context.Context, id any, req *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
+		// Only the first page: the nodes aren't part of the server's resource pagination
+		if req.Params.Cursor != "" {
+			return
+		}
+		result.Resources = append(result.Resources, r.list(ctx)...)
+	})
+}
+
+// list returns a resource for each namespace and its children. Namespaces beyond
+// maxNamespaceFanout are left out, and a namespace whose children can't be listed is listed alone.
+func (r *NamespaceResources) list(ctx context.Context) []mcp.Resource {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+	namespaces, err := r.Clients.Namespaces(ctx)
+	if err != nil {
+		r.Logger.Warn("Unable to list namespaces for resources/list", "error", err)
+		return nil
+	}
+	namespaces = namespaces[:min(len(namespaces), maxNamespaceFanout)]
+
+	nodes := make([]format.NamespaceNode, len(namespaces))
+	r.Pool.Run(ctx, len(namespaces), 0, func(ctx context.Context, i int) {
+		nodes[i] = r.node(ctx, namespaces[i])
+	})
+	var resources []mcp.Resource
+	for i, namespace := range namespaces {
+		resources = append(resources, mcp.NewResource(namespaceURI(namespace), "namespace "+namespace,
+			mcp.WithResourceDescription("Recent workflows and schedules of namespace "+namespace),
+			mcp.WithMIMEType("application/json"),
+		))
+		for _, link := range append(nodes[i].Workflows, nodes[i].Schedules...) {
+			resources = append(resources, mcp.NewResource(link.URI, link.Name,
+				mcp.WithResourceDescription(link.Description),
+				mcp.WithMIMEType("application/json"),
+			))
+		}
+	}
+	return resources
+}
+
+// node lists the children of namespace. Each list degrades on its own: one that can't be fetched
+// carries its error while the other is still returned.
+func (r *NamespaceResources) node(ctx context.Context, namespace string) format.NamespaceNode {
+	node := format.NamespaceNode{Namespace: namespace, Workflows: []format.ResourceLink{}, Schedules: []format.ResourceLink{}}
+	client, err := r.Clients.Client(namespace)
+	if err != nil {
+		node.WorkflowsError, node.SchedulesError = err.Error(), err.Error()
+		return node
+	}
+
+	workflows, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Namespace: namespace,
+		PageSize:  maxResourceChildren,
+	})
+	if err != nil {
+		r.Logger.Warn("Error listing workflows for resources", "namespace", namespace, "error", err)
+		node.WorkflowsError = translateError("Failed to list workflows", err).Error
+	}
+	for _, info := range workflows.GetExecutions() {
+		summary := format.NewWorkflowSummary(info)
+		node.Workflows = append(node.Workflows, format.ResourceLink{
+			URI:         namespaceURI(namespace) + "/workflows/" + url.PathEscape(summary.WorkflowID),
+			Name:        "workflow " + summary.WorkflowID,
+			Description: fmt.Sprintf("%s workflow, %s, started %s", summary.Type, summary.Status, summary.StartTime),
+		})
+	}
+
+	schedules, err := client.ListSchedules(ctx, &workflowservice.ListSchedulesRequest{
+		Namespace:       namespace,
+		MaximumPageSize: maxResourceChildren,
+	})
+	if err != nil {
+		r.Logger.Warn("Error listing schedules for resources", "namespace", namespace, "error", err)
+		node.SchedulesError = translateError("Failed to list schedules", err).Error
+	}
+	for _, entry := range schedules.GetSchedules() {
+		state := "active"
+		if entry.GetInfo().GetPaused() {
+			state = "paused"
+		}
+		node.Schedules = append(node.Schedules, format.ResourceLink{
+			URI:         namespaceURI(namespace) + "/schedules/" + url.PathEscape(entry.GetScheduleId()),
+			Name:        "schedule " + entry.GetScheduleId(),
+			Description: fmt.Sprintf("%s schedule of %s workflows", state, entry.GetInfo().GetWorkflowType().GetName()),
+		})
+	}
+	return node
+}
+
+// read returns the JSON detail of the node named by the request's URI.
+func (r *NamespaceResources) read(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+	uri := req.Params.URI
+	if uri == namespacesURI {
+		return r.toolContents(ctx, uri, r.Namespaces, map[string]interface{}{"format": "json"})
+	}
+
+	segments := strings.Split(strings.TrimPrefix(uri, namespacesURI+"/"), "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource URI %s: %w", uri, err)
+		}
+		segments[i] = unescaped
+	}
+	namespace := segments[0]
+	if !r.Clients.Allowed(namespace) {
+		return nil, fmt.Errorf("%w: %q", temporal.ErrNamespaceNotAllowed, namespace)
+	}
+	switch {
+	case len(segments) == 1:
+		out, err := format.JSON(r.node(ctx, namespace))
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: uri, MIMEType: "application/json", Text: out}}, nil
+	case len(segments) == 3 && segments[1] == "workflows":
+		return r.toolContents(ctx, uri, r.Workflow, map[string]interface{}{"namespace": namespace, "workflow_id": segments[2], "raw": true})
+	case len(segments) == 3 && segments[1] == "schedules":
+		return r.toolContents(ctx, uri, r.ScheduleRuns, map[string]interface{}{"namespace": namespace, "schedule_id": segments[2], "format": "json"})
+	default:
+		return nil, fmt.Errorf("unknown resource %s", uri)
+	}
+}
+
+// CompleteResourceArgument implements server.ResourceCompletionProvider: namespace completes to
+// the namespaces this server may access, and workflow_id and schedule_id to the IDs starting with
+// the typed value, found with a STARTS_WITH visibility query in the namespace already chosen (the
+// default one until then). A failed lookup completes nothing rather than failing the request.
+func (r *NamespaceResources) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, completeCtx mcp.CompleteContext) (*mcp.Completion, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+	var (
+		completion *mcp.Completion
+		err        error
+	)
+	switch {
+	case argument.Name == "namespace":
+		completion, err = r.completeNamespace(ctx, argument.Value)
+	case argument.Name == "workflow_id" && uri == workflowTemplate:
+		completion, err = r.completeWorkflowID(ctx, completeCtx.Arguments["namespace"], argument.Value)
+	case argument.Name == "schedule_id" && uri == scheduleTemplate:
+		completion, err = r.completeScheduleID(ctx, completeCtx.Arguments["namespace"], argument.Value)
+	default:
+		return &mcp.Completion{Values: []string{}}, nil
+	}
+	if err != nil {
+		r.Logger.Warn("Unable to complete resource argument", "uri", uri, "argument", argument.Name, "value", argument.Value, "error", err)
+		return &mcp.Completion{Values: []string{}}, nil
+	}
+	return completion, nil
+}
+
+// completeNamespace completes prefix to the namespaces this server may access.
+func (r *NamespaceResources) completeNamespace(ctx context.Context, prefix string) (*mcp.Completion, error) {
+	namespaces, err := r.Clients.Namespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values := []string{}
+	for _, namespace := range namespaces {
+		if strings.HasPrefix(namespace, prefix) {
+			values = append(values, namespace)
+		}
+	}
+	return completion(values, false), nil
+}
+
+// completeWorkflowID completes prefix to the IDs of the workflows of namespace, most recently
+// started first. IDs shared by several runs are offered once.
+func (r *NamespaceResources) completeWorkflowID(ctx context.Context, namespace, prefix string) (*mcp.Completion, error) {
+	client, err := r.Clients.Client(namespace)
+	if err != nil {
+		return nil, err
+	}
+	req := &workflowservice.ListWorkflowExecutionsRequest{Namespace: client.Namespace(), PageSize: maxCompletions}
+	if prefix != "" {
+		req.Query = "WorkflowId STARTS_WITH " + queryString(prefix)
+	}
+	resp, err := client.ListWorkflow(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	values := []string{}
+	for _, info := range resp.GetExecutions() {
+		if id := info.GetExecution().GetWorkflowId(); !seen[id] {
+			seen[id] = true
+			values = append(values, id)
+		}
+	}
+	return completion(values, len(resp.GetNextPageToken()) > 0), nil
+}
+
+// completeScheduleID completes prefix to the IDs of the schedules of namespace.
+func (r *NamespaceResources) completeScheduleID(ctx context.Context, namespace, prefix string) (*mcp.Completion, error) {
+	client, err := r.Clients.Client(namespace)
+	if err != nil {
+		return nil, err
+	}
+	req := &workflowservice.ListSchedulesRequest{Namespace: client.Namespace(), MaximumPageSize: maxCompletions}
+	if prefix != "" {
+		req.Query = "ScheduleId STARTS_WITH " + queryString(prefix)
+	}
+	resp, err := client.ListSchedules(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	values := []string{}
+	for _, entry := range resp.GetSchedules() {
+		values = append(values, entry.GetScheduleId())
+	}
+	return completion(values, len(resp.GetNextPageToken()) > 0), nil
+}
+
+// completion returns values as a completion, cut to maxCompletions.
+func completion(values []string, more bool) *mcp.Completion {
+	if len(values) > maxCompletions {
+		values, more = values[:maxCompletions], true
+	}
+	return &mcp.Completion{Values: values, HasMore: more}
+}
+
+// toolContents runs tool with args and returns its text as the contents of the resource uri, or
+// its error message as an error.
+func (r *NamespaceResources) toolContents(ctx context.Context, uri string, tool Tool, args map[string]interface{}) ([]mcp.ResourceContents, error) {
+	var req mcp.CallToolRequest
+	req.Params.Name = tool.Definition().Name
+	req.Params.Arguments = args
+	result, err := tool.Handle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if result.IsError {
+		return nil, errors.New(resultText(result))
+	}
+	return []mcp.ResourceContents{mcp.TextResourceContents{URI: uri, MIMEType: "application/json", Text: resultText(result)}}, nil
+}
+
+// namespaceURI returns the URI of the resource of namespace.
+func namespaceURI(namespace string) string {
+	return namespacesURI + "/" + url.PathEscape(namespace)
+}
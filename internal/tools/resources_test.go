@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	schedulepb "go.temporal.io/api/schedule/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/pool"
+)
+
+func TestResourceCompletion(t *testing.T) {
+	var queries []string
+	payments := &fakeClient{
+		namespace: "payments",
+		listWorkflow: func(_ context.Context, req *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+			queries = append(queries, req.GetQuery())
+			retried := execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+			retried.Execution = &commonpb.WorkflowExecution{WorkflowId: "order-1", RunId: "order-1-run-2"}
+			return &workflowservice.ListWorkflowExecutionsResponse{
+				Executions: []*workflowpb.WorkflowExecutionInfo{
+					retried,
+					execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_FAILED),
+					execution(`order-"2"`, "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+				},
+				NextPageToken: []byte("page-2"),
+			}, nil
+		},
+		listSchedules: func(_ context.Context, req *workflowservice.ListSchedulesRequest) (*workflowservice.ListSchedulesResponse, error) {
+			queries = append(queries, req.GetQuery())
+			return &workflowservice.ListSchedulesResponse{Schedules: []*schedulepb.ScheduleListEntry{
+				{ScheduleId: "nightly-report"},
+				{ScheduleId: "nightly-cleanup"},
+			}}, nil
+		},
+	}
+	billing := &fakeClient{namespace: "billing"}
+	srv := server.NewMCPServer("test", "0.0.0")
+	resources := &NamespaceResources{
+		Clients: newFakeProvider(payments, billing),
+		Logger:  testLogger,
+		Pool:    pool.New(2),
+		Timeout: 10 * time.Second,
+	}
+	resources.Register(srv, &server.Hooks{})
+
+	complete := func(uri, name, value string, arguments map[string]string) mcp.Completion {
+		t.Helper()
+		params := map[string]any{
+			"ref":      map[string]any{"type": "ref/resource", "uri": uri},
+			"argument": map[string]any{"name": name, "value": value},
+		}
+		if arguments != nil {
+			params["context"] = map[string]any{"arguments": arguments}
+		}
+		message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "completion/complete", "params": params})
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := json.Marshal(srv.HandleMessage(context.Background(), message))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var response struct {
+			Result *mcp.CompleteResult `json:"result"`
+			Error  any                 `json:"error"`
+		}
+		if err := json.Unmarshal(out, &response); err != nil {
+			t.Fatal(err)
+		}
+		if response.Result == nil {
+			t.Fatalf("completion of %s failed: %v", name, response.Error)
+		}
+		return response.Result.Completion
+	}
+
+	tests := []struct {
+		name      string
+		uri       string
+		argument  string
+		value     string
+		arguments map[string]string
+		want      mcp.Completion
+		query     string
+	}{
+		{
+			// Runs of the same workflow are offered once
+			name:      "workflow_id",
+			uri:       workflowTemplate,
+			argument:  "workflow_id",
+			value:     "order-",
+			arguments: map[string]string{"namespace": "payments"},
+			want:      mcp.Completion{Values: []string{"order-1", `order-"2"`}, HasMore: true},
+			query:     `WorkflowId STARTS_WITH "order-"`,
+		},
+		{
+			// Without a chosen namespace the default one is used
+			name:     "schedule_id",
+			uri:      scheduleTemplate,
+			argument: "schedule_id",
+			value:    "nightly",
+			want:     mcp.Completion{Values: []string{"nightly-report", "nightly-cleanup"}},
+			query:    `ScheduleId STARTS_WITH "nightly"`,
+		},
+		{
+			name:     "namespace",
+			uri:      workflowTemplate,
+			argument: "namespace",
+			value:    "pay",
+			want:     mcp.Completion{Values: []string{"payments"}},
+		},
+		{
+			// A namespace the server may not access completes nothing, without an RPC
+			name:      "namespace not allowed",
+			uri:       workflowTemplate,
+			argument:  "workflow_id",
+			value:     "order-",
+			arguments: map[string]string{"namespace": "orders"},
+			want:      mcp.Completion{Values: []string{}},
+		},
+		{
+			// A failed lookup completes nothing: billing has no listing set up
+			name:      "lookup failed",
+			uri:       scheduleTemplate,
+			argument:  "schedule_id",
+			value:     "n",
+			arguments: map[string]string{"namespace": "billing"},
+			want:      mcp.Completion{Values: []string{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries = nil
+			got := complete(tt.uri, tt.argument, tt.value, tt.arguments)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("completion = %+v, want %+v", got, tt.want)
+			}
+			var want []string
+			if tt.query != "" {
+				want = []string{tt.query}
+			}
+			if !reflect.DeepEqual(queries, want) {
+				t.Errorf("queries = %q, want %q", queries, want)
+			}
+		})
+	}
+}
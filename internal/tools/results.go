@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/wricardo/temporal-mcp/internal/cache"
+)
+
+const (
+	// resultsURI is the root of the URIs of stored results.
+	resultsURI = "temporal://results"
+	// resultStoreCapacity bounds the results a ResultStore keeps; the least recently read go first.
+	resultStoreCapacity = 64
+	// Summaries of stored results quote at most their first summaryLines lines and summaryBytes
+	// bytes, or half the output bound if lower.
+	summaryLines = 20
+	summaryBytes = 2048
+)
+
+// ResultStore keeps the full text of tool results too large to return inline, readable as
+// temporal://results/{id} resources until they expire. The IDs are random, so URIs can't be guessed.
+type ResultStore struct {
+	// TTL is how long a stored result stays readable.
+	TTL time.Duration
+
+	documents *cache.Cache
+}
+
+// storedResult is the text of a result with its MIME type.
+type storedResult struct {
+	mimeType string
+	text     string
+}
+
+// NewResultStore creates a store whose results stay readable for ttl.
+func NewResultStore(ttl time.Duration) *ResultStore {
+	return &ResultStore{TTL: ttl, documents: cache.New(resultStoreCapacity, ttl)}
+}
+
+// put stores text and returns its URI.
+func (s *ResultStore) put(mimeType, text string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	uri := resultsURI + "/" + hex.EncodeToString(id)
+	s.documents.Set(uri, storedResult{mimeType: mimeType, text: text})
+	return uri, nil
+}
+
+// ResourceTemplate returns the template of the stored results' URIs, for registration with the
+// MCP server.
+func (s *ResultStore) ResourceTemplate() (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	template := mcp.NewResourceTemplate(resultsURI+"/{id}", "Stored tool result",
+		mcp.WithTemplateDescription(fmt.Sprintf("The full output of a tool call too large to return inline, kept for %s", s.TTL)),
+	)
+	return template, func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		value, ok := s.documents.Get(req.Params.URI)
+		if !ok {
+			return nil, fmt.Errorf("unknown or expired result %s: call the tool again", req.Params.URI)
+		}
+		doc := value.(storedResult)
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: req.Params.URI, MIMEType: doc.mimeType, Text: doc.text}}, nil
+	}
+}
+
+// withLargeOutput keeps text blocks over maxBytes out of the client's context. With a store, such
+// a block is replaced by a short summary and a link to a resource holding the full text, which the
+// client reads by URI until it expires; the text itself stays in the store. Without one, and in
+// error results, it is truncated. The wrapped result is copied, not modified, as it may be cached.
+func withLargeOutput(results *ResultStore, logger *slog.Logger, maxBytes int, toolName string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, req)
+		if err != nil || result == nil {
+			return result, err
+		}
+		var large bool
+		for _, content := range result.Content {
+			if tc, ok := content.(mcp.TextContent); ok && len(tc.Text) > maxBytes {
+				large = true
+			}
+		}
+		if !large {
+			return result, err
+		}
+
+		out := *result
+		out.Content = nil
+		for _, content := range result.Content {
+			tc, ok := content.(mcp.TextContent)
+			if !ok || len(tc.Text) <= maxBytes {
+				out.Content = append(out.Content, content)
+				continue
+			}
+			if results != nil && !result.IsError {
+				mimeType := "text/plain"
+				if json.Valid([]byte(tc.Text)) {
+					mimeType = "application/json"
+				}
+				uri, err := results.put(mimeType, tc.Text)
+				if err == nil {
+					out.Content = append(out.Content,
+						mcp.NewTextContent(summarizeOutput(tc.Text, uri, maxBytes, results.TTL)),
+						mcp.NewResourceLink(uri, "Full output of "+toolName, fmt.Sprintf("%d bytes, readable for %s", len(tc.Text), results.TTL), mimeType),
+					)
+					continue
+				}
+				logger.Warn("Unable to store large tool output; truncating it", "tool", toolName, "error", err)
+			}
+			out.Content = append(out.Content, mcp.NewTextContent(truncateOutput(tc.Text, maxBytes)))
+		}
+		return &out, err
+	}
+}
+
+// summarizeOutput describes text, stored as the resource uri, and quotes its beginning.
+func summarizeOutput(text, uri string, maxBytes int, ttl time.Duration) string {
+	head := text
+	if lines := strings.SplitAfterN(head, "\n", summaryLines+1); len(lines) > summaryLines {
+		head = strings.Join(lines[:summaryLines], "")
+	}
+	head = strings.TrimRight(cutUTF8(head, min(summaryBytes, maxBytes/2)), "\n")
+	return fmt.Sprintf("[output of %d bytes (%d lines) exceeds the %d-byte limit; the full output is stored as resource %s, readable with resources/read for %s. It begins:]\n%s\n[...]",
+		len(text), strings.Count(text, "\n")+1, maxBytes, uri, ttl, head)
+}
+
+// truncateOutput cuts text to at most maxBytes, at a line boundary when there is one, and says so.
+func truncateOutput(text string, maxBytes int) string {
+	cut := cutUTF8(text, maxBytes)
+	if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+		cut = cut[:i]
+	}
+	return fmt.Sprintf("%s\n[output truncated: showing %d of %d bytes; narrow the request (filters, page_size) for the rest]", cut, len(cut), len(text))
+}
+
+// cutUTF8 returns the longest prefix of s of at most n bytes that doesn't split a character.
+func cutUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
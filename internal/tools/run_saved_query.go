@@ -0,0 +1,211 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/config"
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// RunSavedQuery implements the "run_saved_query" tool.
+type RunSavedQuery struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	// Queries are the operator's saved queries, validated when the config file was loaded.
+	Queries []config.SavedQuery
+	// DefaultPageSize is the page_size of calls that don't pass one; 0 means defaultListPageSize.
+	DefaultPageSize int
+}
+
+// Definition implements Tool.
+func (t *RunSavedQuery) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"run_saved_query",
+		mcp.WithDescription("Run one of the operator's named visibility queries (e.g. stuck payments) and list the matching workflows. Without a name, list the saved queries with their parameters"),
+		mcp.WithString("name",
+			mcp.Description("Saved query to run; omit to list the saved queries"),
+			mcp.Enum(t.names()...),
+		),
+		mcp.WithObject("parameters",
+			mcp.Description("Values of the saved query's parameters by name, e.g. {\"since\": \"24h\"}; parameters with a default may be left out"),
+		),
+		mcp.WithNumber("page_size",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxListPageSize),
+			mcp.Description(fmt.Sprintf("Maximum number of workflows to return (default %d, maximum %d)", listPageSize(t.DefaultPageSize), maxListPageSize)),
+		),
+		withNamespaceArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *RunSavedQuery) CachePolicy() CachePolicy {
+	return CacheListing
+}
+
+// Instructions implements Instructed, so agents find the saved queries without listing them.
+func (t *RunSavedQuery) Instructions() string {
+	var b strings.Builder
+	b.WriteString("Saved queries (run with run_saved_query):\n")
+	for _, q := range t.Queries {
+		fmt.Fprintf(&b, "- %s: %s\n", q.Name, q.Description)
+	}
+	return b.String()
+}
+
+// Handle implements Tool.
+func (t *RunSavedQuery) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	name, err := stringArgument(args, "name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if name == "" {
+		return mcp.NewToolResultText(format.SavedQueriesText(t.describe())), nil
+	}
+	i := slices.IndexFunc(t.Queries, func(q config.SavedQuery) bool { return q.Name == name })
+	if i < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown saved query %q (use one of %s)", name, strings.Join(t.names(), ", "))), nil
+	}
+	saved := t.Queries[i]
+	pageSize, err := intArgument(args, "page_size", listPageSize(t.DefaultPageSize), 1, maxListPageSize)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	query, err := renderSavedQuery(saved, args["parameters"], time.Now())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Namespace: client.Namespace(),
+		PageSize:  int32(pageSize),
+		Query:     query,
+	})
+	if err != nil {
+		t.Logger.Error("Error running saved query", "name", name, "query", query, "error", err)
+		return rpcError(fmt.Sprintf("Failed to run saved query %q", name), err), nil
+	}
+
+	workflows := make([]format.WorkflowSummary, 0, len(resp.GetExecutions()))
+	for _, info := range resp.GetExecutions() {
+		workflows = append(workflows, format.NewWorkflowSummary(info))
+	}
+	more := len(resp.GetNextPageToken()) > 0
+	return mcp.NewToolResultText(format.WorkflowListText("matching", workflows, more) + format.SavedQueryRunText(name, query)), nil
+}
+
+// names returns the names of the saved queries.
+func (t *RunSavedQuery) names() []string {
+	names := make([]string, len(t.Queries))
+	for i, q := range t.Queries {
+		names[i] = q.Name
+	}
+	return names
+}
+
+// describe returns the saved queries as listed by calls without a name.
+func (t *RunSavedQuery) describe() []format.SavedQuery {
+	queries := make([]format.SavedQuery, len(t.Queries))
+	for i, q := range t.Queries {
+		queries[i] = format.SavedQuery{Name: q.Name, Description: q.Description, Query: q.Query}
+		for _, p := range q.Parameters {
+			queries[i].Parameters = append(queries[i].Parameters, format.SavedQueryParameter{
+				Name:        p.Name,
+				Type:        parameterType(p),
+				Description: p.Description,
+				Default:     p.Default,
+			})
+		}
+	}
+	return queries
+}
+
+// renderSavedQuery expands the template of q with the parameter values a call passed in raw,
+// falling back to their defaults. Values are checked against the parameter types and inserted as
+// visibility query literals, so they can't change the query's structure: strings are quoted,
+// integers inserted as numbers, and durations as the quoted time that long before now.
+func renderSavedQuery(q config.SavedQuery, raw interface{}, now time.Time) (string, error) {
+	values, ok := raw.(map[string]interface{})
+	if raw != nil && !ok {
+		return "", invalidArgument("parameters", "object", raw)
+	}
+	declared := make([]string, len(q.Parameters))
+	for i, p := range q.Parameters {
+		declared[i] = p.Name
+	}
+	for name := range values {
+		if !slices.Contains(declared, name) {
+			return "", fmt.Errorf("Invalid 'parameters': saved query %q has no parameter %q (parameters: %s)", q.Name, name, strings.Join(declared, ", "))
+		}
+	}
+
+	literals := make(map[string]string, len(q.Parameters))
+	for _, p := range q.Parameters {
+		args := map[string]interface{}{p.Name: values[p.Name]}
+		if args[p.Name] == "" {
+			args[p.Name] = nil
+		}
+		if args[p.Name] == nil && p.Default != "" {
+			args[p.Name] = p.Default
+		}
+		if args[p.Name] == nil {
+			return "", fmt.Errorf("Missing parameter %q of saved query %q", p.Name, q.Name)
+		}
+		switch parameterType(p) {
+		case "integer":
+			n, err := intArgument(args, p.Name, 0, math.MinInt, 0)
+			if err != nil {
+				return "", err
+			}
+			literals[p.Name] = strconv.Itoa(n)
+		case "duration":
+			d, err := durationArgument(args, p.Name, 0)
+			if err != nil {
+				return "", err
+			}
+			literals[p.Name] = queryString(now.Add(-d).UTC().Format(time.RFC3339))
+		default:
+			s, err := stringArgument(args, p.Name)
+			if err != nil {
+				return "", err
+			}
+			literals[p.Name] = queryString(s)
+		}
+	}
+
+	tmpl, err := q.Template()
+	if err != nil {
+		return "", fmt.Errorf("Invalid saved query %q: %v", q.Name, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, literals); err != nil {
+		return "", fmt.Errorf("Invalid saved query %q: %v", q.Name, err)
+	}
+	return b.String(), nil
+}
+
+// parameterType returns the type of p, defaulting to string.
+func parameterType(p config.QueryParameter) string {
+	if p.Type == "" {
+		return "string"
+	}
+	return p.Type
+}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// ScheduleRecentActions implements the "schedule_recent_actions" tool, linking the recent firings
+// a schedule remembers to the current outcome of the workflows they started.
+type ScheduleRecentActions struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Pool    *pool.Pool
+}
+
+// Definition implements Tool.
+func (t *ScheduleRecentActions) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"schedule_recent_actions",
+		mcp.WithDescription("Show whether a schedule's recent firings succeeded: for each recent action the schedule took (the server keeps the last 10), the scheduled and actual time, the workflow it started, and that workflow's current outcome. Flags a streak of consecutive failed firings. Works without advanced visibility; use list_schedule_runs for older runs"),
+		mcp.WithString("schedule_id",
+			mcp.Required(),
+			mcp.Description("ID of the schedule"),
+		),
+		mcp.WithBoolean("failed_only",
+			mcp.Description("List only the firings whose workflows failed, timed out, or were terminated"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// ServerRequirement implements RequiresServer.
+func (t *ScheduleRecentActions) ServerRequirement() ServerRequirement {
+	return ServerRequirement{
+		Capability:     (*workflowservice.GetSystemInfoResponse_Capabilities).GetSupportsSchedules,
+		CapabilityName: "schedules",
+	}
+}
+
+// Handle implements Tool.
+func (t *ScheduleRecentActions) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	scheduleID, err := stringArgument(args, "schedule_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if scheduleID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'schedule_id' parameter"), nil
+	}
+	failedOnly, err := boolArgument(args, "failed_only")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, err := client.DescribeSchedule(ctx, &workflowservice.DescribeScheduleRequest{Namespace: client.Namespace(), ScheduleId: scheduleID})
+	if err != nil {
+		t.Logger.Error("Error describing schedule", "schedule_id", scheduleID, "error", err)
+		if isNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Schedule %s not found in namespace %s", scheduleID, client.Namespace())), nil
+		}
+		return rpcError("Failed to describe schedule", err), nil
+	}
+	info := resp.GetInfo()
+	view := format.ScheduleActions{
+		ScheduleID:          scheduleID,
+		Paused:              resp.GetSchedule().GetState().GetPaused(),
+		Notes:               resp.GetSchedule().GetState().GetNotes(),
+		ActionCount:         info.GetActionCount(),
+		MissedCatchupWindow: info.GetMissedCatchupWindow(),
+		OverlapSkipped:      info.GetOverlapSkipped(),
+		Actions:             []format.ScheduleAction{},
+		FailedOnly:          failedOnly,
+	}
+	recent := info.GetRecentActions()
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].GetScheduleTime().AsTime().After(recent[j].GetScheduleTime().AsTime())
+	})
+
+	// The status the schedule records is only refreshed as it fires, so describe each workflow
+	actions := make([]format.ScheduleAction, len(recent))
+	statuses := make([]enumspb.WorkflowExecutionStatus, len(recent))
+	t.Pool.Run(ctx, len(recent), 0, func(ctx context.Context, i int) {
+		execution := recent[i].GetStartWorkflowResult()
+		actions[i] = format.ScheduleAction{
+			ScheduledTime: format.Time(recent[i].GetScheduleTime()),
+			ActualTime:    format.Time(recent[i].GetActualTime()),
+			WorkflowID:    execution.GetWorkflowId(),
+			RunID:         execution.GetRunId(),
+		}
+		described, err := client.DescribeWorkflowExecution(ctx, execution.GetWorkflowId(), execution.GetRunId())
+		switch {
+		case isNotFound(err):
+			actions[i].Error = "not found, likely deleted by retention"
+		case err != nil:
+			t.Logger.Warn("Unable to describe workflow started by schedule", "schedule_id", scheduleID, "workflow_id", execution.GetWorkflowId(), "error", err)
+			actions[i].Error = translateError("Failed to describe workflow", err).Error
+		default:
+			statuses[i] = described.GetWorkflowExecutionInfo().GetStatus()
+			actions[i].Status = format.WorkflowStatus(statuses[i])
+			actions[i].CloseTime = format.Time(described.GetWorkflowExecutionInfo().GetCloseTime())
+		}
+	})
+	if err := ctx.Err(); err != nil {
+		return rpcError("Failed to describe the workflows started by the schedule", err), nil
+	}
+
+	streak := true
+	for i, action := range actions {
+		failed := scheduleRunFailed(statuses[i])
+		if streak = streak && failed; streak {
+			view.ConsecutiveFailures++
+		}
+		if failedOnly && !failed {
+			view.Hidden++
+			continue
+		}
+		view.Actions = append(view.Actions, action)
+	}
+	return renderResult(outFormat, view, format.ScheduleActionsText)
+}
+
+// scheduleRunFailed reports whether a workflow started by a schedule closed without doing its
+// work: failed, timed out, or terminated.
+func scheduleRunFailed(status enumspb.WorkflowExecutionStatus) bool {
+	switch status {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT, enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultSearchMatches and maxSearchMatches bound the matching events returned by search_history.
+	defaultSearchMatches = 20
+	maxSearchMatches     = 200
+	// defaultSearchPages and maxSearchPages bound the history pages scanned by search_history.
+	defaultSearchPages = 20
+	maxSearchPages     = 500
+	// snippetContext is how many bytes of context are shown on each side of a match.
+	snippetContext = 60
+)
+
+// SearchHistory implements the "search_history" tool.
+type SearchHistory struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *SearchHistory) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"search_history",
+		mcp.WithDescription("Search a workflow execution's history for a string or regular expression, matching event attributes and decoded payloads. Returns the matching events with their IDs and a snippet around the match"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithString("pattern",
+			mcp.Required(),
+			mcp.Description("Text to search for, or a regular expression (Go syntax) when regex is true"),
+		),
+		mcp.WithBoolean("regex",
+			mcp.Description("Treat pattern as a regular expression (default false: plain substring)"),
+		),
+		mcp.WithBoolean("case_sensitive",
+			mcp.Description("Match case exactly (default false)"),
+		),
+		mcp.WithNumber("max_matches",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxSearchMatches),
+			mcp.Description(fmt.Sprintf("Maximum number of matching events to return (default %d, maximum %d)", defaultSearchMatches, maxSearchMatches)),
+		),
+		mcp.WithNumber("max_pages",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxSearchPages),
+			mcp.Description(fmt.Sprintf("Maximum number of history pages to scan (default %d, maximum %d)", defaultSearchPages, maxSearchPages)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *SearchHistory) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *SearchHistory) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	pattern, err := stringArgument(args, "pattern")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if pattern == "" {
+		return mcp.NewToolResultError("Missing or invalid 'pattern' parameter"), nil
+	}
+	isRegex, err := boolArgument(args, "regex")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	caseSensitive, err := boolArgument(args, "case_sensitive")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	expr := pattern
+	if !isRegex {
+		expr = regexp.QuoteMeta(pattern)
+	}
+	if !caseSensitive {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'pattern' parameter: %v", err)), nil
+	}
+	maxMatches, err := intArgument(args, "max_matches", defaultSearchMatches, 1, maxSearchMatches)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	maxPages, err := intArgument(args, "max_pages", defaultSearchPages, 1, maxSearchPages)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, errResult := describeExecution(ctx, client, t.Logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	info := resp.GetWorkflowExecutionInfo()
+	execution := pinnedExecution(resp)
+
+	result := format.HistorySearch{
+		WorkflowID: wfID,
+		RunID:      execution.GetRunId(),
+		Pattern:    pattern,
+		Matches:    []format.HistoryMatch{},
+	}
+	var (
+		token   []byte
+		partial string
+	)
+	for pages := 0; ; {
+		page, err := client.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+			Namespace:     client.Namespace(),
+			Execution:     execution,
+			NextPageToken: token,
+		})
+		if err != nil && pages > 0 && timedOut(ctx) {
+			t.Logger.Warn("Timed out reading workflow history; returning partial results", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+			partial = (&historyTimeout{err: err, pages: pages, read: result.Scanned, historyLength: info.GetHistoryLength()}).banner()
+			break
+		}
+		if err != nil {
+			t.Logger.Error("Error reading workflow history", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+			return rpcError("Failed to read workflow history", err), nil
+		}
+		pages++
+		for _, event := range page.GetHistory().GetEvents() {
+			result.Scanned++
+			text, err := format.CompactProtoJSON(event, t.Payloads)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to encode event %d: %v", event.GetEventId(), err)), nil
+			}
+			loc := re.FindStringIndex(text)
+			if loc == nil {
+				continue
+			}
+			if len(result.Matches) == maxMatches {
+				result.MatchLimit = true
+				break
+			}
+			result.Matches = append(result.Matches, format.HistoryMatch{
+				EventID:   event.GetEventId(),
+				EventType: event.GetEventType().String(),
+				EventTime: format.Time(event.GetEventTime()),
+				Snippet:   snippet(text, loc),
+			})
+		}
+		token = page.GetNextPageToken()
+		if result.MatchLimit || len(token) == 0 {
+			break
+		}
+		if pages >= maxPages {
+			result.PageLimit = true
+			break
+		}
+		reportProgress(ctx, float64(result.Scanned), float64(info.GetHistoryLength()), "scanned %d of %d events, %d matched", result.Scanned, info.GetHistoryLength(), len(result.Matches))
+	}
+	rendered, err := renderResult(outFormat, result, format.HistorySearchText)
+	return partialResult(partial, outFormat, rendered, err)
+}
+
+// snippet returns the text around the match at loc, with the match wrapped in ** and elided
+// context marked with "...".
+func snippet(text string, loc []int) string {
+	start := max(0, loc[0]-snippetContext)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	end := min(len(text), loc[1]+snippetContext)
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	out := text[start:loc[0]] + "**" + text[loc[0]:loc[1]] + "**" + text[loc[1]:end]
+	if start > 0 {
+		out = "..." + out
+	}
+	if end < len(text) {
+		out += "..."
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+	"github.com/wricardo/temporal-mcp/internal/version"
+)
+
+// ServerInfo implements the "server_info" tool.
+type ServerInfo struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Build   version.Info
+}
+
+// Definition implements Tool.
+func (t *ServerInfo) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"server_info",
+		mcp.WithDescription("Report the temporal-mcp build version and the version of the connected Temporal server, and whether it is Temporal Cloud or self-hosted"),
+		withNamespaceArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *ServerInfo) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *ServerInfo) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := callClient(t.Clients, req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	info := format.ServerInfo{
+		Version:           t.Build.Version,
+		Commit:            t.Build.Commit,
+		BuildDate:         t.Build.Date,
+		GoVersion:         t.Build.GoVersion,
+		TemporalAddress:   client.Address(),
+		TemporalNamespace: client.Namespace(),
+		Identity:          client.Identity(ctx),
+		Cloud:             temporal.IsCloud(client.Address()),
+	}
+	if count, last := client.Reconnects(); count > 0 {
+		info.Reconnects, info.LastReconnect = count, &last
+	}
+
+	// The Temporal server version is informational; report failures inline rather than failing the call
+	resp, err := client.GetSystemInfo(ctx)
+	if err != nil {
+		t.Logger.Error("Error fetching Temporal system info", "error", err)
+		info.TemporalServerError = err.Error()
+	} else {
+		info.TemporalServerVersion = resp.GetServerVersion()
+	}
+	return mcp.NewToolResultText(format.ServerInfoText(info)), nil
+}
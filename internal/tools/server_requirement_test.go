@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/audit"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+func TestServerRequirements(t *testing.T) {
+	modern := &workflowservice.GetSystemInfoResponse{
+		ServerVersion: "1.27.1",
+		Capabilities:  &workflowservice.GetSystemInfoResponse_Capabilities{SupportsSchedules: true},
+	}
+	ancient := &workflowservice.GetSystemInfoResponse{
+		ServerVersion: "1.16.0",
+		Capabilities:  &workflowservice.GetSystemInfoResponse_Capabilities{},
+	}
+	// unsupported is the reason each RequiresServer tool gives on the ancient server
+	unsupported := map[string]string{
+		"pause_activity":          "requires Temporal server >= 1.27; connected server is 1.16.0",
+		"unpause_activity":        "requires Temporal server >= 1.27; connected server is 1.16.0",
+		"reset_activity":          "requires Temporal server >= 1.27; connected server is 1.16.0",
+		"list_schedule_runs":      "requires the schedules capability, which the connected server (1.16.0) doesn't report",
+		"preview_schedule_spec":   "requires the schedules capability, which the connected server (1.16.0) doesn't report",
+		"schedule_recent_actions": "requires the schedules capability, which the connected server (1.16.0) doesn't report",
+	}
+
+	for _, tt := range []struct {
+		name      string
+		info      *workflowservice.GetSystemInfoResponse
+		supported bool
+	}{
+		{"modern", modern, true},
+		{"ancient", ancient, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{
+				namespace: "default",
+				getSystemInfo: func(context.Context) (*workflowservice.GetSystemInfoResponse, error) {
+					return tt.info, nil
+				},
+			}
+			clients := newFakeProvider(client)
+			capabilities := temporal.NewCapabilities(client, nil)
+			srv := server.NewMCPServer("test", "0.0.0")
+			registry := &Registry{
+				Server:       srv,
+				Logger:       testLogger,
+				Auditor:      audit.NopSink{},
+				Namespace:    "default",
+				Timeout:      10 * time.Second,
+				Clients:      clients,
+				Capabilities: capabilities,
+				Calls:        &InflightCalls{},
+			}
+			tools := allTools(clients, capabilities, newTestRenderer(t))
+			for _, tool := range tools {
+				registry.Register(tool)
+			}
+
+			for _, tool := range tools {
+				name := tool.Definition().Name
+				_, requires := tool.(RequiresServer)
+				if _, listed := unsupported[name]; listed != requires {
+					t.Errorf("%s: RequiresServer implemented = %v, but listed = %v", name, requires, listed)
+					continue
+				}
+				if !requires {
+					continue
+				}
+				registered := srv.GetTool(name)
+				note := "[unsupported on connected server (" + unsupported[name] + ")]"
+				if got := strings.Contains(registered.Tool.Description, note); got == tt.supported {
+					t.Errorf("%s: description has the unsupported note = %v, want %v: %s", name, got, !tt.supported, registered.Tool.Description)
+				}
+
+				before := len(client.called())
+				req := mcp.CallToolRequest{}
+				req.Params.Name = name
+				req.Params.Arguments = map[string]any{"workflow_id": "wf-1", "activity_id": "act-1", "schedule_id": "nightly", "cron": "0 9 * * *"}
+				result, err := registered.Handler(context.Background(), req)
+				if err != nil {
+					t.Fatalf("%s: unexpected error: %v", name, err)
+				}
+				text := resultText(result)
+				if tt.supported {
+					if strings.HasPrefix(text, "Unsupported on connected server: this tool") {
+						t.Errorf("%s: call short-circuited on a supporting server: %s", name, text)
+					}
+					continue
+				}
+				if want := "Unsupported on connected server: this tool " + unsupported[name]; !result.IsError || text != want {
+					t.Errorf("%s: result = %q, want the error %q", name, text, want)
+				}
+				// The capabilities are cached, so a short-circuited call makes no RPC at all
+				if calls := client.called()[before:]; len(calls) != 0 {
+					t.Errorf("%s: RPCs = %v, want none", name, calls)
+				}
+			}
+			if calls := strings.Count(strings.Join(client.called(), " "), "GetSystemInfo"); calls != 1 {
+				t.Errorf("GetSystemInfo called %d times, want once", calls)
+			}
+		})
+	}
+}
+
+func TestServerRequirementRecheckedAfterReconnect(t *testing.T) {
+	var version atomic.Value
+	version.Store("1.16.0")
+	var generation atomic.Uint64
+	client := &fakeClient{
+		namespace: "default",
+		getSystemInfo: func(context.Context) (*workflowservice.GetSystemInfoResponse, error) {
+			return &workflowservice.GetSystemInfoResponse{ServerVersion: version.Load().(string)}, nil
+		},
+	}
+	capabilities := temporal.NewCapabilities(client, generation.Load)
+	handler := withServerRequirement(capabilities, testLogger, ServerRequirement{MinVersion: temporal.Version{1, 27}},
+		func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("called"), nil
+		})
+	call := func() string {
+		t.Helper()
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resultText(result)
+	}
+
+	want := "Unsupported on connected server: this tool requires Temporal server >= 1.27; connected server is 1.16.0"
+	if got := call(); got != want {
+		t.Fatalf("result = %q, want %q", got, want)
+	}
+	// The server is upgraded while the connection is up: the cached capabilities still apply
+	version.Store("1.27.0")
+	if got := call(); got != want {
+		t.Errorf("result before the reconnect = %q, want %q", got, want)
+	}
+	// Losing the connection refetches them on the next call, without a restart
+	generation.Add(1)
+	if got := call(); got != "called" {
+		t.Errorf("result after the reconnect = %q, want the call to go through", got)
+	}
+}
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// InflightCalls tracks running tool calls so shutdown can stop accepting new ones and drain the rest.
+type InflightCalls struct {
+	mu      sync.Mutex
+	closing bool
+	count   int
+	done    chan struct{}
+}
+
+// begin registers a new call, returning false once shutdown has started.
+func (c *InflightCalls) begin() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closing {
+		return false
+	}
+	c.count++
+	return true
+}
+
+// end marks a call as finished.
+func (c *InflightCalls) end() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.count--
+	if c.closing && c.count == 0 && c.done != nil {
+		close(c.done)
+		c.done = nil
+	}
+}
+
+// Drain stops accepting new calls and waits up to timeout for in-flight calls to finish. It returns
+// how many calls were in flight when draining started and how many were still running at the end.
+func (c *InflightCalls) Drain(timeout time.Duration) (inflight, abandoned int) {
+	c.mu.Lock()
+	c.closing = true
+	inflight = c.count
+	if inflight == 0 {
+		c.mu.Unlock()
+		return 0, 0
+	}
+	done := make(chan struct{})
+	c.done = done
+	c.mu.Unlock()
+
+	select {
+	case <-done:
+		return inflight, 0
+	case <-time.After(timeout):
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return inflight, c.count
+	}
+}
+
+// withDrain wraps a tool handler so it is tracked for graceful shutdown and refused once shutdown begins.
+func withDrain(calls *InflightCalls, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !calls.begin() {
+			return mcp.NewToolResultError("temporal-mcp is shutting down and is not accepting new tool calls"), nil
+		}
+		defer calls.end()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// maxSignalMatches caps the executions signal_matching signals one by one; larger cohorts are
+// refused, as they belong to the server's batch operations.
+const maxSignalMatches = 100
+
+// SignalMatching implements the "signal_matching" tool, signaling each running workflow matched by
+// a visibility query through the shared fan-out pool.
+type SignalMatching struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Pool    *pool.Pool
+	// InputLimits bound the size of the signal input, which is sent once per workflow.
+	InputLimits InputLimits
+}
+
+// Definition implements Tool.
+func (t *SignalMatching) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"signal_matching",
+		mcp.WithDescription(fmt.Sprintf("Send a signal to every running workflow matching a visibility query, one at a time, reporting per workflow whether it was signaled. Matches that already closed are counted and skipped. Refuses queries matching more than %d executions: for larger cohorts use a server batch operation (temporal workflow signal --query with the Temporal CLI). Without confirm=true, returns the matched workflows and signals nothing", maxSignalMatches)),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Visibility query selecting the workflows, e.g. WorkflowType = 'OrderWorkflow' AND ExecutionStatus = 'Running'"),
+		),
+		mcp.WithString("signal_name",
+			mcp.Required(),
+			mcp.Description("Name of the signal to send"),
+		),
+		mcp.WithAny("input",
+			mcp.Description("Optional signal input, any JSON value, encoded with the client's data converter"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Description("Set to true to send the signal; otherwise only a preview of the matched workflows is returned"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// Mutating implements Mutating.
+func (t *SignalMatching) Mutating() {}
+
+// MaxTimeout implements LongRunning; signaling a hundred executions can take a while.
+func (t *SignalMatching) MaxTimeout() time.Duration {
+	return 5 * time.Minute
+}
+
+// Handle implements Tool.
+func (t *SignalMatching) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	query, err := stringArgument(args, "query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if query == "" {
+		return mcp.NewToolResultError("Missing or invalid 'query' parameter"), nil
+	}
+	signalName, err := stringArgument(args, "signal_name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if signalName == "" {
+		return mcp.NewToolResultError("Missing or invalid 'signal_name' parameter"), nil
+	}
+	input := args["input"]
+	confirm, err := boolArgument(args, "confirm")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// Checked up front, so oversize input is refused before the listing, not by each signal
+	var inputWarning string
+	if input != nil {
+		if inputWarning, err = t.InputLimits.check("input", input); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	view := format.SignalMatching{Query: query, SignalName: signalName, InputWarning: inputWarning, Targets: []format.SignalTarget{}}
+	if input != nil {
+		encoded, err := json.Marshal(input)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'input' parameter: %v", err)), nil
+		}
+		view.Input = string(encoded)
+	}
+
+	// One more than the cap tells a query over it apart from one right at it
+	var token []byte
+	for view.Matched <= maxSignalMatches {
+		resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     client.Namespace(),
+			PageSize:      int32(maxSignalMatches + 1 - view.Matched),
+			NextPageToken: token,
+			Query:         query,
+		})
+		if err != nil {
+			t.Logger.Error("Error listing workflows to signal", "query", query, "error", err)
+			return rpcError("Failed to list workflows", err), nil
+		}
+		for _, info := range resp.GetExecutions() {
+			view.Matched++
+			if info.GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+				view.Closed++
+				continue
+			}
+			view.Targets = append(view.Targets, format.SignalTarget{
+				WorkflowID: info.GetExecution().GetWorkflowId(),
+				RunID:      info.GetExecution().GetRunId(),
+				Type:       info.GetType().GetName(),
+			})
+		}
+		if token = resp.GetNextPageToken(); len(token) == 0 {
+			break
+		}
+	}
+	if view.Matched > maxSignalMatches {
+		return mcp.NewToolResultError(fmt.Sprintf("The query matches more than %d executions; narrow it (e.g. add ExecutionStatus = 'Running' or a WorkflowType filter), or signal the cohort with a server batch operation (temporal workflow signal --query with the Temporal CLI)", maxSignalMatches)), nil
+	}
+	if !confirm || len(view.Targets) == 0 {
+		return renderResult(outFormat, view, format.SignalMatchingText)
+	}
+	if temporal.DryRun(ctx) {
+		t.Logger.Info("Dry run: skipped signaling matching workflows", "query", query, "signal_name", signalName, "workflows", len(view.Targets))
+		view.DryRun = true
+		return renderResult(outFormat, view, format.SignalMatchingText)
+	}
+
+	total := len(view.Targets)
+	var done atomic.Int64
+	stats := t.Pool.Run(ctx, total, 0, func(ctx context.Context, i int) {
+		defer func() {
+			if n := done.Add(1); n < int64(total) {
+				reportProgress(ctx, float64(n), float64(total), "signaled %d of %d workflows", n, total)
+			}
+		}()
+		target := &view.Targets[i]
+		err := client.SignalWorkflow(ctx, target.WorkflowID, target.RunID, signalName, input)
+		switch {
+		case err == nil:
+			target.Outcome = "signaled"
+		case isNotFound(err):
+			// It closed between the listing and the signal, like the matches skipped up front
+			target.Outcome = "closed"
+		default:
+			t.Logger.Warn("Error signaling workflow", "workflow_id", target.WorkflowID, "run_id", target.RunID, "signal_name", signalName, "error", err)
+			target.Outcome, target.Error = "failed", translateError("Failed to signal workflow", err).Error
+		}
+	})
+	view.Performed = true
+	if stats.Processed < stats.Total {
+		view.Processed = stats.String()
+	}
+	t.Logger.Info("Signaled matching workflows", "query", query, "signal_name", signalName, "workflows", total, "processed", stats.Processed)
+	return renderResult(outFormat, view, format.SignalMatchingText)
+}
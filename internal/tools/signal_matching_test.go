@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/pool"
+)
+
+func TestSignalMatchingInputLimits(t *testing.T) {
+	var signaled atomic.Int32
+	client := &fakeClient{
+		listWorkflow: func(context.Context, *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+			return &workflowservice.ListWorkflowExecutionsResponse{Executions: []*workflowpb.WorkflowExecutionInfo{
+				execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+				execution("order-2", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+			}}, nil
+		},
+		signalWorkflow: func(context.Context, string, string, string, interface{}) error {
+			signaled.Add(1)
+			return nil
+		},
+	}
+	tool := &SignalMatching{
+		Clients:     newFakeProvider(client),
+		Logger:      testLogger,
+		Pool:        pool.New(2),
+		InputLimits: InputLimits{WarnBytes: 64, MaxBytes: 128},
+	}
+	call := func(input string) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Name = "signal_matching"
+		req.Params.Arguments = map[string]any{
+			"query":       "WorkflowType = 'OrderWorkflow'",
+			"signal_name": "resume",
+			"input":       map[string]any{"document": input},
+			"confirm":     true,
+		}
+		result, err := tool.Handle(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	// Oversize input is refused before any workflow is listed or signaled
+	result := call(strings.Repeat("x", 200))
+	text := resultText(result)
+	if !result.IsError || !strings.HasPrefix(text, "'input' is 215 B encoded, over the limit of 128 B") || !strings.Contains(text, "pass a reference to the data instead") {
+		t.Errorf("result = %q, want the size limit error", text)
+	}
+	if calls := client.called(); len(calls) != 0 {
+		t.Errorf("RPCs = %v, want none", calls)
+	}
+
+	// Input over the warning threshold is sent, with the warning in the result
+	result = call(strings.Repeat("x", 100))
+	text = resultText(result)
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", text)
+	}
+	assertContains(t, text, "Sent signal resume: 2 signaled", "WARNING: 'input' is 115 B encoded, over the warning threshold of 64 B")
+	if n := signaled.Load(); n != 2 {
+		t.Errorf("signals sent = %d, want 2", n)
+	}
+
+	// Small input has no warning
+	if text := resultText(call("ok")); strings.Contains(text, "WARNING") {
+		t.Errorf("small input got a warning:\n%s", text)
+	}
+}
@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// StopBatchOperation implements the "stop_batch_operation" tool.
+type StopBatchOperation struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *StopBatchOperation) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"stop_batch_operation",
+		mcp.WithDescription("Stop a running server batch operation (a signal, cancel, terminate, reset, or delete applied to the workflows matched by a query, e.g. one started with the Temporal CLI's --query flag). Executions it already processed are not rolled back: the response reports how many there were. Stopping a job that already finished changes nothing. Without confirm=true, returns a preview of the job and stops nothing"),
+		mcp.WithString("job_id",
+			mcp.Required(),
+			mcp.Description("Job ID of the batch operation"),
+		),
+		mcp.WithString("reason",
+			mcp.Required(),
+			mcp.Description("Why the job is stopped, recorded by the server"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Description("Set to true to stop the batch job; otherwise only a preview is returned"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// Mutating implements Mutating.
+func (t *StopBatchOperation) Mutating() {}
+
+// Handle implements Tool.
+func (t *StopBatchOperation) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	jobID, err := stringArgument(args, "job_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if jobID == "" {
+		return mcp.NewToolResultError("Missing or invalid 'job_id' parameter"), nil
+	}
+	reason, err := stringArgument(args, "reason")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if reason == "" {
+		return mcp.NewToolResultError("Missing or invalid 'reason' parameter"), nil
+	}
+	confirm, err := boolArgument(args, "confirm")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	before, err := t.describe(ctx, client, jobID)
+	if err != nil {
+		t.Logger.Error("Error describing batch operation", "job_id", jobID, "error", err)
+		if isNotFound(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Batch job %s not found in namespace %s", jobID, client.Namespace())), nil
+		}
+		return rpcError("Failed to describe batch operation", err), nil
+	}
+	view := format.BatchStop{JobID: jobID, Reason: reason, Job: format.NewBatchOperation(before)}
+	if before.GetState() != enumspb.BATCH_OPERATION_STATE_RUNNING {
+		view.Finished = true
+		return renderResult(outFormat, view, format.BatchStopText)
+	}
+	if !confirm {
+		return renderResult(outFormat, view, format.BatchStopText)
+	}
+	if temporal.DryRun(ctx) {
+		t.Logger.Info("Dry run: skipped stopping batch operation", "job_id", jobID)
+		view.DryRun = true
+		return renderResult(outFormat, view, format.BatchStopText)
+	}
+
+	_, stopErr := client.StopBatchOperation(ctx, &workflowservice.StopBatchOperationRequest{
+		Namespace: client.Namespace(),
+		JobId:     jobID,
+		Reason:    reason,
+		Identity:  client.Identity(ctx),
+	})
+	// Describe again, to report the progress made before the stop, or find the job finished
+	after, err := t.describe(ctx, client, jobID)
+	if stopErr != nil {
+		if err == nil && after.GetState() != enumspb.BATCH_OPERATION_STATE_RUNNING {
+			t.Logger.Info("Batch operation finished; nothing to stop", "job_id", jobID, "error", stopErr)
+			view.Job, view.Finished = format.NewBatchOperation(after), true
+			return renderResult(outFormat, view, format.BatchStopText)
+		}
+		t.Logger.Error("Error stopping batch operation", "job_id", jobID, "error", stopErr)
+		return rpcError("Failed to stop batch operation", stopErr), nil
+	}
+	view.Performed = true
+	view.Processed = view.Job.Completed + view.Job.Failed
+	if err != nil {
+		t.Logger.Warn("Unable to describe batch operation after stopping it", "job_id", jobID, "error", err)
+	} else {
+		now := format.NewBatchOperation(after)
+		view.After, view.Processed = &now, now.Completed+now.Failed
+	}
+	t.Logger.Info("Batch operation stopped", "job_id", jobID, "processed", view.Processed)
+	return renderResult(outFormat, view, format.BatchStopText)
+}
+
+// describe describes the batch job jobID.
+func (t *StopBatchOperation) describe(ctx context.Context, client temporal.Client, jobID string) (*workflowservice.DescribeBatchOperationResponse, error) {
+	return client.DescribeBatchOperation(ctx, &workflowservice.DescribeBatchOperationRequest{
+		Namespace: client.Namespace(),
+		JobId:     jobID,
+	})
+}
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// suggestPrefixLen is how many leading bytes of a missing workflow ID are used to look up
+	// similar IDs.
+	suggestPrefixLen = 20
+	// minSuggestPrefixLen skips the lookup for prefixes too short to be selective.
+	minSuggestPrefixLen = 4
+	// maxSuggestions caps how many similar IDs are offered.
+	maxSuggestions = 5
+	// suggestScanSize is how many executions the lookup inspects.
+	suggestScanSize = 50
+)
+
+// isNotFound reports whether err is a Temporal NotFound error.
+func isNotFound(err error) bool {
+	var notFound *serviceerror.NotFound
+	return errors.As(err, &notFound)
+}
+
+// describeFailed is the tool error for a failed DescribeWorkflowExecution of workflowID. When the
+// workflow wasn't found it suggests similar workflow IDs: LLMs often truncate or mangle IDs, so a
+// visibility query on the ID's prefix usually turns up the one they meant. It also explains the
+// namespace's retention, as workflows that "vanished" have usually aged out of it. Lookup failures
+// are ignored.
+func describeFailed(ctx context.Context, c temporal.Client, workflowID string, err error) *mcp.CallToolResult {
+	e := translateError("Failed to describe workflow", err)
+	if isNotFound(err) {
+		e.Suggestions = similarWorkflowIDs(ctx, c, workflowID)
+		e.Retention = retentionNote(ctx, c)
+	}
+	return errorResult(e)
+}
+
+// retentionNote explains when the namespace of c deletes closed executions, and whether they can
+// still be found in its archives, or returns "" when the namespace can't be described.
+func retentionNote(ctx context.Context, c temporal.Client) string {
+	ns, err := c.NamespaceSettings(ctx)
+	if err != nil || ns.GetConfig().GetWorkflowExecutionRetentionTtl() == nil {
+		return ""
+	}
+	config := ns.GetConfig()
+	retention := config.GetWorkflowExecutionRetentionTtl().AsDuration()
+	cutoff := time.Now().Add(-retention).UTC().Format(time.RFC3339)
+	note := fmt.Sprintf("namespace %s keeps closed executions for %s, so executions that closed before %s have been deleted", c.Namespace(), retentionText(retention), cutoff)
+	if config.GetHistoryArchivalState() == enumspb.ARCHIVAL_STATE_ENABLED {
+		note += fmt.Sprintf("; history archival is enabled (%s), so a deleted execution's history can still be read from the archive, e.g. temporal workflow show with the Temporal CLI", config.GetHistoryArchivalUri())
+	} else {
+		note += "; history archival is disabled, so their histories are gone"
+	}
+	if config.GetVisibilityArchivalState() == enumspb.ARCHIVAL_STATE_ENABLED {
+		note += ", and visibility archival is enabled, so they can be listed with temporal workflow list --archived"
+	} else {
+		note += ", and visibility archival is disabled, so they can't be listed"
+	}
+	return note
+}
+
+// retentionText renders a retention period in days when it is a whole number of them.
+func retentionText(retention time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case retention == day:
+		return "1 day"
+	case retention%day == 0:
+		return fmt.Sprintf("%d days", retention/day)
+	}
+	return retention.String()
+}
+
+// similarWorkflowIDs looks up workflow IDs sharing a prefix with workflowID, ordered by similarity.
+func similarWorkflowIDs(ctx context.Context, c temporal.Client, workflowID string) []string {
+	// The prefix is cut on a character boundary, so a multi-byte character isn't split
+	prefix := cutUTF8(workflowID, suggestPrefixLen)
+	if len(prefix) < minSuggestPrefixLen {
+		return nil
+	}
+
+	resp, err := c.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Namespace: c.Namespace(),
+		PageSize:  suggestScanSize,
+		Query:     "WorkflowId STARTS_WITH " + queryString(prefix),
+	})
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, info := range resp.GetExecutions() {
+		id := info.GetExecution().GetWorkflowId()
+		if id == workflowID || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		return editDistance(workflowID, ids[i]) < editDistance(workflowID, ids[j])
+	})
+	if len(ids) > maxSuggestions {
+		ids = ids[:maxSuggestions]
+	}
+	return ids
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,396 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultSummaryChars, minSummaryChars, and maxSummaryChars bound summarize_history's output size.
+	defaultSummaryChars = 4000
+	minSummaryChars     = 500
+	maxSummaryChars     = 100000
+	// summaryListLimit is the most entries kept per list before shrinking to fit the size limit.
+	summaryListLimit = 50
+)
+
+// SummarizeHistory implements the "summarize_history" tool.
+type SummarizeHistory struct {
+	Clients  temporal.Provider
+	Logger   *slog.Logger
+	Payloads *payload.Renderer
+}
+
+// Definition implements Tool.
+func (t *SummarizeHistory) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"summarize_history",
+		mcp.WithDescription("Condense a workflow execution's history into a bounded summary: start and input shape, activities per type (counts, retries, total duration; local activities apart), signals, child workflows, timers, version markers, other markers, failures, and the terminal event. Prefer this over reading full histories"),
+		mcp.WithString("workflow_id",
+			mcp.Required(),
+			mcp.Description("Workflow ID of the execution"),
+		),
+		mcp.WithString("run_id",
+			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
+		),
+		mcp.WithNumber("max_chars",
+			integer(),
+			mcp.Min(minSummaryChars),
+			mcp.Max(maxSummaryChars),
+			mcp.Description(fmt.Sprintf("Maximum size of the summary in characters (default %d, between %d and %d); lists are shortened to fit", defaultSummaryChars, minSummaryChars, maxSummaryChars)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *SummarizeHistory) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *SummarizeHistory) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	wfID, runID, err := executionArguments(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	maxChars, err := intArgument(args, "max_chars", defaultSummaryChars, minSummaryChars, maxSummaryChars)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, errResult := describeExecution(ctx, client, t.Logger, wfID, runID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	info := resp.GetWorkflowExecutionInfo()
+	execution := pinnedExecution(resp)
+
+	s := newHistorySummarizer(t.Payloads)
+	s.summary.WorkflowID = wfID
+	s.summary.RunID = execution.GetRunId()
+	s.summary.WorkflowType = info.GetType().GetName()
+	err = forEachEvent(ctx, client, execution, info.GetHistoryLength(), func(event *historypb.HistoryEvent) bool {
+		s.add(event)
+		return true
+	})
+	partial := partialHistory(err)
+	if partial != "" {
+		t.Logger.Warn("Timed out reading workflow history; returning partial results", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+	} else if err != nil {
+		t.Logger.Error("Error reading workflow history", "workflow_id", wfID, "run_id", execution.GetRunId(), "error", err)
+		return rpcError("Failed to read workflow history", err), nil
+	}
+	summary := s.finish()
+
+	// Shrink the lists until the output fits; the order of each list decides what is kept
+	render := func() (string, error) {
+		if outFormat == "json" {
+			return format.JSON(summary)
+		}
+		return format.HistorySummaryText(summary), nil
+	}
+	summary.Truncate(summaryListLimit)
+	out, err := render()
+	for limit := summaryListLimit / 2; err == nil && len(out) > maxChars && limit > 0; limit /= 2 {
+		summary.Truncate(limit)
+		out, err = render()
+	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to encode result: %v", err)), nil
+	}
+	if len(out) > maxChars && outFormat != "json" {
+		out = out[:maxChars] + "\n...(truncated)"
+	}
+	return partialResult(partial, outFormat, mcp.NewToolResultText(out), nil)
+}
+
+// historySummarizer accumulates a HistorySummary over the events of a history, in order.
+type historySummarizer struct {
+	payloads *payload.Renderer
+	summary  format.HistorySummary
+
+	activities map[string]*format.ActivityTypeSummary
+	durations  map[string]time.Duration
+	// scheduled maps activity scheduled event IDs to their type; started to their started event.
+	scheduled map[int64]string
+	started   map[int64]*historypb.HistoryEvent
+	signals   map[string]*format.SignalSummary
+	// children maps child initiated event IDs to their index in summary.Children.
+	children map[int64]int
+	markers  markerDecoder
+	// markerCounts counts markers other than local activities and versions by name; bounded marks
+	// the activity types whose durations are upper bounds.
+	markerCounts map[string]int
+	bounded      map[string]bool
+}
+
+func newHistorySummarizer(payloads *payload.Renderer) *historySummarizer {
+	return &historySummarizer{
+		payloads:     payloads,
+		activities:   make(map[string]*format.ActivityTypeSummary),
+		durations:    make(map[string]time.Duration),
+		scheduled:    make(map[int64]string),
+		started:      make(map[int64]*historypb.HistoryEvent),
+		signals:      make(map[string]*format.SignalSummary),
+		children:     make(map[int64]int),
+		markers:      markerDecoder{payloads: payloads},
+		markerCounts: make(map[string]int),
+		bounded:      make(map[string]bool),
+	}
+}
+
+func (s *historySummarizer) add(event *historypb.HistoryEvent) {
+	s.summary.Events++
+	s.markers.observe(event)
+	eventTime := format.Time(event.GetEventTime())
+	switch {
+	case event.GetWorkflowExecutionStartedEventAttributes() != nil:
+		attrs := event.GetWorkflowExecutionStartedEventAttributes()
+		s.summary.StartTime = eventTime
+		s.summary.TaskQueue = attrs.GetTaskQueue().GetName()
+		s.addInput(attrs.GetInput())
+
+	case event.GetActivityTaskScheduledEventAttributes() != nil:
+		activityType := event.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName()
+		s.scheduled[event.GetEventId()] = activityType
+		s.activity(activityType).Scheduled++
+	case event.GetActivityTaskStartedEventAttributes() != nil:
+		s.started[event.GetActivityTaskStartedEventAttributes().GetScheduledEventId()] = event
+	case event.GetActivityTaskCompletedEventAttributes() != nil:
+		s.closeActivity(event, event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId()).Completed++
+	case event.GetActivityTaskFailedEventAttributes() != nil:
+		attrs := event.GetActivityTaskFailedEventAttributes()
+		a := s.closeActivity(event, attrs.GetScheduledEventId())
+		a.Failed++
+		s.addFailure(event, a.Type+": "+format.Failure(attrs.GetFailure()))
+	case event.GetActivityTaskTimedOutEventAttributes() != nil:
+		attrs := event.GetActivityTaskTimedOutEventAttributes()
+		a := s.closeActivity(event, attrs.GetScheduledEventId())
+		a.TimedOut++
+		s.addFailure(event, a.Type+": "+format.Failure(attrs.GetFailure()))
+	case event.GetActivityTaskCanceledEventAttributes() != nil:
+		s.closeActivity(event, event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId()).Canceled++
+
+	case event.GetWorkflowExecutionSignaledEventAttributes() != nil:
+		name := event.GetWorkflowExecutionSignaledEventAttributes().GetSignalName()
+		sig, ok := s.signals[name]
+		if !ok {
+			sig = &format.SignalSummary{Name: name, First: eventTime}
+			s.signals[name] = sig
+		}
+		sig.Count++
+		sig.Last = eventTime
+
+	case event.GetStartChildWorkflowExecutionInitiatedEventAttributes() != nil:
+		attrs := event.GetStartChildWorkflowExecutionInitiatedEventAttributes()
+		s.children[event.GetEventId()] = len(s.summary.Children)
+		s.summary.Children = append(s.summary.Children, format.ChildSummary{
+			WorkflowID: attrs.GetWorkflowId(),
+			Type:       attrs.GetWorkflowType().GetName(),
+			Outcome:    "Initiated",
+		})
+	case event.GetStartChildWorkflowExecutionFailedEventAttributes() != nil:
+		attrs := event.GetStartChildWorkflowExecutionFailedEventAttributes()
+		s.childOutcome(attrs.GetInitiatedEventId(), "FailedToStart ("+attrs.GetCause().String()+")")
+	case event.GetChildWorkflowExecutionStartedEventAttributes() != nil:
+		s.childOutcome(event.GetChildWorkflowExecutionStartedEventAttributes().GetInitiatedEventId(), "Running")
+	case event.GetChildWorkflowExecutionCompletedEventAttributes() != nil:
+		s.childOutcome(event.GetChildWorkflowExecutionCompletedEventAttributes().GetInitiatedEventId(), "Completed")
+	case event.GetChildWorkflowExecutionFailedEventAttributes() != nil:
+		attrs := event.GetChildWorkflowExecutionFailedEventAttributes()
+		s.childOutcome(attrs.GetInitiatedEventId(), "Failed")
+		s.addFailure(event, attrs.GetWorkflowExecution().GetWorkflowId()+": "+format.Failure(attrs.GetFailure()))
+	case event.GetChildWorkflowExecutionCanceledEventAttributes() != nil:
+		s.childOutcome(event.GetChildWorkflowExecutionCanceledEventAttributes().GetInitiatedEventId(), "Canceled")
+	case event.GetChildWorkflowExecutionTimedOutEventAttributes() != nil:
+		s.childOutcome(event.GetChildWorkflowExecutionTimedOutEventAttributes().GetInitiatedEventId(), "TimedOut")
+	case event.GetChildWorkflowExecutionTerminatedEventAttributes() != nil:
+		s.childOutcome(event.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventId(), "Terminated")
+
+	case event.GetTimerStartedEventAttributes() != nil:
+		s.summary.Timers++
+	case event.GetMarkerRecordedEventAttributes() != nil:
+		s.addMarker(s.markers.decode(event))
+	case event.GetWorkflowTaskFailedEventAttributes() != nil:
+		attrs := event.GetWorkflowTaskFailedEventAttributes()
+		s.addFailure(event, attrs.GetCause().String()+": "+format.Failure(attrs.GetFailure()))
+
+	case event.GetWorkflowExecutionCompletedEventAttributes() != nil:
+		s.close(event, "Completed")
+	case event.GetWorkflowExecutionFailedEventAttributes() != nil:
+		message := format.Failure(event.GetWorkflowExecutionFailedEventAttributes().GetFailure())
+		s.addFailure(event, message)
+		s.close(event, "Failed: "+message)
+	case event.GetWorkflowExecutionCanceledEventAttributes() != nil:
+		s.close(event, "Canceled")
+	case event.GetWorkflowExecutionTerminatedEventAttributes() != nil:
+		s.close(event, "Terminated: "+event.GetWorkflowExecutionTerminatedEventAttributes().GetReason())
+	case event.GetWorkflowExecutionTimedOutEventAttributes() != nil:
+		s.close(event, "TimedOut")
+	case event.GetWorkflowExecutionContinuedAsNewEventAttributes() != nil:
+		s.close(event, "ContinuedAsNew as run "+event.GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId())
+	}
+}
+
+// addInput records the shape of the workflow input: payload count, size, and top-level keys.
+func (s *historySummarizer) addInput(input *commonpb.Payloads) {
+	keys := make(map[string]bool)
+	for _, p := range input.GetPayloads() {
+		s.summary.InputPayloads++
+		s.summary.InputBytes += len(p.GetData())
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(s.payloads.Render(p)), &object); err == nil {
+			for key := range object {
+				keys[key] = true
+			}
+		}
+	}
+	for key := range keys {
+		s.summary.InputKeys = append(s.summary.InputKeys, key)
+	}
+	sort.Strings(s.summary.InputKeys)
+}
+
+func (s *historySummarizer) activity(activityType string) *format.ActivityTypeSummary {
+	a, ok := s.activities[activityType]
+	if !ok {
+		a = &format.ActivityTypeSummary{Type: activityType}
+		s.activities[activityType] = a
+	}
+	return a
+}
+
+// closeActivity accounts for the retries and duration of a closed activity and returns its type's
+// summary.
+func (s *historySummarizer) closeActivity(event *historypb.HistoryEvent, scheduledID int64) *format.ActivityTypeSummary {
+	a := s.activity(s.scheduled[scheduledID])
+	if started, ok := s.started[scheduledID]; ok {
+		a.Retries += max(0, int(started.GetActivityTaskStartedEventAttributes().GetAttempt())-1)
+		s.durations[a.Type] += event.GetEventTime().AsTime().Sub(started.GetEventTime().AsTime())
+		delete(s.started, scheduledID)
+	}
+	delete(s.scheduled, scheduledID)
+	return a
+}
+
+// addMarker accounts for a marker: local activities are summarized with the activities, under their
+// own row per type.
+func (s *historySummarizer) addMarker(m format.Marker) {
+	switch m.Kind {
+	case "local_activity":
+		key := "local:" + m.ActivityType
+		a, ok := s.activities[key]
+		if !ok {
+			a = &format.ActivityTypeSummary{Type: m.ActivityType, Local: true}
+			s.activities[key] = a
+		}
+		a.Scheduled++
+		if m.Failure != "" {
+			a.Failed++
+		} else {
+			a.Completed++
+		}
+		a.Retries += max(0, int(m.Attempt)-1)
+		if d, err := time.ParseDuration(m.Duration); err == nil {
+			s.durations[key] += d
+			s.bounded[key] = s.bounded[key] || m.DurationNote == durationAtMost
+		}
+	case "version":
+		s.summary.Versions = append(s.summary.Versions, format.VersionSummary{EventID: m.EventID, ChangeID: m.ChangeID, Version: m.Version})
+	default:
+		s.markerCounts[m.Name]++
+	}
+}
+
+func (s *historySummarizer) childOutcome(initiatedID int64, outcome string) {
+	if i, ok := s.children[initiatedID]; ok {
+		s.summary.Children[i].Outcome = outcome
+	}
+}
+
+func (s *historySummarizer) addFailure(event *historypb.HistoryEvent, message string) {
+	s.summary.Failures = append(s.summary.Failures, format.FailureSummary{
+		EventID:   event.GetEventId(),
+		EventType: event.GetEventType().String(),
+		Message:   message,
+	})
+}
+
+func (s *historySummarizer) close(event *historypb.HistoryEvent, outcome string) {
+	s.summary.Close = outcome
+	s.summary.CloseTime = format.Time(event.GetEventTime())
+}
+
+// finish returns the summary with its lists in a deterministic order: activity types by scheduled
+// count (most first), signals by first receipt, markers by name, and children, failures, and
+// versions in history order.
+func (s *historySummarizer) finish() format.HistorySummary {
+	summary := s.summary
+	summary.Activities = []format.ActivityTypeSummary{}
+	for activityType, a := range s.activities {
+		a.TotalDuration = s.durations[activityType].Round(time.Millisecond).String()
+		if s.bounded[activityType] {
+			a.TotalDuration = "at most " + a.TotalDuration
+		}
+		summary.Activities = append(summary.Activities, *a)
+	}
+	sort.Slice(summary.Activities, func(i, j int) bool {
+		a, b := summary.Activities[i], summary.Activities[j]
+		if a.Scheduled != b.Scheduled {
+			return a.Scheduled > b.Scheduled
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return !a.Local
+	})
+	summary.Signals = []format.SignalSummary{}
+	for _, sig := range s.signals {
+		summary.Signals = append(summary.Signals, *sig)
+	}
+	sort.Slice(summary.Signals, func(i, j int) bool {
+		a, b := summary.Signals[i], summary.Signals[j]
+		if a.First != b.First {
+			return a.First < b.First
+		}
+		return a.Name < b.Name
+	})
+	if summary.Children == nil {
+		summary.Children = []format.ChildSummary{}
+	}
+	if summary.Failures == nil {
+		summary.Failures = []format.FailureSummary{}
+	}
+	if summary.Versions == nil {
+		summary.Versions = []format.VersionSummary{}
+	}
+	summary.Markers = []format.MarkerSummary{}
+	for name, count := range s.markerCounts {
+		summary.Markers = append(summary.Markers, format.MarkerSummary{Name: name, Count: count})
+	}
+	sort.Slice(summary.Markers, func(i, j int) bool { return summary.Markers[i].Name < summary.Markers[j].Name })
+	return summary
+}
@@ -0,0 +1,264 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultLatencyWindow is the range of start times sampled when window isn't given.
+	defaultLatencyWindow = time.Hour
+	// defaultLatencySample and maxLatencySample bound how many executions task_latency_report samples.
+	defaultLatencySample = 20
+	maxLatencySample     = 200
+)
+
+// TaskLatencyReport implements the "task_latency_report" tool, reading the histories of recent
+// executions through the shared fan-out pool to measure how long tasks wait for a worker.
+type TaskLatencyReport struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	Pool    *pool.Pool
+	// MaxItems caps how many histories a call reads; the rest are reported as skipped.
+	MaxItems int
+}
+
+// Definition implements Tool.
+func (t *TaskLatencyReport) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"task_latency_report",
+		mcp.WithDescription("Report the schedule-to-start latency (p50, p95, max) of workflow tasks and activities on a task queue or workflow type, by sampling recent executions and pairing scheduled and started events in their histories. High latency means tasks wait for workers: measure before and after scaling them"),
+		mcp.WithString("task_queue",
+			mcp.Description("Task queue to measure; only tasks scheduled on it are counted. Required unless workflow_type is given"),
+		),
+		mcp.WithString("workflow_type",
+			mcp.Description("Workflow type whose executions to sample. Required unless task_queue is given"),
+		),
+		mcp.WithString("window",
+			mcp.Description(fmt.Sprintf("Sample executions started within this Go duration, e.g. 30m or 6h (default %s)", defaultLatencyWindow)),
+		),
+		mcp.WithNumber("sample_size",
+			integer(),
+			mcp.Min(1),
+			mcp.Max(maxLatencySample),
+			mcp.Description(fmt.Sprintf("How many recent executions to sample (default %d, maximum %d)", defaultLatencySample, maxLatencySample)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *TaskLatencyReport) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// MaxTimeout implements LongRunning; reading hundreds of histories can take a while.
+func (t *TaskLatencyReport) MaxTimeout() time.Duration {
+	return 10 * time.Minute
+}
+
+// Handle implements Tool.
+func (t *TaskLatencyReport) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	taskQueue, err := stringArgument(args, "task_queue")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	workflowType, err := stringArgument(args, "workflow_type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if taskQueue == "" && workflowType == "" {
+		return mcp.NewToolResultError("Missing 'task_queue' or 'workflow_type' parameter: give at least one"), nil
+	}
+	window, err := durationArgument(args, "window", defaultLatencyWindow)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sampleSize, err := intArgument(args, "sample_size", defaultLatencySample, 1, maxLatencySample)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	filters := []string{"StartTime > " + queryString(time.Now().Add(-window).UTC().Format(time.RFC3339))}
+	if taskQueue != "" {
+		filters = append(filters, "TaskQueue = "+queryString(taskQueue))
+	}
+	if workflowType != "" {
+		filters = append(filters, "WorkflowType = "+queryString(workflowType))
+	}
+	query := strings.Join(filters, " AND ")
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Namespace: client.Namespace(),
+		PageSize:  int32(sampleSize),
+		Query:     query,
+	})
+	if err != nil {
+		t.Logger.Error("Error listing workflows", "query", query, "error", err)
+		return rpcError("Failed to list workflows", err), nil
+	}
+	executions := resp.GetExecutions()
+	if len(executions) > sampleSize {
+		executions = executions[:sampleSize]
+	}
+
+	report := format.TaskLatencyReport{TaskQueue: taskQueue, WorkflowType: workflowType, Window: "in the last " + window.String(), Sampled: len(executions)}
+	var (
+		mu                        sync.Mutex
+		done                      atomic.Int64
+		workflowTasks, activities []time.Duration
+		oldestWaiting             time.Duration
+		total                     = len(executions)
+	)
+	if t.MaxItems > 0 {
+		total = min(total, t.MaxItems)
+	}
+	stats := t.Pool.Run(ctx, len(executions), t.MaxItems, func(ctx context.Context, i int) {
+		// The last item isn't reported: the response itself signals completion
+		defer func() {
+			if n := done.Add(1); n < int64(total) {
+				reportProgress(ctx, float64(n), float64(total), "read %d of %d histories", n, total)
+			}
+		}()
+		info := executions[i]
+		sample, err := readTaskLatencies(ctx, client, info.GetExecution(), taskQueue, info.GetStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			t.Logger.Warn("Error reading workflow history", "workflow_id", info.GetExecution().GetWorkflowId(), "run_id", info.GetExecution().GetRunId(), "error", err)
+			report.Unreadable++
+			return
+		}
+		workflowTasks = append(workflowTasks, sample.workflowTasks...)
+		activities = append(activities, sample.activities...)
+		report.RetriedActivities += sample.retried
+		for _, waited := range sample.waiting {
+			report.WaitingWorkflowTasks++
+			oldestWaiting = max(oldestWaiting, waited)
+		}
+	})
+	report.Coverage = stats.String()
+	report.WorkflowTasks = latencySummary(workflowTasks)
+	report.Activities = latencySummary(activities)
+	if report.WaitingWorkflowTasks > 0 {
+		report.OldestWaiting = oldestWaiting.Round(time.Millisecond).String()
+	}
+	result, err := renderResult(outFormat, report, format.TaskLatencyReportText)
+	return partialResult(partialFanout(ctx, stats, "histories"), outFormat, result, err)
+}
+
+// taskLatencies are the schedule-to-start latencies found in one history.
+type taskLatencies struct {
+	workflowTasks, activities []time.Duration
+	// retried counts activity starts of later attempts, whose latency includes the retry backoff.
+	retried int
+	// waiting holds how long each workflow task scheduled but not yet started has waited.
+	waiting []time.Duration
+}
+
+// readTaskLatencies pairs the scheduled and started events of workflow tasks and activities in the
+// history of execution, keeping tasks scheduled on taskQueue (any queue when empty). Activities
+// only get a started event once they close, so running ones can't be told from waiting ones and
+// only workflow tasks of running executions are reported as waiting.
+func readTaskLatencies(ctx context.Context, client temporal.Client, execution *commonpb.WorkflowExecution, taskQueue string, running bool) (taskLatencies, error) {
+	var (
+		sample taskLatencies
+		// Scheduled events by ID, which the started events refer to
+		workflowTasks = make(map[int64]time.Time)
+		activities    = make(map[int64]time.Time)
+	)
+	err := forEachEvent(ctx, client, execution, 0, func(event *historypb.HistoryEvent) bool {
+		at := event.GetEventTime().AsTime()
+		switch {
+		case event.GetWorkflowTaskScheduledEventAttributes() != nil:
+			if onTaskQueue(event.GetWorkflowTaskScheduledEventAttributes().GetTaskQueue(), taskQueue) {
+				workflowTasks[event.GetEventId()] = at
+			}
+		case event.GetWorkflowTaskStartedEventAttributes() != nil:
+			id := event.GetWorkflowTaskStartedEventAttributes().GetScheduledEventId()
+			if scheduled, ok := workflowTasks[id]; ok {
+				sample.workflowTasks = append(sample.workflowTasks, at.Sub(scheduled))
+				delete(workflowTasks, id)
+			}
+		case event.GetWorkflowTaskTimedOutEventAttributes() != nil:
+			delete(workflowTasks, event.GetWorkflowTaskTimedOutEventAttributes().GetScheduledEventId())
+		case event.GetActivityTaskScheduledEventAttributes() != nil:
+			if onTaskQueue(event.GetActivityTaskScheduledEventAttributes().GetTaskQueue(), taskQueue) {
+				activities[event.GetEventId()] = at
+			}
+		case event.GetActivityTaskStartedEventAttributes() != nil:
+			attrs := event.GetActivityTaskStartedEventAttributes()
+			scheduled, ok := activities[attrs.GetScheduledEventId()]
+			switch {
+			case !ok:
+			case attrs.GetAttempt() > 1:
+				sample.retried++
+			default:
+				sample.activities = append(sample.activities, at.Sub(scheduled))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return taskLatencies{}, err
+	}
+	if running {
+		for _, scheduled := range workflowTasks {
+			sample.waiting = append(sample.waiting, time.Since(scheduled))
+		}
+	}
+	return sample, nil
+}
+
+// onTaskQueue reports whether a task was scheduled on the task queue named name, directly or on
+// one of its workers' sticky queues. An empty name matches every queue.
+func onTaskQueue(queue *taskqueuepb.TaskQueue, name string) bool {
+	return name == "" || queue.GetName() == name || queue.GetNormalName() == name
+}
+
+// latencySummary computes the nearest-rank percentiles of latencies.
+func latencySummary(latencies []time.Duration) format.LatencySummary {
+	if len(latencies) == 0 {
+		return format.LatencySummary{}
+	}
+	slices.Sort(latencies)
+	percentile := func(p float64) string {
+		rank := int(math.Ceil(p*float64(len(latencies)))) - 1
+		return latencies[max(rank, 0)].Round(time.Millisecond).String()
+	}
+	return format.LatencySummary{
+		Samples: len(latencies),
+		P50:     percentile(0.50),
+		P95:     percentile(0.95),
+		Max:     latencies[len(latencies)-1].Round(time.Millisecond).String(),
+	}
+}
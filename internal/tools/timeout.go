@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"google.golang.org/grpc/codes"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// timeoutArgument parses the optional per-call timeout_seconds argument.
+func timeoutArgument(args map[string]interface{}) (time.Duration, error) {
+	seconds, ok, err := numberArgument(args, "timeout_seconds", "number")
+	if err != nil || !ok {
+		return 0, err
+	}
+	if seconds <= 0 {
+		return 0, fmt.Errorf("invalid value for 'timeout_seconds': must be greater than zero")
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// withTimeout wraps a tool handler so its Temporal RPCs run under a deadline: the server-wide
+// default, or the per-call timeout_seconds argument up to maxTimeout. A handler that hits the
+// deadline may still return what it gathered as a successful result marked with partialResult;
+// any other result is replaced by a timeout error. It also turns client cancellation (the MCP request
+// context being cancelled) into a prompt "cancelled by client" result.
+func withTimeout(defaultTimeout, maxTimeout time.Duration, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		timeout, err := timeoutArgument(req.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if timeout == 0 {
+			timeout = defaultTimeout
+		}
+		if timeout > maxTimeout {
+			return mcp.NewToolResultError(fmt.Sprintf("timeout_seconds exceeds the maximum of %s for this tool", maxTimeout)), nil
+		}
+
+		trackedCtx, tracker := temporal.WithCallTracker(ctx)
+		callCtx, cancel := context.WithTimeout(trackedCtx, timeout)
+		defer cancel()
+
+		result, err := handler(callCtx, req)
+		switch {
+		case errors.Is(ctx.Err(), context.Canceled):
+			return mcp.NewToolResultError(fmt.Sprintf("Cancelled by client %s", tracker.Interruption())), nil
+		case errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil && !isPartial(result):
+			e := format.ToolError{Error: fmt.Sprintf("Temporal call timed out after %s %s", timeout, tracker.Interruption())}
+			withCode(&e, codes.DeadlineExceeded)
+			return errorResult(e), nil
+		}
+		return result, err
+	}
+}
+
+// timedOut reports whether the call's deadline passed, so a handler whose RPC failed can return
+// what it gathered so far instead of failing.
+func timedOut(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// partialResult marks a result cut short by the call's deadline with banner, e.g. "partial
+// results: timed out after 3 of ~10 pages", and with "partial": true in its _meta, so withTimeout
+// returns it instead of a timeout error and it isn't cached. Text results start with the banner;
+// JSON results keep their document intact and carry the banner as a second content block. Results
+// that are already errors, and calls with no banner, pass through unchanged.
+func partialResult(banner, outFormat string, result *mcp.CallToolResult, err error) (*mcp.CallToolResult, error) {
+	if banner == "" || err != nil || result == nil || result.IsError {
+		return result, err
+	}
+	result.Meta = mcp.NewMetaFromMap(map[string]any{"partial": true})
+	banner = fmt.Sprintf("[%s; pass a larger timeout_seconds for the rest]", banner)
+	if outFormat == "json" {
+		result.Content = append(result.Content, mcp.NewTextContent(banner))
+		return result, nil
+	}
+	result.Content = []mcp.Content{mcp.NewTextContent(banner + "\n" + resultText(result))}
+	return result, nil
+}
+
+// partialFanout is the partialResult banner of a fan-out through the shared pool that the call's
+// deadline cut short, e.g. "partial results: timed out after 40 of 80 histories", or "" when the
+// deadline didn't pass. Items in flight at the deadline count as processed but usually failed.
+func partialFanout(ctx context.Context, stats pool.Stats, items string) string {
+	if !timedOut(ctx) {
+		return ""
+	}
+	return fmt.Sprintf("partial results: timed out after %d of %d %s", stats.Processed, stats.Total-stats.Capped, items)
+}
+
+// isPartial reports whether result was marked by partialResult.
+func isPartial(result *mcp.CallToolResult) bool {
+	if result == nil || result.Meta == nil {
+		return false
+	}
+	partial, _ := result.Meta.AdditionalFields["partial"].(bool)
+	return partial
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+func TestTimeoutCancelledByClient(t *testing.T) {
+	// The first page comes back at once; the second hangs until its context ends
+	secondPage := make(chan struct{})
+	client := &fakeClient{
+		listOpenWorkflow: func(ctx context.Context, req *workflowservice.ListOpenWorkflowExecutionsRequest) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
+			if len(req.GetNextPageToken()) == 0 {
+				return &workflowservice.ListOpenWorkflowExecutionsResponse{
+					Executions: []*workflowpb.WorkflowExecutionInfo{
+						execution("order-1", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+						execution("refund-1", "RefundWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+						execution("order-2", "OrderWorkflow", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+					},
+					NextPageToken: []byte("page-2"),
+				}, nil
+			}
+			close(secondPage)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	tool := &ListWorkflows{Clients: newFakeProvider(client), Logger: testLogger}
+	// A deadline far beyond the test's, so only the client's cancellation can end the call
+	handler := withTimeout(time.Minute, time.Hour, tool.Handle)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-secondPage
+		cancel()
+	}()
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "list_workflows"
+	// id_regex keeps the scan paging until page_size workflows match
+	req.Params.Arguments = map[string]any{"status": "running", "id_regex": "^order-", "page_size": 10.0}
+	start := time.Now()
+	result, err := handler(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancelled call took %s to return", elapsed)
+	}
+
+	text := resultText(result)
+	if !result.IsError || !strings.HasPrefix(text, "Cancelled by client while waiting on ") {
+		t.Fatalf("result = %q, want a cancelled-by-client error", text)
+	}
+	// The result says how far the scan got before the client gave up
+	if want := "(scanned 3 workflows, 2 matched before interruption)"; !strings.HasSuffix(text, want) {
+		t.Errorf("result = %q, want it to end with the progress %q", text, want)
+	}
+	if calls := len(client.called()); calls != 2 {
+		t.Errorf("RPCs = %d, want 2: both pages requested", calls)
+	}
+}
@@ -0,0 +1,83 @@
+// Package tools implements the MCP tools exposed by the server, and the registry that wraps every
+// tool handler in the shared middleware chain (logging, metrics, auditing, caching, timeouts).
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// Tool is an MCP tool: its definition as advertised to clients, and the handler for its calls.
+type Tool interface {
+	Definition() mcp.Tool
+	Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error)
+}
+
+// CachePolicy controls whether a tool's results may be served from the response cache.
+type CachePolicy int
+
+const (
+	// CacheNever disables caching; it is the default for tools that don't implement Cacheable.
+	CacheNever CachePolicy = iota
+	// CacheAlways caches results of point reads such as describe calls.
+	CacheAlways
+	// CacheListing caches results of listing tools only when listings are opted in.
+	CacheListing
+)
+
+// Cacheable is implemented by read tools whose results may be cached briefly.
+type Cacheable interface {
+	CachePolicy() CachePolicy
+}
+
+// Mutating is implemented by tools that change Temporal state. In dry-run mode their calls are
+// marked with temporal.WithDryRun: they validate and preview as usual, then report what they would
+// have done instead of doing it.
+type Mutating interface {
+	Mutating()
+}
+
+// ServerRequirement is the minimum Temporal server a tool needs: a release, a capability flag
+// reported by GetSystemInfo, or both.
+type ServerRequirement struct {
+	// MinVersion is the first supporting server release; the zero value accepts any release.
+	MinVersion temporal.Version
+	// Capability reports whether the server's capability flags support the tool; nil accepts any.
+	Capability func(*workflowservice.GetSystemInfoResponse_Capabilities) bool
+	// CapabilityName names the capability in messages, e.g. "eager workflow start".
+	CapabilityName string
+}
+
+// Supported reports whether server meets the requirement, and otherwise why not.
+func (r ServerRequirement) Supported(server temporal.ServerCapabilities) (bool, string) {
+	if r.MinVersion != (temporal.Version{}) && !server.AtLeast(r.MinVersion) {
+		return false, fmt.Sprintf("requires Temporal server >= %s; connected server is %s", r.MinVersion, server.Version)
+	}
+	if r.Capability != nil && !r.Capability(server.Capabilities) {
+		return false, fmt.Sprintf("requires the %s capability, which the connected server (%s) doesn't report", r.CapabilityName, server.Version)
+	}
+	return true, ""
+}
+
+// RequiresServer is implemented by tools that only work on sufficiently new Temporal servers. The
+// registry annotates their description and short-circuits their calls on servers that fall short.
+type RequiresServer interface {
+	ServerRequirement() ServerRequirement
+}
+
+// Instructed is implemented by tools that add a section of their own to the server instructions,
+// such as the operator's saved queries.
+type Instructed interface {
+	Instructions() string
+}
+
+// LongRunning is implemented by tools that may accept timeout_seconds overrides beyond the default cap.
+type LongRunning interface {
+	MaxTimeout() time.Duration
+}
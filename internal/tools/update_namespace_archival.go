@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+// UpdateNamespaceArchival implements the "update_namespace_archival" tool.
+type UpdateNamespaceArchival struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+}
+
+// Definition implements Tool.
+func (t *UpdateNamespaceArchival) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"update_namespace_archival",
+		mcp.WithDescription("Enable or disable history and/or visibility archival on a namespace, optionally setting the archival URI. An archival URI can't be changed once set. Archival must be enabled on the cluster. Without confirm=true, returns a preview of the changes and updates nothing"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("Namespace to update"),
+		),
+		mcp.WithString("history_archival",
+			mcp.Description("Target state of history archival"),
+			mcp.Enum("enabled", "disabled"),
+		),
+		mcp.WithString("history_archival_uri",
+			mcp.Description("URI to archive histories to, e.g. s3://bucket/path; defaults to the cluster's default URI"),
+		),
+		mcp.WithString("visibility_archival",
+			mcp.Description("Target state of visibility archival"),
+			mcp.Enum("enabled", "disabled"),
+		),
+		mcp.WithString("visibility_archival_uri",
+			mcp.Description("URI to archive visibility records to; defaults to the cluster's default URI"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Description("Set to true to update the namespace; otherwise only a preview is returned"),
+		),
+		withFormatArgument(),
+	)
+}
+
+// Mutating implements Mutating.
+func (t *UpdateNamespaceArchival) Mutating() {}
+
+// Handle implements Tool.
+func (t *UpdateNamespaceArchival) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	namespace, err := stringArgument(args, "namespace")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if namespace == "" {
+		return mcp.NewToolResultError("Missing or invalid 'namespace' parameter"), nil
+	}
+	var wanted [4]string
+	for i, name := range []string{"history_archival", "history_archival_uri", "visibility_archival", "visibility_archival_uri"} {
+		if wanted[i], err = stringArgument(args, name); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	if wanted == [4]string{} {
+		return mcp.NewToolResultError("Nothing to update: pass history_archival, visibility_archival, or an archival URI"), nil
+	}
+	confirm, err := boolArgument(args, "confirm")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	client, err := t.Clients.Client(namespace)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp, err := client.DescribeNamespace(ctx, namespace)
+	if err != nil {
+		t.Logger.Error("Error describing namespace", "namespace", namespace, "error", err)
+		return rpcError("Failed to describe namespace", err), nil
+	}
+	view := format.ArchivalUpdate{Namespace: namespace, Before: format.NewArchival(resp), Changes: []string{}}
+	current := resp.GetConfig()
+	config := &namespacepb.NamespaceConfig{}
+	var problem string
+	config.HistoryArchivalState, config.HistoryArchivalUri, problem = archivalChange(&view, namespace, "history",
+		current.GetHistoryArchivalState(), current.GetHistoryArchivalUri(), wanted[0], wanted[1])
+	if problem != "" {
+		return mcp.NewToolResultError(problem), nil
+	}
+	config.VisibilityArchivalState, config.VisibilityArchivalUri, problem = archivalChange(&view, namespace, "visibility",
+		current.GetVisibilityArchivalState(), current.GetVisibilityArchivalUri(), wanted[2], wanted[3])
+	if problem != "" {
+		return mcp.NewToolResultError(problem), nil
+	}
+	if !confirm || len(view.Changes) == 0 {
+		return renderResult(outFormat, view, format.ArchivalUpdateText)
+	}
+	if temporal.DryRun(ctx) {
+		t.Logger.Info("Dry run: skipped updating namespace archival", "namespace", namespace, "changes", view.Changes)
+		view.DryRun = true
+		return renderResult(outFormat, view, format.ArchivalUpdateText)
+	}
+
+	if _, err := client.UpdateNamespace(ctx, &workflowservice.UpdateNamespaceRequest{Namespace: namespace, Config: config}); err != nil {
+		t.Logger.Error("Error updating namespace archival", "namespace", namespace, "error", err)
+		return rpcError("Failed to update namespace archival", err), nil
+	}
+	t.Logger.Info("Namespace archival updated", "namespace", namespace, "changes", view.Changes)
+	view.Performed = true
+	// Describe again, to report the configuration the server applied
+	if after, err := client.DescribeNamespace(ctx, namespace); err != nil {
+		t.Logger.Warn("Unable to describe namespace after updating its archival", "namespace", namespace, "error", err)
+	} else {
+		now := format.NewArchival(after)
+		view.After = &now
+		applied := after.GetConfig()
+		if config.HistoryArchivalState != enumspb.ARCHIVAL_STATE_UNSPECIFIED && applied.GetHistoryArchivalState() != config.HistoryArchivalState {
+			view.NotApplied = append(view.NotApplied, "the history archival state")
+		}
+		if config.HistoryArchivalUri != "" && applied.GetHistoryArchivalUri() != config.HistoryArchivalUri {
+			view.NotApplied = append(view.NotApplied, "the history archival URI")
+		}
+		if config.VisibilityArchivalState != enumspb.ARCHIVAL_STATE_UNSPECIFIED && applied.GetVisibilityArchivalState() != config.VisibilityArchivalState {
+			view.NotApplied = append(view.NotApplied, "the visibility archival state")
+		}
+		if config.VisibilityArchivalUri != "" && applied.GetVisibilityArchivalUri() != config.VisibilityArchivalUri {
+			view.NotApplied = append(view.NotApplied, "the visibility archival URI")
+		}
+	}
+	return renderResult(outFormat, view, format.ArchivalUpdateText)
+}
+
+// archivalChange works out the update of one kind of archival (history or visibility) from its
+// current state and URI and the requested ones, recording the changes in view. It returns the
+// state and URI to send, left unset when unchanged, or why the request can't be applied. Changing
+// a URI once set is refused: the server rejects it with an opaque error, since archived records
+// would be split across locations.
+func archivalChange(view *format.ArchivalUpdate, namespace, kind string, state enumspb.ArchivalState, uri, wantState, wantURI string) (enumspb.ArchivalState, string, string) {
+	var newState enumspb.ArchivalState
+	switch wantState {
+	case "":
+	case "enabled":
+		newState = enumspb.ARCHIVAL_STATE_ENABLED
+	case "disabled":
+		newState = enumspb.ARCHIVAL_STATE_DISABLED
+	default:
+		return 0, "", fmt.Sprintf("Invalid '%s_archival' parameter %q: use enabled or disabled", kind, wantState)
+	}
+	if newState == state {
+		newState = enumspb.ARCHIVAL_STATE_UNSPECIFIED
+	}
+	if wantURI != "" && uri != "" && wantURI != uri {
+		return 0, "", fmt.Sprintf("Can't change the %s archival URI of namespace %s: it is already set to %s, and Temporal doesn't allow changing an archival URI once set, since records already archived would be left behind. Archival can still be enabled or disabled; to archive elsewhere, use a new namespace",
+			kind, namespace, uri)
+	}
+	if wantURI == uri {
+		wantURI = ""
+	}
+	if newState != enumspb.ARCHIVAL_STATE_UNSPECIFIED {
+		view.Changes = append(view.Changes, fmt.Sprintf("%s archival: %s -> %s", kind, state, newState))
+	}
+	if wantURI != "" {
+		view.Changes = append(view.Changes, fmt.Sprintf("%s archival URI: set to %s (it can't be changed afterwards)", kind, wantURI))
+	} else if newState == enumspb.ARCHIVAL_STATE_ENABLED && uri == "" {
+		view.Changes = append(view.Changes, fmt.Sprintf("%s archival URI: the cluster's default (it can't be changed afterwards)", kind))
+	}
+	return newState, wantURI, ""
+}
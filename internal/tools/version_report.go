@@ -0,0 +1,355 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc/codes"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// maxVersionScan caps how many running workflows version_report lists to count them per build on
+	// servers that can't GROUP BY BuildIds.
+	maxVersionScan = 5000
+	// pollerLiveWindow is how recently a poller must have polled to count as live. Pollers long-poll
+	// for about a minute at a time, and the server remembers them for minutes after they stop.
+	pollerLiveWindow = 2 * time.Minute
+)
+
+// VersionReport implements the "version_report" tool.
+type VersionReport struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+
+	// groupByBuildIDs caches the result of the first GROUP BY BuildIds probe.
+	groupByBuildIDs atomic.Int32
+}
+
+// Definition implements Tool.
+func (t *VersionReport) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"version_report",
+		mcp.WithDescription("Report worker versioning drift on a task queue during a rollout: the build ID assignment and redirect rules, the pollers with the build each runs, and the running workflows pinned to each build (from the BuildIds search attribute). Flags stranded builds, with running workflows pinned to them but no live pollers, and orphan builds, polled by workers but referenced by no rule"),
+		mcp.WithString("task_queue",
+			mcp.Required(),
+			mcp.Description("Task queue to report on"),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// MaxTimeout implements LongRunning; without GROUP BY support thousands of executions are listed.
+func (t *VersionReport) MaxTimeout() time.Duration {
+	return 5 * time.Minute
+}
+
+// Handle implements Tool.
+func (t *VersionReport) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	taskQueue, err := stringArgument(args, "task_queue")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if taskQueue == "" {
+		return mcp.NewToolResultError("Missing or invalid 'task_queue' parameter"), nil
+	}
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	report := format.VersionReport{
+		TaskQueue:          taskQueue,
+		AssignmentRules:    []format.AssignmentRule{},
+		RedirectRules:      []format.RedirectRule{},
+		Builds:             []format.BuildReport{},
+		UnversionedPollers: []format.VersionPoller{},
+		Running:            -1,
+		Unpinned:           -1,
+		Method:             "group_by",
+		Stranded:           []string{},
+		Orphans:            []string{},
+	}
+	builds := make(map[string]*format.BuildReport)
+	build := func(id string) *format.BuildReport {
+		b, ok := builds[id]
+		if !ok {
+			b = &format.BuildReport{BuildID: id, Rules: []string{}, Pollers: []format.VersionPoller{}}
+			builds[id] = b
+		}
+		return b
+	}
+
+	// Servers with versioning disabled (or predating it) don't return rules; the pollers and
+	// workflows may still carry builds, e.g. from worker deployments
+	rules, err := client.GetWorkerVersioningRules(ctx, &workflowservice.GetWorkerVersioningRulesRequest{Namespace: client.Namespace(), TaskQueue: taskQueue})
+	if code, _ := errorCode(err); code == codes.Unimplemented || code == codes.PermissionDenied || code == codes.FailedPrecondition {
+		report.RulesNote = "the server doesn't return build ID rules (" + strings.TrimSuffix(serverMessage(err), ".") + ")"
+	} else if err != nil {
+		t.Logger.Error("Error getting worker versioning rules", "task_queue", taskQueue, "error", err)
+		return rpcError("Failed to get worker versioning rules", err), nil
+	}
+	var redirected map[string]bool
+	if report.RulesNote == "" {
+		redirected = t.rules(rules, &report, build)
+	}
+
+	if err := t.pollers(ctx, client, taskQueue, &report, build); err != nil {
+		t.Logger.Error("Error describing task queue", "task_queue", taskQueue, "error", err)
+		return rpcError("Failed to describe task queue", err), nil
+	}
+
+	query := "TaskQueue = " + queryString(taskQueue) + ` AND ExecutionStatus = "Running"`
+	if count, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{Namespace: client.Namespace(), Query: query}); err != nil {
+		t.Logger.Warn("Unable to count running workflows", "query", query, "error", err)
+	} else {
+		report.Running = count.GetCount()
+	}
+	counts, err := countGrouped(ctx, client, t.Logger, query, "BuildIds", &t.groupByBuildIDs)
+	if err == nil && counts == nil {
+		report.Method = "scan"
+		counts, err = t.scan(ctx, client, query, &report)
+	}
+	if err != nil {
+		t.Logger.Error("Error counting running workflows per build", "query", query, "error", err)
+		return rpcError("Failed to count running workflows per build", err), nil
+	}
+	var pinned int64
+	for value, count := range counts {
+		switch kind, id := buildIDValue(value); kind {
+		case "pinned":
+			build(id).Pinned += count
+			pinned += count
+		case "processed":
+			build(id).Processed += count
+		}
+	}
+	if report.Running >= 0 && !report.Truncated {
+		report.Unpinned = max(0, report.Running-pinned)
+	}
+	report.Behaviors = t.behaviors(ctx, client, query)
+
+	if len(builds) == 0 && len(report.AssignmentRules) == 0 && len(report.RedirectRules) == 0 {
+		report.NotVersioned = true
+		return renderResult(outFormat, report, format.VersionReportText)
+	}
+	for _, b := range builds {
+		live := false
+		for _, p := range b.Pollers {
+			live = live || p.Live
+		}
+		// Redirected workflows move to the target build, so they aren't stuck on this one
+		b.Stranded = b.Pinned > 0 && !live && !redirected[b.BuildID]
+		// Worker deployments route by their own configuration rather than by rules
+		b.Orphan = report.RulesNote == "" && len(b.Pollers) > 0 && len(b.Rules) == 0 && b.Deployment == ""
+		report.Builds = append(report.Builds, *b)
+	}
+	sort.Slice(report.Builds, func(i, j int) bool { return report.Builds[i].BuildID < report.Builds[j].BuildID })
+	for _, b := range report.Builds {
+		if b.Stranded {
+			report.Stranded = append(report.Stranded, b.BuildID)
+		}
+		if b.Orphan {
+			report.Orphans = append(report.Orphans, b.BuildID)
+		}
+	}
+	return renderResult(outFormat, report, format.VersionReportText)
+}
+
+// behaviors counts the executions matching query by their effective worker deployment versioning
+// behavior, overrides included, or returns nil when the server doesn't index it (before worker
+// deployments) or none has one.
+func (t *VersionReport) behaviors(ctx context.Context, client temporal.Client, query string) map[string]int64 {
+	counts := make(map[string]int64)
+	for _, behavior := range []enumspb.VersioningBehavior{enumspb.VERSIONING_BEHAVIOR_PINNED, enumspb.VERSIONING_BEHAVIOR_AUTO_UPGRADE} {
+		count, err := countWorkflows(ctx, client, query+" AND TemporalWorkflowVersioningBehavior = "+queryString(behavior.String()))
+		if err != nil {
+			t.Logger.Debug("Unable to count workflows by versioning behavior", "behavior", behavior.String(), "error", err)
+			return nil
+		}
+		if count > 0 {
+			counts[behavior.String()] = count
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	return counts
+}
+
+// rules records the versioning rules in report and on the builds they reference, returning the
+// builds redirected elsewhere.
+func (t *VersionReport) rules(rules *workflowservice.GetWorkerVersioningRulesResponse, report *format.VersionReport, build func(string) *format.BuildReport) map[string]bool {
+	// New workflows take the first rule that matches: the first unramped one always does
+	defaulted := false
+	for i, rule := range rules.GetAssignmentRules() {
+		r := format.AssignmentRule{
+			Index:          i,
+			BuildID:        rule.GetRule().GetTargetBuildId(),
+			RampPercentage: 100,
+			CreateTime:     format.Time(rule.GetCreateTime()),
+		}
+		if ramp := rule.GetRule().GetPercentageRamp(); ramp != nil {
+			r.RampPercentage = ramp.GetRampPercentage()
+		}
+		switch {
+		case defaulted:
+			r.Role = "unreachable"
+		case r.RampPercentage >= 100:
+			r.Role, defaulted = "default", true
+		default:
+			r.Role = "ramping"
+		}
+		report.AssignmentRules = append(report.AssignmentRules, r)
+		description := fmt.Sprintf("assignment #%d (%s)", i, r.Role)
+		if r.Role == "ramping" {
+			description = fmt.Sprintf("assignment #%d (ramping %g%%)", i, r.RampPercentage)
+		}
+		b := build(r.BuildID)
+		b.Rules = append(b.Rules, description)
+	}
+	redirected := make(map[string]bool)
+	for _, rule := range rules.GetCompatibleRedirectRules() {
+		r := format.RedirectRule{
+			SourceBuildID: rule.GetRule().GetSourceBuildId(),
+			TargetBuildID: rule.GetRule().GetTargetBuildId(),
+			CreateTime:    format.Time(rule.GetCreateTime()),
+		}
+		report.RedirectRules = append(report.RedirectRules, r)
+		source, target := build(r.SourceBuildID), build(r.TargetBuildID)
+		source.Rules = append(source.Rules, "redirects to "+r.TargetBuildID)
+		target.Rules = append(target.Rules, "redirect target of "+r.SourceBuildID)
+		redirected[r.SourceBuildID] = true
+	}
+	return redirected
+}
+
+// pollers records the workflow and activity pollers of taskQueue on the builds they report, merging
+// each worker's pollers of both types.
+func (t *VersionReport) pollers(ctx context.Context, client temporal.Client, taskQueue string, report *format.VersionReport, build func(string) *format.BuildReport) error {
+	type key struct{ build, identity string }
+	var (
+		order  []key
+		merged = make(map[key]*format.VersionPoller)
+		seen   = make(map[key]time.Time)
+		now    = time.Now()
+	)
+	for _, taskQueueType := range []enumspb.TaskQueueType{enumspb.TASK_QUEUE_TYPE_WORKFLOW, enumspb.TASK_QUEUE_TYPE_ACTIVITY} {
+		// The legacy mode reports every poller with its build; the enhanced mode only those of the
+		// builds asked for
+		resp, err := client.DescribeTaskQueue(ctx, &workflowservice.DescribeTaskQueueRequest{
+			Namespace:     client.Namespace(),
+			TaskQueue:     &taskqueuepb.TaskQueue{Name: taskQueue, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+			TaskQueueType: taskQueueType,
+		})
+		if err != nil {
+			return err
+		}
+		for _, p := range resp.GetPollers() {
+			k := key{identity: p.GetIdentity()}
+			deployment := ""
+			switch {
+			case p.GetDeploymentOptions().GetBuildId() != "":
+				k.build, deployment = p.GetDeploymentOptions().GetBuildId(), p.GetDeploymentOptions().GetDeploymentName()
+			case p.GetWorkerVersionCapabilities().GetUseVersioning():
+				k.build = p.GetWorkerVersionCapabilities().GetBuildId()
+			}
+			poller, ok := merged[k]
+			if !ok {
+				poller = &format.VersionPoller{Identity: p.GetIdentity()}
+				merged[k] = poller
+				order = append(order, k)
+				if k.build != "" && deployment != "" {
+					build(k.build).Deployment = deployment
+				}
+			}
+			poller.Types = append(poller.Types, strings.ToLower(strings.TrimPrefix(taskQueueType.String(), "TaskQueueType")))
+			if last := p.GetLastAccessTime().AsTime(); last.After(seen[k]) {
+				seen[k] = last
+				poller.LastAccessTime = format.Time(p.GetLastAccessTime())
+				poller.Live = now.Sub(last) < pollerLiveWindow
+			}
+		}
+	}
+	for _, k := range order {
+		if k.build == "" {
+			report.UnversionedPollers = append(report.UnversionedPollers, *merged[k])
+			continue
+		}
+		b := build(k.build)
+		b.Pollers = append(b.Pollers, *merged[k])
+	}
+	return nil
+}
+
+// scan counts the BuildIds values of up to maxVersionScan executions matching query, recording the
+// coverage in report.
+func (t *VersionReport) scan(ctx context.Context, client temporal.Client, query string, report *format.VersionReport) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	var token []byte
+	for {
+		resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     client.Namespace(),
+			PageSize:      int32(min(maxListPageSize, maxVersionScan-report.Scanned)),
+			NextPageToken: token,
+			Query:         query,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range resp.GetExecutions() {
+			report.Scanned++
+			for _, value := range searchAttributeValues(info.GetSearchAttributes().GetIndexedFields()["BuildIds"]) {
+				counts[value]++
+			}
+		}
+		token = resp.GetNextPageToken()
+		if len(token) == 0 {
+			return counts, nil
+		}
+		if report.Scanned >= maxVersionScan {
+			report.Truncated = true
+			return counts, nil
+		}
+		reportProgress(ctx, float64(report.Scanned), maxVersionScan, "scanned %d running workflows", report.Scanned)
+	}
+}
+
+// buildIDValue parses a value of the BuildIds search attribute: "assigned:<build>" and
+// "pinned:<deployment>:<build>" or "pinned:<deployment>.<build>" (a deployment version) pin a
+// workflow to a build, "versioned:<build>" records a build that processed its tasks, and
+// "unversioned" or "unversioned:<build>" a worker without versioning.
+func buildIDValue(value string) (kind, buildID string) {
+	prefix, rest, _ := strings.Cut(value, ":")
+	switch prefix {
+	case "assigned":
+		return "pinned", rest
+	case "pinned":
+		if i := strings.LastIndex(rest, ":"); i >= 0 {
+			return "pinned", rest[i+1:]
+		}
+		// Deployment names can't contain dots, so a version's build ID follows the first one
+		_, build, _ := strings.Cut(rest, ".")
+		return "pinned", build
+	case "versioned":
+		return "processed", rest
+	}
+	return "unversioned", rest
+}
@@ -0,0 +1,171 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultHistogramWindow and defaultHistogramBucket are used when window and bucket aren't given.
+	defaultHistogramWindow = 24 * time.Hour
+	defaultHistogramBucket = time.Hour
+	// maxHistogramBuckets caps the buckets, and so the count queries, of one histogram.
+	maxHistogramBuckets = 96
+)
+
+// WorkflowHistogram implements the "workflow_histogram" tool.
+type WorkflowHistogram struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+	// Pool bounds the concurrent per-bucket count queries.
+	Pool *pool.Pool
+}
+
+// Definition implements Tool.
+func (t *WorkflowHistogram) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"workflow_histogram",
+		mcp.WithDescription("Count workflow starts per time bucket over a window (e.g. starts per hour over the last 48 hours), for capacity planning and spotting bursts"),
+		mcp.WithString("window",
+			mcp.Description(fmt.Sprintf("Time range to cover, as a Go duration such as 48h (default %s)", defaultHistogramWindow)),
+		),
+		mcp.WithString("bucket",
+			mcp.Description(fmt.Sprintf("Bucket size, as a Go duration of whole minutes such as 15m or 1h (default %s). Buckets align to round UTC boundaries; at most %d buckets", defaultHistogramBucket, maxHistogramBuckets)),
+		),
+		mcp.WithString("workflow_type",
+			mcp.Description("Optional workflow type to count"),
+		),
+		mcp.WithString("status",
+			mcp.Description("Optional status of the executions to count (e.g. Failed)"),
+			mcp.Enum(executionStatuses...),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *WorkflowHistogram) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *WorkflowHistogram) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	window, err := durationArgument(args, "window", defaultHistogramWindow)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	bucket, err := durationArgument(args, "bucket", defaultHistogramBucket)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if bucket%time.Minute != 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'bucket' parameter %s (use whole minutes, e.g. 15m or 1h)", bucket)), nil
+	}
+
+	// Buckets start at a multiple of the bucket size, so hourly buckets begin at the top of the
+	// hour; the first one may reach back before the window and the last one into the future
+	now := time.Now().UTC()
+	first := now.Add(-window).Truncate(bucket)
+	n := int((now.Sub(first) + bucket - 1) / bucket)
+	if n > maxHistogramBuckets {
+		return mcp.NewToolResultError(fmt.Sprintf("A %s window in %s buckets needs %d buckets, more than the maximum of %d; use a larger bucket or a shorter window", window, bucket, n, maxHistogramBuckets)), nil
+	}
+
+	histogram := format.WorkflowHistogram{Bucket: bucket.String()}
+	var filters []string
+	if histogram.WorkflowType, err = stringArgument(args, "workflow_type"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if histogram.WorkflowType != "" {
+		filters = append(filters, "WorkflowType = "+queryString(histogram.WorkflowType))
+	}
+	raw, err := stringArgument(args, "status")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if raw != "" {
+		status, ok := executionStatus(raw)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'status' parameter %q (use one of %s)", raw, strings.Join(executionStatuses, ", "))), nil
+		}
+		histogram.Status = status
+		filters = append(filters, "ExecutionStatus = "+queryString(status))
+	}
+
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Visibility can't group counts by start time, so each bucket is a count query of its own,
+	// run concurrently through the shared pool
+	histogram.Buckets = make([]format.HistogramBucket, n)
+	var (
+		done     atomic.Int64
+		mu       sync.Mutex
+		firstErr error
+	)
+	stats := t.Pool.Run(ctx, n, 0, func(ctx context.Context, i int) {
+		defer func() {
+			if finished := done.Add(1); finished < int64(n) {
+				reportProgress(ctx, float64(finished), float64(n), "counted %d of %d buckets", finished, n)
+			}
+		}()
+		start := first.Add(time.Duration(i) * bucket)
+		end := start.Add(bucket)
+		query := strings.Join(append([]string{
+			"StartTime >= " + queryString(start.Format(time.RFC3339)),
+			"StartTime < " + queryString(end.Format(time.RFC3339)),
+		}, filters...), " AND ")
+		resp, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+			Namespace: client.Namespace(),
+			Query:     query,
+		})
+		if err != nil {
+			t.Logger.Error("Error counting workflows", "query", query, "error", err)
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
+			return
+		}
+		histogram.Buckets[i] = format.HistogramBucket{
+			Start: start.Format(time.RFC3339),
+			End:   end.Format(time.RFC3339),
+			Count: resp.GetCount(),
+		}
+	})
+	if firstErr != nil {
+		return rpcError("Failed to count workflows", firstErr), nil
+	}
+	if stats.Cancelled > 0 {
+		return rpcError("Failed to count workflows", ctx.Err()), nil
+	}
+
+	for _, b := range histogram.Buckets {
+		histogram.Total += b.Count
+	}
+	histogram.Partial = first.Add(time.Duration(n) * bucket).After(now)
+	return renderResult(outFormat, histogram, format.WorkflowHistogramText)
+}
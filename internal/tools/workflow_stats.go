@@ -0,0 +1,329 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+)
+
+const (
+	// defaultStatsWindow is the time range counted when window isn't given.
+	defaultStatsWindow = 24 * time.Hour
+	// defaultStatsTop is how many workflow types group_by=type reports when top isn't given.
+	defaultStatsTop = 10
+	// maxStatsSample caps how many executions are listed to approximate per-type counts on servers
+	// without GROUP BY support.
+	maxStatsSample = 5000
+)
+
+// executionStatuses lists the ExecutionStatus values counted by workflow_stats, in display order.
+var executionStatuses = []string{"Running", "Completed", "Failed", "Canceled", "Terminated", "ContinuedAsNew", "TimedOut"}
+
+// groupBySupport records whether the server accepts GROUP BY on a field in count queries.
+type groupBySupport int32
+
+const (
+	groupByUnknown groupBySupport = iota
+	groupBySupported
+	groupByUnsupported
+)
+
+// WorkflowStats implements the "workflow_stats" tool.
+type WorkflowStats struct {
+	Clients temporal.Provider
+	Logger  *slog.Logger
+
+	// groupByStatus and groupByType cache the result of the first GROUP BY probe per field so the
+	// fallback decision isn't re-probed on every call.
+	groupByStatus atomic.Int32
+	groupByType   atomic.Int32
+}
+
+// Definition implements Tool.
+func (t *WorkflowStats) Definition() mcp.Tool {
+	return mcp.NewTool(
+		"workflow_stats",
+		mcp.WithDescription("Count workflow executions grouped by status (Running, Completed, Failed, ...) or by workflow type, for a namespace health snapshot"),
+		mcp.WithString("group_by",
+			mcp.Description("Group counts by status (default) or by workflow type"),
+			mcp.Enum("status", "type"),
+		),
+		mcp.WithString("window",
+			mcp.Description(fmt.Sprintf("Count executions started within this time window, as a Go duration such as 1h or 168h (default %s)", defaultStatsWindow)),
+		),
+		mcp.WithString("workflow_type",
+			mcp.Description("Optional workflow type to count"),
+		),
+		mcp.WithString("status",
+			mcp.Description("With group_by=type, only count executions in this status (e.g. Failed)"),
+			mcp.Enum(executionStatuses...),
+		),
+		mcp.WithNumber("top",
+			integer(),
+			mcp.Min(1),
+			mcp.Description(fmt.Sprintf("With group_by=type, how many types to report, most frequent first (default %d)", defaultStatsTop)),
+		),
+		withNamespaceArgument(),
+		withFormatArgument(),
+	)
+}
+
+// CachePolicy implements Cacheable.
+func (t *WorkflowStats) CachePolicy() CachePolicy {
+	return CacheAlways
+}
+
+// Handle implements Tool.
+func (t *WorkflowStats) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	outFormat, err := outputFormat(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := callClient(t.Clients, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	groupBy, err := stringArgument(args, "group_by")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	switch groupBy = strings.ToLower(groupBy); groupBy {
+	case "":
+		groupBy = "status"
+	case "status", "type":
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'group_by' parameter %q (use status or type)", groupBy)), nil
+	}
+
+	window, err := durationArgument(args, "window", defaultStatsWindow)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	workflowType, err := stringArgument(args, "workflow_type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	filters := []string{fmt.Sprintf("StartTime > %q", time.Now().Add(-window).UTC().Format(time.RFC3339))}
+	if workflowType != "" {
+		filters = append(filters, fmt.Sprintf("WorkflowType = %q", workflowType))
+	}
+	stats := format.WorkflowStats{GroupBy: groupBy, Window: "in the last " + window.String(), WorkflowType: workflowType}
+
+	if groupBy == "status" {
+		query := strings.Join(filters, " AND ")
+		counts, err := t.countByStatus(ctx, client, query)
+		if err != nil {
+			t.Logger.Error("Error counting workflows", "query", query, "error", err)
+			return rpcError("Failed to count workflows", err), nil
+		}
+		for _, status := range executionStatuses {
+			stats.Counts = append(stats.Counts, format.StatusCount{Status: status, Count: counts[status]})
+			stats.Total += counts[status]
+		}
+		return renderResult(outFormat, stats, format.WorkflowStatsText)
+	}
+
+	raw, err := stringArgument(args, "status")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if raw != "" {
+		status, ok := executionStatus(raw)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'status' parameter %q (use one of %s)", raw, strings.Join(executionStatuses, ", "))), nil
+		}
+		stats.Status = status
+		filters = append(filters, fmt.Sprintf("ExecutionStatus = %q", status))
+	}
+	top, err := intArgument(args, "top", defaultStatsTop, 1, 0)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	query := strings.Join(filters, " AND ")
+	counts, err := t.countByType(ctx, client, query, &stats)
+	if err != nil {
+		t.Logger.Error("Error counting workflows", "query", query, "error", err)
+		return rpcError("Failed to count workflows", err), nil
+	}
+	for workflowType, count := range counts {
+		stats.Types = append(stats.Types, format.TypeCount{Type: workflowType, Count: count})
+		stats.Total += count
+	}
+	sort.Slice(stats.Types, func(i, j int) bool {
+		if stats.Types[i].Count != stats.Types[j].Count {
+			return stats.Types[i].Count > stats.Types[j].Count
+		}
+		return stats.Types[i].Type < stats.Types[j].Type
+	})
+	stats.DistinctTypes = len(stats.Types)
+	if len(stats.Types) > top {
+		stats.Types = stats.Types[:top]
+	}
+	return renderResult(outFormat, stats, format.WorkflowStatsText)
+}
+
+// countByStatus counts the executions matching query per ExecutionStatus, with a single GROUP BY
+// query when the server supports it and one count per status otherwise.
+func (t *WorkflowStats) countByStatus(ctx context.Context, client temporal.Client, query string) (map[string]int64, error) {
+	counts, err := countGrouped(ctx, client, t.Logger, query, "ExecutionStatus", &t.groupByStatus)
+	if err != nil || counts != nil {
+		return counts, err
+	}
+
+	counts = make(map[string]int64)
+	for i, status := range executionStatuses {
+		reportProgress(ctx, float64(i), float64(len(executionStatuses)), "counting %s workflows", status)
+		resp, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+			Namespace: client.Namespace(),
+			Query:     fmt.Sprintf("%s AND ExecutionStatus = %q", query, status),
+		})
+		if err != nil {
+			return nil, err
+		}
+		counts[status] = resp.GetCount()
+	}
+	return counts, nil
+}
+
+// countByType counts the executions matching query per WorkflowType. Without GROUP BY support the
+// counts are approximated by listing up to maxStatsSample executions, recorded in stats.Sampled.
+func (t *WorkflowStats) countByType(ctx context.Context, client temporal.Client, query string, stats *format.WorkflowStats) (map[string]int64, error) {
+	counts, err := countGrouped(ctx, client, t.Logger, query, "WorkflowType", &t.groupByType)
+	if err != nil || counts != nil {
+		return counts, err
+	}
+
+	counts = make(map[string]int64)
+	var token []byte
+	for {
+		resp, err := client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     client.Namespace(),
+			PageSize:      int32(min(maxListPageSize, maxStatsSample-stats.Sampled)),
+			NextPageToken: token,
+			Query:         query,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range resp.GetExecutions() {
+			counts[info.GetType().GetName()]++
+			stats.Sampled++
+		}
+		token = resp.GetNextPageToken()
+		if len(token) == 0 {
+			break
+		}
+		if stats.Sampled >= maxStatsSample {
+			stats.Truncated = true
+			break
+		}
+		reportProgress(ctx, float64(stats.Sampled), maxStatsSample, "sampled %d executions", stats.Sampled)
+	}
+	return counts, nil
+}
+
+// countGrouped runs a GROUP BY count on field, returning the count per group value. It returns
+// nil counts and no error when the server doesn't support grouping by field; support records the
+// outcome of the first probe so later calls go straight to the caller's fallback.
+func countGrouped(ctx context.Context, client temporal.Client, logger *slog.Logger, query, field string, support *atomic.Int32) (map[string]int64, error) {
+	if groupBySupport(support.Load()) == groupByUnsupported {
+		return nil, nil
+	}
+	resp, err := client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: client.Namespace(),
+		Query:     strings.TrimSpace(query + " GROUP BY " + field),
+	})
+	if err != nil {
+		if !isQueryUnsupported(err) {
+			return nil, err
+		}
+		logger.Info("Temporal server doesn't support GROUP BY in count queries; using fallback", "field", field, "error", err)
+		support.Store(int32(groupByUnsupported))
+		return nil, nil
+	}
+
+	support.Store(int32(groupBySupported))
+	counts := make(map[string]int64)
+	for _, group := range resp.GetGroups() {
+		if values := group.GetGroupValues(); len(values) > 0 {
+			if decoded := searchAttributeValues(values[0]); len(decoded) > 0 {
+				counts[decoded[0]] += group.GetCount()
+			}
+		}
+	}
+	return counts, nil
+}
+
+// searchAttributeValues decodes a search attribute payload (JSON) into display strings: one per
+// item of a KeywordList, else a single value. Undecodable payloads yield none.
+func searchAttributeValues(p *commonpb.Payload) []string {
+	decoder := json.NewDecoder(bytes.NewReader(p.GetData()))
+	decoder.UseNumber()
+	var value interface{}
+	if decoder.Decode(&value) != nil || value == nil {
+		return nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, fmt.Sprint(item))
+	}
+	return values
+}
+
+// statusAliases maps other names agents use for a status, lowercased, to its canonical name.
+var statusAliases = map[string]string{
+	"open":      "Running",
+	"complete":  "Completed",
+	"cancelled": "Canceled",
+}
+
+// executionStatus returns the canonical ExecutionStatus name matching s case-insensitively, also
+// accepting enum names such as WORKFLOW_EXECUTION_STATUS_TIMED_OUT and the statusAliases.
+func executionStatus(s string) (string, bool) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	name = strings.ReplaceAll(strings.TrimPrefix(name, "workflow_execution_status_"), "_", "")
+	if status, ok := statusAliases[name]; ok {
+		return status, true
+	}
+	for _, status := range executionStatuses {
+		if strings.EqualFold(name, status) {
+			return status, true
+		}
+	}
+	return "", false
+}
+
+// isQueryUnsupported reports whether a failed visibility query means the server (or its visibility
+// store) doesn't support a clause such as GROUP BY or ORDER BY, rather than a transient failure.
+func isQueryUnsupported(err error) bool {
+	var (
+		invalid       *serviceerror.InvalidArgument
+		unimplemented *serviceerror.Unimplemented
+	)
+	return errors.As(err, &invalid) || errors.As(err, &unimplemented)
+}
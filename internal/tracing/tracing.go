@@ -0,0 +1,85 @@
+// Package tracing exports OpenTelemetry traces of tool calls and the Temporal RPCs they make.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// Configured reports whether the standard OTEL_ environment variables name an OTLP endpoint for
+// traces. Without one nothing is set up and tracing costs nothing.
+func Configured() bool {
+	if os.Getenv("OTEL_TRACES_EXPORTER") == "none" {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Tracing exports spans over OTLP/gRPC to the endpoint configured by the OTEL_ environment
+// variables.
+type Tracing struct {
+	provider   *sdktrace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+// New creates the exporter and tracer provider. Spans are attributed to the temporal-mcp service at
+// version unless OTEL_SERVICE_NAME or OTEL_RESOURCE_ATTRIBUTES say otherwise.
+func New(ctx context.Context, version string) (*Tracing, error) {
+	for _, name := range []string{"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL"} {
+		if protocol := os.Getenv(name); protocol != "" && protocol != "grpc" {
+			return nil, fmt.Errorf("unsupported %s %q: only grpc is supported", name, protocol)
+		}
+	}
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", "temporal-mcp"),
+			attribute.String("service.version", version),
+		),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("building trace resource: %w", err)
+	}
+	return &Tracing{
+		provider:   sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res)),
+		propagator: propagation.TraceContext{},
+	}, nil
+}
+
+// Tracer returns the tracer of tool call spans, or nil when t is nil.
+func (t *Tracing) Tracer() trace.Tracer {
+	if t == nil {
+		return nil
+	}
+	return t.provider.Tracer("github.com/wricardo/temporal-mcp")
+}
+
+// DialOption instruments a gRPC connection so each RPC becomes a span, nested under the span of the
+// tool call making it, and carries the trace context to the server. The spans hold the RPC's
+// method and status only, never its messages, so payloads don't end up in traces.
+func (t *Tracing) DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+		otelgrpc.WithTracerProvider(t.provider),
+		otelgrpc.WithPropagators(t.propagator),
+	))
+}
+
+// Shutdown flushes the spans not yet exported and stops the exporter.
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	return t.provider.Shutdown(ctx)
+}
@@ -2,222 +2,395 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
 	"os"
+	"os/signal"
+	"slices"
 	"strings"
+	"syscall"
 	"time"
 
-	enumspb "go.temporal.io/api/enums/v1"
-	workflowpb "go.temporal.io/api/workflow/v1"
-	workflowservice "go.temporal.io/api/workflowservice/v1"
-	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/grpc"
 
-	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/wricardo/temporal-mcp/internal/audit"
+	"github.com/wricardo/temporal-mcp/internal/cache"
+	"github.com/wricardo/temporal-mcp/internal/config"
+	"github.com/wricardo/temporal-mcp/internal/export"
+	"github.com/wricardo/temporal-mcp/internal/format"
+	"github.com/wricardo/temporal-mcp/internal/logging"
+	"github.com/wricardo/temporal-mcp/internal/metrics"
+	"github.com/wricardo/temporal-mcp/internal/payload"
+	"github.com/wricardo/temporal-mcp/internal/pool"
+	"github.com/wricardo/temporal-mcp/internal/temporal"
+	"github.com/wricardo/temporal-mcp/internal/tools"
+	"github.com/wricardo/temporal-mcp/internal/tracing"
+	buildversion "github.com/wricardo/temporal-mcp/internal/version"
+)
+
+// Build information, injected at build time via:
+//
+//	go build -ldflags "-X main.version=v1.2.3 -X main.commit=abc1234 -X main.date=2024-01-01T00:00:00Z"
+//
+// When not injected, values are derived from the module build info embedded by the Go toolchain.
+var (
+	version = ""
+	commit  = ""
+	date    = ""
 )
 
+// defaultShutdownTimeout bounds how long shutdown waits for in-flight tool calls to finish.
+const defaultShutdownTimeout = 10 * time.Second
+
 func main() {
-	// Configure logging format (include date, time, source file)
-	log.SetFlags(log.Ldate | log.Ltime | log.LUTC | log.Lshortfile)
+	showVersion := flag.Bool("version", false, "Print version information and exit")
+	flag.Parse()
 
-	// Read Temporal connection settings from environment
-	temporalAddress := os.Getenv("TEMPORAL_ADDRESS")
-	temporalNamespace := os.Getenv("TEMPORAL_NAMESPACE")
-	if temporalAddress == "" {
-		temporalAddress = "localhost:7233"
-	}
-	if temporalNamespace == "" {
-		temporalNamespace = "default"
+	build := buildversion.Resolve(version, commit, date)
+	if *showVersion {
+		fmt.Println(build.String())
+		return
 	}
 
-	// Connect to Temporal server
-	c, err := client.Dial(client.Options{
-		HostPort:  temporalAddress,
-		Namespace: temporalNamespace,
-	})
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Unable to connect to Temporal at %s (namespace %s): %v", temporalAddress, temporalNamespace, err)
-	}
-	defer c.Close()
-	log.Printf("Connected to Temporal at %s (namespace: %s)", temporalAddress, temporalNamespace)
-
-	// Create the MCP server instance
-	mcpServer := server.NewMCPServer("temporal-mcp", "1.0.0")
-
-	// Define the "list_workflows" tool
-	listWorkflowsTool := mcp.NewTool(
-		"list_workflows",
-		mcp.WithDescription("List Temporal workflows filtered by status (running, completed, or failed)"),
-		mcp.WithString("status",
-			mcp.Required(),
-			mcp.Description("Workflow status to filter by (running, completed, failed)"),
-		),
-	)
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
 
-	// Define the "describe_workflow" tool
-	describeWorkflowTool := mcp.NewTool(
-		"describe_workflow",
-		mcp.WithDescription("Retrieve detailed information about a specific workflow execution"),
-		mcp.WithString("workflow_id",
-			mcp.Required(),
-			mcp.Description("Workflow ID of the execution to describe"),
-		),
-		mcp.WithString("run_id",
-			mcp.Description("Optional Run ID (if not provided, the latest run is used)"),
-		),
-	)
+	// Configure structured logging. Logs go to stderr or TEMPORAL_MCP_LOG_FILE; stdout is
+	// reserved for MCP traffic.
+	logOutput, logDestination, logOutputErr := logging.OpenOutput(cfg.LogFile, cfg.LogMaxSizeBytes)
+	if closer, ok := logOutput.(io.Closer); ok && logOutput != os.Stderr {
+		defer closer.Close()
+	}
+	logger := logging.New(logOutput, cfg.LogLevel, cfg.LogFormat)
+	slog.SetDefault(logger)
+	if logOutputErr != nil {
+		logger.Warn("Unable to use log file, logging to stderr instead", "error", logOutputErr)
+	}
 
-	// Register the "list_workflows" tool with its handler
-	mcpServer.AddTool(listWorkflowsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Validate and retrieve the status parameter
-		statusVal, ok := req.Params.Arguments["status"].(string)
-		if !ok || statusVal == "" {
-			return mcp.NewToolResultError("Missing or invalid 'status' parameter"), nil
-		}
-		statusFilter := strings.ToLower(statusVal)
-
-		// Prepare list request based on the status filter
-		var executions []*workflowpb.WorkflowExecutionInfo
-		if statusFilter == "running" {
-			// List open (running) workflows
-			resp, err := c.ListOpenWorkflow(ctx, &workflowservice.ListOpenWorkflowExecutionsRequest{
-				Namespace:       temporalNamespace,
-				MaximumPageSize: 100, // limit results for performance
-			})
-			if err != nil {
-				log.Printf("Error listing running workflows: %v", err)
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to list running workflows: %v", err)), nil
-			}
-			executions = resp.GetExecutions()
-		} else if statusFilter == "completed" || statusFilter == "failed" {
-			// List closed workflows filtered by close status (Completed or Failed)
-			// var closeStatus enumspb.WorkflowExecutionStatus
-			// if statusFilter == "completed" {
-			// 	closeStatus = enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
-			// } else {
-			// 	closeStatus = enumspb.WORKFLOW_EXECUTION_STATUS_FAILED
-			// }
-			resp, err := c.ListClosedWorkflow(ctx, &workflowservice.ListClosedWorkflowExecutionsRequest{
-				Namespace:       temporalNamespace,
-				MaximumPageSize: 100,
-				Filters:         &workflowservice.ListClosedWorkflowExecutionsRequest_StatusFilter{
-					// StatusFilter: &filterpb.WorkflowExecutionCloseStatusFilter{Status: closeStatus},
-				},
-			})
-			if err != nil {
-				log.Printf("Error listing %s workflows: %v", statusFilter, err)
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to list %s workflows: %v", statusFilter, err)), nil
+	// Expose Prometheus metrics when a listen address is configured; otherwise metrics are no-ops
+	var (
+		serverMetrics *metrics.Metrics
+		metricsServer *http.Server
+	)
+	if cfg.MetricsAddr != "" {
+		serverMetrics = metrics.New()
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", serverMetrics.Handler())
+		metricsServer = &http.Server{Addr: cfg.MetricsAddr, Handler: mux}
+		go func() {
+			logger.Info("Serving Prometheus metrics", "address", cfg.MetricsAddr, "path", "/metrics")
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Metrics listener error", "address", cfg.MetricsAddr, "error", err)
 			}
-			executions = resp.GetExecutions()
-		} else {
-			// Unsupported status filter
-			return mcp.NewToolResultError(fmt.Sprintf("Unsupported status '%s' (use running, completed, or failed)", statusVal)), nil
-		}
+		}()
+	}
 
-		// Build the output based on retrieved executions
-		if len(executions) == 0 {
-			return mcp.NewToolResultText(fmt.Sprintf("No %s workflows found.", statusFilter)), nil
+	// Export OpenTelemetry traces when the standard OTEL_ variables name an OTLP endpoint; otherwise
+	// nothing is set up
+	var tracer *tracing.Tracing
+	var dialOptions []grpc.DialOption
+	if tracing.Configured() {
+		if tracer, err = tracing.New(context.Background(), build.Version); err != nil {
+			logger.Error("Invalid tracing configuration", "error", err)
+			os.Exit(1)
 		}
-		var outputBuilder strings.Builder
-		outputBuilder.WriteString(fmt.Sprintf("Found %d %s workflow(s):\n", len(executions), statusFilter))
-		for _, info := range executions {
-			id := info.GetExecution().GetWorkflowId()
-			runID := info.GetExecution().GetRunId()
-			wfType := info.GetType().GetName()
-			start := info.GetStartTime().AsTime().UTC().Format(time.RFC3339)
-			statusStr := workflowStatusToString(info.GetStatus())
-			if info.GetCloseTime() != nil {
-				end := info.GetCloseTime().AsTime().UTC().Format(time.RFC3339)
-				outputBuilder.WriteString(
-					fmt.Sprintf("- ID: %s | Run: %s | Type: %s | Status: %s | Start: %s | End: %s\n",
-						id, runID, wfType, statusStr, start, end),
-				)
-			} else {
-				outputBuilder.WriteString(
-					fmt.Sprintf("- ID: %s | Run: %s | Type: %s | Status: %s | Start: %s\n",
-						id, runID, wfType, statusStr, start),
-				)
-			}
+		dialOptions = append(dialOptions, tracer.DialOption())
+		logger.Info("Exporting OpenTelemetry traces")
+	}
+
+	// Token-bucket rate limit shared by all outbound Temporal RPCs (a rate of 0 disables it)
+	var limiter *temporal.Limiter
+	if cfg.RPCRate > 0 {
+		limiter = temporal.NewLimiter(cfg.RPCRate, cfg.RPCBurst, cfg.RPCMaxWait, serverMetrics)
+		logger.Info("Rate limiting Temporal RPCs", "rate", cfg.RPCRate, "burst", cfg.RPCBurst, "max_wait", cfg.RPCMaxWait)
+	}
+
+	// Data converter and codecs shared by the client (encoding tool inputs) and the payload renderer
+	// (decoding output), so both agree on the payload format
+	dataConverter, err := payload.NewConverter(cfg.DataConverter)
+	if err != nil {
+		logger.Error("Invalid data converter configuration", "error", err)
+		os.Exit(1)
+	}
+	var codecs []converter.PayloadCodec
+	if cfg.EncryptionKeyID != "" {
+		codec, err := payload.NewAESCodec(cfg.EncryptionKeyID, cfg.EncryptionKeys)
+		if err != nil {
+			logger.Error("Invalid encryption configuration", "error", err)
+			os.Exit(1)
 		}
-		return mcp.NewToolResultText(outputBuilder.String()), nil
+		codecs = append(codecs, codec)
+		logger.Info("Encrypting payloads with AES-GCM", "key_id", cfg.EncryptionKeyID, "keys", len(cfg.EncryptionKeys))
+	}
+	payloads, err := payload.NewRenderer(payload.Options{
+		Redaction:   cfg.Redaction,
+		KeyPatterns: cfg.RedactKeys,
+		MaxSize:     cfg.MaxPayloadBytes,
+		Converter:   dataConverter,
+		Codecs:      codecs,
 	})
+	if err != nil {
+		logger.Error("Invalid redaction configuration", "error", err)
+		os.Exit(1)
+	}
 
-	// Register the "describe_workflow" tool with its handler
-	mcpServer.AddTool(describeWorkflowTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Validate and get required workflow_id
-		wfID, ok := req.Params.Arguments["workflow_id"].(string)
-		if !ok || wfID == "" {
-			return mcp.NewToolResultError("Missing or invalid 'workflow_id' parameter"), nil
-		}
-		// Get optional run_id (may be empty if not provided)
-		runID, _ := req.Params.Arguments["run_id"].(string)
+	// Header values are credentials; only their names are logged
+	if len(cfg.GRPCHeaders) > 0 || cfg.HeadersCommand != "" {
+		logger.Info("Sending custom gRPC headers", "static", slices.Sorted(maps.Keys(cfg.GRPCHeaders)), "command", cfg.HeadersCommand != "", "command_ttl", cfg.HeadersCommandTTL)
+	}
 
-		// Describe the workflow execution via Temporal
-		resp, err := c.DescribeWorkflowExecution(ctx, wfID, runID)
+	// Connect to Temporal server, sharing our log handler and metrics with the SDK
+	connection := temporal.Options{
+		Addresses: cfg.TemporalAddresses,
+		Namespace: cfg.Namespace,
+		Identity:  cfg.Identity,
+		KeepAlive: temporal.KeepAlive{
+			Time:                cfg.KeepAliveTime,
+			Timeout:             cfg.KeepAliveTimeout,
+			PermitWithoutStream: cfg.KeepAlivePermitWithoutStream,
+		},
+		Headers:        temporal.NewHeaders(cfg.GRPCHeaders, cfg.HeadersCommand, cfg.HeadersCommandTTL),
+		Logger:         logger.With("component", "temporal-sdk"),
+		MetricsHandler: serverMetrics.SDKHandler(),
+		Limiter:        limiter,
+		DataConverter:  converter.NewCodecDataConverter(dataConverter, codecs...),
+		Retry: temporal.RetryPolicy{
+			MaxAttempts:    cfg.RetryMaxAttempts,
+			InitialBackoff: cfg.RetryInitialBackoff,
+			MaxBackoff:     cfg.RetryMaxBackoff,
+		},
+		DialOptions: dialOptions,
+	}
+	c, err := temporal.Dial(connection)
+	if err != nil {
+		logger.Error("Unable to connect to Temporal", "addresses", cfg.TemporalAddresses, "namespace", cfg.Namespace, "error", err)
+		os.Exit(1)
+	}
+	logger.Info("Connected to Temporal", "address", c.Address(), "namespace", cfg.Namespace)
+
+	// Per-namespace clients for calls overriding the namespace, restricted to the allowlist if set
+	clients, err := temporal.NewClients(c, cfg.AllowedNamespaces)
+	if err != nil {
+		logger.Error("Invalid namespace configuration", "error", err)
+		os.Exit(1)
+	}
+	if len(cfg.AllowedNamespaces) > 0 {
+		logger.Info("Restricting tool calls to allowed namespaces", "namespaces", cfg.AllowedNamespaces)
+	}
+
+	// A missing default namespace fails every call that doesn't name one; warn early with the
+	// namespaces that do exist, but keep serving calls naming them
+	namespacesCtx, cancel := context.WithTimeout(context.Background(), cfg.ToolTimeout)
+	if available, err := clients.Namespaces(namespacesCtx); err != nil {
+		logger.Warn("Unable to list namespaces", "error", err)
+	} else if !slices.Contains(available, cfg.Namespace) {
+		logger.Warn("Namespace does not exist on this cluster", "namespace", cfg.Namespace, "available", available)
+	}
+	cancel()
+
+	// Open the audit log of tool invocations, if configured
+	var auditor audit.Sink = audit.NopSink{}
+	if cfg.AuditLog != "" {
+		fileSink, err := audit.NewFileSink(cfg.AuditLog)
 		if err != nil {
-			log.Printf("Error describing workflow %q (run %q): %v", wfID, runID, err)
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to describe workflow: %v", err)), nil
-		}
-		info := resp.GetWorkflowExecutionInfo()
-		if info == nil {
-			// This case is unlikely if no error, but handle defensively
-			return mcp.NewToolResultError("No information available for the specified workflow"), nil
+			logger.Error("Unable to open audit log", "error", err)
+			os.Exit(1)
 		}
+		auditor = fileSink
+		logger.Info("Auditing tool invocations", "path", cfg.AuditLog)
+	}
 
-		// Extract fields from WorkflowExecutionInfo
-		id := info.GetExecution().GetWorkflowId()
-		run := info.GetExecution().GetRunId()
-		wfType := info.GetType().GetName()
-		statusStr := workflowStatusToString(info.GetStatus())
-		startTime := ""
-		if info.GetStartTime() != nil {
-			startTime = info.GetStartTime().AsTime().UTC().Format(time.RFC3339)
-		}
-		endTime := ""
-		if info.GetCloseTime() != nil {
-			endTime = info.GetCloseTime().AsTime().UTC().Format(time.RFC3339)
-		}
+	// Short-lived response cache for read tools (a size of 0 disables it)
+	var responseCache *cache.Cache
+	if cfg.CacheSize > 0 {
+		responseCache = cache.New(cfg.CacheSize, cfg.CacheTTL)
+	}
 
-		// Format the details into a multi-line output
-		var outputBuilder strings.Builder
-		outputBuilder.WriteString("Workflow Execution Details:\n")
-		outputBuilder.WriteString(fmt.Sprintf("Workflow ID: %s\n", id))
-		outputBuilder.WriteString(fmt.Sprintf("Run ID: %s\n", run))
-		outputBuilder.WriteString(fmt.Sprintf("Type: %s\n", wfType))
-		outputBuilder.WriteString(fmt.Sprintf("Status: %s\n", statusStr))
-		outputBuilder.WriteString(fmt.Sprintf("Start Time: %s\n", startTime))
-		if endTime != "" {
-			outputBuilder.WriteString(fmt.Sprintf("End Time: %s\n", endTime))
-		}
-		return mcp.NewToolResultText(outputBuilder.String()), nil
+	// Worker pool bounding the concurrent RPCs of fan-out tools
+	fanout := pool.New(cfg.FanoutConcurrency)
+
+	// Each session's recently looked-at workflows are dropped when the session ends
+	recent := &tools.RecentWorkflows{}
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		recent.Forget(session)
 	})
+	// Sessions may name their own identity, attributing their calls apart from other sessions
+	identities := &tools.SessionIdentities{}
+	identities.Register(hooks)
+
+	// Create the MCP server instance, announcing the real build version in the initialize handshake
+	mcpServer := server.NewMCPServer("temporal-mcp", build.Version, server.WithHooks(hooks))
+	mcpServer.AddResource(recent.Resource())
 
-	// Start the MCP server (listening on STDIO for tool requests)
-	log.Println("Starting temporal-mcp server...")
-	if err := server.ServeStdio(mcpServer); err != nil {
-		log.Fatalf("MCP server error: %v", err)
+	// Outputs over the size bound are attached as resources kept for a while, or truncated
+	var results *tools.ResultStore
+	if cfg.MaxOutputBytes > 0 && cfg.LargeOutput == "resource" {
+		results = tools.NewResultStore(cfg.LargeOutputTTL)
+		mcpServer.AddResourceTemplate(results.ResourceTemplate())
 	}
-}
+	registry := &tools.Registry{
+		Server:    mcpServer,
+		Logger:    logger,
+		Auditor:   auditor,
+		Metrics:   serverMetrics,
+		Tracer:    tracer.Tracer(),
+		Namespace: cfg.Namespace,
+		Target:    cfg.Target(),
+		Timeout:   cfg.ToolTimeout,
+
+		Cache:         responseCache,
+		CacheListings: cfg.CacheListings,
+
+		DryRun: cfg.DryRun,
 
-// workflowStatusToString converts a WorkflowExecutionStatus enum to a readable string.
-func workflowStatusToString(status enumspb.WorkflowExecutionStatus) string {
-	switch status {
-	case enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:
-		return "Running"
-	case enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED:
-		return "Completed"
-	case enumspb.WORKFLOW_EXECUTION_STATUS_FAILED:
-		return "Failed"
-	case enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED:
-		return "Canceled"
-	case enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED:
-		return "Terminated"
-	case enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW:
-		return "ContinuedAsNew"
-	case enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT:
-		return "TimedOut"
-	default:
-		return "Unknown"
+		MaxOutputBytes: cfg.MaxOutputBytes,
+		Results:        results,
+
+		Recent:     recent,
+		Identities: identities,
+
+		Clients:      clients,
+		Capabilities: c.Capabilities(),
+
+		Calls: &tools.InflightCalls{},
+	}
+	if cfg.DryRun {
+		logger.Warn("Dry-run mode: mutating tools only report what they would do")
+	}
+	var exports *export.Dir
+	if cfg.ExportDir != "" {
+		exports = &export.Dir{Path: cfg.ExportDir, MaxRows: cfg.ExportMaxRows}
+		logger.Info("Listing exports enabled", "dir", cfg.ExportDir, "max_rows", cfg.ExportMaxRows)
+	}
+	historyThresholds := format.HistoryThresholds{Events: int64(cfg.HistoryWarnEvents), Bytes: cfg.HistoryWarnBytes}
+	registry.Register(&tools.ListWorkflows{Clients: clients, Logger: logger, Export: exports, DefaultPageSize: cfg.DefaultPageSize, Pool: fanout})
+	registry.Register(&tools.ListScheduleRuns{Clients: clients, Logger: logger, DefaultPageSize: cfg.DefaultPageSize})
+	registry.Register(&tools.PreviewScheduleSpec{Clients: clients, Logger: logger})
+	registry.Register(&tools.ScheduleRecentActions{Clients: clients, Logger: logger, Pool: fanout})
+	if len(cfg.File.SavedQueries) > 0 {
+		registry.Register(&tools.RunSavedQuery{Clients: clients, Logger: logger, Queries: cfg.File.SavedQueries, DefaultPageSize: cfg.DefaultPageSize})
+	}
+	registry.Register(&tools.DescribeWorkflow{Clients: clients, Logger: logger, Payloads: payloads, HistoryThresholds: historyThresholds})
+	registry.Register(&tools.DescribeWorkflows{Clients: clients, Logger: logger, Pool: fanout, MaxItems: cfg.FanoutMaxItems})
+	registry.Register(&tools.FindWorkflowsPendingActivity{Clients: clients, Logger: logger, Pool: fanout, MaxItems: cfg.FanoutMaxItems})
+	registry.Register(&tools.FindExpiringWorkflows{Clients: clients, Logger: logger, Pool: fanout, MaxItems: cfg.FanoutMaxItems})
+	registry.Register(&tools.FindLargeHistories{Clients: clients, Logger: logger, Pool: fanout, MaxItems: cfg.FanoutMaxItems, Thresholds: historyThresholds})
+	registry.Register(&tools.FindOrphanedTaskQueues{Clients: clients, Logger: logger, Pool: fanout, MaxItems: cfg.FanoutMaxItems})
+	registry.Register(&tools.VersionReport{Clients: clients, Logger: logger})
+	registry.Register(&tools.TaskLatencyReport{Clients: clients, Logger: logger, Pool: fanout, MaxItems: cfg.FanoutMaxItems})
+	registry.Register(&tools.GetHistoryEvent{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.ListActivities{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.ListTimers{Clients: clients, Logger: logger})
+	registry.Register(&tools.CheckWorkflowTaskFailures{Clients: clients, Logger: logger})
+	registry.Register(&tools.ListSignals{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.ListMarkers{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.GetUpdateResult{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.SummarizeHistory{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.SearchHistory{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.ListResetPoints{Clients: clients, Logger: logger})
+	registry.Register(&tools.ResetWorkflow{Clients: clients, Logger: logger, Capabilities: c.Capabilities()})
+	registry.Register(&tools.PauseActivity{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.UnpauseActivity{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.ResetActivity{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.SignalMatching{Clients: clients, Logger: logger, Pool: fanout, InputLimits: tools.InputLimits{WarnBytes: cfg.InputWarnBytes, MaxBytes: cfg.InputMaxBytes}})
+	registry.Register(&tools.StopBatchOperation{Clients: clients, Logger: logger})
+	registry.Register(&tools.NamespaceSummary{Clients: clients, Logger: logger, Pool: fanout, TaskQueues: cfg.File.TaskQueues})
+	registry.Register(&tools.WorkflowStats{Clients: clients, Logger: logger})
+	registry.Register(&tools.WorkflowHistogram{Clients: clients, Logger: logger, Pool: fanout})
+	registry.Register(&tools.AggregateSearchAttribute{Clients: clients, Logger: logger})
+	registry.Register(&tools.QuerySyntaxHelp{Clients: clients, Logger: logger})
+	registry.Register(&tools.ListWorkflowTypes{Clients: clients, Logger: logger, Catalog: cfg.File.WorkflowTypes})
+	registry.Register(&tools.ListNamespaces{Clients: clients, Logger: logger})
+	registry.Register(&tools.NamespaceReplication{Clients: clients, Logger: logger})
+	registry.Register(&tools.UpdateNamespaceArchival{Clients: clients, Logger: logger})
+	if cfg.NamespaceDeletion {
+		logger.Warn("Namespace deletion enabled: delete_namespace can permanently delete namespaces")
+		registry.Register(&tools.DeleteNamespace{Clients: clients, Logger: logger})
+	}
+	registry.Register(&tools.ListNexusEndpoints{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.DescribeNexusEndpoint{Clients: clients, Logger: logger, Payloads: payloads})
+	registry.Register(&tools.ServerInfo{Clients: clients, Logger: logger, Build: build})
+	registry.Register(&tools.DiagnoseConnection{Clients: clients, Logger: logger, Connection: connection})
+	registry.Register(&tools.RecentWorkflowsTool{Recent: recent})
+
+	// Browsable namespaces, workflows, and schedules for clients that read resources
+	namespaceResources := &tools.NamespaceResources{
+		Clients:      clients,
+		Logger:       logger,
+		Pool:         fanout,
+		Timeout:      cfg.ToolTimeout,
+		Namespaces:   &tools.ListNamespaces{Clients: clients, Logger: logger},
+		Workflow:     &tools.DescribeWorkflow{Clients: clients, Logger: logger, Payloads: payloads, HistoryThresholds: historyThresholds},
+		ScheduleRuns: &tools.ListScheduleRuns{Clients: clients, Logger: logger, DefaultPageSize: cfg.DefaultPageSize},
+	}
+	namespaceResources.Register(mcpServer, hooks)
+
+	// Tell clients what they are connected to, built from the registered tools and live namespaces
+	instructionsCtx, cancel := context.WithTimeout(context.Background(), cfg.ToolTimeout)
+	server.WithInstructions(registry.Instructions(instructionsCtx, clients, strings.Join(cfg.TemporalAddresses, ", ")))(mcpServer)
+	cancel()
+
+	// Start the MCP server (listening on STDIO for tool requests). The listener runs on its own
+	// context so that a shutdown signal doesn't cancel in-flight tool calls before they drain.
+	logger.Info("Starting temporal-mcp server", "version", build.Version, "commit", build.Commit, "logs", logDestination)
+	stdioServer := server.NewStdioServer(mcpServer)
+	stdioServer.SetErrorLogger(slog.NewLogLogger(logger.Handler(), slog.LevelError))
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- stdioServer.Listen(context.Background(), os.Stdin, os.Stdout)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	var shutdownReason string
+	select {
+	case sig := <-signals:
+		shutdownReason = "received " + sig.String()
+	case err := <-serveErr:
+		shutdownReason = "client disconnected"
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, context.Canceled) {
+			shutdownReason = fmt.Sprintf("MCP server error: %v", err)
+		}
+	}
+
+	// Stop accepting tool calls, drain in-flight ones, then release resources
+	logger.Info("Shutting down temporal-mcp server", "reason", shutdownReason)
+	inflight, abandoned := registry.Calls.Drain(defaultShutdownTimeout)
+	if abandoned > 0 {
+		logger.Warn("Shutdown timed out waiting for tool calls", "in_flight", inflight, "abandoned", abandoned, "timeout", defaultShutdownTimeout)
+	} else {
+		logger.Info("Drained in-flight tool calls", "drained", inflight)
+	}
+	clients.Close()
+	if err := auditor.Close(); err != nil {
+		logger.Error("Unable to flush audit log", "error", err)
+	}
+	if metricsServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Unable to shut down metrics listener", "error", err)
+		}
+		cancel()
+	}
+	serverMetrics.Close()
+	if tracer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := tracer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Unable to flush traces", "error", err)
+		}
+		cancel()
 	}
+	logger.Info("temporal-mcp server stopped")
 }